@@ -7,19 +7,32 @@ import (
 	"strings"
 )
 
-var (
-	blacklistRe *regexp.Regexp
-)
+// blacklist matches the paths of interface definition files that are ignored.
+type blacklist struct {
+	patterns []string
+	re       *regexp.Regexp
+}
+
+var defaultBlacklist = mustCompileBlacklist(blacklistedMessages)
 
-func init() { prepareBlacklistMatchingRegexp() }
+func mustCompileBlacklist(patterns []string) *blacklist {
+	b, err := compileBlacklist(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
 
-func prepareBlacklistMatchingRegexp() {
-	if len(blacklistedMessages) == 0 {
-		blacklistRe = nil
-		return
+func compileBlacklist(patterns []string) (*blacklist, error) {
+	bl := &blacklist{patterns: patterns}
+	if len(patterns) == 0 {
+		return bl, nil
 	}
 	var b strings.Builder
-	for i, pat := range blacklistedMessages {
+	for i, pat := range patterns {
+		if _, err := regexp.Compile(pat); err != nil {
+			return nil, fmt.Errorf("invalid blacklist pattern %q: %w", pat, err)
+		}
 		if i > 0 {
 			b.WriteByte('|')
 		}
@@ -29,20 +42,31 @@ func prepareBlacklistMatchingRegexp() {
 	}
 	re, err := regexp.Compile(b.String())
 	if err != nil {
-		panic(fmt.Errorf("invalid blacklist pattern: %w", err))
+		return nil, fmt.Errorf("invalid blacklist pattern: %w", err)
+	}
+	bl.re = re
+	return bl, nil
+}
+
+// blacklistFor returns the built-in blacklist extended with the patterns of
+// config.
+func blacklistFor(config *Config) (*blacklist, error) {
+	if len(config.Blacklist) == 0 {
+		return defaultBlacklist, nil
 	}
-	blacklistRe = re
+	patterns := append(append([]string{}, blacklistedMessages...), config.Blacklist...)
+	return compileBlacklist(patterns)
 }
 
-func blacklisted(path string) (bool, string) {
-	if blacklistRe == nil {
+func (bl *blacklist) matches(path string) (bool, string) {
+	if bl.re == nil {
 		return false, ""
 	}
-	sub := blacklistRe.FindStringSubmatch(path)
+	sub := bl.re.FindStringSubmatch(path)
 	if sub == nil {
 		return false, ""
 	}
-	names := blacklistRe.SubexpNames()
+	names := bl.re.SubexpNames()
 
 	for i := len(sub) - 1; i >= 1; i-- {
 		if sub[i] == "" {
@@ -50,8 +74,8 @@ func blacklisted(path string) (bool, string) {
 		}
 		// Our named groups are r0, r1, ...
 		if strings.HasPrefix(names[i], "r") {
-			if idx, err := strconv.Atoi(names[i][1:]); err == nil && idx >= 0 && idx < len(blacklistedMessages) {
-				return true, blacklistedMessages[idx]
+			if idx, err := strconv.Atoi(names[i][1:]); err == nil && idx >= 0 && idx < len(bl.patterns) {
+				return true, bl.patterns[idx]
 			}
 		}
 	}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// checkFile compares content with the file at path and records a diff if
+// they differ.
+func (g *Generator) checkFile(path string, content []byte) error {
+	path = filepath.Clean(path)
+	var imports []string
+	if g.config.PackageModules && filepath.Ext(path) == ".go" {
+		var err error
+		if imports, err = goFileImports(path, content); err != nil {
+			return err
+		}
+	}
+	old, err := os.ReadFile(path)
+	fromFile := path
+	if errors.Is(err, os.ErrNotExist) {
+		fromFile = "/dev/null"
+	} else if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rendered[path] = true
+	if imports != nil {
+		g.renderedImports[path] = imports
+	}
+	if err == nil && bytes.Equal(old, content) {
+		return nil
+	}
+	g.drift[path] = unifiedDiff(fromFile, path, string(old), string(content))
+	return nil
+}
+
+// checkStale records a diff removing the file at path if it exists and was
+// not rendered.
+func (g *Generator) checkStale(path string) {
+	path = filepath.Clean(path)
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.rendered[path] {
+		g.drift[path] = unifiedDiff(path, "/dev/null", string(old), "")
+	}
+}
+
+func unifiedDiff(fromFile, toFile, a, b string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{ //nolint:errcheck
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	return diff
+}
+
+// ReportDrift writes a unified diff of each file in Config.DestPath that
+// differs from what Config.Check rendered to w, sorted by path. Generated
+// files left in the directories of the rendered packages count as drift as
+// well. An error is returned if there is any drift.
+func (g *Generator) ReportDrift(w io.Writer) error {
+	dirs := map[string]bool{}
+	for pkgAndType := range g.cImportsByPkgAndType {
+		if pkg, typ, err := parsePkgAndType(pkgAndType); err == nil {
+			dirs[filepath.Join(g.config.DestPath, pkg, typ)] = true
+		}
+	}
+	for dir := range dirs {
+		stale, _ := filepath.Glob(filepath.Join(dir, "*.gen.go"))
+		for _, path := range stale {
+			g.checkStale(path)
+		}
+	}
+
+	paths := make([]string, 0, len(g.drift))
+	for path := range g.drift {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		diff := g.drift[path]
+		if !strings.HasSuffix(diff, "\n") {
+			diff += "\n"
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return err
+		}
+	}
+	if len(paths) > 0 {
+		return fmt.Errorf("%d generated files are out of date", len(paths))
+	}
+	return nil
+}
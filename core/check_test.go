@@ -0,0 +1,65 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg":        "int32 a\n",
+		"my_msgs/action/Do.action": "int32 goal\n---\n---\n",
+	})
+	dest := t.TempDir()
+	config := DefaultConfig
+	config.RootPaths = []string{root}
+	config.DestPath = dest
+	config.CGOFlagsPath = filepath.Join(dest, "cgo-flags.env")
+
+	run := func(check bool) (string, error) {
+		config := config
+		config.Check = check
+		g := New(&config)
+		_, err := g.GenerateGolangMessageTypes()
+		require.NoError(t, err)
+		require.NoError(t, g.GenerateROS2AllMessagesImporter())
+		require.NoError(t, g.GenerateCGOFlags())
+		if !check {
+			return "", nil
+		}
+		var buf bytes.Buffer
+		err = g.ReportDrift(&buf)
+		return buf.String(), err
+	}
+
+	diff, err := run(true)
+	assert.EqualError(t, err, "16 generated files are out of date")
+	assert.Contains(t, diff, "--- /dev/null\n+++ "+filepath.Join(dest, "my_msgs/msg/A.gen.go"))
+	assert.Contains(t, diff, "+++ "+filepath.Join(dest, "msgs.gen.go"))
+	assert.Contains(t, diff, "+++ "+filepath.Join(dest, "my_msgs/action/common.gen.go"))
+	assert.Contains(t, diff, "+++ "+config.CGOFlagsPath)
+	entries, err := os.ReadDir(dest)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "check must not write files")
+
+	_, err = run(false)
+	require.NoError(t, err)
+	diff, err = run(true)
+	assert.NoError(t, err)
+	assert.Empty(t, diff)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "my_msgs/msg/A.msg"), []byte("int64 a\n"), 0o600))
+	stale := filepath.Join(dest, "my_msgs/msg/Old.gen.go")
+	require.NoError(t, os.WriteFile(stale, []byte("package my_msgs_msg\n"), 0o600))
+	diff, err = run(true)
+	assert.EqualError(t, err, "2 generated files are out of date")
+	assert.Contains(t, diff, "-\tA int32")
+	assert.Contains(t, diff, "+\tA int64")
+	assert.Contains(t, diff, "--- "+stale+"\n+++ /dev/null\n")
+	assert.FileExists(t, stale)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/okieraised/rclgo/core"
@@ -26,28 +27,126 @@ func Execute() {
 	cobra.CheckErr(rootCmd.Execute())
 }
 
+// ConfigFileName is the name of the project configuration file looked up from
+// the working directory and its parents.
+const ConfigFileName = "ros2gen.yaml"
+
 func init() {
+	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().String("config", "", "Path to the project configuration file. Defaults to the first "+ConfigFileName+" found in the working directory or its parents.")
+}
+
+// configPathKeys are the keys of the configuration file whose values are
+// paths. Relative paths are relative to the directory of the file.
+var configPathKeys = []string{"root-path", "dest-path", "cgo-flags-path", "template"}
+
+func initConfig() {
+	file, _ := rootCmd.PersistentFlags().GetString("config")
+	if file == "" {
+		file = findConfigFile()
+		if file == "" {
+			return
+		}
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType("yaml")
+	cobra.CheckErr(v.ReadInConfig())
+	settings := v.AllSettings()
+	resolveConfigPaths(settings, filepath.Dir(file))
+	cobra.CheckErr(viper.MergeConfigMap(settings))
+}
+
+// findConfigFile returns the path of the first ConfigFileName found in the
+// working directory or its parents, or "" if there is none. The path is
+// relative to the working directory.
+func findConfigFile() string {
+	dir := "."
+	for {
+		file := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil || filepath.Dir(abs) == abs {
+			return ""
+		}
+		dir = filepath.Join(dir, "..")
+	}
+}
+
+// resolveConfigPaths makes the relative paths in settings and its command
+// sections relative to the working directory instead of dir.
+func resolveConfigPaths(settings map[string]any, dir string) {
+	for key, value := range settings {
+		switch value := value.(type) {
+		case map[string]any:
+			resolveConfigPaths(value, dir)
+		case string:
+			if slices.Contains(configPathKeys, key) {
+				settings[key] = resolveConfigValue(key, value, dir)
+			}
+		case []any:
+			if slices.Contains(configPathKeys, key) {
+				for i, v := range value {
+					if s, ok := v.(string); ok {
+						value[i] = resolveConfigValue(key, s, dir)
+					}
+				}
+			}
+		}
+	}
+}
+
+func resolveConfigValue(key, value, dir string) string {
+	if key == "template" {
+		// Only the path of KIND[:OUTPUT]=PATH is resolved.
+		if spec, p, ok := strings.Cut(value, "="); ok {
+			return spec + "=" + resolveConfigPath(p, dir)
+		}
+		return value
+	}
+	return resolveConfigPath(value, dir)
+}
+
+func resolveConfigPath(p, dir string) string {
+	if p == "" || p == "-" {
+		return p
+	}
+	paths := filepath.SplitList(p)
+	for i, p := range paths {
+		if !filepath.IsAbs(p) {
+			paths[i] = filepath.Join(dir, p)
+		}
+	}
+	return strings.Join(paths, string(filepath.ListSeparator))
 }
 
 func validate(cmd *cobra.Command, _ []string) error {
 	rootPaths := getRootPaths(cmd)
 	if len(rootPaths) == 0 {
 		if os.Getenv(distro.AmentPrefixPath) == "" {
-			return fmt.Errorf("you haven't sourced your ROS2 environment")
+			return fmt.Errorf("root-path is required when you haven't sourced your ROS2 environment")
 		}
 		return fmt.Errorf("root-path is required")
 	}
-	rosVersion := filepath.Base(os.Getenv(distro.AmentPrefixPath))
-
+	rosVersion := getDistro(cmd)
+	if rosVersion == "" {
+		return fmt.Errorf("distro is required when you haven't sourced your ROS2 environment")
+	}
 	if _, ok := distro.SupportedDistroMapper[rosVersion]; !ok {
 		return fmt.Errorf("unsupported distro: %s", rosVersion)
 	}
 
-	destPath := getString(cmd, "dest-path")
+	destPath := getDestPath(cmd)
 	if destPath == "" {
 		return fmt.Errorf("dest-path is required")
 	}
 
+	if getBool(cmd, "check") {
+		// Checking must not touch the tree.
+		return nil
+	}
 	_, err := os.Stat(destPath)
 	if errors.Is(err, os.ErrNotExist) {
 		//#nosec G301 -- The generated directory doesn't contain secrets.
@@ -68,17 +167,32 @@ var generateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		cmd.SilenceUsage = true
 		gen := core.New(config)
-		if err := gen.GenerateGolangMessageTypes(); err != nil {
-			return fmt.Errorf("failed to generate interface bindings: %w", err)
+		summary, genErr := gen.GenerateGolangMessageTypes()
+		if !config.Check {
+			_, _ = fmt.Fprintln(os.Stderr, summary)
 		}
-		if err := gen.GenerateROS2AllMessagesImporter(); err != nil {
-			return fmt.Errorf("failed to generate all importer: %w", err)
+		if genErr != nil {
+			genErr = fmt.Errorf("failed to generate interface bindings: %w", genErr)
+			if !config.KeepGoing {
+				return genErr
+			}
+		}
+		if config.PackageModules {
+			if err := gen.GenerateGoModules(); err != nil {
+				return errors.Join(genErr, fmt.Errorf("failed to generate Go modules: %w", err))
+			}
+		} else if err := gen.GenerateROS2AllMessagesImporter(); err != nil {
+			return errors.Join(genErr, fmt.Errorf("failed to generate all importer: %w", err))
 		}
 		if err := gen.GenerateCGOFlags(); err != nil {
-			return fmt.Errorf("failed to generate CGO flags: %w", err)
+			return errors.Join(genErr, fmt.Errorf("failed to generate CGO flags: %w", err))
 		}
-		return nil
+		if config.Check {
+			return errors.Join(genErr, gen.ReportDrift(os.Stdout))
+		}
+		return genErr
 	},
 	Args: validate,
 }
@@ -109,20 +223,68 @@ var generateRclgoCmd = &cobra.Command{
 	Args: validate,
 }
 
+var lintCmd = &cobra.Command{
+	Use:   "lint <path>...",
+	Short: "Check ROS2 interface definitions under <path> without generating code",
+	Long: `Check ROS2 interface definitions under <path> without generating code.
+
+References to messages of other packages are resolved from the definitions
+under <path> and root-path. Issues are written to stdout in the given format,
+and the command fails if any issues are found.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issues := core.Lint(args, getRootPaths(cmd))
+		if err := core.WriteLintReport(os.Stdout, getString(cmd, "format"), issues); err != nil {
+			return err
+		}
+		if len(issues) > 0 {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return fmt.Errorf("found %d issues", len(issues))
+		}
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(generateCmd)
+	generateCmd.PersistentFlags().IntP("jobs", "j", 0, "Maximum number of interfaces generated in parallel. Defaults to the number of CPUs.")
+	generateCmd.PersistentFlags().Bool("force", false, "Regenerate all interfaces, including the ones that are up to date according to the manifest in dest-path.")
+	generateCmd.PersistentFlags().Bool("keep-going", false, "Keep generating after errors. The errors are still reported and make the command fail.")
+	generateCmd.PersistentFlags().Bool("package-modules", false, "Make each generated ROS2 package a Go module of its own with a go.mod file, and maintain a go.work file using them in dest-path. The all-packages importer is not generated.")
+	generateCmd.PersistentFlags().String("rclgo-version", "", "Version of the rclgo module required by the modules generated with --package-modules.")
+	generateCmd.PersistentFlags().String("rclgo-dir", "", "Directory of the rclgo module used by the go.work file generated with --package-modules instead of a version of the module.")
+	generateCmd.PersistentFlags().Bool("check", false, "Don't write any files. Print a diff of each file in dest-path that differs from the generated one, and fail if there are any.")
 	configureFlags(generateCmd, "./ros2_msgs")
 
 	rootCmd.AddCommand(generateRclgoCmd)
-	configureFlags(generateRclgoCmd, core.RclgoRepoRootPath())
+	configureFlags(generateRclgoCmd, "")
+
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.PersistentFlags().StringArrayP("root-path", "r", []string{os.Getenv("AMENT_PREFIX_PATH")}, "Root lookup path for ROS2 interfaces referenced by the checked definitions. If ROS2 environment is sourced, is auto-detected.")
+	lintCmd.PersistentFlags().StringP("format", "f", "text", `Output format: "text", "json" or "sarif".`)
+	bindPFlags(lintCmd)
 }
 
 func configureFlags(cmd *cobra.Command, destPathDefault string) {
 	cmd.PersistentFlags().StringArrayP("root-path", "r", []string{os.Getenv("AMENT_PREFIX_PATH")}, "Root lookup path for ROS2 .msg files. If ROS2 environment is sourced, is auto-detected.")
-	cmd.PersistentFlags().StringP("dest-path", "d", destPathDefault, "Output directory for the Golang ROS2 messages.")
+	cmd.PersistentFlags().String("distro", "", "ROS2 distribution to generate for, humble or jazzy. If ROS2 environment is sourced, is auto-detected.")
+	destPathUsage := "Output directory for the Golang ROS2 messages."
+	if destPathDefault == "" {
+		destPathUsage += " Defaults to the rclgo module of the distro."
+	}
+	cmd.PersistentFlags().StringP("dest-path", "d", destPathDefault, destPathUsage)
 	cmd.PersistentFlags().StringArray("include-package", nil, "Include only packages matching a regex. Can be passed multiple times. If multiple include options are passed, the union of the matches is generated.")
 	cmd.PersistentFlags().StringArray("include-package-deps", nil, "Include only packages which are dependencies of listed packages. Can be passed multiple times. If multiple include options are passed, the union of the matches is generated.")
 	cmd.PersistentFlags().StringArray("include-go-package-deps", nil, "Include only packages which are dependencies of listed Go packages. Can be passed multiple times. If multiple include options are passed, the union of the matches is generated.")
+	cmd.PersistentFlags().StringArray("exclude-package", nil, "Exclude packages matching a regex, even if they are included otherwise. Can be passed multiple times.")
+	cmd.PersistentFlags().StringArray("blacklist", nil, "Ignore interface definition files whose path matches a regex. Can be passed multiple times.")
+	cmd.PersistentFlags().String("module-prefix", "", "Go import path prefix of the generated packages. Defaults to the import path of dest-path in the Go module of the working directory.")
+	cmd.PersistentFlags().StringToString("go-import-path", nil, "Import the bindings of a ROS2 package from a Go import path instead of generating them, e.g. std_msgs=example.com/msgs/std_msgs. Can be passed multiple times.")
+	cmd.PersistentFlags().StringArray("template", nil, `User-supplied template as KIND[:OUTPUT]=PATH, where KIND is "message", "service", "action" or "package". Without OUTPUT, the template replaces the built-in one. Otherwise OUTPUT is a template of the name of an additional file generated in the Go package, e.g. "message:{{.Message.Name}}_conv.gen.go=conv.tmpl". Can be passed multiple times.`)
+	cmd.PersistentFlags().StringArray("typed-enum-package", nil, "Type the fields of messages in packages matching a regex with the enum types generated for their constants, e.g. the status field with the type of the STATUS_* constants. Can be passed multiple times.")
+	cmd.PersistentFlags().Bool("native-time", false, "Use time.Time and time.Duration for fields of the types builtin_interfaces/msg/Time and Duration.")
+	cmd.PersistentFlags().StringArray("struct-tag", nil, `Struct tag key, e.g. "json", added to the fields of generated messages with the ROS2 field name as its value. Can be passed multiple times.`)
 	cmd.PersistentFlags().String("cgo-flags-path", "cgo-flags.env", `Path to file where CGO flags are written. If empty, no flags are written. If "-", flags are written to stdout.`)
 	bindPFlags(cmd)
 }
@@ -142,12 +304,30 @@ func getPrefix(cmd *cobra.Command) string {
 	return prefix
 }
 
+// getKey returns the viper key of the option key of cmd. Options set on the
+// command line take precedence over the command's section of the
+// configuration file, which takes precedence over its top level.
+func getKey(cmd *cobra.Command, key string) string {
+	k := getPrefix(cmd) + key
+	if f := cmd.Flags().Lookup(key); f != nil && f.Changed || viper.InConfig(k) {
+		return k
+	}
+	if viper.InConfig(key) {
+		return key
+	}
+	return k
+}
+
 func getString(cmd *cobra.Command, key string) string {
-	return viper.GetString(getPrefix(cmd) + key)
+	return viper.GetString(getKey(cmd, key))
 }
 
 func getBool(cmd *cobra.Command, key string) bool {
-	return viper.GetBool(getPrefix(cmd) + key)
+	return viper.GetBool(getKey(cmd, key))
+}
+
+func getStringSlice(cmd *cobra.Command, key string) []string {
+	return viper.GetStringSlice(getKey(cmd, key))
 }
 
 func bindPFlags(cmd *cobra.Command) {
@@ -160,34 +340,81 @@ func bindPFlags(cmd *cobra.Command) {
 	})
 }
 
-func getConfig(cmd *cobra.Command) (*core.Config, error) {
+func getDistro(cmd *cobra.Command) string {
+	if d := getString(cmd, "distro"); d != "" {
+		return d
+	}
+	return distro.FromEnv()
+}
+
+func getDestPath(cmd *cobra.Command) string {
 	destPath := getString(cmd, "dest-path")
-	modulePrefix := core.DefaultConfig.MessageModulePrefix
-	pkgs, err := packages.Load(&packages.Config{})
-	if err == nil && len(pkgs) > 0 {
-		modulePrefix = path.Join(pkgs[0].PkgPath, destPath)
+	if destPath == "" && cmd.Name() == "generate-rclgo" {
+		if d := getDistro(cmd); d != "" {
+			return core.RclgoRepoRootPath(d)
+		}
 	}
+	return destPath
+}
 
-	rules, err := getPackageRules(cmd)
+func getConfig(cmd *cobra.Command) (*core.Config, error) {
+	config := core.DefaultConfigForDistro(getDistro(cmd))
+	destPath := getDestPath(cmd)
+	modulePrefix := getString(cmd, "module-prefix")
+	if modulePrefix == "" {
+		modulePrefix = config.MessageModulePrefix
+		pkgs, err := packages.Load(&packages.Config{})
+		if err == nil && len(pkgs) > 0 {
+			modulePrefix = path.Join(pkgs[0].PkgPath, destPath)
+		}
+	}
+
+	rules, err := getPackageRules(cmd, "include-package")
+	if err != nil {
+		return nil, err
+	}
+	excludes, err := getPackageRules(cmd, "exclude-package")
 	if err != nil {
 		return nil, err
 	}
-	return &core.Config{
-		RclgoImportPath:     core.DefaultConfig.RclgoImportPath,
-		DistroImportPath:    core.DefaultConfig.DistroImportPath,
-		MessageModulePrefix: modulePrefix,
-		RootPaths:           getRootPaths(cmd),
-		DestPath:            destPath,
-		CGOFlagsPath:        getString(cmd, "cgo-flags-path"),
+	typedEnums, err := getPackageRules(cmd, "typed-enum-package")
+	if err != nil {
+		return nil, err
+	}
+	config.MessageModulePrefix = modulePrefix
+	config.RootPaths = getRootPaths(cmd)
+	config.DestPath = destPath
+	config.CGOFlagsPath = getString(cmd, "cgo-flags-path")
+
+	config.RegexIncludes = rules
+	config.ROSPkgIncludes = getStringSlice(cmd, "include-package-deps")
+	config.GoPkgIncludes = getStringSlice(cmd, "include-go-package-deps")
+	config.RegexExcludes = excludes
+	config.Blacklist = getStringSlice(cmd, "blacklist")
+	config.GoImportPaths = viper.GetStringMapString(getKey(cmd, "go-import-path"))
+	config.StructTags = getStringSlice(cmd, "struct-tag")
+	config.TypedEnums = typedEnums
+	config.NativeTime = getBool(cmd, "native-time")
+	for _, spec := range getStringSlice(cmd, "template") {
+		t, err := core.ParseTemplateSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		config.Templates = append(config.Templates, t)
+	}
 
-		RegexIncludes:  rules,
-		ROSPkgIncludes: viper.GetStringSlice(getPrefix(cmd) + "include-package-deps"),
-		GoPkgIncludes:  viper.GetStringSlice(getPrefix(cmd) + "include-go-package-deps"),
-	}, nil
+	config.Workers = viper.GetInt(getKey(cmd, "jobs"))
+	config.Force = getBool(cmd, "force")
+	config.Check = getBool(cmd, "check")
+	config.KeepGoing = getBool(cmd, "keep-going")
+	config.PackageModules = getBool(cmd, "package-modules")
+	config.RclgoVersion = getString(cmd, "rclgo-version")
+	config.RclgoDir = getString(cmd, "rclgo-dir")
+	return &config, nil
 }
 
 func getRootPaths(cmd *cobra.Command) []string {
-	pathLists := viper.GetStringSlice(getPrefix(cmd) + "root-path")
+	pathLists := getStringSlice(cmd, "root-path")
 	found := make(map[string]bool)
 	var paths []string
 	for _, pl := range pathLists {
@@ -201,10 +428,10 @@ func getRootPaths(cmd *cobra.Command) []string {
 	return paths
 }
 
-func getPackageRules(cmd *cobra.Command) (_ core.RuleSet, err error) {
-	includes := viper.GetStringSlice(getPrefix(cmd) + "include-package")
-	rules := make(core.RuleSet, len(includes))
-	for i, pattern := range includes {
+func getPackageRules(cmd *cobra.Command, key string) (_ core.RuleSet, err error) {
+	patterns := getStringSlice(cmd, key)
+	rules := make(core.RuleSet, len(patterns))
+	for i, pattern := range patterns {
 		rules[i], err = core.NewRule(pattern)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/okieraised/rclgo/core/internal/utilities"
+)
+
+// ROS2Enum is a group of integer constants of a message sharing a name prefix
+// and a type, e.g. the STATUS_* constants of action_msgs/msg/GoalStatus. A
+// named Go type is generated for each group.
+type ROS2Enum struct {
+	// Name is the name of the Go type, e.g. GoalStatus_Status.
+	Name string
+	// Prefix is the common prefix of the constant names, e.g. STATUS.
+	Prefix    string
+	RosType   string
+	GoType    string
+	Unsigned  bool
+	Constants []*ROS2Constant
+	// Distinct contains the first constant of each distinct value.
+	Distinct []*ROS2Constant
+	// Field is the field typed with the enum type, or nil if there is none.
+	Field *ROS2Field
+}
+
+var enumRosTypes = map[string]bool{
+	"byte": true, "char": true,
+	"int8": true, "int16": true, "int32": true, "int64": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true,
+}
+
+// findEnums groups the integer constants of msg whose names share the prefix
+// before the first underscore and which have the same type. Groups of a
+// single constant are not enums.
+func findEnums(msg *ROS2Message) []*ROS2Enum {
+	var enums []*ROS2Enum
+	byPrefix := map[string]*ROS2Enum{}
+	for _, c := range msg.Constants {
+		prefix, _, ok := strings.Cut(c.RosName, "_")
+		if !ok || prefix == "" || !enumRosTypes[c.RosType] {
+			continue
+		}
+		e := byPrefix[prefix]
+		if e == nil {
+			e = &ROS2Enum{
+				Name:     msg.Name + "_" + utilities.SnakeToCamel(strings.ToLower(prefix)),
+				Prefix:   prefix,
+				RosType:  c.RosType,
+				GoType:   c.GoType,
+				Unsigned: strings.HasPrefix(c.GoType, "u") || c.GoType == "byte",
+			}
+			byPrefix[prefix] = e
+			enums = append(enums, e)
+		}
+		if e.RosType != c.RosType {
+			// Mixed types can't share a Go type.
+			e.Constants = nil
+			e.RosType = ""
+			continue
+		}
+		e.Constants = append(e.Constants, c)
+	}
+	var groups []*ROS2Enum
+	for _, e := range enums {
+		if len(e.Constants) < 2 {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, c := range e.Constants {
+			v := enumValue(c.Value)
+			if !seen[v] {
+				seen[v] = true
+				e.Distinct = append(e.Distinct, c)
+			}
+		}
+		groups = append(groups, e)
+	}
+	return groups
+}
+
+// enumValue normalizes the integer constant value v for comparisons.
+func enumValue(v string) string {
+	if i, err := strconv.ParseInt(v, 0, 64); err == nil {
+		return strconv.FormatInt(i, 10)
+	}
+	if u, err := strconv.ParseUint(v, 0, 64); err == nil {
+		return strconv.FormatUint(u, 10)
+	}
+	return v
+}
+
+// addEnums adds the enums of msg to it. If typed is true, the scalar field
+// named after the prefix of an enum, e.g. status for STATUS_*, and the
+// constants of the enum are given the enum type.
+func addEnums(msg *ROS2Message, typed bool) {
+	msg.Enums = findEnums(msg)
+	if len(msg.Enums) == 0 {
+		return
+	}
+	msg.GoImports["strconv"] = ""
+	if !typed {
+		return
+	}
+	for _, e := range msg.Enums {
+		for _, f := range msg.Fields {
+			if f.TypeArray == "" && f.PkgName == "" && f.RosType == e.RosType && strings.EqualFold(f.RosName, e.Prefix) {
+				e.Field = f
+				f.GoType = e.Name
+				for _, c := range e.Constants {
+					c.GoType = e.Name
+				}
+				break
+			}
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/okieraised/rclgo/core/internal/distro"
 	"github.com/okieraised/rclgo/core/internal/utilities"
 )
 
@@ -81,7 +80,7 @@ func (g *Generator) GenerateROS2ErrorTypes() error {
 			"errorTypes":  errorTypes,
 			"includes":    cErrorTypeFiles,
 			"dedupFilter": ros2errorTypesDeduplicationFilter,
-			"ROSDistro":   filepath.Base(os.Getenv(distro.AmentPrefixPath)),
+			"ROSDistro":   g.config.Distro,
 		},
 	)
 }
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GenerateError is an error that occurred while generating bindings.
+type GenerateError struct {
+	// Path is the interface definition file the error is about. It is empty
+	// for errors that are not specific to an interface.
+	Path string
+	// Line is the line of Path where the error was found, or 0 if unknown.
+	Line int
+	Err  error
+}
+
+func newGenerateError(path string, err error) *GenerateError {
+	e := &GenerateError{Path: path, Err: err}
+	var diags Diagnostics
+	if errors.As(err, &diags) && len(diags) > 0 {
+		e.Line = diags[0].Pos.Line
+	}
+	return e
+}
+
+func (e *GenerateError) Error() string {
+	var diags Diagnostics
+	switch {
+	case e.Path == "":
+		return e.Err.Error()
+	case errors.As(e.Err, &diags):
+		// Diagnostics include the path already.
+		return e.Err.Error()
+	case e.Line > 0:
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	default:
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+}
+
+func (e *GenerateError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateErrors is the aggregated error returned by the generator. Its
+// elements are sorted by path.
+type GenerateErrors []*GenerateError
+
+func (es GenerateErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	noun := "errors"
+	if len(es) == 1 {
+		noun = "error"
+	}
+	return fmt.Sprintf("%d %s:\n%s", len(es), noun, strings.Join(msgs, "\n"))
+}
+
+func (es GenerateErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateErrors(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg": "int32 a\n",
+		"my_msgs/msg/B.msg": "int32 b\nint33 c\n",
+	})
+	config := DefaultConfig
+	config.RootPaths = []string{root}
+	config.DestPath = t.TempDir()
+	config.KeepGoing = true
+
+	_, err := New(&config).GenerateGolangMessageTypes()
+	var errs GenerateErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	bPath := filepath.Join(root, "my_msgs/msg/B.msg")
+	assert.Equal(t, bPath, errs[0].Path)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, "1 error:\n"+bPath+`:2:1: unknown type "int33"`, err.Error())
+	var diags Diagnostics
+	assert.ErrorAs(t, err, &diags)
+	assert.FileExists(t, filepath.Join(config.DestPath, "my_msgs/msg/A.gen.go"))
+	assert.FileExists(t, filepath.Join(config.DestPath, "my_msgs/msg/common.gen.go"))
+	assert.NotContains(t, loadManifest(config.DestPath).Interfaces, "my_msgs/msg/B")
+
+	config.KeepGoing = false
+	config.ROSPkgIncludes = []string{"other_msgs"}
+	_, err = New(&config).GenerateGolangMessageTypes()
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "", errs[0].Path)
+	assert.EqualError(t, errs[0], "failed to generate package other_msgs: package not found")
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -10,7 +11,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
 	"al.essio.dev/pkg/shellescape"
@@ -32,6 +35,14 @@ func NewRule(pattern string) (*Rule, error) {
 
 type RuleSet []*Rule
 
+func (s RuleSet) patterns() []string {
+	patterns := make([]string, len(s))
+	for i, r := range s {
+		patterns[i] = r.Pattern.String()
+	}
+	return patterns
+}
+
 func (s RuleSet) Includes(str string) bool {
 	for _, r := range s {
 		if r.Pattern.MatchString(str) {
@@ -42,6 +53,9 @@ func (s RuleSet) Includes(str string) bool {
 }
 
 type Config struct {
+	// Distro is the ROS2 distribution the bindings are generated for, e.g.
+	// "jazzy". It selects the rclgo runtime package used by the bindings.
+	Distro              string
 	DistroImportPath    string
 	RclgoImportPath     string
 	MessageModulePrefix string
@@ -51,26 +65,91 @@ type Config struct {
 	RegexIncludes       RuleSet
 	ROSPkgIncludes      []string
 	GoPkgIncludes       []string
-}
-
-var DefaultConfig = Config{
-	DistroImportPath:    fmt.Sprintf("github.com/okieraised/rclgo/%s", filepath.Base(os.Getenv(distro.AmentPrefixPath))),
-	RclgoImportPath:     fmt.Sprintf("github.com/okieraised/rclgo/%s", filepath.Base(os.Getenv(distro.AmentPrefixPath))),
-	MessageModulePrefix: "github.com/okieraised/rclgo-msgs",
-}
-
-// RclgoRepoRootPath returns the path to the root of the rclgo repository.
-// Panics if the path can't be determined.
-func RclgoRepoRootPath() string {
+	// RegexExcludes excludes the packages it matches from generation, even
+	// if they are included otherwise.
+	RegexExcludes RuleSet
+	// Blacklist contains regular expressions matched against the paths of
+	// interface definition files in addition to the built-in blacklist.
+	// Matching files are ignored.
+	Blacklist []string
+	// GoImportPaths maps ROS2 packages to the Go import paths of their
+	// bindings, overriding MessageModulePrefix/<package>. The bindings of
+	// these packages must exist already, and they are not generated.
+	GoImportPaths map[string]string
+	// StructTags are struct tag keys, e.g. "json", added to the fields of
+	// generated messages next to the yaml tag. The value of each tag is the
+	// ROS2 name of the field.
+	StructTags []string
+	// TypedEnums matches the packages whose messages use the enum types
+	// generated for groups of constants as the types of the fields named
+	// after the groups, e.g. the status field for the STATUS_* constants.
+	TypedEnums RuleSet
+	// NativeTime maps fields of the types builtin_interfaces/msg/Time and
+	// Duration to time.Time and time.Duration. Arrays and sequences of them
+	// keep the message types.
+	NativeTime bool
+	// Templates are user-supplied templates replacing the built-in ones or
+	// generating additional files.
+	Templates []Template
+	// PackageModules makes each generated ROS2 package a Go module of its
+	// own. See Generator.GenerateGoModules.
+	PackageModules bool
+	// RclgoVersion is the version of the rclgo module, whose path is
+	// RclgoImportPath, required by the modules generated with PackageModules.
+	RclgoVersion string
+	// RclgoDir is a directory containing the rclgo module. If it is set,
+	// the go.work file generated with PackageModules uses it, and
+	// RclgoVersion is not needed.
+	RclgoDir string
+
+	// Workers is the maximum number of interfaces generated concurrently.
+	// If it is not positive, runtime.GOMAXPROCS(0) is used.
+	Workers int
+	// Force regenerates interfaces that are up to date according to the
+	// manifest in DestPath.
+	Force bool
+	// KeepGoing generates as much as possible after errors instead of
+	// stopping at the first one. The errors are returned in either case.
+	KeepGoing bool
+	// Check renders all files in memory and compares them with the files in
+	// DestPath instead of writing them. The differences are reported by
+	// Generator.ReportDrift.
+	Check bool
+}
+
+// GoPackagePath returns the Go import path of the directory containing the
+// msg, srv and action packages of the bindings of the ROS2 package pkg.
+func (c *Config) GoPackagePath(pkg string) string {
+	if p, ok := c.GoImportPaths[pkg]; ok {
+		return p
+	}
+	return c.MessageModulePrefix + "/" + pkg
+}
+
+// DefaultConfig is the default configuration for the distribution of the
+// sourced ROS2 environment, if any.
+var DefaultConfig = DefaultConfigForDistro(distro.FromEnv())
+
+// DefaultConfigForDistro returns the default configuration for the ROS2
+// distribution d, e.g. "jazzy".
+func DefaultConfigForDistro(d string) Config {
+	return Config{
+		Distro:              d,
+		DistroImportPath:    fmt.Sprintf("github.com/okieraised/rclgo/%s", d),
+		RclgoImportPath:     fmt.Sprintf("github.com/okieraised/rclgo/%s", d),
+		MessageModulePrefix: "github.com/okieraised/rclgo-msgs",
+	}
+}
+
+// RclgoRepoRootPath returns the path to the rclgo module of the ROS2
+// distribution d in the rclgo repository. Panics if the path can't be
+// determined.
+func RclgoRepoRootPath(d string) string {
 	_, file, _, ok := runtime.Caller(0)
 	if !ok {
 		panic("could not determine rclgo repo root path")
 	}
-	outputPath := filepath.Join(file, fmt.Sprintf("../../%s", filepath.Base(os.Getenv(distro.AmentPrefixPath))))
-
-	fmt.Println(fmt.Sprintf("generating rclgo module for ROS2 [%s] in [%s]", filepath.Base(os.Getenv(distro.AmentPrefixPath)), outputPath))
-
-	return outputPath
+	return filepath.Join(file, "../..", d)
 }
 
 type Generator struct {
@@ -78,12 +157,36 @@ type Generator struct {
 	cImportsByPkgAndType map[string]utilities.StringSet
 	allPackages          map[string]*rosPkgRef
 	actionMsgNeeded      bool
+	plugins              []Plugin
+	templatePlugin       *templatePlugin
+	templates            map[string]*template.Template // replacements by kind
+	configHash           string
+
+	// State of GenerateGolangMessageTypes. The fields below mu and the ones
+	// above are guarded by mu while interfaces are generated.
+	wg          sync.WaitGroup
+	workers     chan struct{}
+	oldManifest *manifest
+	mu          sync.Mutex
+	manifest    *manifest
+	processed   map[string]bool // manifest keys of the interfaces handled
+	generated   int
+	skipped     int
+	errs        GenerateErrors
+
+	// Results of Config.Check, guarded by mu.
+	rendered        map[string]bool     // paths of the files rendered
+	renderedImports map[string][]string // import paths of the Go files rendered by path
+	drift           map[string]string   // unified diffs of outdated files by path
 }
 
 func New(config *Config) *Generator {
 	return &Generator{
 		config:               config,
 		cImportsByPkgAndType: make(map[string]utilities.StringSet),
+		rendered:             map[string]bool{},
+		renderedImports:      map[string][]string{},
+		drift:                map[string]string{},
 	}
 }
 
@@ -94,7 +197,7 @@ func (g *Generator) GeneratePrimitives() error {
 		primitiveTypes,
 		templateData{
 			"PMap":      &primitiveTypeMappings,
-			"ROSDistro": filepath.Base(os.Getenv(distro.AmentPrefixPath)),
+			"ROSDistro": g.config.Distro,
 		},
 	)
 }
@@ -105,8 +208,8 @@ func (g *Generator) GenerateRclgoFlags() error {
 		filepath.Join(g.config.DestPath, "flags.gen.go"),
 		rclgoFlags,
 		templateData{
-			"ROSIncludes": retrieveRCLGOROSIncludes(),
-			"ROSDistro":   filepath.Base(os.Getenv(distro.AmentPrefixPath)),
+			"ROSIncludes": retrieveRCLGOROSIncludes(g.config.Distro),
+			"ROSDistro":   g.config.Distro,
 		},
 	)
 }
@@ -130,6 +233,14 @@ func (g *Generator) GenerateROS2AllMessagesImporter() error {
 			)] = struct{}{}
 		}
 	}
+	if g.config.Check {
+		// The directories of new packages exist only in memory.
+		for pkgAndType := range g.cImportsByPkgAndType {
+			if pkg, typ, err := parsePkgAndType(pkgAndType); err == nil {
+				packages[path.Join(pkg, typ)] = struct{}{}
+			}
+		}
+	}
 	return g.generateGoFile(
 		filepath.Join(g.config.DestPath, "msgs.gen.go"),
 		ros2MsgImportAllPackage,
@@ -137,7 +248,7 @@ func (g *Generator) GenerateROS2AllMessagesImporter() error {
 	)
 }
 
-func retrieveRCLGOROSIncludes() []string {
+func retrieveRCLGOROSIncludes(d string) []string {
 	rclgoROSIncludes := []string{
 		"rcl",
 		"rmw",
@@ -151,7 +262,7 @@ func retrieveRCLGOROSIncludes() []string {
 		"rcl_yaml_param_parser",
 	}
 
-	if filepath.Base(os.Getenv(distro.AmentPrefixPath)) == distro.ROSJazzy {
+	if d == distro.ROSJazzy {
 		rclgoROSIncludes = append(rclgoROSIncludes, "rosidl_dynamic_typesupport", "service_msgs", "type_description_interfaces")
 	}
 
@@ -179,12 +290,14 @@ func (g *Generator) GenerateCGOFlags() error {
 	if g.config.CGOFlagsPath == "" {
 		return nil
 	}
-	_, _ = fmt.Fprintln(os.Stderr, "Generating CGO flags:", g.config.CGOFlagsPath)
+	if !g.config.Check {
+		_, _ = fmt.Fprintln(os.Stderr, "Generating CGO flags:", g.config.CGOFlagsPath)
+	}
 	libDirs := utilities.StringSet{}
 	includes := utilities.StringSet{}
 	for _, rootPath := range g.config.RootPaths {
 		libDirs.Add(libDirFlag(rootPath))
-		for _, dep := range retrieveRCLGOROSIncludes() {
+		for _, dep := range retrieveRCLGOROSIncludes(g.config.Distro) {
 			includes.Add(includeDirFlag(rootPath, dep))
 		}
 		for pkgAndType, imports := range g.cImportsByPkgAndType {
@@ -199,6 +312,15 @@ func (g *Generator) GenerateCGOFlags() error {
 			}
 		}
 	}
+	if g.config.Check {
+		if g.config.CGOFlagsPath == "-" {
+			return nil
+		}
+		var buf bytes.Buffer
+		_ = writeFlagEnv(&buf, "CFLAGS", includes)
+		_ = writeFlagEnv(&buf, "LDFLAGS", libDirs)
+		return g.checkFile(g.config.CGOFlagsPath, buf.Bytes())
+	}
 	var err error
 	out := os.Stdout
 	if g.config.CGOFlagsPath != "-" {
@@ -224,8 +346,38 @@ type rosPkgRef struct {
 	Generated  bool
 }
 
-func (g *Generator) GenerateGolangMessageTypes() error {
-	g.findPackages()
+// GenerateSummary counts the interfaces handled by GenerateGolangMessageTypes.
+type GenerateSummary struct {
+	Generated int
+	// Skipped is the number of interfaces whose bindings were up to date.
+	Skipped int
+	Failed  int
+	// Removed is the number of files removed because their interfaces no
+	// longer exist.
+	Removed int
+}
+
+func (s GenerateSummary) String() string {
+	return fmt.Sprintf("Generated %d interfaces, skipped %d up-to-date interfaces, %d failed, removed %d stale files", s.Generated, s.Skipped, s.Failed, s.Removed)
+}
+
+func (g *Generator) GenerateGolangMessageTypes() (GenerateSummary, error) {
+	if err := g.findPackages(); err != nil {
+		return GenerateSummary{}, err
+	}
+	if err := g.loadTemplates(); err != nil {
+		return GenerateSummary{}, err
+	}
+	g.configHash = configHash(g.config)
+	workers := g.config.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	g.workers = make(chan struct{}, workers)
+	g.oldManifest = loadManifest(g.config.DestPath)
+	g.manifest = &manifest{Interfaces: map[string]*manifestEntry{}}
+	g.processed = map[string]bool{}
+
 	if len(g.config.RegexIncludes) == 0 && len(g.config.ROSPkgIncludes) == 0 && len(g.config.GoPkgIncludes) == 0 {
 		for pkg := range g.allPackages {
 			g.generatePkg(pkg, false)
@@ -236,7 +388,8 @@ func (g *Generator) GenerateGolangMessageTypes() error {
 		}
 		goDeps, err := loadGoPkgDeps(g.config.GoPkgIncludes...)
 		if err != nil {
-			return fmt.Errorf("failed to load Go deps: %w", err)
+			g.wg.Wait()
+			return GenerateSummary{}, fmt.Errorf("failed to load Go deps: %w", err)
 		}
 		prefix := g.config.MessageModulePrefix + "/"
 		for goDep := range goDeps {
@@ -245,44 +398,127 @@ func (g *Generator) GenerateGolangMessageTypes() error {
 				g.generatePkg(path.Dir(pkgWithType), true)
 			}
 		}
-		if g.actionMsgNeeded {
-			g.generatePkg("action_msgs", true)
-		}
 		for pkg := range g.allPackages {
 			if g.config.RegexIncludes.Includes(pkg) {
 				g.generatePkg(pkg, false)
 			}
 		}
+		// Whether actions are needed is known only after the dependencies
+		// have been generated.
+		g.wg.Wait()
+		if g.actionMsgNeeded {
+			g.generatePkg("action_msgs", true)
+		}
 	}
-	for pkgAndType, imports := range g.cImportsByPkgAndType {
-		err := g.generateCommonPackageGoFile(pkgAndType, imports)
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Failed to generate common package file for package %s: %v\n", pkgAndType, err)
+	g.wg.Wait()
+
+	removed := g.pruneRemovedInterfaces()
+	if !g.config.Check {
+		if err := g.manifest.save(g.config.DestPath); err != nil {
+			g.addError(newGenerateError("", fmt.Errorf("failed to write generation manifest: %w", err)))
 		}
 	}
-	return nil
+	if len(g.errs) == 0 || g.config.KeepGoing {
+		for pkgAndType, imports := range g.cImportsByPkgAndType {
+			err := g.generateCommonPackageGoFile(pkgAndType, imports)
+			if err != nil {
+				g.addError(newGenerateError("", fmt.Errorf("failed to generate common package file for package %s: %w", pkgAndType, err)))
+			}
+		}
+	}
+	summary := GenerateSummary{Generated: g.generated, Skipped: g.skipped, Failed: g.failed(), Removed: removed}
+	if len(g.errs) > 0 {
+		sort.SliceStable(g.errs, func(i, j int) bool { return g.errs[i].Path < g.errs[j].Path })
+		return summary, g.errs
+	}
+	return summary, nil
+}
+
+// addError records err. Unless Config.KeepGoing is set, no more interfaces
+// are generated after the first error.
+func (g *Generator) addError(err *GenerateError) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs = append(g.errs, err)
+}
+
+// stopped reports whether generation should stop because of an error.
+func (g *Generator) stopped() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.errs) > 0 && !g.config.KeepGoing
+}
+
+// failed returns the number of interfaces that failed to generate.
+func (g *Generator) failed() (n int) {
+	for _, err := range g.errs {
+		if err.Path != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// pruneRemovedInterfaces deletes the outputs of interfaces in the previous
+// manifest that no longer exist. Interfaces that exist but were not handled
+// in this run keep their entries.
+func (g *Generator) pruneRemovedInterfaces() (removed int) {
+	exists := map[string]bool{}
+	for _, ref := range g.allPackages {
+		for meta := range ref.Interfaces {
+			exists[manifestKey(meta)] = true
+		}
+	}
+	for key, entry := range g.oldManifest.Interfaces {
+		switch {
+		case g.processed[key]:
+		case exists[key]:
+			g.manifest.Interfaces[key] = entry
+		case g.config.Check:
+			for _, out := range entry.allOutputs() {
+				g.checkStale(filepath.Join(g.config.DestPath, out))
+			}
+		default:
+			removed += pruneOutputs(g.config.DestPath, entry)
+		}
+	}
+	return removed
 }
 
 func (g *Generator) generatePkg(pkg string, genDeps bool) {
+	if _, ok := g.config.GoImportPaths[pkg]; ok || g.config.RegexExcludes.Includes(pkg) {
+		return
+	}
+	g.mu.Lock()
 	ref := g.allPackages[pkg]
 	if ref == nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Failed to generate package %s: package not found\n", pkg)
-	} else if !ref.Generated {
-		ref.Generated = true
-		for meta, pth := range ref.Interfaces {
-			g.generateInterface(meta, pth)
-		}
-		if genDeps {
-			for imp := range g.cImportsByPkgAndType[pkg+"_msg"] {
-				g.generatePkg(imp, genDeps)
-			}
-			for imp := range g.cImportsByPkgAndType[pkg+"_srv"] {
-				g.generatePkg(imp, genDeps)
+		g.mu.Unlock()
+		g.addError(newGenerateError("", fmt.Errorf("failed to generate package %s: package not found", pkg)))
+		return
+	}
+	if ref.Generated {
+		g.mu.Unlock()
+		return
+	}
+	ref.Generated = true
+	g.mu.Unlock()
+	for meta, pth := range ref.Interfaces {
+		g.wg.Add(1)
+		go func(meta Metadata, pth string) {
+			defer g.wg.Done()
+			g.workers <- struct{}{}
+			if g.stopped() {
+				<-g.workers
+				return
 			}
-			for imp := range g.cImportsByPkgAndType[pkg+"_action"] {
-				g.generatePkg(imp, genDeps)
+			cImports := g.generateInterface(meta, pth)
+			<-g.workers
+			if genDeps {
+				for imp := range cImports {
+					g.generatePkg(imp, genDeps)
+				}
 			}
-		}
+		}(meta, pth)
 	}
 }
 
@@ -295,81 +531,169 @@ func (g *Generator) getCImportsForPkgAndType(pkgAndType string) utilities.String
 	return set
 }
 
-func (g *Generator) generateInterface(meta Metadata, ifacePath string) {
-	_, _ = fmt.Fprintf(os.Stderr, "Generating: %s\n", ifacePath)
+// generateInterface generates the bindings of the interface meta defined in
+// ifacePath unless they are up to date, and returns the C packages they
+// depend on.
+func (g *Generator) generateInterface(meta Metadata, ifacePath string) utilities.StringSet {
+	key := manifestKey(meta)
+	entry := &manifestEntry{
+		Source:           ifacePath,
+		GeneratorVersion: generatorVersion(),
+		ConfigHash:       g.configHash,
+		Outputs:          interfaceOutputs(meta),
+	}
+	hash, err := hashFile(ifacePath)
+	if err == nil {
+		entry.SourceHash = hash
+		old := g.oldManifest.Interfaces[key]
+		if !g.config.Force && !g.config.Check && upToDate(old, entry, g.config.DestPath) {
+			cImports := utilities.StringSet{}
+			cImports.Add(old.CImports...)
+			g.addGenerated(meta, key, old, cImports, nil)
+			return cImports
+		}
+	}
+
+	if !g.config.Check {
+		_, _ = fmt.Fprintf(os.Stderr, "Generating: %s\n", ifacePath)
+	}
+	var cImports utilities.StringSet
+	var iface any
 	switch meta.Type {
 	case "msg":
-		result, err := g.generateMessage(&meta, ifacePath)
+		var result *ROS2Message
+		result, err = g.generateMessage(&meta, ifacePath)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error converting ROS2 Message '%s' to '%s', error: %v\n", ifacePath, g.config.DestPath, err)
-		}
-		set := g.getCImportsForPkgAndType(result.GoPackage())
-		if result != nil {
-			set.AddFrom(result.CImports)
+			break
 		}
+		iface = result
+		cImports = result.CImports
 	case "srv":
-		result, err := g.generateService(&meta, ifacePath)
+		var result *ROS2Service
+		result, err = g.generateService(&meta, ifacePath)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error converting ROS2 Service '%s' to '%s', error: %v\n", ifacePath, g.config.DestPath, err)
-		}
-		set := g.getCImportsForPkgAndType(result.GoPackage())
-		if result != nil {
-			set.AddFrom(result.Request.CImports)
-			set.AddFrom(result.Response.CImports)
+			break
 		}
+		iface = result
+		cImports = utilities.StringSet{}
+		cImports.AddFrom(result.Request.CImports)
+		cImports.AddFrom(result.Response.CImports)
 	case "action":
-		result, err := g.generateAction(ifacePath)
+		var result *ROS2Action
+		result, err = g.generateAction(ifacePath)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error converting ROS2 Action '%s' to '%s', error: %v\n", ifacePath, g.config.DestPath, err)
+			break
 		}
+		iface = result
+		cImports = utilities.StringSet{}
+		cImports.AddFrom(result.Goal.CImports)
+		cImports.AddFrom(result.SendGoal.Request.CImports)
+		cImports.AddFrom(result.SendGoal.Response.CImports)
+		cImports.AddFrom(result.Result.CImports)
+		cImports.AddFrom(result.GetResult.Request.CImports)
+		cImports.AddFrom(result.GetResult.Response.CImports)
+		cImports.AddFrom(result.Feedback.CImports)
+		cImports.AddFrom(result.FeedbackMessage.CImports)
+	default:
+		err = fmt.Errorf("invalid interface type: %s", meta.Type)
+	}
+	if err == nil {
+		entry.PluginOutputs, err = g.runInterfacePlugins(meta, iface)
+	}
+	if err != nil {
+		g.addGenerated(meta, key, nil, nil, newGenerateError(ifacePath, err))
+		return nil
+	}
+	entry.CImports = cImports.ToSortedSlice()
+	g.addGenerated(meta, key, entry, cImports, nil)
+	return cImports
+}
+
+// addGenerated records that the bindings of meta have been generated, or
+// skipped if they were up to date. entry is nil if the generation failed
+// with err.
+func (g *Generator) addGenerated(meta Metadata, key string, entry *manifestEntry, cImports utilities.StringSet, err *GenerateError) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// The package is generated even if some of its interfaces fail, so
+	// that the others can be used with Config.KeepGoing.
+	set := g.getCImportsForPkgAndType(meta.GoPackage())
+	set.AddFrom(cImports)
+	if meta.Type == "action" {
 		g.actionMsgNeeded = true
-		set := g.getCImportsForPkgAndType(result.GoPackage())
-		if result != nil {
-			set.AddFrom(result.Goal.CImports)
-			set.AddFrom(result.SendGoal.Request.CImports)
-			set.AddFrom(result.SendGoal.Response.CImports)
-			set.AddFrom(result.Result.CImports)
-			set.AddFrom(result.GetResult.Request.CImports)
-			set.AddFrom(result.GetResult.Response.CImports)
-			set.AddFrom(result.Feedback.CImports)
-			set.AddFrom(result.FeedbackMessage.CImports)
-		}
+	}
+	g.processed[key] = true
+	switch {
+	case err != nil:
+		g.errs = append(g.errs, err)
+	case entry == g.oldManifest.Interfaces[key]:
+		g.skipped++
+		g.manifest.Interfaces[key] = entry
 	default:
-		_, _ = fmt.Fprintf(os.Stderr, "Interface file %s has an invalid type: %s\n", ifacePath, meta.Type)
+		g.generated++
+		g.manifest.Interfaces[key] = entry
 	}
 }
 
-var interfaceFileRE = regexp.MustCompile(`/(?:msg/.+\.msg|srv/.+\.srv|action/.+\.action)$`)
+var interfaceFileRE = regexp.MustCompile(`/(?:msg/.+\.(?:msg|idl)|srv/.+\.(?:srv|idl)|action/.+\.(?:action|idl))$`)
 
-func (g *Generator) findPackages() {
+func (g *Generator) findPackages() error {
+	bl, err := blacklistFor(g.config)
+	if err != nil {
+		return err
+	}
 	g.allPackages = map[string]*rosPkgRef{}
 	for i := len(g.config.RootPaths) - 1; i >= 0; i-- {
-		_ = filepath.Walk(g.config.RootPaths[i], func(path string, info fs.FileInfo, err error) error { //nolint:errcheck
+		for meta, path := range findInterfaces(g.config.RootPaths[i], bl) {
+			ref := g.allPackages[meta.Package]
+			if ref == nil {
+				ref = &rosPkgRef{Interfaces: map[Metadata]string{}}
+				g.allPackages[meta.Package] = ref
+			}
+			ref.Interfaces[meta] = path
+		}
+	}
+	return nil
+}
+
+// findInterfaces returns the paths of the interface definition files under
+// root.
+func findInterfaces(root string, bl *blacklist) map[Metadata]string {
+	// Packages usually install an .idl file generated by rosidl next to each
+	// .msg, .srv and .action file. The original definition is preferred, and
+	// the .idl file is used only if it is the only one.
+	interfaces := map[Metadata]string{}
+	_ = filepath.Walk(root, func(path string, info fs.FileInfo, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		skip, blacklistEntry := bl.matches(path)
+		if skip {
+			_, _ = fmt.Fprintf(os.Stderr, "Blacklisted: %s, matched regex '%s'\n", path, blacklistEntry)
+			return nil
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil
+		}
+		if interfaceFileRE.MatchString(filepath.ToSlash(absPath)) {
+			meta, err := parseMetadataFromPath(absPath)
 			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Failed to parse metadata from path %s: %v\n", path, err)
 				return nil
 			}
-			skip, blacklistEntry := blacklisted(path)
-			if skip {
-				_, _ = fmt.Fprintf(os.Stderr, "Blacklisted: %s, matched regex '%s'\n", path, blacklistEntry)
-				return nil
+			if prev, ok := interfaces[*meta]; !ok || isIDLFile(prev) {
+				interfaces[*meta] = path
 			}
+		}
+		return nil
+	})
+	return interfaces
+}
 
-			if interfaceFileRE.MatchString(filepath.ToSlash(path)) {
-				meta, err := parseMetadataFromPath(path)
-				if err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Failed to parse metadata from path %s: %v\n", path, err)
-					return nil
-				}
-				ref := g.allPackages[meta.Package]
-				if ref == nil {
-					ref = &rosPkgRef{Interfaces: map[Metadata]string{}}
-					g.allPackages[meta.Package] = ref
-				}
-				ref.Interfaces[*meta] = path
-			}
-			return nil
-		})
-	}
+func isIDLFile(path string) bool {
+	return filepath.Ext(path) == ".idl"
 }
 
 func (g *Generator) generateMessage(md *Metadata, sourcePath string) (*ROS2Message, error) {
@@ -383,12 +707,16 @@ func (g *Generator) generateMessage(md *Metadata, sourcePath string) (*ROS2Messa
 		return nil, err
 	}
 
-	prs := parser{config: g.config}
-	err = prs.ParseROS2Message(msg, string(content))
+	prs := parser{config: g.config, file: sourcePath}
+	if isIDLFile(sourcePath) {
+		err = prs.ParseIDLMessage(msg, string(content))
+	} else {
+		err = prs.ParseROS2Message(msg, string(content))
+	}
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateMessageGoFile(&prs, msg)
+	err = g.generateMessageGoFile(msg)
 	if err != nil {
 		return nil, err
 	}
@@ -403,6 +731,10 @@ func parseMetadataFromPath(p string) (*Metadata, error) {
 		Type: ext[1:],
 	}
 	dirs := strings.Split(p, string(filepath.Separator))
+	if ext == ".idl" && len(dirs) >= 2 {
+		// The type of an IDL file is given by its directory.
+		m.Type = dirs[len(dirs)-2]
+	}
 
 	if len(dirs) >= 2 {
 		m.Package = dirs[len(dirs)-3]
@@ -423,11 +755,16 @@ func (g *Generator) generateService(m *Metadata, srcPath string) (*ROS2Service,
 	if err != nil {
 		return nil, err
 	}
-	prs := parser{config: g.config}
-	if err = prs.ParseService(service, string(srcFile)); err != nil {
+	prs := parser{config: g.config, file: srcPath}
+	if isIDLFile(srcPath) {
+		err = prs.ParseIDLService(service, string(srcFile))
+	} else {
+		err = prs.ParseService(service, string(srcFile))
+	}
+	if err != nil {
 		return nil, err
 	}
-	err = g.generateServiceGoFiles(&prs, service)
+	err = g.generateServiceGoFiles(service)
 	if err != nil {
 		return nil, err
 	}
@@ -444,42 +781,44 @@ func (g *Generator) generateAction(srcPath string) (*ROS2Action, error) {
 	if err != nil {
 		return nil, err
 	}
-	prs := parser{config: g.config}
-	if err = prs.ParseAction(action, string(srcFile)); err != nil {
+	prs := parser{config: g.config, file: srcPath}
+	if isIDLFile(srcPath) {
+		err = prs.ParseIDLAction(action, string(srcFile))
+	} else {
+		err = prs.ParseAction(action, string(srcFile))
+	}
+	if err != nil {
 		return nil, err
 	}
 	err = g.generateIfaceGoFile(
 		action.Metadata,
-		ros2ActionToGolangTypeTemplate,
-		templateData{
-			"Action":    action,
-			"ROSDistro": filepath.Base(os.Getenv(distro.AmentPrefixPath)),
-		},
+		g.template(TemplateAction, ros2ActionToGolangTypeTemplate),
+		g.actionTemplateData(action),
 	)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateMessageGoFile(&prs, action.Goal)
+	err = g.generateMessageGoFile(action.Goal)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateMessageGoFile(&prs, action.Result)
+	err = g.generateMessageGoFile(action.Result)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateMessageGoFile(&prs, action.Feedback)
+	err = g.generateMessageGoFile(action.Feedback)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateServiceGoFiles(&prs, action.SendGoal)
+	err = g.generateServiceGoFiles(action.SendGoal)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateServiceGoFiles(&prs, action.GetResult)
+	err = g.generateServiceGoFiles(action.GetResult)
 	if err != nil {
 		return nil, err
 	}
-	err = g.generateMessageGoFile(&prs, action.FeedbackMessage)
+	err = g.generateMessageGoFile(action.FeedbackMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -489,6 +828,18 @@ func (g *Generator) generateAction(srcPath string) (*ROS2Action, error) {
 type templateData = map[string]interface{}
 
 func (g *Generator) generateGoFile(destPath string, tmpl *template.Template, data templateData) error {
+	if data == nil {
+		data = templateData{"Config": g.config}
+	} else if _, ok := data["Config"]; !ok {
+		data["Config"] = g.config
+	}
+	if g.config.Check {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		return g.checkFile(destPath, buf.Bytes())
+	}
 	f, err := utilities.MkDirParent(destPath)
 	if err != nil {
 		return err
@@ -499,11 +850,6 @@ func (g *Generator) generateGoFile(destPath string, tmpl *template.Template, dat
 			err = cErr
 		}
 	}(f)
-	if data == nil {
-		data = templateData{"Config": g.config}
-	} else if _, ok := data["Config"]; !ok {
-		data["Config"] = g.config
-	}
 	return tmpl.Execute(f, data)
 }
 
@@ -511,36 +857,60 @@ func (g *Generator) generateIfaceGoFile(meta *Metadata, tmpl *template.Template,
 	return g.generateGoFile(iFaceFilePath(g.config.DestPath, meta), tmpl, data)
 }
 
-func (g *Generator) generateMessageGoFile(parser *parser, msg *ROS2Message) error {
+func (g *Generator) generateMessageGoFile(msg *ROS2Message) error {
+	addEnums(msg, g.config.TypedEnums.Includes(msg.Package))
+	if matchMsg(msg, "builtin_interfaces_msg", "Time") || matchMsg(msg, "builtin_interfaces_msg", "Duration") {
+		// The conversion methods use the time package.
+		msg.GoImports["time"] = ""
+	}
 	return g.generateIfaceGoFile(
 		msg.Metadata,
-		ros2MsgToGolangTypeTemplate,
-		templateData{
-			"Message":             msg,
-			"cSerializationCode":  parser.cSerializationCode,
-			"goSerializationCode": parser.goSerializationCode,
-			"ROSDistro":           filepath.Base(os.Getenv(distro.AmentPrefixPath)),
-		},
+		g.template(TemplateMessage, ros2MsgToGolangTypeTemplate),
+		g.messageTemplateData(msg),
 	)
 }
 
-func (g *Generator) generateServiceGoFiles(parser *parser, srv *ROS2Service) error {
+func (g *Generator) messageTemplateData(msg *ROS2Message) templateData {
+	prs := &parser{config: g.config}
+	return templateData{
+		"Message":              msg,
+		"cSerializationCode":   prs.cSerializationCode,
+		"goSerializationCode":  prs.goSerializationCode,
+		"validateCode":         prs.validateCode,
+		"diffCode":             prs.diffCode,
+		"fieldDescriptionCode": prs.fieldDescriptionCode,
+		"ROSDistro":            g.config.Distro,
+	}
+}
+
+func (g *Generator) serviceTemplateData(srv *ROS2Service) templateData {
+	return templateData{
+		"Service":   srv,
+		"ROSDistro": g.config.Distro,
+	}
+}
+
+func (g *Generator) actionTemplateData(action *ROS2Action) templateData {
+	return templateData{
+		"Action":    action,
+		"ROSDistro": g.config.Distro,
+	}
+}
+
+func (g *Generator) generateServiceGoFiles(srv *ROS2Service) error {
 	err := g.generateIfaceGoFile(
 		srv.Metadata,
-		ros2ServiceToGolangTypeTemplate,
-		templateData{
-			"Service":   srv,
-			"ROSDistro": filepath.Base(os.Getenv(distro.AmentPrefixPath)),
-		},
+		g.template(TemplateService, ros2ServiceToGolangTypeTemplate),
+		g.serviceTemplateData(srv),
 	)
 	if err != nil {
 		return err
 	}
-	err = g.generateMessageGoFile(parser, srv.Request)
+	err = g.generateMessageGoFile(srv.Request)
 	if err != nil {
 		return err
 	}
-	return g.generateMessageGoFile(parser, srv.Response)
+	return g.generateMessageGoFile(srv.Response)
 }
 
 func (g *Generator) generateCommonPackageGoFile(pkgAndType string, cImports utilities.StringSet) error {
@@ -549,28 +919,29 @@ func (g *Generator) generateCommonPackageGoFile(pkgAndType string, cImports util
 		return err
 	}
 
-	if filepath.Base(os.Getenv(distro.AmentPrefixPath)) == distro.ROSHumble {
-		return g.generateGoFile(
-			filepath.Join(g.config.DestPath, cPkg, pkgType, "common.gen.go"),
-			ros2HumblePackageCommonTemplate,
-			templateData{
-				"GoPackage": pkgAndType,
-				"CPackage":  cPkg,
-				"CImports":  cImports,
-			},
-		)
-	} else {
-		return g.generateGoFile(
-			filepath.Join(g.config.DestPath, cPkg, pkgType, "common.gen.go"),
-			ros2JazzyPackageCommonTemplate,
-			templateData{
-				"GoPackage": pkgAndType,
-				"CPackage":  cPkg,
-				"CImports":  cImports,
-			},
-		)
+	tmpl := ros2JazzyPackageCommonTemplate
+	if g.config.Distro == distro.ROSHumble {
+		tmpl = ros2HumblePackageCommonTemplate
 	}
-
+	err = g.generateGoFile(
+		filepath.Join(g.config.DestPath, cPkg, pkgType, "common.gen.go"),
+		g.template(TemplatePackage, tmpl),
+		templateData{
+			"GoPackage": pkgAndType,
+			"CPackage":  cPkg,
+			"CImports":  cImports,
+			"ROSDistro": g.config.Distro,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return g.runPackagePlugins(&PluginPackage{
+		Package:   cPkg,
+		Type:      pkgType,
+		GoPackage: pkgAndType,
+		CImports:  cImports.ToSortedSlice(),
+	})
 }
 
 func parsePkgAndType(pkgAndType string) (pkg string, typ string, err error) {
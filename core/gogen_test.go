@@ -0,0 +1,160 @@
+package core
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateForDistro(t *testing.T) {
+	t.Setenv("AMENT_PREFIX_PATH", "")
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg": "string s\n",
+	})
+	for _, d := range []string{"humble", "jazzy"} {
+		t.Run(d, func(t *testing.T) {
+			config := DefaultConfigForDistro(d)
+			config.RootPaths = []string{root}
+			config.DestPath = t.TempDir()
+			_, err := New(&config).GenerateGolangMessageTypes()
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(config.DestPath, "my_msgs/msg/A.gen.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(content), `"github.com/okieraised/rclgo/`+d+`"`)
+			assert.Contains(t, string(content), d+".StringAsCStruct(")
+		})
+	}
+}
+
+func TestGenerateProjectOptions(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg":    "other_msgs/B b\nint32 a\n",
+		"my_msgs/msg/Skip.msg": "int32 a\n",
+		"other_msgs/msg/B.msg": "int32 b\n",
+		"test_msgs/msg/C.msg":  "int32 c\n",
+	})
+	config := DefaultConfig
+	config.RootPaths = []string{root}
+	config.DestPath = t.TempDir()
+	config.ROSPkgIncludes = []string{"my_msgs"}
+	config.RegexIncludes = RuleSet{mustNewRule(t, ".*_msgs")}
+	config.RegexExcludes = RuleSet{mustNewRule(t, "test_.*")}
+	config.Blacklist = []string{"my_msgs/msg/Skip"}
+	config.GoImportPaths = map[string]string{"other_msgs": "example.com/other/other_msgs"}
+	config.StructTags = []string{"json"}
+	_, err := New(&config).GenerateGolangMessageTypes()
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(config.DestPath, "my_msgs/msg/A.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `other_msgs_msg "example.com/other/other_msgs/msg"`)
+	assert.Contains(t, string(content), "`yaml:\"a\" json:\"a\"`")
+	assert.NoFileExists(t, filepath.Join(config.DestPath, "my_msgs/msg/Skip.gen.go"))
+	assert.NoDirExists(t, filepath.Join(config.DestPath, "other_msgs"))
+	assert.NoDirExists(t, filepath.Join(config.DestPath, "test_msgs"))
+
+	config.Blacklist = []string{"("}
+	_, err = New(&config).GenerateGolangMessageTypes()
+	assert.ErrorContains(t, err, `invalid blacklist pattern "("`)
+}
+
+func mustNewRule(t *testing.T, pattern string) *Rule {
+	t.Helper()
+	rule, err := NewRule(pattern)
+	require.NoError(t, err)
+	return rule
+}
+
+var update = flag.Bool("update", false, "rewrite the golden files of TestGenerateGolden")
+
+// TestGenerateGolden generates the interfaces in testdata/interfaces and
+// compares the generated files with the golden files in testdata/golden. Run
+// the test with -update after a change of the templates and review the diff of
+// the golden files.
+func TestGenerateGolden(t *testing.T) {
+	t.Setenv("AMENT_PREFIX_PATH", "")
+	for _, tc := range []struct {
+		name   string
+		distro string
+		config func(*Config)
+	}{
+		{name: "jazzy", distro: "jazzy"},
+		{name: "humble", distro: "humble"},
+		{name: "jazzy_options", distro: "jazzy", config: func(c *Config) {
+			c.RegexIncludes = RuleSet{mustNewRule(t, "my_msgs")}
+			c.NativeTime = true
+			c.TypedEnums = RuleSet{mustNewRule(t, "my_.*")}
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultConfigForDistro(tc.distro)
+			config.RootPaths = []string{filepath.Join("testdata", "interfaces")}
+			config.DestPath = t.TempDir()
+			if tc.config != nil {
+				tc.config(&config)
+			}
+			_, err := New(&config).GenerateGolangMessageTypes()
+			require.NoError(t, err)
+
+			generated := readGoFiles(t, config.DestPath)
+			goldenDir := filepath.Join("testdata", "golden", tc.name)
+			if *update {
+				require.NoError(t, os.RemoveAll(goldenDir))
+				for file, content := range generated {
+					path := filepath.Join(goldenDir, file+".golden")
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+					require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+				}
+			}
+			golden := map[string]string{}
+			for file, content := range readGoFiles(t, goldenDir) {
+				golden[strings.TrimSuffix(file, ".golden")] = content
+			}
+			assert.ElementsMatch(t, mapKeys(golden), mapKeys(generated), "generated files")
+			for file, content := range generated {
+				if want, ok := golden[file]; ok {
+					assert.Equal(t, want, content, "%s differs from its golden file, run the test with -update", file)
+				}
+			}
+		})
+	}
+}
+
+// readGoFiles returns the contents of the Go and golden files in dir by their
+// slash-separated path relative to dir.
+func readGoFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, ".golden") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,421 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	goparser "go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okieraised/rclgo/core/internal/utilities"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const (
+	// goModuleGoVersion is the go directive of new go.mod and go.work files.
+	goModuleGoVersion = "1.25.1"
+	// unversionedModule is the version required of the modules in the
+	// generated workspace whose version is not pinned.
+	unversionedModule = "v0.0.0-00010101000000-000000000000"
+)
+
+// GenerateGoModules makes each generated ROS2 package a Go module of its own
+// by writing a go.mod file to its directory, and writes a go.work file that
+// uses them to Config.DestPath.
+//
+// A module requires the modules of the packages imported by its Go files:
+// the rclgo module, the modules of other generated ROS2 packages and the
+// modules containing the bindings of Config.GoImportPaths. Versions already
+// required by an existing go.mod are kept. Generated modules are required
+// with a placeholder version that the workspace resolves, and so is the rclgo
+// module if Config.RclgoDir is set. Otherwise, the rclgo module is required
+// at Config.RclgoVersion, and other modules at the version the go command
+// finds in the working directory. Modules of packages that are no longer
+// generated are removed.
+func (g *Generator) GenerateGoModules() error {
+	generated := map[string]bool{}
+	for pkgAndType := range g.cImportsByPkgAndType {
+		pkg, _, err := parsePkgAndType(pkgAndType)
+		if err != nil {
+			return err
+		}
+		generated[pkg] = true
+	}
+	pkgs := make([]string, 0, len(generated))
+	for pkg := range generated {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	deps := make(map[string]utilities.StringSet, len(pkgs))
+	external := utilities.StringSet{} // imports outside of the workspace
+	for _, pkg := range pkgs {
+		imports, err := g.packageGoImports(pkg)
+		if err != nil {
+			return err
+		}
+		deps[pkg] = imports
+		for imp := range imports {
+			if g.workspaceModule(imp, generated) == "" && !isStdImport(imp) {
+				external.Add(imp)
+			}
+		}
+	}
+	externalModules, err := loadGoModules(external.ToSortedSlice()...)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		if err := g.generateGoMod(pkg, deps[pkg], generated, externalModules); err != nil {
+			return err
+		}
+	}
+	stale, err := g.pruneGoModules(generated)
+	if err != nil {
+		return err
+	}
+	return g.generateGoWork(pkgs, stale)
+}
+
+// packageGoImports returns the import paths of the Go files generated for the
+// ROS2 package pkg. With Config.Check, the rendered files are used instead of
+// the files in Config.DestPath.
+func (g *Generator) packageGoImports(pkg string) (utilities.StringSet, error) {
+	dir := filepath.Join(g.config.DestPath, pkg) + string(filepath.Separator)
+	imports := utilities.StringSet{}
+	if g.config.Check {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		for path, paths := range g.renderedImports {
+			if strings.HasPrefix(path, dir) {
+				imports.Add(paths...)
+			}
+		}
+		return imports, nil
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		paths, err := goFileImports(path, nil)
+		imports.Add(paths...)
+		return err
+	})
+	return imports, err
+}
+
+// goFileImports returns the import paths of the Go file at path. If src is
+// nil, the file is read.
+func goFileImports(path string, src []byte) ([]string, error) {
+	var source any // A nil []byte would be parsed as an empty file.
+	if src != nil {
+		source = src
+	}
+	f, err := goparser.ParseFile(token.NewFileSet(), path, source, goparser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(f.Imports))
+	for i, imp := range f.Imports {
+		if paths[i], err = strconv.Unquote(imp.Path.Value); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// workspaceModule returns the path of the module in the generated workspace
+// that contains the package with importPath, or "" if the package is not in
+// the workspace. The modules of the workspace are the rclgo module and the
+// modules of the generated ROS2 packages.
+func (g *Generator) workspaceModule(importPath string, generated map[string]bool) string {
+	if importPath == g.config.RclgoImportPath || strings.HasPrefix(importPath, g.config.RclgoImportPath+"/") {
+		return g.config.RclgoImportPath
+	}
+	if rest, ok := strings.CutPrefix(importPath, g.config.MessageModulePrefix+"/"); ok {
+		pkg, _, _ := strings.Cut(rest, "/")
+		if generated[pkg] {
+			return g.config.GoPackagePath(pkg)
+		}
+	}
+	return ""
+}
+
+func (g *Generator) generateGoMod(pkg string, imports utilities.StringSet, generated map[string]bool, externalModules map[string]module.Version) error {
+	modPath := filepath.Join(g.config.DestPath, pkg, "go.mod")
+	f, err := readModFile(modPath, func(path string, data []byte) (*modfile.File, error) {
+		return modfile.Parse(path, data, nil)
+	})
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		f = &modfile.File{Syntax: &modfile.FileSyntax{}}
+	}
+	modulePath := g.config.GoPackagePath(pkg)
+	if err := f.AddModuleStmt(modulePath); err != nil {
+		return err
+	}
+	if f.Go == nil {
+		if err := f.AddGoStmt(goModuleGoVersion); err != nil {
+			return err
+		}
+	}
+	pinned := map[string]string{}
+	for _, r := range f.Require {
+		pinned[r.Mod.Path] = r.Mod.Version
+	}
+	versions := map[string]string{}
+	for imp := range imports {
+		var mod module.Version
+		switch path := g.workspaceModule(imp, generated); {
+		case path == g.config.RclgoImportPath:
+			mod, err = g.rclgoModule(pinned[path])
+		case path != "":
+			mod = module.Version{Path: path, Version: unversionedModule}
+			if v := pinned[path]; v != "" {
+				mod.Version = v
+			}
+		case isStdImport(imp):
+			continue
+		default:
+			mod = externalModules[imp]
+			if v := pinned[mod.Path]; v != "" {
+				mod.Version = v
+			}
+			if mod.Version == "" {
+				err = fmt.Errorf("failed to find the version of module %s imported by package %s", mod.Path, pkg)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if mod.Path != modulePath {
+			versions[mod.Path] = mod.Version
+		}
+	}
+	reqs := make([]*modfile.Require, 0, len(versions))
+	for path, version := range versions {
+		reqs = append(reqs, &modfile.Require{Mod: module.Version{Path: path, Version: version}})
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].Mod.Path < reqs[j].Mod.Path })
+	f.SetRequire(reqs)
+	f.Cleanup()
+	data, err := f.Format()
+	if err != nil {
+		return err
+	}
+	return g.writeFile(modPath, data)
+}
+
+// rclgoModule returns the version of the rclgo module to require, given the
+// version pinned in the go.mod file being generated.
+func (g *Generator) rclgoModule(pinned string) (module.Version, error) {
+	mod := module.Version{Path: g.config.RclgoImportPath}
+	switch {
+	case g.config.RclgoVersion != "":
+		mod.Version = g.config.RclgoVersion
+	case pinned != "":
+		mod.Version = pinned
+	case g.config.RclgoDir != "":
+		mod.Version = unversionedModule
+	default:
+		return mod, fmt.Errorf("the version of module %s is unknown: set Config.RclgoVersion or Config.RclgoDir", mod.Path)
+	}
+	return mod, nil
+}
+
+// pruneGoModules removes the modules of ROS2 packages in Config.DestPath that
+// are not in pkgs, and returns their directories relative to
+// Config.DestPath. A module is removed only if its directory contains nothing
+// but the module files.
+func (g *Generator) pruneGoModules(pkgs map[string]bool) ([]string, error) {
+	mods, err := filepath.Glob(filepath.Join(g.config.DestPath, "*", "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, mod := range mods {
+		dir := filepath.Dir(mod)
+		if pkgs[filepath.Base(dir)] {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		onlyModFiles := true
+		for _, e := range entries {
+			if e.Name() != "go.mod" && e.Name() != "go.sum" {
+				onlyModFiles = false
+			}
+		}
+		if !onlyModFiles {
+			continue
+		}
+		stale = append(stale, "./"+filepath.Base(dir))
+		if g.config.Check {
+			g.checkStale(mod)
+		} else if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+	}
+	return stale, nil
+}
+
+// generateGoWork adds the modules of pkgs and Config.RclgoDir to the go.work
+// file in Config.DestPath and drops the stale ones. Other modules used by the
+// workspace are kept.
+//
+// The go command reads the go.mod files of the versions required of the
+// workspace modules, so the placeholder version of each module is also
+// replaced with its directory.
+func (g *Generator) generateGoWork(pkgs, stale []string) error {
+	workPath := filepath.Join(g.config.DestPath, "go.work")
+	f, err := readModFile(workPath, func(path string, data []byte) (*modfile.WorkFile, error) {
+		return modfile.ParseWork(path, data, nil)
+	})
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		f = &modfile.WorkFile{Syntax: &modfile.FileSyntax{}}
+	}
+	if f.Go == nil {
+		if err := f.AddGoStmt(goModuleGoVersion); err != nil {
+			return err
+		}
+	}
+	for _, dir := range stale {
+		if err := f.DropUse(dir); err != nil {
+			return err
+		}
+		if err := f.DropReplace(g.config.GoPackagePath(filepath.Base(dir)), unversionedModule); err != nil {
+			return err
+		}
+	}
+	use := func(dir, modulePath string) error {
+		if err := f.AddUse(dir, modulePath); err != nil {
+			return err
+		}
+		return f.AddReplace(modulePath, unversionedModule, dir, "")
+	}
+	for _, pkg := range pkgs {
+		if err := use("./"+pkg, g.config.GoPackagePath(pkg)); err != nil {
+			return err
+		}
+	}
+	if g.config.RclgoDir != "" {
+		dir, err := workspaceRelPath(g.config.DestPath, g.config.RclgoDir)
+		if err != nil {
+			return err
+		}
+		if err := use(dir, g.config.RclgoImportPath); err != nil {
+			return err
+		}
+	}
+	groupReplaces(f.Syntax)
+	f.SortBlocks()
+	f.Cleanup()
+	return g.writeFile(workPath, modfile.Format(f.Syntax))
+}
+
+// groupReplaces moves the replace statements of syntax to a single block.
+// AddReplace adds each new replacement as a statement of its own.
+func groupReplaces(syntax *modfile.FileSyntax) {
+	var block *modfile.LineBlock
+	stmts := syntax.Stmt[:0]
+	for _, stmt := range syntax.Stmt {
+		switch stmt := stmt.(type) {
+		case *modfile.Line:
+			if len(stmt.Token) > 0 && stmt.Token[0] == "replace" {
+				if block == nil {
+					block = &modfile.LineBlock{Token: []string{"replace"}}
+					stmts = append(stmts, block)
+				}
+				stmt.Token = stmt.Token[1:]
+				stmt.InBlock = true
+				block.Line = append(block.Line, stmt)
+				continue
+			}
+		case *modfile.LineBlock:
+			if len(stmt.Token) == 1 && stmt.Token[0] == "replace" {
+				if block == nil {
+					block = stmt
+					stmts = append(stmts, block)
+				} else {
+					block.Line = append(block.Line, stmt.Line...)
+				}
+				continue
+			}
+		}
+		stmts = append(stmts, stmt)
+	}
+	syntax.Stmt = stmts
+}
+
+// workspaceRelPath returns dir relative to the workspace in workspaceDir in
+// the form used by go.work files.
+func workspaceRelPath(workspaceDir, dir string) (string, error) {
+	absWorkspace, err := filepath.Abs(workspaceDir)
+	if err != nil {
+		return "", err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absWorkspace, absDir)
+	if err != nil {
+		return absDir, nil //nolint:nilerr // Use the absolute path on another volume.
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") && rel != ".." {
+		rel = "./" + rel
+	}
+	return rel, nil
+}
+
+// readModFile parses the go.mod or go.work file at path, or returns nil if
+// it doesn't exist.
+func readModFile[F any](path string, parse func(string, []byte) (*F, error)) (*F, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parse(path, data)
+}
+
+// writeFile writes data to the file at path, or checks it if Config.Check is
+// set.
+func (g *Generator) writeFile(path string, data []byte) (err error) {
+	if g.config.Check {
+		return g.checkFile(path, data)
+	}
+	f, err := utilities.MkDirParent(path)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) {
+		cErr := f.Close()
+		if cErr != nil && err == nil {
+			err = cErr
+		}
+	}(f)
+	_, err = f.Write(data)
+	return err
+}
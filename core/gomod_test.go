@@ -0,0 +1,198 @@
+package core
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateGoModules(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"a_msgs/msg/A.msg":                    "int32 a\nstd_msgs/String s\n",
+		"b_msgs/msg/B.msg":                    "a_msgs/A a\n",
+		"std_msgs/msg/String.msg":             "string data\n",
+		"b_msgs/action/Do.action":             "int32 goal\n---\n---\n",
+		"action_msgs/msg/GoalInfo.msg":        "unique_identifier_msgs/UUID goal_id\n",
+		"action_msgs/msg/GoalStatus.msg":      "int8 status\n",
+		"action_msgs/srv/CancelGoal.srv":      "GoalInfo goal_info\n---\nint8 return_code\n",
+		"unique_identifier_msgs/msg/UUID.msg": "uint8[16] uuid\n",
+		"builtin_interfaces/msg/Time.msg":     "int32 sec\nuint32 nanosec\n",
+	})
+	dest := t.TempDir()
+	config := DefaultConfigForDistro("jazzy")
+	config.RootPaths = []string{root}
+	config.DestPath = dest
+	config.MessageModulePrefix = "example.com/msgs"
+	config.PackageModules = true
+	config.RclgoVersion = "v1.0.0"
+	// The bindings of std_msgs are a package in the rclgo module.
+	config.GoImportPaths = map[string]string{"std_msgs": "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_msgs"}
+	generate := func() {
+		t.Helper()
+		g := New(&config)
+		_, err := g.GenerateGolangMessageTypes()
+		require.NoError(t, err)
+		require.NoError(t, g.GenerateGoModules())
+	}
+	readFile := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dest, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	generate()
+	assert.Equal(t, `module example.com/msgs/b_msgs
+
+go 1.25.1
+
+require (
+	example.com/msgs/a_msgs v0.0.0-00010101000000-000000000000
+	example.com/msgs/action_msgs v0.0.0-00010101000000-000000000000
+	example.com/msgs/builtin_interfaces v0.0.0-00010101000000-000000000000
+	example.com/msgs/unique_identifier_msgs v0.0.0-00010101000000-000000000000
+	github.com/okieraised/rclgo/jazzy v1.0.0
+)
+`, readFile("b_msgs/go.mod"))
+	assert.Equal(t, `module example.com/msgs/a_msgs
+
+go 1.25.1
+
+require github.com/okieraised/rclgo/jazzy v1.0.0
+`, readFile("a_msgs/go.mod"))
+	assert.Equal(t, `go 1.25.1
+
+use (
+	./a_msgs
+	./action_msgs
+	./b_msgs
+	./builtin_interfaces
+	./unique_identifier_msgs
+)
+
+replace (
+	example.com/msgs/a_msgs v0.0.0-00010101000000-000000000000 => ./a_msgs
+	example.com/msgs/action_msgs v0.0.0-00010101000000-000000000000 => ./action_msgs
+	example.com/msgs/b_msgs v0.0.0-00010101000000-000000000000 => ./b_msgs
+	example.com/msgs/builtin_interfaces v0.0.0-00010101000000-000000000000 => ./builtin_interfaces
+	example.com/msgs/unique_identifier_msgs v0.0.0-00010101000000-000000000000 => ./unique_identifier_msgs
+)
+`, readFile("go.work"))
+
+	// The modules are up to date according to Config.Check.
+	checkConfig := config
+	checkConfig.Check = true
+	g := New(&checkConfig)
+	_, err := g.GenerateGolangMessageTypes()
+	require.NoError(t, err)
+	require.NoError(t, g.GenerateGoModules())
+	require.NoError(t, g.ReportDrift(io.Discard))
+
+	// Pinned versions and other workspace modules are kept, and modules of
+	// removed packages are dropped.
+	config.RclgoVersion = ""
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "a_msgs/go.mod"), []byte(
+		"module example.com/msgs/a_msgs\n\ngo 1.24\n\nrequire github.com/okieraised/rclgo/jazzy v1.2.3\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "go.work"), []byte(
+		readFile("go.work")+"\nuse ../app\n"), 0o600))
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "b_msgs")))
+	generate()
+	assert.Equal(t, `module example.com/msgs/a_msgs
+
+go 1.24
+
+require github.com/okieraised/rclgo/jazzy v1.2.3
+`, readFile("a_msgs/go.mod"))
+	assert.NoDirExists(t, filepath.Join(dest, "b_msgs"))
+	assert.Equal(t, `go 1.25.1
+
+use (
+	./a_msgs
+	./action_msgs
+	./builtin_interfaces
+	./unique_identifier_msgs
+)
+
+replace (
+	example.com/msgs/a_msgs v0.0.0-00010101000000-000000000000 => ./a_msgs
+	example.com/msgs/action_msgs v0.0.0-00010101000000-000000000000 => ./action_msgs
+	example.com/msgs/builtin_interfaces v0.0.0-00010101000000-000000000000 => ./builtin_interfaces
+	example.com/msgs/unique_identifier_msgs v0.0.0-00010101000000-000000000000 => ./unique_identifier_msgs
+)
+
+use ../app
+`, readFile("go.work"))
+}
+
+func TestGenerateGoModulesWorkspaceBuilds(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+	rclgoDir, err := filepath.Abs(RclgoRepoRootPath("jazzy"))
+	require.NoError(t, err)
+	root := writeLintFiles(t, map[string]string{
+		"a_msgs/msg/A.msg":                    "int32 a\n",
+		"b_msgs/msg/B.msg":                    "a_msgs/A a\nbuiltin_interfaces/Time stamp\n",
+		"b_msgs/srv/Get.srv":                  "---\nB b\n",
+		"b_msgs/action/Do.action":             "int32 goal\n---\n---\n",
+		"action_msgs/msg/GoalInfo.msg":        "unique_identifier_msgs/UUID goal_id\nbuiltin_interfaces/Time stamp\n",
+		"action_msgs/msg/GoalStatus.msg":      "GoalInfo goal_info\nint8 status\n",
+		"action_msgs/msg/GoalStatusArray.msg": "GoalStatus[] status_list\n",
+		"action_msgs/srv/CancelGoal.srv":      "GoalInfo goal_info\n---\nint8 return_code\nGoalInfo[] goals_canceling\n",
+		"unique_identifier_msgs/msg/UUID.msg": "uint8[16] uuid\n",
+		"builtin_interfaces/msg/Time.msg":     "int32 sec\nuint32 nanosec\n",
+	})
+	dest := t.TempDir()
+	config := DefaultConfigForDistro("jazzy")
+	config.RootPaths = []string{root}
+	config.DestPath = dest
+	config.MessageModulePrefix = "example.com/msgs"
+	config.PackageModules = true
+	config.RclgoDir = rclgoDir
+	g := New(&config)
+	_, err = g.GenerateGolangMessageTypes()
+	require.NoError(t, err)
+	require.NoError(t, g.GenerateGoModules())
+
+	// The packages of each module must resolve their imports in the
+	// workspace without downloading modules.
+	var listed []string
+	for _, pkg := range []string{"a_msgs", "action_msgs", "b_msgs", "builtin_interfaces", "unique_identifier_msgs"} {
+		cmd := exec.Command(goBin, "list", "-e", "-f", "{{.ImportPath}}{{with .Error}} {{.}}{{end}}{{with .DepsErrors}} {{.}}{{end}}", "-deps", "./...")
+		cmd.Dir = filepath.Join(dest, pkg)
+		cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK="+filepath.Join(dest, "go.work"), "GOPROXY=off")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "%s", out)
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			require.NotContains(t, line, " ", "package %s has errors", pkg)
+			if strings.HasPrefix(line, config.MessageModulePrefix+"/"+pkg+"/") {
+				listed = append(listed, line)
+			}
+		}
+	}
+	assert.Equal(t, []string{
+		"example.com/msgs/a_msgs/msg",
+		"example.com/msgs/action_msgs/msg",
+		"example.com/msgs/action_msgs/srv",
+		"example.com/msgs/b_msgs/action",
+		"example.com/msgs/b_msgs/msg",
+		"example.com/msgs/b_msgs/srv",
+		"example.com/msgs/builtin_interfaces/msg",
+		"example.com/msgs/unique_identifier_msgs/msg",
+	}, listed)
+}
+
+func TestLoadGoModules(t *testing.T) {
+	mods, err := loadGoModules("github.com/stretchr/testify/assert", "golang.org/x/mod/modfile")
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/stretchr/testify", mods["github.com/stretchr/testify/assert"].Path)
+	assert.NotEmpty(t, mods["github.com/stretchr/testify/assert"].Version)
+	assert.Equal(t, "golang.org/x/mod", mods["golang.org/x/mod/modfile"].Path)
+}
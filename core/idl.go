@@ -0,0 +1,940 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The IDL front-end supports the subset of OMG IDL 4.2 used for ROS
+// interfaces: nested modules, structs, constants, typedefs, enums, fixed-size
+// arrays, (bounded) sequences and strings, and the annotations @default and
+// @verbatim. Other annotations, such as @key and @unit, are accepted and
+// ignored. Preprocessor directives are skipped.
+//
+// Definitions are converted to the same model as .msg, .srv and .action
+// files, by building the same captures the .msg parser produces, so an
+// interface generates identically regardless of which format it was read
+// from.
+
+type idlTokenKind int
+
+const (
+	idlEOF idlTokenKind = iota
+	idlIdent
+	idlNumber
+	idlString
+	idlChar
+	idlPunct
+)
+
+type idlToken struct {
+	kind     idlTokenKind
+	text     string
+	pos      Pos
+	comments []string // comments preceding the token
+	trailing []string // comments following the token on the same line
+}
+
+func tokenizeIDL(file, src string) ([]idlToken, error) {
+	var toks []idlToken
+	var comments []string
+	line, lineOffset := 1, 0
+	lineStart := true
+	pos := func(i int) Pos { return Pos{File: file, Line: line, Column: i - lineOffset + 1} }
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			lineOffset = i + 1
+			lineStart = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+			continue
+		case c == '#' && lineStart:
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			if text := strings.TrimSpace(src[i+2 : i+end]); text == "" {
+			} else if n := len(toks); n > 0 && toks[n-1].pos.Line == line && comments == nil {
+				toks[n-1].trailing = append(toks[n-1].trailing, text)
+			} else {
+				comments = append(comments, text)
+			}
+			i += end
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, Diagnostics{{Pos: pos(i), Message: "unterminated comment"}}
+			}
+			for _, l := range strings.Split(src[i+2:i+2+end], "\n") {
+				if l = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l), "*")); l != "" {
+					comments = append(comments, l)
+				}
+			}
+			if n := strings.Count(src[i:i+2+end], "\n"); n > 0 {
+				line += n
+				lineOffset = i + 2 + strings.LastIndexByte(src[i+2:i+2+end], '\n') + 1
+			}
+			i += end + 4
+			continue
+		}
+		lineStart = false
+		tok := idlToken{pos: pos(i), comments: comments}
+		comments = nil
+		start := i
+		switch {
+		case c == '_' || isASCIILetter(c):
+			for i < len(src) && (src[i] == '_' || isASCIILetter(src[i]) || isASCIIDigit(src[i])) {
+				i++
+			}
+			tok.kind = idlIdent
+		case isASCIIDigit(c) || (c == '.' && i+1 < len(src) && isASCIIDigit(src[i+1])):
+			hex := strings.HasPrefix(src[i:], "0x") || strings.HasPrefix(src[i:], "0X")
+			for i < len(src) {
+				d := src[i]
+				if d == '.' || d == '_' || isASCIILetter(d) || isASCIIDigit(d) ||
+					(!hex && (d == '+' || d == '-') && (src[i-1] == 'e' || src[i-1] == 'E')) {
+					i++
+				} else {
+					break
+				}
+			}
+			tok.kind = idlNumber
+		case c == '"' || c == '\'':
+			i++
+			for i < len(src) && src[i] != c {
+				if src[i] == '\\' {
+					i++
+				}
+				if i < len(src) && src[i] == '\n' {
+					return nil, Diagnostics{{Pos: tok.pos, Message: "newline in literal"}}
+				}
+				i++
+			}
+			if i >= len(src) {
+				return nil, Diagnostics{{Pos: tok.pos, Message: "unterminated literal"}}
+			}
+			i++
+			tok.kind = idlString
+			if c == '\'' {
+				tok.kind = idlChar
+			}
+		case strings.HasPrefix(src[i:], "::"):
+			i += 2
+			tok.kind = idlPunct
+		default:
+			i++
+			tok.kind = idlPunct
+		}
+		tok.text = src[start:i]
+		toks = append(toks, tok)
+	}
+	return append(toks, idlToken{kind: idlEOF, pos: pos(len(src)), comments: comments}), nil
+}
+
+func isASCIILetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isASCIIDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+type idlModule struct {
+	name     string
+	parent   *idlModule
+	modules  []*idlModule
+	structs  []*idlStruct
+	consts   []*idlConst
+	typedefs map[string]*idlType
+	enums    map[string][]string
+}
+
+func newIDLModule(name string, parent *idlModule) *idlModule {
+	return &idlModule{
+		name:     name,
+		parent:   parent,
+		typedefs: map[string]*idlType{},
+		enums:    map[string][]string{},
+	}
+}
+
+func (m *idlModule) module(name string) *idlModule {
+	for _, sub := range m.modules {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (m *idlModule) structByName(name string) *idlStruct {
+	for _, s := range m.structs {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+type idlStruct struct {
+	name    string
+	pos     Pos
+	members []*idlMember
+}
+
+type idlMember struct {
+	name        string
+	pos         Pos
+	typ         *idlType
+	annotations []*idlAnnotation
+	comments    []string
+}
+
+type idlConst struct {
+	name     string
+	pos      Pos
+	typ      *idlType
+	value    string
+	valuePos Pos
+	comments []string
+}
+
+type idlAnnotation struct {
+	pos    Pos
+	name   string
+	params map[string]string // a single unnamed parameter is stored as "value"
+}
+
+// idlType is a type specification. Sequences and arrays are represented by
+// their element type with the sequence or array fields set.
+type idlType struct {
+	name          string // primitive type or scoped name, e.g. "unsigned long" or "pkg::msg::Type"
+	stringBound   string
+	sequence      bool
+	sequenceBound string
+	arraySize     string
+}
+
+func (t *idlType) isArray() bool { return t.sequence || t.arraySize != "" }
+
+type idlParser struct {
+	toks []idlToken
+	pos  int
+}
+
+// parseIDL parses the source of an IDL file to the module tree rooted at an
+// unnamed module.
+func parseIDL(file, source string) (*idlModule, error) {
+	toks, err := tokenizeIDL(file, source)
+	if err != nil {
+		return nil, err
+	}
+	p := &idlParser{toks: toks}
+	root := newIDLModule("", nil)
+	for p.peek().kind != idlEOF {
+		if err := p.definition(root); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+func (p *idlParser) peek() idlToken { return p.toks[p.pos] }
+
+func (p *idlParser) next() idlToken {
+	tok := p.toks[p.pos]
+	if tok.kind != idlEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *idlParser) accept(text string) bool {
+	if tok := p.peek(); tok.kind != idlString && tok.kind != idlChar && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *idlParser) errorf(tok idlToken, format string, args ...interface{}) error {
+	return Diagnostics{{Pos: tok.pos, Message: fmt.Sprintf(format, args...)}}
+}
+
+func (p *idlParser) expect(text string) error {
+	if !p.accept(text) {
+		tok := p.peek()
+		return p.errorf(tok, "expected %q, got %s", text, describeIDLToken(tok))
+	}
+	return nil
+}
+
+func (p *idlParser) ident() (idlToken, error) {
+	tok := p.next()
+	if tok.kind != idlIdent {
+		return tok, p.errorf(tok, "expected an identifier, got %s", describeIDLToken(tok))
+	}
+	return tok, nil
+}
+
+func describeIDLToken(tok idlToken) string {
+	if tok.kind == idlEOF {
+		return "end of file"
+	}
+	return strconv.Quote(tok.text)
+}
+
+func (p *idlParser) definition(scope *idlModule) error {
+	comments := p.peek().comments
+	annotations, err := p.annotations()
+	if err != nil {
+		return err
+	}
+	tok := p.next()
+	switch tok.text {
+	case ";":
+		return nil
+	case "module":
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		mod := scope.module(name.text)
+		if mod == nil {
+			mod = newIDLModule(name.text, scope)
+			scope.modules = append(scope.modules, mod)
+		}
+		if err := p.expect("{"); err != nil {
+			return err
+		}
+		for !p.accept("}") {
+			if p.peek().kind == idlEOF {
+				return p.errorf(p.peek(), "module %s is not closed", name.text)
+			}
+			if err := p.definition(mod); err != nil {
+				return err
+			}
+		}
+		return p.expect(";")
+	case "struct":
+		s, err := p.structDef(tok)
+		if err != nil {
+			return err
+		}
+		if s != nil {
+			scope.structs = append(scope.structs, s)
+		}
+		return nil
+	case "typedef":
+		typ, err := p.typeSpec()
+		if err != nil {
+			return err
+		}
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if err := p.arraySize(typ); err != nil {
+			return err
+		}
+		scope.typedefs[name.text] = typ
+		return p.expect(";")
+	case "const":
+		typ, err := p.typeSpec()
+		if err != nil {
+			return err
+		}
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		valuePos := p.peek().pos
+		value, err := p.constExpr()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(";"); err != nil {
+			return err
+		}
+		comments = append(comments, verbatimComments(annotations)...)
+		scope.consts = append(scope.consts, &idlConst{
+			name:     name.text,
+			pos:      name.pos,
+			typ:      typ,
+			value:    value,
+			valuePos: valuePos,
+			comments: append(comments, p.toks[p.pos-1].trailing...),
+		})
+		return nil
+	case "enum":
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("{"); err != nil {
+			return err
+		}
+		var enumerators []string
+		for {
+			if _, err := p.annotations(); err != nil {
+				return err
+			}
+			e, err := p.ident()
+			if err != nil {
+				return err
+			}
+			enumerators = append(enumerators, e.text)
+			if !p.accept(",") {
+				break
+			}
+		}
+		scope.enums[name.text] = enumerators
+		if err := p.expect("}"); err != nil {
+			return err
+		}
+		return p.expect(";")
+	}
+	return p.errorf(tok, "unexpected %s", describeIDLToken(tok))
+}
+
+// structDef parses a struct definition after the keyword struct. Forward
+// declarations return a nil struct.
+func (p *idlParser) structDef(kw idlToken) (*idlStruct, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept(";") {
+		return nil, nil
+	}
+	if p.accept(":") {
+		return nil, p.errorf(kw, "struct inheritance is not supported")
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	s := &idlStruct{name: name.text, pos: name.pos}
+	for !p.accept("}") {
+		comments := p.peek().comments
+		annotations, err := p.annotations()
+		if err != nil {
+			return nil, err
+		}
+		typ, err := p.typeSpec()
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, verbatimComments(annotations)...)
+		first := len(s.members)
+		for {
+			decl, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			declType := *typ
+			if err := p.arraySize(&declType); err != nil {
+				return nil, err
+			}
+			s.members = append(s.members, &idlMember{
+				name:        decl.text,
+				pos:         decl.pos,
+				typ:         &declType,
+				annotations: annotations,
+				comments:    comments,
+			})
+			if !p.accept(",") {
+				break
+			}
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+		if trailing := p.toks[p.pos-1].trailing; trailing != nil {
+			for _, m := range s.members[first:] {
+				m.comments = append(slices.Clip(m.comments), trailing...)
+			}
+		}
+	}
+	return s, p.expect(";")
+}
+
+// arraySize parses an optional fixed-size array declarator into typ.
+func (p *idlParser) arraySize(typ *idlType) error {
+	tok := p.peek()
+	if !p.accept("[") {
+		return nil
+	}
+	if typ.isArray() {
+		return p.errorf(tok, "multidimensional arrays and arrays of sequences are not supported")
+	}
+	size := p.next()
+	if size.kind != idlNumber {
+		return p.errorf(size, "expected an array size, got %s", describeIDLToken(size))
+	}
+	typ.arraySize = size.text
+	if err := p.expect("]"); err != nil {
+		return err
+	}
+	if p.peek().text == "[" {
+		return p.errorf(p.peek(), "multidimensional arrays are not supported")
+	}
+	return nil
+}
+
+func (p *idlParser) typeSpec() (*idlType, error) {
+	tok := p.peek()
+	switch {
+	case p.accept("sequence"):
+		if err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		elem, err := p.typeSpec()
+		if err != nil {
+			return nil, err
+		}
+		if elem.isArray() {
+			return nil, p.errorf(tok, "nested sequences are not supported")
+		}
+		elem.sequence = true
+		if p.accept(",") {
+			bound := p.next()
+			if bound.kind != idlNumber {
+				return nil, p.errorf(bound, "expected a sequence bound, got %s", describeIDLToken(bound))
+			}
+			elem.sequenceBound = bound.text
+		}
+		return elem, p.expect(">")
+	case p.accept("string"), p.accept("wstring"):
+		typ := &idlType{name: tok.text}
+		if p.accept("<") {
+			bound := p.next()
+			if bound.kind != idlNumber {
+				return nil, p.errorf(bound, "expected a string bound, got %s", describeIDLToken(bound))
+			}
+			typ.stringBound = bound.text
+			if err := p.expect(">"); err != nil {
+				return nil, err
+			}
+		}
+		return typ, nil
+	case p.accept("unsigned"):
+		switch {
+		case p.accept("short"):
+			return &idlType{name: "unsigned short"}, nil
+		case p.accept("long"):
+			if p.accept("long") {
+				return &idlType{name: "unsigned long long"}, nil
+			}
+			return &idlType{name: "unsigned long"}, nil
+		}
+		return nil, p.errorf(p.peek(), "expected short or long after unsigned")
+	case p.accept("long"):
+		switch {
+		case p.accept("long"):
+			return &idlType{name: "long long"}, nil
+		case p.accept("double"):
+			return &idlType{name: "long double"}, nil
+		}
+		return &idlType{name: "long"}, nil
+	}
+	var name strings.Builder
+	if p.accept("::") {
+		name.WriteString("::")
+	}
+	for {
+		part, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		name.WriteString(part.text)
+		if !p.accept("::") {
+			break
+		}
+		name.WriteString("::")
+	}
+	return &idlType{name: name.String()}, nil
+}
+
+func (p *idlParser) annotations() ([]*idlAnnotation, error) {
+	var annotations []*idlAnnotation
+	for p.peek().text == "@" {
+		at := p.next()
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		for p.accept("::") {
+			part, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			name.text = part.text
+		}
+		a := &idlAnnotation{pos: at.pos, name: name.text, params: map[string]string{}}
+		if p.accept("(") {
+			for !p.accept(")") {
+				key := "value"
+				if p.peek().kind == idlIdent && p.toks[p.pos+1].text == "=" {
+					key = p.next().text
+					p.next()
+				}
+				value, err := p.constExpr()
+				if err != nil {
+					return nil, err
+				}
+				a.params[key] = value
+				if !p.accept(",") {
+					if err := p.expect(")"); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+		}
+		annotations = append(annotations, a)
+	}
+	return annotations, nil
+}
+
+// constExpr returns the text of a constant expression. Adjacent string
+// literals are concatenated.
+func (p *idlParser) constExpr() (string, error) {
+	var b strings.Builder
+	depth := 0
+	start := p.peek()
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == idlEOF:
+			return "", p.errorf(tok, "unexpected end of file in expression")
+		case depth == 0 && tok.kind == idlPunct && (tok.text == "," || tok.text == ")" || tok.text == ";"):
+			if b.Len() == 0 {
+				return "", p.errorf(start, "expected an expression")
+			}
+			return b.String(), nil
+		case tok.kind == idlPunct && tok.text == "(":
+			depth++
+		case tok.kind == idlPunct && tok.text == ")":
+			depth--
+		}
+		p.next()
+		if tok.kind == idlString && strings.HasSuffix(b.String(), `"`) {
+			s := b.String()
+			b.Reset()
+			b.WriteString(s[:len(s)-1])
+			b.WriteString(tok.text[1:])
+			continue
+		}
+		b.WriteString(tok.text)
+	}
+}
+
+// verbatimComments returns the texts of @verbatim annotations with the
+// comment language.
+func verbatimComments(annotations []*idlAnnotation) []string {
+	var comments []string
+	for _, a := range annotations {
+		if a.name != "verbatim" || unquoteIDL(a.params["language"]) != "comment" {
+			continue
+		}
+		for _, l := range strings.Split(unquoteIDL(a.params["text"]), "\n") {
+			if l = strings.TrimSpace(l); l != "" {
+				comments = append(comments, l)
+			}
+		}
+	}
+	return comments
+}
+
+func findAnnotation(annotations []*idlAnnotation, name string) *idlAnnotation {
+	for _, a := range annotations {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func unquoteIDL(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s[1 : len(s)-1]
+}
+
+var idlPrimitiveTypes = map[string]string{
+	"boolean":            "bool",
+	"octet":              "byte",
+	"char":               "char",
+	"int8":               "int8",
+	"uint8":              "uint8",
+	"int16":              "int16",
+	"uint16":             "uint16",
+	"int32":              "int32",
+	"uint32":             "uint32",
+	"int64":              "int64",
+	"uint64":             "uint64",
+	"short":              "int16",
+	"unsigned short":     "uint16",
+	"long":               "int32",
+	"unsigned long":      "uint32",
+	"long long":          "int64",
+	"unsigned long long": "uint64",
+	"float":              "float32",
+	"double":             "float64",
+	"string":             "string",
+	"wstring":            "wstring",
+}
+
+// idlPlaceholderMember is the member rosidl adds to the IDL of messages
+// without fields, because IDL does not allow empty structs.
+const idlPlaceholderMember = "structure_needs_at_least_one_member"
+
+// ParseIDLMessage parses the struct named msg.Name from an IDL definition.
+func (p *parser) ParseIDLMessage(msg *ROS2Message, source string) error {
+	scope, err := p.idlInterfaceModule(source, msg.Package, msg.Type)
+	if err != nil {
+		return err
+	}
+	msg.Source = source
+	return p.parseIDLStruct(scope, msg)
+}
+
+// ParseIDLService parses the request and response structs of a service from
+// an IDL definition.
+func (p *parser) ParseIDLService(service *ROS2Service, source string) error {
+	scope, err := p.idlInterfaceModule(source, service.Package, service.Type)
+	if err != nil {
+		return err
+	}
+	service.Source, service.Request.Source, service.Response.Source = source, source, source
+	if err := p.parseIDLStruct(scope, service.Request); err != nil {
+		return err
+	}
+	return p.parseIDLStruct(scope, service.Response)
+}
+
+// ParseIDLAction parses the goal, result and feedback structs of an action
+// from an IDL definition.
+func (p *parser) ParseIDLAction(action *ROS2Action, source string) error {
+	scope, err := p.idlInterfaceModule(source, action.Package, action.Type)
+	if err != nil {
+		return err
+	}
+	action.Source = source
+	for _, msg := range []*ROS2Message{action.Goal, action.Result, action.Feedback} {
+		msg.Source = source
+		if err := p.parseIDLStruct(scope, msg); err != nil {
+			return err
+		}
+	}
+	p.addActionFields(action)
+	return nil
+}
+
+// idlInterfaceModule parses source and returns the module pkg::typ.
+func (p *parser) idlInterfaceModule(source, pkg, typ string) (*idlModule, error) {
+	root, err := parseIDL(p.file, source)
+	if err != nil {
+		return nil, err
+	}
+	if mod := root.module(pkg); mod != nil {
+		if mod = mod.module(typ); mod != nil {
+			return mod, nil
+		}
+	}
+	return nil, fmt.Errorf("module %s::%s not found", pkg, typ)
+}
+
+// parseIDLStruct converts the struct msg.Name of scope to declarations, which
+// are validated and added to msg like those of .msg files.
+func (p *parser) parseIDLStruct(scope *idlModule, msg *ROS2Message) error {
+	s := scope.structByName(msg.Name)
+	if s == nil {
+		return fmt.Errorf("struct %s not found in module %s::%s", msg.Name, msg.Package, msg.Type)
+	}
+	var diags Diagnostics
+	section := &MsgSection{}
+	if constants := scope.module(msg.Name + "_Constants"); constants != nil {
+		for _, c := range constants.consts {
+			decl, cDiags := idlConstantDecl(constants, c)
+			diags = append(diags, cDiags...)
+			if len(cDiags) == 0 {
+				section.Constants = append(section.Constants, decl)
+			}
+		}
+	}
+	var enumConstants []*ROS2Constant
+	if len(s.members) != 1 || s.members[0].name != idlPlaceholderMember {
+		for _, m := range s.members {
+			decl, enumerators, mDiags := idlMemberDecl(scope, msg.Package, m)
+			diags = append(diags, mDiags...)
+			if len(mDiags) == 0 {
+				section.Fields = append(section.Fields, decl)
+			}
+			for i, e := range enumerators {
+				if !slices.ContainsFunc(enumConstants, func(c *ROS2Constant) bool { return c.RosName == e }) {
+					enumConstants = append(enumConstants, &ROS2Constant{
+						RosType: "int32",
+						GoType:  "int32",
+						RosName: e,
+						Value:   strconv.Itoa(i),
+					})
+				}
+			}
+		}
+	}
+	if len(diags) > 0 {
+		return diags
+	}
+	p.addSection(msg, section)
+	msg.Constants = append(msg.Constants, enumConstants...)
+	return nil
+}
+
+func idlConstantDecl(scope *idlModule, c *idlConst) (*MsgConstant, Diagnostics) {
+	typ, _, diags := idlMsgType(scope, "", c.typ, c.pos)
+	if len(diags) > 0 {
+		return nil, diags
+	}
+	decl := &MsgConstant{
+		Pos:      c.pos,
+		Type:     typ,
+		Name:     c.name,
+		Value:    c.value,
+		ValuePos: c.valuePos,
+		Comment:  strings.Join(c.comments, " "),
+	}
+	return decl, validateMsgConstant(decl)
+}
+
+func idlMemberDecl(scope *idlModule, pkg string, m *idlMember) (*MsgField, []string, Diagnostics) {
+	typ, enumerators, diags := idlMsgType(scope, pkg, m.typ, m.pos)
+	if len(diags) > 0 {
+		return nil, nil, diags
+	}
+	decl := &MsgField{
+		Pos:     m.pos,
+		Type:    typ,
+		Name:    m.name,
+		Comment: strings.Join(m.comments, " "),
+	}
+	if a := findAnnotation(m.annotations, "default"); a != nil {
+		decl.Default = a.params["value"]
+		decl.DefaultPos = a.pos
+		if typ.IsArray {
+			decl.Default = idlArrayDefaultToMsg(decl.Default)
+		}
+	}
+	return decl, enumerators, validateMsgField(decl)
+}
+
+// idlMsgType converts an IDL type to a .msg type reference. Types of the
+// same package are referenced with an explicit package, because unqualified
+// IDL names never refer to std_msgs.
+func idlMsgType(scope *idlModule, pkg string, t *idlType, pos Pos) (*MsgType, []string, Diagnostics) {
+	var diags Diagnostics
+	t, enumerators, err := resolveIDLType(scope, t)
+	if err != nil {
+		diags.addf(pos, "%v", err)
+		return nil, nil, diags
+	}
+	typ := &MsgType{Pos: pos, IsArray: t.isArray()}
+	if rosType, ok := idlPrimitiveTypes[t.name]; ok {
+		typ.Name = rosType
+	} else if t.name == "wchar" || t.name == "long double" {
+		diags.addf(pos, "type %s is not supported", t.name)
+		return nil, nil, diags
+	} else {
+		parts := strings.Split(strings.TrimPrefix(t.name, "::"), "::")
+		typ.Name = parts[len(parts)-1]
+		typ.Package = pkg
+		if len(parts) > 2 {
+			typ.Package = parts[0]
+		}
+	}
+	for _, n := range []struct {
+		value string
+		dst   *int
+	}{
+		{t.stringBound, &typ.StringBound},
+		{t.arraySize, &typ.ArraySize},
+		{t.sequenceBound, &typ.ArrayBound},
+	} {
+		if n.value == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(n.value, 0, 32)
+		if err != nil || v <= 0 {
+			diags.addf(pos, "expected a positive integer, got %s", n.value)
+		}
+		*n.dst = int(v)
+	}
+	return typ, enumerators, append(diags, validateMsgType(typ)...)
+}
+
+// resolveIDLType resolves typedefs and enums referenced by typ. Enums are
+// represented as int32 values, and their enumerators are returned so that
+// they can be added as constants.
+func resolveIDLType(scope *idlModule, typ *idlType) (*idlType, []string, error) {
+	if strings.Contains(typ.name, "::") || idlPrimitiveTypes[typ.name] != "" {
+		return typ, nil, nil
+	}
+	for s := scope; s != nil; s = s.parent {
+		if enumerators, ok := s.enums[typ.name]; ok {
+			resolved := *typ
+			resolved.name = "int32"
+			return &resolved, enumerators, nil
+		}
+		td := s.typedefs[typ.name]
+		if td == nil {
+			continue
+		}
+		base, enumerators, err := resolveIDLType(s, td)
+		if err != nil {
+			return nil, nil, err
+		}
+		if base.isArray() && typ.isArray() {
+			return nil, nil, errors.New("multidimensional arrays and arrays of sequences are not supported")
+		}
+		resolved := *base
+		if typ.sequence {
+			resolved.sequence, resolved.sequenceBound = true, typ.sequenceBound
+		} else if typ.arraySize != "" {
+			resolved.arraySize = typ.arraySize
+		}
+		return &resolved, enumerators, nil
+	}
+	return typ, nil, nil
+}
+
+// idlArrayDefaultToMsg converts the default value of an array, which rosidl
+// writes as a string containing a tuple, to the .msg syntax.
+func idlArrayDefaultToMsg(value string) string {
+	value = strings.TrimSpace(unquoteIDL(value))
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		value = "[" + value[1:len(value)-1] + "]"
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, value)
+}
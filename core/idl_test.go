@@ -0,0 +1,243 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleMsg = `uint8 FOO=1
+string BAR="bar"
+
+# Comment for a
+int32 a 5
+float64[3] b [1.0, 2.0, 3.0]
+string<=10 c "hi"
+geometry_msgs/Point[] points
+int8[<=4] d
+bool e true
+Header header
+Sample2 local
+`
+
+const sampleIDL = `// generated from rosidl_adapter/resource/msg.idl.em
+// with input from test_msgs/msg/Sample.msg
+// generated code does not contain a copyright notice
+
+#include "geometry_msgs/msg/Point.idl"
+#include "std_msgs/msg/Header.idl"
+#include "test_msgs/msg/Sample2.idl"
+
+module test_msgs {
+  module msg {
+    typedef double double__3[3];
+    module Sample_Constants {
+      const uint8 FOO = 1;
+      const string BAR = "bar";
+    };
+    struct Sample {
+      @verbatim (language="comment", text=
+        "Comment for a")
+      @default (value=5)
+      int32 a;
+
+      @default (value="(1.0, 2.0, 3.0)")
+      double__3 b;
+
+      @default (value="hi")
+      string<10> c;
+
+      sequence<geometry_msgs::msg::Point> points;
+
+      sequence<int8, 4> d;
+
+      @default (value=TRUE)
+      boolean e;
+
+      std_msgs::msg::Header header;
+
+      test_msgs::msg::Sample2 local;
+    };
+  };
+};
+`
+
+func TestParseIDLMessage(t *testing.T) {
+	want := ROS2MessageNew("test_msgs", "Sample")
+	p := &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseROS2Message(want, sampleMsg))
+
+	got := ROS2MessageNew("test_msgs", "Sample")
+	p = &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseIDLMessage(got, sampleIDL))
+
+	// Only the source texts differ.
+	assert.Equal(t, sampleIDL, got.Source)
+	got.Source = want.Source
+	assert.Equal(t, want, got)
+}
+
+func TestParseIDLService(t *testing.T) {
+	const srv = `string name
+Sample sample
+---
+bool ok
+`
+	const idl = `module test_msgs {
+  module srv {
+    struct Get_Request {
+      string name;
+      test_msgs::msg::Sample sample;
+    };
+    struct Get_Response {
+      boolean ok;
+    };
+  };
+};
+`
+	want := NewROS2Service("test_msgs", "Get")
+	p := &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseService(want, srv))
+
+	got := NewROS2Service("test_msgs", "Get")
+	p = &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseIDLService(got, idl))
+
+	assert.Equal(t, idl, got.Request.Source)
+	got.Source, got.Request.Source, got.Response.Source = want.Source, want.Request.Source, want.Response.Source
+	assert.Equal(t, want, got)
+}
+
+func TestParseIDLAction(t *testing.T) {
+	const action = `int32 order
+---
+int32[] sequence
+---
+int32[] partial_sequence
+`
+	const idl = `module test_msgs {
+  module action {
+    struct Fibonacci_Goal {
+      int32 order;
+    };
+    struct Fibonacci_Result {
+      sequence<int32> sequence;
+    };
+    struct Fibonacci_Feedback {
+      sequence<int32> partial_sequence;
+    };
+  };
+};
+`
+	want := NewROS2Action("test_msgs", "Fibonacci")
+	p := &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseAction(want, action))
+
+	got := NewROS2Action("test_msgs", "Fibonacci")
+	p = &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseIDLAction(got, idl))
+
+	assert.Equal(t, idl, got.Goal.Source)
+	got.Source = want.Source
+	got.Goal.Source, got.Result.Source, got.Feedback.Source = want.Goal.Source, want.Result.Source, want.Feedback.Source
+	assert.Equal(t, want, got)
+}
+
+func TestParseIDLHandWritten(t *testing.T) {
+	const idl = `/*
+ * Hand-written definition.
+ */
+module vendor_msgs {
+  typedef sequence<float, 8> Samples;
+  enum Mode { IDLE, RUNNING };
+
+  module msg {
+    struct Status {
+      @key unsigned long id; // The ID.
+      // The samples.
+      Samples samples;
+      Mode mode;
+      long long a, b[2]; // Two values.
+      @verbatim (language="comment", text="Empty" " message")
+      uint8 structure_needs_at_least_one_member;
+    };
+    struct Empty {
+      uint8 structure_needs_at_least_one_member;
+    };
+  };
+};
+`
+	msg := ROS2MessageNew("vendor_msgs", "Status")
+	p := &parser{config: &DefaultConfig}
+	require.NoError(t, p.ParseIDLMessage(msg, idl))
+
+	type field struct{ name, typ, array, bounded, comment string }
+	var fields []field
+	for _, f := range msg.Fields {
+		fields = append(fields, field{f.RosName, f.RosType, f.TypeArray, f.ArrayBounded, f.Comment})
+	}
+	assert.Equal(t, []field{
+		{"id", "uint32", "", "", "The ID."},
+		{"samples", "float32", "[]", "<=8", "The samples."},
+		{"mode", "int32", "", "", ""},
+		{"a", "int64", "", "", "Two values."},
+		{"b", "int64", "[2]", "", "Two values."},
+		{"structure_needs_at_least_one_member", "uint8", "", "", "Empty message"},
+	}, fields)
+	require.Len(t, msg.Constants, 2)
+	assert.Equal(t, "IDLE", msg.Constants[0].RosName)
+	assert.Equal(t, "1", msg.Constants[1].Value)
+
+	empty := ROS2MessageNew("vendor_msgs", "Empty")
+	require.NoError(t, p.ParseIDLMessage(empty, idl))
+	assert.Empty(t, empty.Fields)
+}
+
+func TestParseIDLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		idl  string
+		want string
+	}{
+		{
+			name: "syntax",
+			idl:  "module pkg {\n  module msg {\n    struct M {\n      int32 a\n    };\n  };\n};\n",
+			want: `5:5: expected ";", got "}"`,
+		},
+		{
+			name: "unsupported type",
+			idl:  "module pkg { module msg {\nstruct M {\nwchar a;\n};\n}; };",
+			want: "3:7: type wchar is not supported",
+		},
+		{
+			name: "multidimensional array",
+			idl:  "module pkg { module msg { struct M {\nint32 a[2][3];\n}; }; };",
+			want: "2:11: multidimensional arrays are not supported",
+		},
+		{
+			name: "missing struct",
+			idl:  "module pkg { module msg { struct N { int32 a; }; }; };",
+			want: "struct M not found in module pkg::msg",
+		},
+		{
+			name: "missing module",
+			idl:  "module other { module msg { struct M { int32 a; }; }; };",
+			want: "module pkg::msg not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &parser{config: &DefaultConfig}
+			err := p.ParseIDLMessage(ROS2MessageNew("pkg", "M"), tt.idl)
+			require.Error(t, err)
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestParseMetadataFromIDLPath(t *testing.T) {
+	meta, err := parseMetadataFromPath("/opt/ros/jazzy/share/test_msgs/srv/Get.idl")
+	require.NoError(t, err)
+	assert.Equal(t, Metadata{Name: "Get", Package: "test_msgs", Type: "srv"}, *meta)
+}
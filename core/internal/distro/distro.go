@@ -1,5 +1,10 @@
 package distro
 
+import (
+	"os"
+	"path/filepath"
+)
+
 var AmentPrefixPath = "AMENT_PREFIX_PATH"
 
 var (
@@ -11,3 +16,13 @@ var SupportedDistroMapper = map[string]struct{}{
 	ROSJazzy:  {},
 	ROSHumble: {},
 }
+
+// FromEnv returns the distribution of the sourced ROS2 environment, which is
+// the base name of its install prefix, or "" if no environment is sourced.
+func FromEnv() string {
+	prefix := os.Getenv(AmentPrefixPath)
+	if prefix == "" {
+		return ""
+	}
+	return filepath.Base(prefix)
+}
@@ -0,0 +1,417 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okieraised/rclgo/core/internal/utilities"
+)
+
+// Lint rules reported by Lint.
+const (
+	LintInvalidDefinition   = "invalid-definition"
+	LintUnresolvedReference = "unresolved-reference"
+	LintDependencyCycle     = "dependency-cycle"
+	LintDuplicateName       = "duplicate-name"
+	LintNameCollision       = "name-collision"
+)
+
+// LintRules describes the rules reported by Lint.
+var LintRules = []struct{ ID, Description string }{
+	{LintInvalidDefinition, "The definition is malformed, violates the ROS naming rules or has a default value that violates its type."},
+	{LintUnresolvedReference, "A field references a message that can't be found."},
+	{LintDependencyCycle, "Messages depend on each other cyclically."},
+	{LintDuplicateName, "Fields or constants have the same name, or fields map to the same Go name."},
+	{LintNameCollision, "A name collides with an identifier of the generated Go code."},
+}
+
+// LintIssue is a problem found by Lint.
+type LintIssue struct {
+	Rule    string `json:"rule"`
+	Pos     Pos    `json:"pos"`
+	Message string `json:"message"`
+}
+
+func (i *LintIssue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Pos, i.Message, i.Rule)
+}
+
+// Lint checks the interface definitions under paths without generating code.
+// References to other packages are resolved using the definitions under paths
+// and rootPaths. The issues are sorted by position.
+func Lint(paths, rootPaths []string) []*LintIssue {
+	l := &linter{
+		known:    map[Metadata]bool{},
+		packages: map[string]bool{},
+		deps:     map[string][]lintDep{},
+	}
+	for _, root := range rootPaths {
+		for meta := range findInterfaces(root, defaultBlacklist) {
+			l.known[meta] = true
+			l.packages[meta.Package] = true
+		}
+	}
+	files := map[Metadata]string{}
+	for _, root := range paths {
+		for meta, path := range findInterfaces(root, defaultBlacklist) {
+			files[meta] = path
+			l.known[meta] = true
+			l.packages[meta.Package] = true
+		}
+	}
+	metas := make([]Metadata, 0, len(files))
+	for meta := range files {
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool { return files[metas[i]] < files[metas[j]] })
+	idents := map[string]map[string]string{}
+	for _, meta := range metas {
+		l.lintFile(meta, files[meta])
+		l.checkIdentifiers(meta, files[meta], idents)
+	}
+	l.checkCycles()
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i].Pos, l.issues[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.issues
+}
+
+type linter struct {
+	known    map[Metadata]bool
+	packages map[string]bool
+	deps     map[string][]lintDep // message dependencies, keyed by "pkg/Name"
+	issues   []*LintIssue
+}
+
+type lintDep struct {
+	msg string
+	pos Pos
+}
+
+func (l *linter) addf(rule string, pos Pos, format string, args ...interface{}) {
+	l.issues = append(l.issues, &LintIssue{Rule: rule, Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) lintFile(meta Metadata, path string) {
+	source, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		l.addf(LintInvalidDefinition, Pos{File: path}, "%v", err)
+		return
+	}
+	p := &parser{config: &DefaultConfig, file: path, sectionAdded: l.checkSection}
+	switch meta.Type {
+	case "msg":
+		msg := ROS2MessageNew(meta.Package, meta.Name)
+		if isIDLFile(path) {
+			err = p.ParseIDLMessage(msg, string(source))
+		} else {
+			err = p.ParseROS2Message(msg, string(source))
+		}
+	case "srv":
+		srv := NewROS2Service(meta.Package, meta.Name)
+		if isIDLFile(path) {
+			err = p.ParseIDLService(srv, string(source))
+		} else {
+			err = p.ParseService(srv, string(source))
+		}
+	case "action":
+		action := NewROS2Action(meta.Package, meta.Name)
+		if isIDLFile(path) {
+			err = p.ParseIDLAction(action, string(source))
+		} else {
+			err = p.ParseAction(action, string(source))
+		}
+	}
+	var diags Diagnostics
+	if errors.As(err, &diags) {
+		for _, d := range diags {
+			l.addf(LintInvalidDefinition, d.Pos, "%s", d.Message)
+		}
+	} else if err != nil {
+		l.addf(LintInvalidDefinition, Pos{File: path}, "%v", err)
+	}
+}
+
+// generatedMessageMethods are the methods that generated message types have
+// in addition to their fields.
+var generatedMessageMethods = []string{
+	"Clone", "CloneMsg", "SetDefaults", "GetTypeSupport", "Validate",
+	"DeepCopyInto", "Equal", "Diff", "DiffWith",
+	"MarshalJSON", "UnmarshalJSON", "MarshalYAML", "UnmarshalYAML",
+}
+
+// checkSection checks the names and references of the declarations of a
+// single message.
+func (l *linter) checkSection(msg *ROS2Message, section *MsgSection) {
+	constants := map[string]bool{}
+	for _, c := range section.Constants {
+		if constants[c.Name] {
+			l.addf(LintDuplicateName, c.Pos, "duplicate constant %s in %s", c.Name, msg.Name)
+		}
+		constants[c.Name] = true
+	}
+	goNames := map[string]string{}
+	for _, f := range section.Fields {
+		goName := utilities.SnakeToCamel(f.Name)
+		switch prev, ok := goNames[goName]; {
+		case ok && prev == f.Name:
+			l.addf(LintDuplicateName, f.Pos, "duplicate field %s in %s", f.Name, msg.Name)
+		case ok:
+			l.addf(LintDuplicateName, f.Pos, "fields %s and %s of %s both map to the Go name %s", prev, f.Name, msg.Name, goName)
+		default:
+			goNames[goName] = f.Name
+		}
+		for _, m := range generatedMessageMethods {
+			if goName == m {
+				l.addf(LintNameCollision, f.Pos, "field %s maps to the Go name %s, which collides with the method %s.%s", f.Name, goName, msg.Name, m)
+			}
+		}
+		l.checkReference(msg, f)
+	}
+}
+
+func (l *linter) checkReference(msg *ROS2Message, f *MsgField) {
+	if f.Type.IsPrimitive() {
+		return
+	}
+	pkg, _, _ := translateROS2Type(&ROS2Field{RosType: f.Type.Name, PkgName: f.Type.Package}, msg)
+	if pkg == "." {
+		pkg = msg.Package
+	}
+	ref := pkg + "/" + f.Type.Name
+	switch {
+	case l.known[Metadata{Package: pkg, Type: "msg", Name: f.Type.Name}]:
+	case l.packages[pkg]:
+		l.addf(LintUnresolvedReference, f.Type.Pos, "message %s not found in package %s", f.Type.Name, pkg)
+		return
+	default:
+		l.addf(LintUnresolvedReference, f.Type.Pos, "package %s of %s not found", pkg, ref)
+		return
+	}
+	if msg.Type == "msg" {
+		from := msg.Package + "/" + msg.Name
+		l.deps[from] = append(l.deps[from], lintDep{msg: ref, pos: f.Type.Pos})
+	}
+}
+
+// checkCycles reports each dependency that closes a cycle of messages.
+func (l *linter) checkCycles() {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var stack []string
+	var visit func(msg string)
+	visit = func(msg string) {
+		state[msg] = visiting
+		stack = append(stack, msg)
+		for _, dep := range l.deps[msg] {
+			switch state[dep.msg] {
+			case visiting:
+				i := len(stack) - 1
+				for stack[i] != dep.msg {
+					i--
+				}
+				cycle := append(append([]string{}, stack[i:]...), dep.msg)
+				l.addf(LintDependencyCycle, dep.pos, "dependency cycle: %s", strings.Join(cycle, " -> "))
+			case 0:
+				visit(dep.msg)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[msg] = done
+	}
+	msgs := make([]string, 0, len(l.deps))
+	for msg := range l.deps {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+	for _, msg := range msgs {
+		if state[msg] == 0 {
+			visit(msg)
+		}
+	}
+}
+
+// checkIdentifiers reports top-level identifiers of the code generated for
+// meta that collide with those of other interfaces of the same Go package.
+// idents maps Go packages to identifiers and the interfaces defining them.
+func (l *linter) checkIdentifiers(meta Metadata, path string, idents map[string]map[string]string) {
+	pkg := meta.GoPackage()
+	if idents[pkg] == nil {
+		idents[pkg] = map[string]string{}
+	}
+	for _, ident := range generatedIdentifiers(meta) {
+		if other, ok := idents[pkg][ident]; ok && other != meta.Name {
+			l.addf(LintNameCollision, Pos{File: path}, "the generated identifier %s.%s of %s collides with that of %s", pkg, ident, meta.Name, other)
+			continue
+		}
+		idents[pkg][ident] = meta.Name
+	}
+}
+
+// generatedIdentifiers returns the top-level identifiers declared by the code
+// generated for meta.
+func generatedIdentifiers(meta Metadata) []string {
+	msgIdents := func(n string) []string {
+		return []string{
+			n, "New" + n, "Clone" + n + "Slice",
+			n + "Publisher", "New" + n + "Publisher",
+			n + "Subscription", n + "SubscriptionCallback", "New" + n + "Subscription",
+			n + "TypeSupport", "_" + n + "TypeSupport",
+			"C" + n, "C" + n + "Sequence",
+			n + "SequenceToGo", n + "SequenceToC", n + "ArrayToGo", n + "ArrayToC",
+		}
+	}
+	srvIdents := func(n string) []string {
+		idents := []string{
+			n + "TypeSupport", "_" + n + "TypeSupport",
+			n + "Client", "New" + n + "Client",
+			n + "Service", "New" + n + "Service",
+			n + "ServiceResponseSender", n + "ServiceRequestHandler",
+		}
+		idents = append(idents, msgIdents(n+"_Request")...)
+		return append(idents, msgIdents(n+"_Response")...)
+	}
+	n := meta.Name
+	switch meta.Type {
+	case "srv":
+		return srvIdents(n)
+	case "action":
+		idents := []string{
+			n + "TypeSupport", "_" + n + "TypeSupport",
+			n + "FeedbackSender", n + "GoalHandle",
+			n + "Action", "New" + n + "Action", "_" + n + "FuncAction", "_" + n + "Action",
+			n + "Server", "New" + n + "Server",
+			n + "FeedbackHandler", n + "StatusHandler",
+			n + "Client", "New" + n + "Client",
+		}
+		for _, m := range []string{"_Goal", "_Result", "_Feedback", "_FeedbackMessage"} {
+			idents = append(idents, msgIdents(n+m)...)
+		}
+		idents = append(idents, srvIdents(n+"_SendGoal")...)
+		return append(idents, srvIdents(n+"_GetResult")...)
+	default:
+		return msgIdents(n)
+	}
+}
+
+// WriteLintReport writes issues to w in format, which is "text", "json" or
+// "sarif".
+func WriteLintReport(w io.Writer, format string, issues []*LintIssue) error {
+	switch format {
+	case "text":
+		for _, issue := range issues {
+			if _, err := fmt.Fprintln(w, issue); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		if issues == nil {
+			issues = []*LintIssue{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(issues)
+	case "sarif":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(newSARIFLog(issues))
+	default:
+		return fmt.Errorf("unknown lint report format: %s", format)
+	}
+}
+
+// The subset of SARIF 2.1.0 needed to report lint issues.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name  string      `json:"name"`
+		Rules []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+func newSARIFLog(issues []*LintIssue) *sarifLog {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "ros2gen"}},
+		Results: []sarifResult{},
+	}
+	for _, r := range LintRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               r.ID,
+			ShortDescription: sarifMessage{Text: r.Description},
+		})
+	}
+	for _, issue := range issues {
+		loc := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(issue.Pos.File)},
+		}
+		if issue.Pos.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: issue.Pos.Line, StartColumn: issue.Pos.Column}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    issue.Rule,
+			Level:     "error",
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+	return &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLintFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLint(t *testing.T) {
+	dir := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg":          "B b\nint32 clone\nint32 a_b1\nint32 a_b_1\ngeometry_msgs/Point p\nMissing m\n",
+		"my_msgs/msg/B.msg":          "A a\nint32 X=1\nint32 X=2\n",
+		"my_msgs/msg/APublisher.msg": "int32 x\n",
+		"my_msgs/srv/Get.srv":        "int33 Bad\nstring<=2 s \"abc\"\n---\nbool ok\n",
+		"my_msgs/action/Do.idl": `module my_msgs { module action {
+struct Do_Goal { my_msgs::msg::C c; };
+struct Do_Result { boolean ok; };
+struct Do_Feedback { boolean ok; };
+}; };`,
+	})
+	deps := writeLintFiles(t, map[string]string{
+		"geometry_msgs/msg/Point.msg": "float64 x\n",
+	})
+
+	type issue struct {
+		rule, file string
+		line, col  int
+		msg        string
+	}
+	var got []issue
+	for _, i := range Lint([]string{dir}, []string{deps}) {
+		rel, err := filepath.Rel(dir, i.Pos.File)
+		require.NoError(t, err)
+		got = append(got, issue{i.Rule, filepath.ToSlash(rel), i.Pos.Line, i.Pos.Column, i.Message})
+	}
+	assert.Equal(t, []issue{
+		{LintUnresolvedReference, "my_msgs/action/Do.idl", 2, 34, "message C not found in package my_msgs"},
+		{LintNameCollision, "my_msgs/msg/A.msg", 2, 7, "field clone maps to the Go name Clone, which collides with the method A.Clone"},
+		{LintDuplicateName, "my_msgs/msg/A.msg", 4, 7, "fields a_b1 and a_b_1 of A both map to the Go name AB1"},
+		{LintUnresolvedReference, "my_msgs/msg/A.msg", 6, 1, "message Missing not found in package my_msgs"},
+		{LintNameCollision, "my_msgs/msg/APublisher.msg", 0, 0, "the generated identifier my_msgs_msg.APublisher of APublisher collides with that of A"},
+		{LintNameCollision, "my_msgs/msg/APublisher.msg", 0, 0, "the generated identifier my_msgs_msg.NewAPublisher of APublisher collides with that of A"},
+		{LintDependencyCycle, "my_msgs/msg/B.msg", 1, 1, "dependency cycle: my_msgs/A -> my_msgs/B -> my_msgs/A"},
+		{LintDuplicateName, "my_msgs/msg/B.msg", 3, 7, "duplicate constant X in B"},
+		{LintInvalidDefinition, "my_msgs/srv/Get.srv", 1, 1, `unknown type "int33"`},
+		{LintInvalidDefinition, "my_msgs/srv/Get.srv", 1, 7, `invalid field name "Bad": field names must be lower case letters, digits and underscores starting with a letter`},
+		{LintInvalidDefinition, "my_msgs/srv/Get.srv", 2, 13, `invalid default value: the length of "abc" is 3, which exceeds the bound of string<=2`},
+	}, got)
+}
+
+func TestWriteLintReport(t *testing.T) {
+	issues := []*LintIssue{
+		{Rule: LintDuplicateName, Pos: Pos{File: "pkg/msg/A.msg", Line: 2, Column: 7}, Message: "duplicate field a in A"},
+		{Rule: LintNameCollision, Pos: Pos{File: "pkg/msg/B.msg"}, Message: "collision"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteLintReport(&buf, "text", issues))
+	assert.Equal(t, "pkg/msg/A.msg:2:7: duplicate field a in A (duplicate-name)\npkg/msg/B.msg: collision (name-collision)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteLintReport(&buf, "json", issues))
+	var decoded []*LintIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, issues, decoded)
+
+	buf.Reset()
+	require.NoError(t, WriteLintReport(&buf, "sarif", issues))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Results, 2)
+	assert.Equal(t, "duplicate-name", log.Runs[0].Results[0].RuleID)
+	assert.Equal(t, &sarifRegion{StartLine: 2, StartColumn: 7}, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+	assert.Nil(t, log.Runs[0].Results[1].Locations[0].PhysicalLocation.Region)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(LintRules))
+
+	assert.Error(t, WriteLintReport(&buf, "xml", issues))
+}
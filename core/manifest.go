@@ -0,0 +1,223 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// ManifestFileName is the name of the file in Config.DestPath recording the
+// interfaces the files in it were generated from.
+const ManifestFileName = ".ros2gen-manifest.json"
+
+// manifest records how the bindings of each interface were generated, so that
+// unchanged interfaces can be skipped and the outputs of removed interfaces
+// can be deleted.
+type manifest struct {
+	Interfaces map[string]*manifestEntry `json:"interfaces"`
+}
+
+type manifestEntry struct {
+	Source           string   `json:"source"`
+	SourceHash       string   `json:"source_hash"`
+	GeneratorVersion string   `json:"generator_version"`
+	ConfigHash       string   `json:"config_hash"`
+	Outputs          []string `json:"outputs"` // relative to Config.DestPath
+	CImports         []string `json:"c_imports,omitempty"`
+	PluginOutputs    []string `json:"plugin_outputs,omitempty"` // relative to Config.DestPath
+}
+
+// allOutputs returns the files generated for the entry, including the ones
+// written by plugins.
+func (e *manifestEntry) allOutputs() []string {
+	return append(append([]string{}, e.Outputs...), e.PluginOutputs...)
+}
+
+func manifestKey(meta Metadata) string {
+	return meta.Package + "/" + meta.Type + "/" + meta.Name
+}
+
+// loadManifest reads the manifest in dir. A missing or unreadable manifest is
+// treated as empty, which causes everything to be regenerated.
+func loadManifest(dir string) *manifest {
+	m := &manifest{Interfaces: map[string]*manifestEntry{}}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed to read generation manifest: %v\n", err)
+		}
+		return m
+	}
+	if err := json.Unmarshal(data, m); err != nil || m.Interfaces == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Ignoring invalid generation manifest: %v\n", err)
+		return &manifest{Interfaces: map[string]*manifestEntry{}}
+	}
+	return m
+}
+
+func (m *manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	//#nosec G301 -- The generated directory doesn't contain secrets.
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ManifestFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, ManifestFileName))
+}
+
+// upToDate reports whether the outputs recorded in old were generated from
+// the same source with the same generator and configuration as entry and
+// still exist.
+func upToDate(old, entry *manifestEntry, destPath string) bool {
+	if old == nil || entry.GeneratorVersion == "" ||
+		old.Source != entry.Source ||
+		old.SourceHash != entry.SourceHash ||
+		old.GeneratorVersion != entry.GeneratorVersion ||
+		old.ConfigHash != entry.ConfigHash ||
+		len(old.Outputs) != len(entry.Outputs) {
+		return false
+	}
+	for i, out := range old.Outputs {
+		if out != entry.Outputs[i] {
+			return false
+		}
+		if _, err := os.Stat(filepath.Join(destPath, out)); err != nil {
+			return false
+		}
+	}
+	for _, out := range old.PluginOutputs {
+		if _, err := os.Stat(filepath.Join(destPath, out)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// pruneOutputs deletes the outputs of entry. Directories left with only the
+// common package file are deleted as well.
+func pruneOutputs(destPath string, entry *manifestEntry) (removed int) {
+	dirs := map[string]bool{}
+	for _, out := range entry.allOutputs() {
+		p := filepath.Join(destPath, out)
+		err := os.Remove(p)
+		if err == nil {
+			removed++
+		} else if !errors.Is(err, os.ErrNotExist) {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed to remove stale file %s: %v\n", p, err)
+		}
+		dirs[filepath.Dir(p)] = true
+	}
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 1 || (len(entries) == 1 && entries[0].Name() != "common.gen.go") {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed to remove stale directory %s: %v\n", dir, err)
+		}
+	}
+	return removed
+}
+
+// interfaceOutputs returns the paths of the files generated for meta relative
+// to Config.DestPath.
+func interfaceOutputs(meta Metadata) []string {
+	var names []string
+	switch meta.Type {
+	case "msg":
+		names = []string{meta.Name}
+	case "srv":
+		names = []string{meta.Name, meta.Name + "_Request", meta.Name + "_Response"}
+	case "action":
+		names = []string{meta.Name, meta.Name + "_Goal", meta.Name + "_Result", meta.Name + "_Feedback", meta.Name + "_FeedbackMessage"}
+		for _, srv := range []string{"_SendGoal", "_GetResult"} {
+			names = append(names, meta.Name+srv, meta.Name+srv+"_Request", meta.Name+srv+"_Response")
+		}
+	}
+	outs := make([]string, len(names))
+	for i, name := range names {
+		outs[i] = iFaceFilePath("", &Metadata{Package: meta.Package, Type: meta.Type, Name: name})
+	}
+	sort.Strings(outs)
+	return outs
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// configHash hashes the parts of config that affect the generated bindings of
+// an interface.
+func configHash(config *Config) string {
+	data, _ := json.Marshal([]any{ //nolint:errchkjson
+		config.DistroImportPath,
+		config.RclgoImportPath,
+		config.MessageModulePrefix,
+		config.Distro,
+		config.GoImportPaths,
+		config.StructTags,
+		config.Templates,
+		templateHashes(config.Templates),
+		config.TypedEnums.patterns(),
+		config.NativeTime,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// templateHashes hashes the files of templates, so that changing them
+// invalidates the outputs.
+func templateHashes(templates []Template) []string {
+	hashes := make([]string, len(templates))
+	for i, t := range templates {
+		hashes[i], _ = hashFile(t.Path)
+	}
+	return hashes
+}
+
+var generatorVersion = sync.OnceValue(func() string {
+	// Released versions identify the generator. Development builds are
+	// identified by the hash of the executable, so that any change of the
+	// templates invalidates the outputs. If neither is available, outputs
+	// are never considered up to date.
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	if exe, err := os.Executable(); err == nil {
+		if hash, err := hashFile(exe); err == nil {
+			return hash
+		}
+	}
+	return ""
+})
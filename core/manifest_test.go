@@ -0,0 +1,64 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalGeneration(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg":   "int32 a\n",
+		"my_msgs/msg/B.msg":   "A a\n",
+		"my_msgs/srv/Get.srv": "string name\n---\nB b\n",
+	})
+	dest := t.TempDir()
+	config := DefaultConfig
+	config.RootPaths = []string{root}
+	config.DestPath = dest
+	config.Workers = 2
+
+	generate := func() (*Generator, GenerateSummary) {
+		g := New(&config)
+		summary, err := g.GenerateGolangMessageTypes()
+		require.NoError(t, err)
+		return g, summary
+	}
+
+	_, summary := generate()
+	assert.Equal(t, GenerateSummary{Generated: 3}, summary)
+	for _, out := range []string{"my_msgs/msg/A.gen.go", "my_msgs/msg/B.gen.go", "my_msgs/srv/Get_Request.gen.go", "my_msgs/msg/common.gen.go"} {
+		assert.FileExists(t, filepath.Join(dest, out))
+	}
+	m := loadManifest(dest)
+	require.Len(t, m.Interfaces, 3)
+	assert.Equal(t, []string{"my_msgs/srv/Get.gen.go", "my_msgs/srv/Get_Request.gen.go", "my_msgs/srv/Get_Response.gen.go"}, m.Interfaces["my_msgs/srv/Get"].Outputs)
+	assert.Equal(t, []string{"my_msgs"}, m.Interfaces["my_msgs/srv/Get"].CImports)
+
+	g, summary := generate()
+	assert.Equal(t, GenerateSummary{Skipped: 3}, summary)
+	assert.Contains(t, g.cImportsByPkgAndType["my_msgs_srv"], "my_msgs")
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "my_msgs/msg/A.msg"), []byte("int64 a\n"), 0o600))
+	require.NoError(t, os.Remove(filepath.Join(root, "my_msgs/srv/Get.srv")))
+	_, summary = generate()
+	assert.Equal(t, GenerateSummary{Generated: 1, Skipped: 1, Removed: 3}, summary)
+	content, err := os.ReadFile(filepath.Join(dest, "my_msgs/msg/A.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "int64")
+	assert.NoDirExists(t, filepath.Join(dest, "my_msgs/srv"))
+	assert.Len(t, loadManifest(dest).Interfaces, 2)
+
+	config.Force = true
+	_, summary = generate()
+	assert.Equal(t, GenerateSummary{Generated: 2}, summary)
+}
+
+func TestInterfaceOutputs(t *testing.T) {
+	outs := interfaceOutputs(Metadata{Package: "pkg", Type: "action", Name: "Do"})
+	assert.Len(t, outs, 11)
+	assert.Contains(t, outs, filepath.Join("pkg", "action", "Do_SendGoal_Request.gen.go"))
+}
@@ -0,0 +1,546 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/okieraised/rclgo/core/internal/utilities"
+)
+
+// Pos is a position in an interface definition file. Lines and columns start
+// at 1. Columns count bytes.
+type Pos struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (p Pos) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	s := strconv.Itoa(p.Line)
+	if p.Column > 0 {
+		s += ":" + strconv.Itoa(p.Column)
+	}
+	if p.File != "" {
+		s = p.File + ":" + s
+	}
+	return s
+}
+
+// Diagnostic is a problem found in an interface definition.
+type Diagnostic struct {
+	Pos     Pos
+	Message string
+}
+
+func (d *Diagnostic) Error() string {
+	return d.Pos.String() + ": " + d.Message
+}
+
+// Diagnostics is a list of problems found in an interface definition. It is
+// returned as an error by the parsers.
+type Diagnostics []*Diagnostic
+
+func (ds Diagnostics) Error() string {
+	msgs := make([]string, len(ds))
+	for i, d := range ds {
+		msgs[i] = d.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (ds *Diagnostics) addf(pos Pos, format string, args ...interface{}) {
+	*ds = append(*ds, &Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+func (ds Diagnostics) err() error {
+	if len(ds) == 0 {
+		return nil
+	}
+	return ds
+}
+
+// MsgFile is the syntax tree of a .msg, .srv or .action file. It has one
+// section for each part separated by "---".
+type MsgFile struct {
+	Sections []*MsgSection
+}
+
+type MsgSection struct {
+	Fields    []*MsgField
+	Constants []*MsgConstant
+}
+
+// MsgType is a type reference, e.g. "pkg/Type[<=3]" or "string<=10".
+type MsgType struct {
+	Pos         Pos
+	Package     string // empty if not given explicitly
+	Name        string
+	StringBound int // 0 if the string is unbounded
+	IsArray     bool
+	ArraySize   int // size of a fixed-size array, 0 for sequences
+	ArrayBound  int // upper bound of a bounded sequence, 0 if unbounded
+}
+
+func (t *MsgType) IsPrimitive() bool {
+	if t.Package != "" {
+		return false
+	}
+	_, ok := primitiveTypeMappings[t.Name]
+	return ok
+}
+
+func (t *MsgType) String() string {
+	var b strings.Builder
+	if t.Package != "" {
+		b.WriteString(t.Package + "/")
+	}
+	b.WriteString(t.Name)
+	if t.StringBound > 0 {
+		b.WriteString("<=" + strconv.Itoa(t.StringBound))
+	}
+	switch {
+	case t.ArraySize > 0:
+		b.WriteString("[" + strconv.Itoa(t.ArraySize) + "]")
+	case t.ArrayBound > 0:
+		b.WriteString("[<=" + strconv.Itoa(t.ArrayBound) + "]")
+	case t.IsArray:
+		b.WriteString("[]")
+	}
+	return b.String()
+}
+
+type MsgField struct {
+	Pos        Pos // position of the name
+	Type       *MsgType
+	Name       string
+	Default    string // empty if there is no default value
+	DefaultPos Pos
+	Comment    string
+}
+
+type MsgConstant struct {
+	Pos      Pos // position of the name
+	Type     *MsgType
+	Name     string
+	Value    string
+	ValuePos Pos
+	Comment  string
+}
+
+// ParseMsgFile parses the source of a file with at most maxSections sections.
+// Parsing continues after errors, and all problems found are returned as
+// Diagnostics together with the part of the tree that could be parsed.
+func ParseMsgFile(file, source string, maxSections int) (*MsgFile, error) {
+	p := &msgParser{file: file}
+	p.parse(source, maxSections)
+	return p.tree, p.diags.err()
+}
+
+type msgParser struct {
+	file     string
+	tree     *MsgFile
+	diags    Diagnostics
+	comments strings.Builder // comment lines preceding the current line
+}
+
+func (p *msgParser) pos(line, col int) Pos {
+	return Pos{File: p.file, Line: line, Column: col}
+}
+
+func (p *msgParser) parse(source string, maxSections int) {
+	section := &MsgSection{}
+	p.tree = &MsgFile{Sections: []*MsgSection{section}}
+	for i, line := range strings.Split(source, "\n") {
+		lineNo := i + 1
+		trimmed := strings.TrimLeft(line, " \t")
+		col := len(line) - len(trimmed) + 1
+		trimmed = strings.TrimRight(trimmed, " \t\r")
+		switch {
+		case trimmed == "":
+			p.comments.Reset()
+		case trimmed == "---":
+			p.comments.Reset()
+			if len(p.tree.Sections) >= maxSections {
+				p.diags.addf(p.pos(lineNo, col), "too many sections, expected at most %d", maxSections)
+			}
+			section = &MsgSection{}
+			p.tree.Sections = append(p.tree.Sections, section)
+		case trimmed[0] == '#':
+			if text := strings.TrimSpace(trimmed[1:]); text != "" {
+				if p.comments.Len() > 0 {
+					p.comments.WriteByte(' ')
+				}
+				p.comments.WriteString(text)
+			}
+		default:
+			p.parseDeclaration(section, trimmed, lineNo, col)
+		}
+	}
+}
+
+// parseDeclaration parses a field or constant declaration starting at column
+// col of line lineNo.
+func (p *msgParser) parseDeclaration(section *MsgSection, s string, lineNo, col int) {
+	typeEnd := strings.IndexAny(s, " \t")
+	if typeEnd < 0 {
+		p.diags.addf(p.pos(lineNo, col), "expected a type and a name")
+		p.comments.Reset()
+		return
+	}
+	typ, ok := p.parseType(s[:typeEnd], p.pos(lineNo, col))
+	rest := strings.TrimLeft(s[typeEnd:], " \t")
+	nameCol := col + len(s) - len(rest)
+	nameEnd := 0
+	for nameEnd < len(rest) && isNameChar(rest[nameEnd]) {
+		nameEnd++
+	}
+	if nameEnd == 0 {
+		p.diags.addf(p.pos(lineNo, nameCol), "expected a name, got %q", rest)
+		p.comments.Reset()
+		return
+	}
+	name := rest[:nameEnd]
+	namePos := p.pos(lineNo, nameCol)
+	rest = rest[nameEnd:]
+	afterName := strings.TrimLeft(rest, " \t")
+	valueCol := nameCol + nameEnd + len(rest) - len(afterName)
+
+	if strings.HasPrefix(afterName, "=") {
+		value := strings.TrimLeft(afterName[1:], " \t")
+		valueCol += len(afterName) - len(value)
+		var comment string
+		if typ.Name == "string" || typ.Name == "wstring" {
+			// String constants extend to the end of the line unless they
+			// are quoted, so that they can contain '#'.
+			if lit, after, ok := cutQuoted(value); ok {
+				value = lit
+				after = strings.TrimSpace(after)
+				if after != "" && after[0] != '#' {
+					p.diags.addf(p.pos(lineNo, valueCol+len(lit)), "unexpected %q after the value", after)
+				}
+				comment = strings.TrimSpace(strings.TrimPrefix(after, "#"))
+			}
+		} else {
+			value, comment = splitComment(value)
+		}
+		c := &MsgConstant{
+			Pos:      namePos,
+			Type:     typ,
+			Name:     name,
+			Value:    strings.TrimSpace(value),
+			ValuePos: p.pos(lineNo, valueCol),
+			Comment:  utilities.CommentSerializer(comment, &p.comments),
+		}
+		p.comments.Reset()
+		if ok {
+			p.diags = append(p.diags, validateMsgConstant(c)...)
+		} else {
+			p.diags = append(p.diags, validateMsgConstantName(c)...)
+		}
+		section.Constants = append(section.Constants, c)
+		return
+	}
+
+	if afterName != "" && afterName == rest && afterName[0] != '#' {
+		p.diags.addf(p.pos(lineNo, valueCol), "invalid character %q in name", afterName[:1])
+		p.comments.Reset()
+		return
+	}
+	value, comment := splitComment(afterName)
+	f := &MsgField{
+		Pos:        namePos,
+		Type:       typ,
+		Name:       name,
+		Default:    strings.TrimSpace(value),
+		DefaultPos: p.pos(lineNo, valueCol),
+		Comment:    utilities.CommentSerializer(comment, &p.comments),
+	}
+	p.comments.Reset()
+	if ok {
+		p.diags = append(p.diags, validateMsgField(f)...)
+	} else {
+		p.diags = append(p.diags, validateMsgFieldName(f)...)
+	}
+	section.Fields = append(section.Fields, f)
+}
+
+// parseType parses a type reference at pos. If the reference is malformed, a
+// diagnostic is added and ok is false.
+func (p *msgParser) parseType(s string, pos Pos) (typ *MsgType, ok bool) {
+	typ = &MsgType{Pos: pos}
+	i := 0
+	ident := func() string {
+		start := i
+		for i < len(s) && isNameChar(s[i]) {
+			i++
+		}
+		return s[start:i]
+	}
+	number := func() (int, bool) {
+		start := i
+		for i < len(s) && isASCIIDigit(s[i]) {
+			i++
+		}
+		n, err := strconv.Atoi(s[start:i])
+		if err != nil || n <= 0 {
+			p.diags.addf(Pos{File: pos.File, Line: pos.Line, Column: pos.Column + start}, "expected a positive integer in type %q", s)
+			return 0, false
+		}
+		return n, true
+	}
+	typ.Name = ident()
+	if i < len(s) && s[i] == '/' {
+		i++
+		typ.Package = typ.Name
+		typ.Name = ident()
+	}
+	if typ.Name == "" {
+		p.diags.addf(pos, "invalid type %q", s)
+		return typ, false
+	}
+	if strings.HasPrefix(s[i:], "<=") {
+		i += 2
+		if typ.StringBound, ok = number(); !ok {
+			return typ, false
+		}
+	}
+	if i < len(s) && s[i] == '[' {
+		i++
+		typ.IsArray = true
+		switch {
+		case strings.HasPrefix(s[i:], "<="):
+			i += 2
+			if typ.ArrayBound, ok = number(); !ok {
+				return typ, false
+			}
+		case i < len(s) && s[i] != ']':
+			if typ.ArraySize, ok = number(); !ok {
+				return typ, false
+			}
+		}
+		if i >= len(s) || s[i] != ']' {
+			p.diags.addf(pos, "invalid array specification in type %q", s)
+			return typ, false
+		}
+		i++
+	}
+	if i < len(s) {
+		p.diags.addf(Pos{File: pos.File, Line: pos.Line, Column: pos.Column + i}, "unexpected %q in type %q", s[i:], s)
+		return typ, false
+	}
+	diags := validateMsgType(typ)
+	p.diags = append(p.diags, diags...)
+	return typ, len(diags) == 0
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || isASCIILetter(c) || isASCIIDigit(c)
+}
+
+// cutQuoted cuts a quoted string literal from the start of s.
+func cutQuoted(s string) (lit, after string, ok bool) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return "", s, false
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case s[0]:
+			return s[:i+1], s[i+1:], true
+		}
+	}
+	return "", s, false
+}
+
+// splitComment splits s at the first '#' outside of quotes.
+func splitComment(s string) (code, comment string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return s[:i], strings.TrimSpace(s[i+1:])
+		}
+	}
+	return s, ""
+}
+
+// Naming rules of ROS interfaces.
+var (
+	reMsgFieldName    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	reMsgConstantName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	reMsgPackageName  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	reMsgTypeName     = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// checkName checks name against re and the rule that names must not contain
+// consecutive underscores or end with one.
+func checkName(re *regexp.Regexp, name string) bool {
+	return re.MatchString(name) && !strings.Contains(name, "__") && !strings.HasSuffix(name, "_")
+}
+
+func validateMsgType(typ *MsgType) Diagnostics {
+	var diags Diagnostics
+	switch {
+	case typ.Package != "":
+		if !checkName(reMsgPackageName, typ.Package) {
+			diags.addf(typ.Pos, "invalid package name %q: package names must be lower case letters, digits and underscores starting with a letter", typ.Package)
+		}
+		if !reMsgTypeName.MatchString(typ.Name) {
+			diags.addf(typ.Pos, "invalid type name %q: type names must be CamelCase starting with an upper case letter", typ.Name)
+		}
+	case typ.IsPrimitive():
+	case reMsgTypeName.MatchString(typ.Name):
+	default:
+		diags.addf(typ.Pos, "unknown type %q", typ.Name)
+	}
+	if typ.StringBound > 0 && !(typ.Package == "" && (typ.Name == "string" || typ.Name == "wstring")) {
+		diags.addf(typ.Pos, "only string and wstring can have an upper bound, not %s", typ.Name)
+	}
+	return diags
+}
+
+func validateMsgFieldName(f *MsgField) Diagnostics {
+	var diags Diagnostics
+	if !checkName(reMsgFieldName, f.Name) {
+		diags.addf(f.Pos, "invalid field name %q: field names must be lower case letters, digits and underscores starting with a letter", f.Name)
+	}
+	return diags
+}
+
+func validateMsgField(f *MsgField) Diagnostics {
+	diags := validateMsgFieldName(f)
+	if f.Default == "" {
+		return diags
+	}
+	if !f.Type.IsPrimitive() {
+		diags.addf(f.DefaultPos, "fields of type %s can't have a default value", f.Type)
+		return diags
+	}
+	if !f.Type.IsArray {
+		value, err := normalizeMsgValue(f.Type, f.Default)
+		if err != nil {
+			diags.addf(f.DefaultPos, "invalid default value: %v", err)
+		}
+		f.Default = value
+		return diags
+	}
+	if !strings.HasPrefix(f.Default, "[") || !strings.HasSuffix(f.Default, "]") {
+		diags.addf(f.DefaultPos, "the default value of an array must be enclosed in brackets")
+		return diags
+	}
+	elems := utilities.SplitMsgDefaultArrayValues("", f.Default)
+	switch {
+	case f.Type.ArraySize > 0 && len(elems) != f.Type.ArraySize:
+		diags.addf(f.DefaultPos, "%s needs %d default values, got %d", f.Type, f.Type.ArraySize, len(elems))
+	case f.Type.ArrayBound > 0 && len(elems) > f.Type.ArrayBound:
+		diags.addf(f.DefaultPos, "%s can have at most %d default values, got %d", f.Type, f.Type.ArrayBound, len(elems))
+	}
+	for i, elem := range elems {
+		value, err := normalizeMsgValue(f.Type, elem)
+		if err != nil {
+			diags.addf(f.DefaultPos, "invalid default value at index %d: %v", i, err)
+		}
+		elems[i] = value
+	}
+	f.Default = "[" + strings.Join(elems, ", ") + "]"
+	return diags
+}
+
+func validateMsgConstantName(c *MsgConstant) Diagnostics {
+	var diags Diagnostics
+	if !checkName(reMsgConstantName, c.Name) {
+		diags.addf(c.Pos, "invalid constant name %q: constant names must be upper case letters, digits and underscores starting with a letter", c.Name)
+	}
+	return diags
+}
+
+func validateMsgConstant(c *MsgConstant) Diagnostics {
+	diags := validateMsgConstantName(c)
+	if !c.Type.IsPrimitive() || c.Type.IsArray {
+		diags.addf(c.Type.Pos, "constants must have a primitive non-array type, not %s", c.Type)
+		return diags
+	}
+	if c.Value == "" {
+		diags.addf(c.ValuePos, "constant %s has no value", c.Name)
+		return diags
+	}
+	value, err := normalizeMsgValue(c.Type, c.Value)
+	if err != nil {
+		diags.addf(c.ValuePos, "invalid value: %v", err)
+	}
+	c.Value = value
+	return diags
+}
+
+var msgIntBits = map[string]int{
+	"byte": 8, "char": 8,
+	"int8": 8, "int16": 16, "int32": 32, "int64": 64,
+	"uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64,
+}
+
+// normalizeMsgValue checks that value is valid for the primitive type typ and
+// returns it in the form used in generated code. Boolean values are converted
+// to lower case.
+func normalizeMsgValue(typ *MsgType, value string) (string, error) {
+	switch name := typ.Name; name {
+	case "bool":
+		switch strings.ToLower(value) {
+		case "true", "1":
+			return "true", nil
+		case "false", "0":
+			return "false", nil
+		}
+		return value, fmt.Errorf("%q is not a boolean", value)
+	case "float32", "float64":
+		bits := 64
+		if name == "float32" {
+			bits = 32
+		}
+		f, err := strconv.ParseFloat(value, bits)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return value, fmt.Errorf("%q is not a valid %s", value, name)
+		}
+	case "string", "wstring":
+		s := value
+		if lit, after, ok := cutQuoted(value); ok && after == "" {
+			s = lit[1 : len(lit)-1]
+		}
+		if n := utf8.RuneCountInString(s); typ.StringBound > 0 && n > typ.StringBound {
+			return value, fmt.Errorf("the length of %s is %d, which exceeds the bound of %s", value, n, typ)
+		}
+	default:
+		bits, ok := msgIntBits[name]
+		if !ok {
+			return value, nil
+		}
+		var err error
+		if strings.HasPrefix(name, "int") {
+			_, err = strconv.ParseInt(value, 0, bits)
+		} else {
+			_, err = strconv.ParseUint(value, 0, bits)
+		}
+		if err != nil {
+			if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+				return value, fmt.Errorf("%s is out of the range of %s", value, name)
+			}
+			return value, fmt.Errorf("%q is not a valid %s", value, name)
+		}
+	}
+	return value, nil
+}
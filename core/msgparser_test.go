@@ -0,0 +1,145 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMsgFilePositions(t *testing.T) {
+	const src = `# A comment
+int32 FOO=1
+  geometry_msgs/Point[<=3]  points
+---
+string<=5 name "abc"
+`
+	tree, err := ParseMsgFile("Test.srv", src, 2)
+	require.NoError(t, err)
+	require.Len(t, tree.Sections, 2)
+
+	c := tree.Sections[0].Constants[0]
+	assert.Equal(t, Pos{File: "Test.srv", Line: 2, Column: 7}, c.Pos)
+	assert.Equal(t, Pos{File: "Test.srv", Line: 2, Column: 11}, c.ValuePos)
+	assert.Equal(t, "A comment", c.Comment)
+
+	f := tree.Sections[0].Fields[0]
+	assert.Equal(t, Pos{File: "Test.srv", Line: 3, Column: 3}, f.Type.Pos)
+	assert.Equal(t, Pos{File: "Test.srv", Line: 3, Column: 29}, f.Pos)
+	assert.Equal(t, &MsgType{Pos: f.Type.Pos, Package: "geometry_msgs", Name: "Point", IsArray: true, ArrayBound: 3}, f.Type)
+
+	f = tree.Sections[1].Fields[0]
+	assert.Equal(t, Pos{File: "Test.srv", Line: 5, Column: 16}, f.DefaultPos)
+	assert.Equal(t, `"abc"`, f.Default)
+	assert.Equal(t, 5, f.Type.StringBound)
+}
+
+func TestParseMsgFileValues(t *testing.T) {
+	const src = `string A="a # b" # The A.
+string B=a # b
+string[2] c ["x # y", 'z'] # The c.
+bool[] d [TRUE, 0]
+# Not attached to E.
+
+int8 E=-0x10 # The E.
+`
+	tree, err := ParseMsgFile("", src, 1)
+	require.NoError(t, err)
+	s := tree.Sections[0]
+
+	require.Len(t, s.Constants, 3)
+	assert.Equal(t, `"a # b"`, s.Constants[0].Value)
+	assert.Equal(t, "The A.", s.Constants[0].Comment)
+	assert.Equal(t, "a # b", s.Constants[1].Value)
+	assert.Equal(t, "", s.Constants[1].Comment)
+	assert.Equal(t, "-0x10", s.Constants[2].Value)
+	assert.Equal(t, "The E.", s.Constants[2].Comment)
+
+	require.Len(t, s.Fields, 2)
+	assert.Equal(t, `["x # y", 'z']`, s.Fields[0].Default)
+	assert.Equal(t, "The c.", s.Fields[0].Comment)
+	assert.Equal(t, "[true, false]", s.Fields[1].Default)
+}
+
+func TestParseMsgFileDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "unknown type",
+			src:  "int33 a",
+			want: `1:1: unknown type "int33"`,
+		},
+		{
+			name: "invalid field name",
+			src:  "int32 Abc",
+			want: `1:7: invalid field name "Abc": field names must be lower case letters, digits and underscores starting with a letter`,
+		},
+		{
+			name: "invalid constant name",
+			src:  "int32 A__B=1",
+			want: `1:7: invalid constant name "A__B": constant names must be upper case letters, digits and underscores starting with a letter`,
+		},
+		{
+			name: "invalid character in name",
+			src:  "int32 a-b",
+			want: `1:8: invalid character "-" in name`,
+		},
+		{
+			name: "string bound",
+			src:  `string<=2 a "abc"`,
+			want: `1:13: invalid default value: the length of "abc" is 3, which exceeds the bound of string<=2`,
+		},
+		{
+			name: "array size",
+			src:  "int32[3] a [1, 2]",
+			want: "1:12: int32[3] needs 3 default values, got 2",
+		},
+		{
+			name: "array bound",
+			src:  "int32[<=1] a [1, 2]",
+			want: "1:14: int32[<=1] can have at most 1 default values, got 2",
+		},
+		{
+			name: "integer range",
+			src:  "uint8 A=256",
+			want: "1:9: invalid value: 256 is out of the range of uint8",
+		},
+		{
+			name: "complex default",
+			src:  "pkg/Type a 1",
+			want: "1:12: fields of type pkg/Type can't have a default value",
+		},
+		{
+			name: "too many sections",
+			src:  "int32 a\n---\nint32 b",
+			want: "2:1: too many sections, expected at most 1",
+		},
+		{
+			name: "multiple",
+			src:  "int33 a\n\nfloat32 b x",
+			want: "f.msg:1:1: unknown type \"int33\"\nf.msg:3:11: invalid default value: \"x\" is not a valid float32",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := ""
+			if tt.name == "multiple" {
+				file = "f.msg"
+			}
+			_, err := ParseMsgFile(file, tt.src, 1)
+			require.Error(t, err)
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestParseMessageDiagnostics(t *testing.T) {
+	_, err := ParseMessage(&DefaultConfig, "int32 a\nbad_type b\n")
+	var diags Diagnostics
+	require.ErrorAs(t, err, &diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, Pos{Line: 2, Column: 1}, diags[0].Pos)
+}
@@ -1,15 +1,10 @@
 package core
 
 import (
-	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/okieraised/rclgo/core/internal/distro"
 	"github.com/okieraised/rclgo/core/internal/utilities"
 )
 
@@ -29,8 +24,12 @@ func cName(rosName string) string {
 }
 
 type parser struct {
-	config                     *Config
-	ros2messagesCommentsBuffer strings.Builder
+	config *Config
+	file   string // the path of the parsed file used in diagnostics
+
+	// sectionAdded is called after the declarations of section have been
+	// added to msg, if it is set.
+	sectionAdded func(msg *ROS2Message, section *MsgSection)
 }
 
 func ParseMessage(config *Config, content string) (*ROS2Message, error) {
@@ -48,35 +47,43 @@ func (p *parser) ParseROS2Message(res *ROS2Message, content string) error {
 }
 
 func (p *parser) ParseService(service *ROS2Service, source string) error {
+	service.Source = source
 	return p.parseSections(source, service.Request, service.Response)
 }
 
 func (p *parser) ParseAction(action *ROS2Action, source string) error {
+	action.Source = source
 	err := p.parseSections(source, action.Goal, action.Result, action.Feedback)
 	if err != nil {
 		return err
 	}
+	p.addActionFields(action)
+	return nil
+}
+
+// addActionFields populates the messages wrapping the goal, result and
+// feedback of action.
+func (p *parser) addActionFields(action *ROS2Action) {
 	p.addImport(action.SendGoal.Request, "unique_identifier_msgs")
 	action.SendGoal.Request.Fields = []*ROS2Field{
 		p.goalIDField(),
 		p.actionLocalField("goal", "Goal", action, action.Goal),
 	}
-	p.addImport(action.SendGoal.Response, "builtin_interfaces")
 	action.SendGoal.Response.Fields = []*ROS2Field{
 		p.primitiveField("accepted", "Accepted", "bool", "bool"),
-		{
-			RosName: "stamp",
-			CName:   "stamp",
-			GoName:  "Stamp",
+	}
+	p.addField(action.SendGoal.Response, p.nativeTime(&ROS2Field{
+		RosName: "stamp",
+		CName:   "stamp",
+		GoName:  "Stamp",
 
-			PkgName:   "builtin_interfaces",
-			GoPkgName: "builtin_interfaces_msg",
+		PkgName:   "builtin_interfaces",
+		GoPkgName: "builtin_interfaces_msg",
 
-			RosType: "Time",
-			CType:   "Time",
-			GoType:  "Time",
-		},
-	}
+		RosType: "Time",
+		CType:   "Time",
+		GoType:  "Time",
+	}))
 	p.addImport(action.GetResult.Request, "unique_identifier_msgs")
 	action.GetResult.Request.Fields = []*ROS2Field{p.goalIDField()}
 	action.GetResult.Response.Fields = []*ROS2Field{
@@ -88,7 +95,18 @@ func (p *parser) ParseAction(action *ROS2Action, source string) error {
 		p.goalIDField(),
 		p.actionLocalField("feedback", "Feedback", action, action.Feedback),
 	}
-	return nil
+
+	// The wrappers are not defined by a file, so their sources are written
+	// like rosidl_parser defines them.
+	goalID := "unique_identifier_msgs/UUID goal_id\n"
+	action.SendGoal.Request.Source = goalID + action.Goal.Name + " goal\n"
+	action.SendGoal.Response.Source = "bool accepted\nbuiltin_interfaces/Time stamp\n"
+	action.GetResult.Request.Source = goalID
+	action.GetResult.Response.Source = "int8 status\n" + action.Result.Name + " result\n"
+	action.FeedbackMessage.Source = goalID + action.Feedback.Name + " feedback\n"
+	for _, srv := range []*ROS2Service{action.SendGoal, action.GetResult} {
+		srv.Source = srv.Request.Source + "---\n" + srv.Response.Source
+	}
 }
 
 func (p *parser) goalIDField() *ROS2Field {
@@ -145,191 +163,128 @@ func (p *parser) addImportSpecial(msg *ROS2Message, cPkg, goPkg, goImport string
 }
 
 func (p *parser) addImport(msg *ROS2Message, pkg string) {
-	goImport := p.config.MessageModulePrefix + "/" + pkg + "/msg"
+	goImport := p.config.GoPackagePath(pkg) + "/msg"
 	p.addImportSpecial(msg, pkg, pkg+"_msg", goImport)
 }
 
 func (p *parser) parseSections(source string, sections ...*ROS2Message) error {
-	current := 0
-	for i, line := range strings.Split(source, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "---" {
-			if current >= len(sections) {
-				return errors.New("too many sections")
-			}
-			current++
-		} else if err := p.parseLine(sections[current], line); err != nil {
-			return fmt.Errorf("error on line %d: %w", i+1, err)
-		}
-	}
-	return nil
-}
-
-func (p *parser) parseLine(msg *ROS2Message, line string) error {
-	obj, err := p.parseMessageLine(line, msg)
+	tree, err := ParseMsgFile(p.file, source, len(sections))
 	if err != nil {
 		return err
 	}
-
-	switch obj := obj.(type) {
-	case *ROS2Constant:
-		msg.Constants = append(msg.Constants, obj)
-	case *ROS2Field:
-		msg.Fields = append(msg.Fields, obj)
-		switch obj.PkgName {
-		case "":
-		case ".":
-		case "time":
-			msg.GoImports["time"] = ""
-		case "primitives":
-			msg.GoImports[p.config.RclgoImportPath+"/pkg/rclgo/"+obj.PkgName] = obj.GoPkgName
-		default:
-			msg.GoImports[p.config.MessageModulePrefix+"/"+obj.PkgName+"/msg"] = obj.GoPkgName
-			msg.CImports.Add(obj.PkgName)
-		}
-	case nil:
-	default:
-		return fmt.Errorf("couldn't parse the input row '%s'", line)
+	texts := splitSections(source)
+	for i, section := range tree.Sections {
+		sections[i].Source = texts[i]
+		p.addSection(sections[i], section)
 	}
 	return nil
 }
 
-var msgCommentRE = regexp.MustCompile(`^#\s*(.*)$`)
-
-func (p *parser) parseMessageLine(testRow string, ros2msg *ROS2Message) (interface{}, error) {
-	// Comment line: "# ...". Accumulate comment text and stop.
-	if m := msgCommentRE.FindStringSubmatch(testRow); m != nil {
-		if m[1] != "" {
-			p.ros2messagesCommentsBuffer.WriteString(m[1])
+// splitSections splits source at the "---" lines separating the sections of
+// services and actions.
+func splitSections(source string) []string {
+	var sections []string
+	var section strings.Builder
+	for _, line := range strings.SplitAfter(source, "\n") {
+		if strings.TrimSpace(line) == "---" {
+			sections = append(sections, section.String())
+			section.Reset()
+			continue
 		}
-		return nil, nil
+		section.WriteString(line)
 	}
-
-	// Empty line: flush the accumulated comment buffer.
-	if testRow == "" {
-		p.ros2messagesCommentsBuffer.Reset()
-		return nil, nil
-	}
-
-	typeChar, capture := isRowConstantOrField(testRow)
-	switch typeChar {
-	case 'c':
-		if con, err := p.ParseROS2MessageConstant(capture); err == nil {
-			return con, nil
-		}
-	case 'f':
-		if f, err := p.ParseROS2MessageField(capture, ros2msg); err == nil {
-			return f, nil
-		}
-	}
-
-	return nil, fmt.Errorf("couldn't parse the input row as either ROS2 Field or Constant? input %q", testRow)
+	return append(sections, section.String())
 }
 
-// Constant row, e.g.:
-//
-//	pkg/Type[<=N] NAME = VALUE  # comment
-var reConstRow = regexp.MustCompile(
-	`^(?:(?P<package>\w+)/)?(?P<type>\w+)(?P<array>\[(?P<bounded><=)?(?P<size>\d*)\])?\s+(?P<field>\w+)\s*=\s*(?P<default>[^#]+)?(?:\s*#\s*(?P<comment>.*))?$`,
-)
-
-// Field row, e.g. (incl. bounded strings):
-//
-//	pkg/Type<=N[<=M] name  default  # comment
-var reFieldRow = regexp.MustCompile(
-	`^(?:(?P<package>\w+)/)?(?P<type>\w+)(?P<boundedString><=\d*)?(?P<array>\[(?P<bounded><=)?(?P<size>\d*)\])?\s+(?P<field>\w+)\s*(?P<default>[^#]+)?(?:\s+#\s*(?P<comment>.*))?$`,
-)
-
-// helper: build a map[name]value from a named-group match
-func findNamed(re *regexp.Regexp, s string) (map[string]string, bool) {
-	m := re.FindStringSubmatch(s)
-	if m == nil {
-		return nil, false
-	}
-	names := re.SubexpNames()
-	out := make(map[string]string, len(m)-1)
-	for i := 1; i < len(m); i++ {
-		if names[i] != "" {
-			out[names[i]] = m[i]
-		}
+// addSection adds the constants and fields of section to msg.
+func (p *parser) addSection(msg *ROS2Message, section *MsgSection) {
+	for _, c := range section.Constants {
+		msg.Constants = append(msg.Constants, p.constant(c))
 	}
-	return out, true
-}
-
-func isRowConstantOrField(textRow string) (byte, map[string]string) {
-	if z, ok := findNamed(reConstRow, textRow); ok {
-		return 'c', z
+	for _, f := range section.Fields {
+		p.addField(msg, p.field(f, msg))
 	}
-	if z, ok := findNamed(reFieldRow, textRow); ok {
-		return 'f', z
+	if p.sectionAdded != nil {
+		p.sectionAdded(msg, section)
 	}
-	return 'e', nil
 }
 
-func (p *parser) ParseROS2MessageConstant(capture map[string]string) (*ROS2Constant, error) {
-	d := &ROS2Constant{
-		RosType: capture["type"],
-		RosName: capture["field"],
-		Value:   strings.TrimSpace(capture["default"]),
-		Comment: utilities.CommentSerializer(capture["comment"], &p.ros2messagesCommentsBuffer),
+// addField appends f to the fields of msg and adds the imports f needs.
+func (p *parser) addField(msg *ROS2Message, f *ROS2Field) {
+	msg.Fields = append(msg.Fields, f)
+	switch f.PkgName {
+	case "":
+	case ".":
+	case "time":
+		msg.GoImports["time"] = ""
+		// The type description references the builtin_interfaces message.
+		msg.GoImports[p.config.GoPackagePath("builtin_interfaces")+"/msg"] = "builtin_interfaces_msg"
+		msg.CImports.Add("builtin_interfaces")
+	case "primitives":
+		msg.GoImports[p.config.RclgoImportPath+"/pkg/rclgo/"+f.PkgName] = f.GoPkgName
+	default:
+		msg.GoImports[p.config.GoPackagePath(f.PkgName)+"/msg"] = f.GoPkgName
+		msg.CImports.Add(f.PkgName)
 	}
+}
 
-	t, ok := primitiveTypeMappings[d.RosType]
-	if !ok {
-		d.GoType = fmt.Sprintf("<MISSING translation from ROS2 Constant type '%s'>", d.RosType)
-		return d, fmt.Errorf("Unknown ROS2 Constant type '%s'\n", d.RosType)
+// constant converts a validated constant declaration to the model.
+func (p *parser) constant(decl *MsgConstant) *ROS2Constant {
+	t := primitiveTypeMappings[decl.Type.Name]
+	return &ROS2Constant{
+		RosType: decl.Type.Name,
+		GoType:  t.GoType,
+		RosName: decl.Name,
+		Value:   decl.Value,
+		Comment: decl.Comment,
+		PkgName: t.PackageName,
 	}
-	d.GoType = t.GoType
-	d.PkgName = t.PackageName
-	return d, nil
 }
 
-func (p *parser) ParseROS2MessageField(capture map[string]string, ros2msg *ROS2Message) (*ROS2Field, error) {
-	size, err := strconv.ParseInt(capture["size"], 10, 32)
-	if err != nil && capture["size"] != "" {
-		return nil, err
-	}
-	if capture["boundedString"] != "" &&
-		!(capture["package"] == "" && capture["type"] == "string") {
-		return nil, errors.New("the only base type that supports an upper boundary is string")
-	}
-	if capture["bounded"] != "" {
-		capture["array"] = strings.Replace(capture["array"], capture["bounded"]+capture["size"], "", 1)
-		capture["bounded"] += capture["size"]
-		size = 0
-	}
+// field converts a validated field declaration of msg to the model.
+func (p *parser) field(decl *MsgField, msg *ROS2Message) *ROS2Field {
 	f := &ROS2Field{
-		Comment:      utilities.CommentSerializer(capture["comment"], &p.ros2messagesCommentsBuffer),
-		GoName:       utilities.SnakeToCamel(capture["field"]),
-		RosName:      capture["field"],
-		CName:        cName(capture["field"]),
-		RosType:      capture["type"],
-		TypeArray:    capture["array"],
-		ArrayBounded: capture["bounded"],
-		ArraySize:    int(size),
-		DefaultValue: capture["default"],
-		PkgName:      capture["package"],
-	}
-
-	f.PkgName, f.CType, f.GoType = translateROS2Type(f, ros2msg)
+		Comment:      decl.Comment,
+		GoName:       utilities.SnakeToCamel(decl.Name),
+		RosName:      decl.Name,
+		CName:        cName(decl.Name),
+		RosType:      decl.Type.Name,
+		ArraySize:    decl.Type.ArraySize,
+		StringBound:  decl.Type.StringBound,
+		DefaultValue: decl.Default,
+		PkgName:      decl.Type.Package,
+	}
+	switch {
+	case decl.Type.ArraySize > 0:
+		f.TypeArray = "[" + strconv.Itoa(decl.Type.ArraySize) + "]"
+	case decl.Type.IsArray:
+		f.TypeArray = "[]"
+		if decl.Type.ArrayBound > 0 {
+			f.ArrayBounded = "<=" + strconv.Itoa(decl.Type.ArrayBound)
+			f.ArrayBound = decl.Type.ArrayBound
+		}
+	}
+
+	f.PkgName, f.CType, f.GoType = translateROS2Type(f, msg)
 	f.GoPkgName = f.PkgName
 	switch f.PkgName {
 	case "", "time", "primitives":
 	case ".":
-		if ros2msg.Type == "msg" {
+		if msg.Type == "msg" {
 			f.IsPkgLocal = true
 		} else {
-			f.PkgName = ros2msg.Package
-			f.GoPkgName = ros2msg.Package + "_msg"
+			f.PkgName = msg.Package
+			f.GoPkgName = msg.Package + "_msg"
 		}
 	default:
 		f.GoPkgName = f.PkgName + "_msg"
 	}
+	p.nativeTime(f)
 	// Prepopulate extra Go imports
-	p.cSerializationCode(f, ros2msg)
-	p.goSerializationCode(f, ros2msg)
+	p.cSerializationCode(f, msg)
+	p.goSerializationCode(f, msg)
 
-	return f, nil
+	return f
 }
 
 func translateROS2Type(f *ROS2Field, m *ROS2Message) (pkgName string, cType string, goType string) {
@@ -371,7 +326,25 @@ func translateROS2Type(f *ROS2Field, m *ROS2Message) (pkgName string, cType stri
 	return ".", f.RosType, f.RosType
 }
 
+// nativeTime changes the type of f to time.Time or time.Duration if f is a
+// builtin_interfaces Time or Duration and Config.NativeTime is set. Arrays and
+// sequences keep their message types. It returns f.
+func (p *parser) nativeTime(f *ROS2Field) *ROS2Field {
+	if p.config.NativeTime && f.PkgName == "builtin_interfaces" && f.TypeArray == "" &&
+		(f.RosType == "Time" || f.RosType == "Duration") {
+		f.PkgName = "time"
+		f.GoPkgName = "time"
+	}
+	return f
+}
+
 func (p *parser) cSerializationCode(f *ROS2Field, m *ROS2Message) string {
+	if f.PkgName == "time" {
+		// Native time value single
+		sec, nanosec := "sec_"+f.RosName, "nanosec_"+f.RosName
+		return sec + ", " + nanosec + " := " + p.config.Distro + "." + f.GoType + "ToParts(m." + f.GoName + ")\n" +
+			"\tmem." + f.CName + ".sec, mem." + f.CName + ".nanosec = C.int32_t(" + sec + "), C.uint32_t(" + nanosec + ")"
+	}
 	if f.TypeArray != "" && f.ArraySize > 0 && f.PkgName != "" && f.IsPkgLocal {
 		// Complex value Array local package reference
 		return utilities.UpperCaseFirst(f.RosType) + `ArrayToC(mem.` + f.CName + `[:], m.` + f.GoName + `[:])`
@@ -395,11 +368,11 @@ func (p *parser) cSerializationCode(f *ROS2Field, m *ROS2Message) string {
 	} else if f.TypeArray != "" && f.ArraySize > 0 && f.PkgName == "" {
 		// Primitive value Array
 		return `cSlice_` + f.RosName + ` := mem.` + f.CName + `[:]
-	` + fmt.Sprintf("%s.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + fmt.Sprintf(`ArrayToC(*(*[]%s.C`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `)(unsafe.Pointer(&cSlice_` + f.RosName + `)), m.` + f.GoName + `[:])`
+	` + fmt.Sprintf("%s.", p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + fmt.Sprintf(`ArrayToC(*(*[]%s.C`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `)(unsafe.Pointer(&cSlice_` + f.RosName + `)), m.` + f.GoName + `[:])`
 
 	} else if f.TypeArray != "" && f.ArraySize == 0 && f.PkgName == "" {
 		// Primitive value Slice
-		return fmt.Sprintf("%s.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + fmt.Sprintf(`SequenceToC((*%s.C`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `Sequence)(unsafe.Pointer(&mem.` + f.CName + `)), m.` + f.GoName + `)`
+		return fmt.Sprintf("%s.", p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + fmt.Sprintf(`SequenceToC((*%s.C`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `Sequence)(unsafe.Pointer(&mem.` + f.CName + `)), m.` + f.GoName + `)`
 
 	} else if f.TypeArray == "" && f.PkgName == "" {
 		// Primitive value single
@@ -407,9 +380,9 @@ func (p *parser) cSerializationCode(f *ROS2Field, m *ROS2Message) string {
 		// serialization implementations but still use a non-generated type in
 		// generated message fields.
 		if f.RosType == "string" {
-			return fmt.Sprintf("%s.StringAsCStruct(unsafe.Pointer(&mem.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + f.CName + "), m." + f.GoName + ")"
+			return fmt.Sprintf("%s.StringAsCStruct(unsafe.Pointer(&mem.", p.config.Distro) + f.CName + "), m." + f.GoName + ")"
 		} else if f.RosType == "U16String" {
-			return fmt.Sprintf("%s.U16StringAsCStruct(unsafe.Pointer(&mem.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + f.CName + "), m." + f.GoName + ")"
+			return fmt.Sprintf("%s.U16StringAsCStruct(unsafe.Pointer(&mem.", p.config.Distro) + f.CName + "), m." + f.GoName + ")"
 		}
 		return `mem.` + f.CName + ` = C.` + f.CType + `(m.` + f.GoName + `)`
 	}
@@ -417,6 +390,10 @@ func (p *parser) cSerializationCode(f *ROS2Field, m *ROS2Message) string {
 }
 
 func (p *parser) goSerializationCode(f *ROS2Field, m *ROS2Message) string {
+	if f.PkgName == "time" {
+		// Native time value single
+		return "m." + f.GoName + " = " + p.config.Distro + "." + f.GoType + "FromParts(int32(mem." + f.CName + ".sec), uint32(mem." + f.CName + ".nanosec))"
+	}
 
 	if f.TypeArray != "" && f.ArraySize > 0 && f.PkgName != "" && f.IsPkgLocal {
 		// Complex value Array local package reference
@@ -442,11 +419,11 @@ func (p *parser) goSerializationCode(f *ROS2Field, m *ROS2Message) string {
 	} else if f.TypeArray != "" && f.ArraySize > 0 && f.PkgName == "" {
 		// Primitive value Array
 		return `cSlice_` + f.RosName + ` := mem.` + f.CName + `[:]
-	` + fmt.Sprintf(`%s.`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `ArrayToGo(m.` + f.GoName + fmt.Sprintf(`[:], *(*[]%s.C`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `)(unsafe.Pointer(&cSlice_` + f.RosName + `)))`
+	` + fmt.Sprintf(`%s.`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `ArrayToGo(m.` + f.GoName + fmt.Sprintf(`[:], *(*[]%s.C`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `)(unsafe.Pointer(&cSlice_` + f.RosName + `)))`
 
 	} else if f.TypeArray != "" && f.ArraySize == 0 && f.PkgName == "" {
 		// Primitive value Slice
-		return fmt.Sprintf(`%s.`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `SequenceToGo(&m.` + f.GoName + fmt.Sprintf(`, *(*%s.C`, filepath.Base(os.Getenv(distro.AmentPrefixPath))) + utilities.UpperCaseFirst(f.RosType) + `Sequence)(unsafe.Pointer(&mem.` + f.CName + `)))`
+		return fmt.Sprintf(`%s.`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `SequenceToGo(&m.` + f.GoName + fmt.Sprintf(`, *(*%s.C`, p.config.Distro) + utilities.UpperCaseFirst(f.RosType) + `Sequence)(unsafe.Pointer(&mem.` + f.CName + `)))`
 
 	} else if f.TypeArray == "" && f.PkgName == "" {
 		// Primitive value single
@@ -455,9 +432,9 @@ func (p *parser) goSerializationCode(f *ROS2Field, m *ROS2Message) string {
 		// serialization implementations but still use a non-generated type in
 		// generated message fields.
 		if f.RosType == "string" {
-			return fmt.Sprintf("%s.StringAsGoStruct(&m.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + f.GoName + ", unsafe.Pointer(&mem." + f.CName + "))"
+			return fmt.Sprintf("%s.StringAsGoStruct(&m.", p.config.Distro) + f.GoName + ", unsafe.Pointer(&mem." + f.CName + "))"
 		} else if f.RosType == "U16String" {
-			return fmt.Sprintf("%s.U16StringAsGoStruct(&m.", filepath.Base(os.Getenv(distro.AmentPrefixPath))) + f.GoName + ", unsafe.Pointer(&mem." + f.CName + "))"
+			return fmt.Sprintf("%s.U16StringAsGoStruct(&m.", p.config.Distro) + f.GoName + ", unsafe.Pointer(&mem." + f.CName + "))"
 		}
 		return `m.` + f.GoName + ` = ` + f.GoType + `(mem.` + f.CName + `)`
 
@@ -466,6 +443,11 @@ func (p *parser) goSerializationCode(f *ROS2Field, m *ROS2Message) string {
 }
 
 func defaultCode(f *ROS2Field) string {
+	if f.PkgName == "time" && f.GoType == "Time" {
+		return "t." + f.GoName + " = time.Time{}"
+	} else if f.PkgName == "time" {
+		return "t." + f.GoName + " = 0"
+	}
 	if f.PkgName != "" && f.TypeArray != "" && f.DefaultValue == "" {
 		// Complex value array and slice common default
 		if f.ArraySize == 0 {
@@ -528,22 +510,131 @@ func primitiveCommonDefault(f *ROS2Field) string {
 	}
 }
 
+// validateCode returns the statements of the Validate method of a message
+// checking f, or "" if f has no constraints to check.
+func (p *parser) validateCode(f *ROS2Field) string {
+	d := p.config.Distro
+	name := strconv.Quote(f.RosName)
+	var checks []string
+	if f.ArrayBound > 0 {
+		checks = append(checks, "if err := "+d+".ValidateSequenceBound("+name+", len(t."+f.GoName+"), "+strconv.Itoa(f.ArrayBound)+"); err != nil {\n"+
+			"\t\treturn err\n"+
+			"\t}")
+	}
+	elem, field := "t."+f.GoName, name
+	if f.TypeArray != "" {
+		elem, field = "t."+f.GoName+"[i]", d+".FieldIndex("+name+", i)"
+	}
+	var check string
+	switch {
+	case isMessageField(f):
+		check = "if err := " + elem + ".Validate(); err != nil {\n" +
+			"\t\treturn " + d + ".ValidateField(" + field + ", err)\n" +
+			"\t}"
+	case f.StringBound > 0 && f.RosType == "U16String":
+		check = "if err := " + d + ".ValidateU16StringBound(" + field + ", " + elem + ", " + strconv.Itoa(f.StringBound) + "); err != nil {\n" +
+			"\t\treturn err\n" +
+			"\t}"
+	case f.StringBound > 0:
+		check = "if err := " + d + ".ValidateStringBound(" + field + ", " + elem + ", " + strconv.Itoa(f.StringBound) + "); err != nil {\n" +
+			"\t\treturn err\n" +
+			"\t}"
+	}
+	if check != "" && f.TypeArray != "" {
+		check = "for i := range t." + f.GoName + " {\n\t\t" +
+			strings.ReplaceAll(check, "\n", "\n\t") + "\n" +
+			"\t}"
+	}
+	if check != "" {
+		checks = append(checks, check)
+	}
+	return strings.Join(checks, "\n\t")
+}
+
+// isMessageField reports whether f is a message or a sequence or array of
+// messages.
+func isMessageField(f *ROS2Field) bool {
+	return f.PkgName != "" && f.PkgName != "time" && f.PkgName != "primitives"
+}
+
+// diffCode returns the statements of the DiffWith method of a message
+// comparing f.
+func (p *parser) diffCode(f *ROS2Field) string {
+	d := p.config.Distro
+	name := strconv.Quote(f.RosName)
+	a, b := "t."+f.GoName, "other."+f.GoName
+	if f.ArraySize > 0 {
+		a, b = a+"[:]", b+"[:]"
+	}
+	isFloat := f.PkgName == "" && (f.GoType == "float32" || f.GoType == "float64")
+	switch {
+	case isMessageField(f) && f.TypeArray != "":
+		return "if " + d + ".DiffLen(d, " + name + ", " + a + ", " + b + ") {\n" +
+			"\t\tfor i := range t." + f.GoName + " {\n" +
+			"\t\t\td.EnterIndex(" + name + ", i)\n" +
+			"\t\t\tt." + f.GoName + "[i].DiffWith(d, &other." + f.GoName + "[i])\n" +
+			"\t\t\td.Leave()\n" +
+			"\t\t}\n" +
+			"\t}"
+	case isMessageField(f):
+		return "d.Enter(" + name + ")\n" +
+			"\tt." + f.GoName + ".DiffWith(d, &other." + f.GoName + ")\n" +
+			"\td.Leave()"
+	case f.TypeArray != "" && isFloat:
+		return d + ".DiffFloatSlice(d, " + name + ", " + a + ", " + b + ")"
+	case f.TypeArray != "":
+		return d + ".DiffSlice(d, " + name + ", " + a + ", " + b + ")"
+	case isFloat:
+		return d + ".DiffFloat(d, " + name + ", " + a + ", " + b + ")"
+	case f.PkgName == "time" && f.GoType == "Time":
+		return d + ".DiffTime(d, " + name + ", " + a + ", " + b + ")"
+	default:
+		return d + ".DiffValue(d, " + name + ", " + a + ", " + b + ")"
+	}
+}
+
+// deepCopyCode returns the statements of the DeepCopyInto method of a message
+// copying f. The memory of sequences in dst is reused if their capacity
+// allows.
+func deepCopyCode(f *ROS2Field) string {
+	switch {
+	case isMessageField(f) && f.TypeArray == "[]":
+		return "if cap(dst." + f.GoName + ") < len(t." + f.GoName + ") {\n" +
+			"\t\tdst." + f.GoName + " = make([]" + f.GoPkgReference() + f.GoType + ", len(t." + f.GoName + "))\n" +
+			"\t}\n" +
+			"\tdst." + f.GoName + " = dst." + f.GoName + "[:len(t." + f.GoName + ")]\n" +
+			"\tfor i := range t." + f.GoName + " {\n" +
+			"\t\tt." + f.GoName + "[i].DeepCopyInto(&dst." + f.GoName + "[i])\n" +
+			"\t}"
+	case isMessageField(f) && f.TypeArray != "":
+		return "for i := range t." + f.GoName + " {\n" +
+			"\t\tt." + f.GoName + "[i].DeepCopyInto(&dst." + f.GoName + "[i])\n" +
+			"\t}"
+	case isMessageField(f):
+		return "t." + f.GoName + ".DeepCopyInto(&dst." + f.GoName + ")"
+	case f.TypeArray == "[]":
+		return "dst." + f.GoName + " = append(dst." + f.GoName + "[:0], t." + f.GoName + "...)"
+	default:
+		return "dst." + f.GoName + " = t." + f.GoName
+	}
+}
+
 func cloneCode(f *ROS2Field) string {
-	if f.PkgName != "" && f.TypeArray != "" && f.ArraySize == 0 {
+	if isMessageField(f) && f.TypeArray != "" && f.ArraySize == 0 {
 		return "if t." + f.GoName + " != nil {\n" +
 			"\t\tc." + f.GoName + " = make([]" + f.GoPkgReference() + f.GoType + ", len(t." + f.GoName + "))\n" +
 			"\t\t" + f.GoPkgReference() + "Clone" + f.GoType + "Slice(c." + f.GoName + ", t." + f.GoName + ")\n" +
 			"\t}"
-	} else if f.PkgName != "" && f.TypeArray != "" && f.ArraySize > 0 {
+	} else if isMessageField(f) && f.TypeArray != "" && f.ArraySize > 0 {
 		return f.GoPkgReference() + "Clone" + f.GoType + "Slice(c." + f.GoName + "[:], t." + f.GoName + "[:])"
-	} else if f.PkgName != "" && f.TypeArray == "" {
+	} else if isMessageField(f) && f.TypeArray == "" {
 		return "c." + f.GoName + " = *t." + f.GoName + ".Clone()"
-	} else if f.PkgName == "" && f.TypeArray != "" && f.ArraySize == 0 {
+	} else if !isMessageField(f) && f.TypeArray != "" && f.ArraySize == 0 {
 		return "if t." + f.GoName + " != nil {\n" +
 			"\t\tc." + f.GoName + " = make([]" + f.GoType + ", len(t." + f.GoName + "))\n" +
 			"\t\tcopy(c." + f.GoName + ", t." + f.GoName + ")\n" +
 			"\t}"
-	} else if f.PkgName == "" {
+	} else if !isMessageField(f) {
 		// primitive value single and array
 		return "c." + f.GoName + " = t." + f.GoName
 	}
@@ -0,0 +1,277 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Plugin generates additional output for the generated interfaces and
+// packages. Plugins are registered with Generator.AddPlugin. The methods may
+// be called concurrently.
+type Plugin interface {
+	// GenerateInterface is called once per generated interface after its
+	// bindings have been generated. iface is a *ROS2Message, *ROS2Service or
+	// *ROS2Action. Interfaces skipped because they are up to date are not
+	// passed to plugins, and neither are their outputs deleted.
+	GenerateInterface(out *PluginOutput, iface any) error
+	// GeneratePackage is called once per generated Go package, e.g.
+	// std_msgs/msg, after the bindings of its interfaces have been
+	// generated.
+	GeneratePackage(out *PluginOutput, pkg *PluginPackage) error
+}
+
+// PluginPackage describes a generated Go package to plugins.
+type PluginPackage struct {
+	// Package is the name of the ROS2 package.
+	Package string
+	// Type is "msg", "srv" or "action".
+	Type string
+	// GoPackage is the name of the Go package, e.g. std_msgs_msg.
+	GoPackage string
+	// CImports are the ROS2 packages the Go package depends on.
+	CImports []string
+}
+
+// PluginOutput writes the files of a plugin to the directory of a generated
+// Go package.
+type PluginOutput struct {
+	g     *Generator
+	dir   string
+	mu    sync.Mutex
+	files []string // relative to Config.DestPath
+}
+
+// Config returns the configuration of the generator.
+func (o *PluginOutput) Config() *Config {
+	return o.g.config
+}
+
+// WriteFile writes content to the file name in the directory of the Go
+// package, or checks it if Config.Check is set. The files written for an
+// interface are recorded in the manifest and deleted with the interface.
+func (o *PluginOutput) WriteFile(name string, content []byte) error {
+	if name == "" || name != filepath.Base(name) {
+		return fmt.Errorf("invalid plugin output file name %q", name)
+	}
+	rel := filepath.Join(o.dir, name)
+	if err := o.g.writeFile(filepath.Join(o.g.config.DestPath, rel), content); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files = append(o.files, rel)
+	return nil
+}
+
+// ExecuteTemplate executes tmpl with data and writes the result to the file
+// name. See WriteFile.
+func (o *PluginOutput) ExecuteTemplate(name string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	return o.WriteFile(name, buf.Bytes())
+}
+
+// AddPlugin registers p to be run by GenerateGolangMessageTypes.
+func (g *Generator) AddPlugin(p Plugin) {
+	g.plugins = append(g.plugins, p)
+}
+
+// runInterfacePlugins runs the plugins for iface and returns the files they
+// wrote.
+func (g *Generator) runInterfacePlugins(meta Metadata, iface any) ([]string, error) {
+	out := &PluginOutput{g: g, dir: meta.ImportPath()}
+	for _, p := range g.allPlugins() {
+		if err := p.GenerateInterface(out, iface); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(out.files)
+	return out.files, nil
+}
+
+func (g *Generator) runPackagePlugins(pkg *PluginPackage) error {
+	out := &PluginOutput{g: g, dir: filepath.Join(pkg.Package, pkg.Type)}
+	for _, p := range g.allPlugins() {
+		if err := p.GeneratePackage(out, pkg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Template kinds of Config.Templates.
+const (
+	TemplateMessage = "message"
+	TemplateService = "service"
+	TemplateAction  = "action"
+	TemplatePackage = "package"
+)
+
+// Template is a user-supplied template of the generator. It is executed with
+// the same data and functions as the built-in template of its kind. The data
+// contains the model of the interface as Message (*ROS2Message), Service
+// (*ROS2Service) or Action (*ROS2Action), the GoPackage, CPackage and
+// CImports of packages, the ROSDistro and the Config.
+type Template struct {
+	// Kind is TemplateMessage, TemplateService, TemplateAction or
+	// TemplatePackage. Message templates are executed for the request and
+	// response messages of services and the messages of actions as well.
+	Kind string
+	// Path is the path of the template file.
+	Path string
+	// Output is the name of the file generated in the directory of the Go
+	// package. It is a template executed with the same data, e.g.
+	// "{{.Message.Name}}_conv.gen.go". If it is empty, the template replaces
+	// the built-in template of its kind.
+	Output string
+}
+
+// ParseTemplateSpec parses a template specification of the form
+// KIND[:OUTPUT]=PATH.
+func ParseTemplateSpec(spec string) (Template, error) {
+	kindAndOutput, path, ok := strings.Cut(spec, "=")
+	if !ok || path == "" {
+		return Template{}, fmt.Errorf("invalid template %q: expected KIND[:OUTPUT]=PATH", spec)
+	}
+	kind, output, _ := strings.Cut(kindAndOutput, ":")
+	switch kind {
+	case TemplateMessage, TemplateService, TemplateAction, TemplatePackage:
+	default:
+		return Template{}, fmt.Errorf("invalid template %q: unknown kind %q", spec, kind)
+	}
+	return Template{Kind: kind, Path: path, Output: output}, nil
+}
+
+type parsedTemplate struct {
+	kind   string
+	tmpl   *template.Template
+	output *template.Template
+}
+
+// loadTemplates parses Config.Templates. Replacements are stored in
+// g.templates, and the other templates are run by a plugin.
+func (g *Generator) loadTemplates() error {
+	g.templates = map[string]*template.Template{}
+	var extra []parsedTemplate
+	for _, t := range g.config.Templates {
+		content, err := os.ReadFile(filepath.Clean(t.Path))
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		tmpl, err := template.New(filepath.Base(t.Path)).Funcs(templateFuncMap).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template: %w", err)
+		}
+		if t.Output == "" {
+			if _, ok := g.templates[t.Kind]; ok {
+				return fmt.Errorf("multiple replacements of the %s template", t.Kind)
+			}
+			g.templates[t.Kind] = tmpl
+			continue
+		}
+		output, err := template.New(t.Output).Funcs(templateFuncMap).Parse(t.Output)
+		if err != nil {
+			return fmt.Errorf("failed to parse template output %q: %w", t.Output, err)
+		}
+		extra = append(extra, parsedTemplate{kind: t.Kind, tmpl: tmpl, output: output})
+	}
+	g.templatePlugin = nil
+	if len(extra) > 0 {
+		g.templatePlugin = &templatePlugin{g: g, templates: extra}
+	}
+	return nil
+}
+
+// allPlugins returns the plugins to run, starting with the one running the
+// additional templates of Config.Templates.
+func (g *Generator) allPlugins() []Plugin {
+	if g.templatePlugin == nil {
+		return g.plugins
+	}
+	return append([]Plugin{g.templatePlugin}, g.plugins...)
+}
+
+// template returns the template of kind, which is builtin unless it is
+// replaced by Config.Templates.
+func (g *Generator) template(kind string, builtin *template.Template) *template.Template {
+	if t := g.templates[kind]; t != nil {
+		return t
+	}
+	return builtin
+}
+
+// templatePlugin executes the additional templates of Config.Templates.
+type templatePlugin struct {
+	g         *Generator
+	templates []parsedTemplate
+}
+
+func (p *templatePlugin) GenerateInterface(out *PluginOutput, iface any) error {
+	switch iface := iface.(type) {
+	case *ROS2Message:
+		return p.messages(out, iface)
+	case *ROS2Service:
+		return p.service(out, iface)
+	case *ROS2Action:
+		if err := p.execute(out, TemplateAction, p.g.actionTemplateData(iface)); err != nil {
+			return err
+		}
+		for _, srv := range []*ROS2Service{iface.SendGoal, iface.GetResult} {
+			if err := p.service(out, srv); err != nil {
+				return err
+			}
+		}
+		return p.messages(out, iface.Goal, iface.Result, iface.Feedback, iface.FeedbackMessage)
+	}
+	return nil
+}
+
+func (p *templatePlugin) service(out *PluginOutput, srv *ROS2Service) error {
+	if err := p.execute(out, TemplateService, p.g.serviceTemplateData(srv)); err != nil {
+		return err
+	}
+	return p.messages(out, srv.Request, srv.Response)
+}
+
+func (p *templatePlugin) messages(out *PluginOutput, msgs ...*ROS2Message) error {
+	for _, msg := range msgs {
+		if err := p.execute(out, TemplateMessage, p.g.messageTemplateData(msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *templatePlugin) GeneratePackage(out *PluginOutput, pkg *PluginPackage) error {
+	return p.execute(out, TemplatePackage, templateData{
+		"GoPackage": pkg.GoPackage,
+		"CPackage":  pkg.Package,
+		"CImports":  pkg.CImports,
+		"ROSDistro": p.g.config.Distro,
+	})
+}
+
+func (p *templatePlugin) execute(out *PluginOutput, kind string, data templateData) error {
+	data["Config"] = p.g.config
+	for _, t := range p.templates {
+		if t.kind != kind {
+			continue
+		}
+		var name strings.Builder
+		if err := t.output.Execute(&name, data); err != nil {
+			return err
+		}
+		if err := out.ExecuteTemplate(name.String(), t.tmpl, data); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", t.tmpl.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPlugin struct {
+	mu       sync.Mutex
+	ifaces   []string
+	packages []string
+}
+
+func (p *recordingPlugin) GenerateInterface(out *PluginOutput, iface any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch iface := iface.(type) {
+	case *ROS2Message:
+		p.ifaces = append(p.ifaces, "msg "+iface.Name)
+	case *ROS2Service:
+		p.ifaces = append(p.ifaces, "srv "+iface.Name)
+	case *ROS2Action:
+		p.ifaces = append(p.ifaces, "action "+iface.Name)
+	}
+	return nil
+}
+
+func (p *recordingPlugin) GeneratePackage(out *PluginOutput, pkg *PluginPackage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.packages = append(p.packages, pkg.GoPackage)
+	return out.WriteFile("plugin.gen.go", []byte("package "+pkg.GoPackage+"\n"))
+}
+
+func TestPlugins(t *testing.T) {
+	root := writeLintFiles(t, map[string]string{
+		"my_msgs/msg/A.msg":   "int32 a\nstring b\n",
+		"my_msgs/srv/Get.srv": "int32 id\n---\nA a\n",
+		"tmpl/fields.tmpl":    "{{range .Message.Fields}}{{.GoName}} {{.GoType}}\n{{end}}",
+		"tmpl/service.tmpl":   "{{.Service.Name}} {{.ROSDistro}}\n",
+		"tmpl/common.tmpl":    "package {{.GoPackage}} // {{.Config.Distro}}\n",
+	})
+	dest := t.TempDir()
+	config := DefaultConfigForDistro("jazzy")
+	config.RootPaths = []string{root}
+	config.DestPath = dest
+	config.Templates = []Template{
+		{Kind: TemplateMessage, Path: filepath.Join(root, "tmpl/fields.tmpl"), Output: "{{.Message.Name}}.fields.txt"},
+		{Kind: TemplateService, Path: filepath.Join(root, "tmpl/service.tmpl"), Output: "service.txt"},
+		{Kind: TemplatePackage, Path: filepath.Join(root, "tmpl/common.tmpl")},
+	}
+	plugin := &recordingPlugin{}
+	g := New(&config)
+	g.AddPlugin(plugin)
+	_, err := g.GenerateGolangMessageTypes()
+	require.NoError(t, err)
+
+	sort.Strings(plugin.ifaces)
+	sort.Strings(plugin.packages)
+	assert.Equal(t, []string{"msg A", "srv Get"}, plugin.ifaces)
+	assert.Equal(t, []string{"my_msgs_msg", "my_msgs_srv"}, plugin.packages)
+	readFile := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(dest, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "A int32\nB string\n", readFile("my_msgs/msg/A.fields.txt"))
+	assert.Equal(t, "Id int32\n", readFile("my_msgs/srv/Get_Request.fields.txt"))
+	assert.Equal(t, "A A\n", readFile("my_msgs/srv/Get_Response.fields.txt"))
+	assert.Equal(t, "Get jazzy\n", readFile("my_msgs/srv/service.txt"))
+	assert.Equal(t, "package my_msgs_msg // jazzy\n", readFile("my_msgs/msg/common.gen.go"))
+	assert.Equal(t, "package my_msgs_msg\n", readFile("my_msgs/msg/plugin.gen.go"))
+	assert.Equal(t, []string{"my_msgs/msg/A.fields.txt"}, loadManifest(dest).Interfaces["my_msgs/msg/A"].PluginOutputs)
+
+	require.NoError(t, os.Remove(filepath.Join(root, "my_msgs/msg/A.msg")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "my_msgs/srv/Get.srv"), []byte("int32 id\n---\n"), 0o600))
+	_, err = New(&config).GenerateGolangMessageTypes()
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dest, "my_msgs/msg/A.fields.txt"))
+}
+
+func TestParseTemplateSpec(t *testing.T) {
+	tmpl, err := ParseTemplateSpec("message:{{.Message.Name}}_conv.gen.go=conv.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, Template{Kind: TemplateMessage, Path: "conv.tmpl", Output: "{{.Message.Name}}_conv.gen.go"}, tmpl)
+	tmpl, err = ParseTemplateSpec("package=common.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, Template{Kind: TemplatePackage, Path: "common.tmpl"}, tmpl)
+	_, err = ParseTemplateSpec("message")
+	assert.EqualError(t, err, `invalid template "message": expected KIND[:OUTPUT]=PATH`)
+	_, err = ParseTemplateSpec("msg=conv.tmpl")
+	assert.EqualError(t, err, `invalid template "msg=conv.tmpl": unknown kind "msg"`)
+}
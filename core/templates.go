@@ -18,7 +18,9 @@ var templateFuncMap template.FuncMap = template.FuncMap{
 	"actionNameFromActionSrvName": utilities.ActionNameFromActionSrvName,
 	"cReturnCodeNameToGo":         utilities.CReturnCodeNameToGo,
 	"cloneCode":                   cloneCode,
+	"deepCopyCode":                deepCopyCode,
 	"actionHasSuffix":             actionHasSuffix,
+	"referencedTypeSupports":      referencedTypeSupports,
 	"matchMsg":                    matchMsg,
 	"sanitizeValue":               utilities.DefaultValueSanitizer,
 	"goLicenseHeader":             utilities.LicenseHeader,
@@ -115,6 +117,7 @@ import (
 	"unsafe"
 
 	"{{.Config.RclgoImportPath}}"
+	"{{.Config.RclgoImportPath}}/msgcodec"
 	{{range $path, $name := $Md.GoImports -}}
 	{{$name}} "{{$path}}"
 	{{""}}{{- end}}
@@ -143,11 +146,42 @@ const (
 {{- end }}
 )
 {{- end }}
+{{- range $e := $Md.Enums }}
+
+// {{$e.Name}} is the type of the {{$e.Prefix}}_* constants of {{$Md.Name}}.
+type {{$e.Name}} {{$e.GoType}}
+
+// String returns the name of the constant e, or the value of e if it is not
+// one of the constants.
+func (e {{$e.Name}}) String() string {
+	switch e {
+	{{- range $e.Distinct }}
+	case {{$e.Name}}({{$Md.Name}}_{{.RosName}}):
+		return "{{.RosName}}"
+	{{- end }}
+	}
+	return "{{$e.Name}}(" + {{if $e.Unsigned}}strconv.FormatUint(uint64(e), 10){{else}}strconv.FormatInt(int64(e), 10){{end}} + ")"
+}
+
+// IsValid reports whether e is one of the {{$e.Prefix}}_* constants.
+func (e {{$e.Name}}) IsValid() bool {
+	switch e {
+	case {{range $i, $c := $e.Distinct}}{{if $i}}, {{end}}{{$e.Name}}({{$Md.Name}}_{{$c.RosName}}){{end}}:
+		return true
+	}
+	return false
+}
+
+// Values returns the distinct values of the {{$e.Prefix}}_* constants.
+func ({{$e.Name}}) Values() []{{$e.Name}} {
+	return []{{$e.Name}}{ {{- range $i, $c := $e.Distinct}}{{if $i}}, {{end}}{{$e.Name}}({{$Md.Name}}_{{$c.RosName}}){{end -}} }
+}
+{{- end }}
 
 type {{$Md.Name}} struct {
 	{{- range $k, $v := $Md.Fields }}
 	{{$v.GoName }} {{$v.TypeArray}}{{$v.GoPkgReference}}{{$v.GoType}}` +
-			"{{\"\"}} `yaml:\"{{$v.RosName}}\"`" + `{{if .Comment -}} // {{.Comment}}{{- end}}
+			"{{\"\"}} `yaml:\"{{$v.RosName}}\"{{range $.Config.StructTags}} {{.}}:\"{{$v.RosName}}\"{{end}}`" + `{{if .Comment -}} // {{.Comment}}{{- end}}
 	{{- end }}
 }
 
@@ -170,12 +204,78 @@ func (t *{{$Md.Name}}) CloneMsg() {{ $.ROSDistro }}.Message {
 	return t.Clone()
 }
 
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *{{$Md.Name}}) DeepCopyInto(dst *{{$Md.Name}}) {
+	{{- range $Md.Fields }}
+	{{deepCopyCode .}}
+	{{- end }}
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *{{$Md.Name}}) Equal(other *{{$Md.Name}}, opts ...{{ $.ROSDistro }}.DiffOption) bool {
+	d := {{ $.ROSDistro }}.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *{{$Md.Name}}) Diff(other *{{$Md.Name}}, opts ...{{ $.ROSDistro }}.DiffOption) []{{ $.ROSDistro }}.FieldDiff {
+	d := {{ $.ROSDistro }}.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *{{$Md.Name}}) DiffWith(d *{{ $.ROSDistro }}.Differ, other *{{$Md.Name}}) {
+	{{- range $Md.Fields }}
+	{{call $.diffCode .}}
+	{{- end }}
+}
+
 func (t *{{$Md.Name}}) SetDefaults() {
 	{{- range $k, $v := $Md.Fields }}
 	{{defaultCode $v}}
 	{{- end }}
 }
 
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *{{$Md.Name}}) Validate() error {
+	{{- range $Md.Fields }}
+	{{- with call $.validateCode . }}
+	{{.}}
+	{{- end }}
+	{{- end }}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *{{$Md.Name}}) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *{{$Md.Name}}) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *{{$Md.Name}}) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *{{$Md.Name}}) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
 func (t *{{$Md.Name}}) GetTypeSupport() {{ $.ROSDistro }}.MessageTypeSupport {
 	return {{$Md.Name}}TypeSupport
 }
@@ -213,6 +313,48 @@ func (t *{{$Md.Name}}) GetGoalAccepted() bool {
 }
 {{- end -}}
 
+{{- if matchMsg $Md "builtin_interfaces_msg" "Time" }}
+// ToTime returns t as a time.Time. The zero ROS time, which usually means
+// that the time is not set, is returned as the zero time.Time instead of the
+// Unix epoch. See {{ $.ROSDistro }}.TimeFromParts.
+func (t *Time) ToTime() time.Time {
+	return {{ $.ROSDistro }}.TimeFromParts(t.Sec, t.Nanosec)
+}
+
+// FromTime sets t to tm. The zero time.Time sets t to the zero ROS time, so
+// time.Unix(0, 0) doesn't round-trip through ToTime. See
+// {{ $.ROSDistro }}.TimeToParts.
+func (t *Time) FromTime(tm time.Time) {
+	t.Sec, t.Nanosec = {{ $.ROSDistro }}.TimeToParts(tm)
+}
+
+// Now returns the current time of clock, or the system time if clock is nil.
+func Now(clock *{{ $.ROSDistro }}.Clock) (*Time, error) {
+	now := time.Now()
+	if clock != nil {
+		var err error
+		if now, err = clock.Now(); err != nil {
+			return nil, err
+		}
+	}
+	t := &Time{}
+	t.FromTime(now)
+	return t, nil
+}
+{{- end -}}
+
+{{- if matchMsg $Md "builtin_interfaces_msg" "Duration" }}
+// ToDuration returns t as a time.Duration.
+func (t *Duration) ToDuration() time.Duration {
+	return {{ $.ROSDistro }}.DurationFromParts(t.Sec, t.Nanosec)
+}
+
+// FromDuration sets t to d. See {{ $.ROSDistro }}.DurationToParts.
+func (t *Duration) FromDuration(d time.Duration) {
+	t.Sec, t.Nanosec = {{ $.ROSDistro }}.DurationToParts(d)
+}
+{{- end -}}
+
 {{ if matchMsg $Md "action_msgs_srv" "CancelGoal_Request" }}
 func (t *{{$Md.Name}}) GetGoalID() *{{ $.ROSDistro }}.GoalID {
 	return (*{{ $.ROSDistro }}.GoalID)(&t.GoalInfo.GoalId.Uuid)
@@ -301,7 +443,7 @@ func Clone{{$Md.Name}}Slice(dst, src []{{$Md.Name}}) {
 }
 
 // Modifying this variable is undefined behavior.
-var {{$Md.Name}}TypeSupport {{ $.ROSDistro }}.MessageTypeSupport = _{{$Md.Name}}TypeSupport{}
+var {{$Md.Name}}TypeSupport {{ $.ROSDistro }}.DescribedMessageTypeSupport = _{{$Md.Name}}TypeSupport{}
 
 type _{{$Md.Name}}TypeSupport struct{}
 
@@ -317,6 +459,10 @@ func (t _{{$Md.Name}}TypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer)
 	C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}}__destroy((*C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}})(pointer_to_free))
 }
 
+func (t _{{$Md.Name}}TypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}}__fini((*C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}})(pointer_to_reset))
+}
+
 func (t _{{$Md.Name}}TypeSupport) AsCStruct(dst unsafe.Pointer, msg {{ $.ROSDistro }}.Message) {
 	{{ if $Md.Fields -}}
 	m := msg.(*{{$Md.Name}})
@@ -341,46 +487,68 @@ func (t _{{$Md.Name}}TypeSupport) TypeSupport() unsafe.Pointer {
 	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}}())
 }
 
+func (t _{{$Md.Name}}TypeSupport) TypeName() string {
+	return "{{$Md.TypeName}}"
+}
+
+func (t _{{$Md.Name}}TypeSupport) TypeDescription() *{{ $.ROSDistro }}.TypeDescription {
+	return &{{ $.ROSDistro }}.TypeDescription{
+		TypeName: "{{$Md.TypeName}}",
+		Fields: []{{ $.ROSDistro }}.FieldDescription{
+			{{- range $Md.Fields }}
+			{{call $.fieldDescriptionCode . $Md}},
+			{{- else }}
+			{Name: "structure_needs_at_least_one_member", Type: {{ $.ROSDistro }}.FieldType{TypeID: {{ $.ROSDistro }}.FieldTypeUint8}},
+			{{- end }}
+		},
+	}
+}
+
+func (t _{{$Md.Name}}TypeSupport) ReferencedTypes() []{{ $.ROSDistro }}.TypeDescriber {
+	return []{{ $.ROSDistro }}.TypeDescriber{
+		{{- range referencedTypeSupports $Md }}
+		{{.}},
+		{{- end }}
+	}
+}
+
+func (t _{{$Md.Name}}TypeSupport) Source() string {
+	return {{printf "%q" $Md.Source}}
+}
+
+func (t _{{$Md.Name}}TypeSupport) Definition() string {
+	return {{ $.ROSDistro }}.Definition(t)
+}
+
+func (t _{{$Md.Name}}TypeSupport) TypeHash() string {
+	return {{ $.ROSDistro }}.TypeHash(t)
+}
+
 type C{{$Md.Name}} = C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}}
 type C{{$Md.Name}}Sequence = C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}}__Sequence
 
 func {{$Md.Name}}SequenceToGo(goSlice *[]{{$Md.Name}}, cSlice C{{$Md.Name}}Sequence) {
-	if cSlice.size == 0 {
-		return
-	}
-	*goSlice = make([]{{$Md.Name}}, cSlice.size)
-	src := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range src {
-		{{$Md.Name}}TypeSupport.AsGoStruct(&(*goSlice)[i], unsafe.Pointer(&src[i]))
-	}
+	{{ $.ROSDistro }}.SequenceToGo(goSlice, (*{{ $.ROSDistro }}.CSequence[C{{$Md.Name}}])(unsafe.Pointer(&cSlice)), _{{$Md.Name}}ToGo)
 }
 
 func {{$Md.Name}}SequenceToC(cSlice *C{{$Md.Name}}Sequence, goSlice []{{$Md.Name}}) {
-	if len(goSlice) == 0 {
-		cSlice.data = nil
-		cSlice.capacity = 0
-		cSlice.size = 0
-		return
-	}
-	cSlice.data = (*C.{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}})(C.malloc(C.sizeof_struct_{{$Md.Package}}__{{$Md.Type}}__{{$Md.Name}} * C.size_t(len(goSlice))))
-	cSlice.capacity = C.size_t(len(goSlice))
-	cSlice.size = cSlice.capacity
-	dst := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range goSlice {
-		{{$Md.Name}}TypeSupport.AsCStruct(unsafe.Pointer(&dst[i]), &goSlice[i])
-	}
+	{{ $.ROSDistro }}.SequenceToC((*{{ $.ROSDistro }}.CSequence[C{{$Md.Name}}])(unsafe.Pointer(cSlice)), goSlice, _{{$Md.Name}}ToC)
 }
 
 func {{$Md.Name}}ArrayToGo(goSlice []{{$Md.Name}}, cSlice []C{{$Md.Name}}) {
-	for i := 0; i < len(cSlice); i++ {
-		{{$Md.Name}}TypeSupport.AsGoStruct(&goSlice[i], unsafe.Pointer(&cSlice[i]))
-	}
+	{{ $.ROSDistro }}.ArrayToGo(goSlice, cSlice, _{{$Md.Name}}ToGo)
 }
 
 func {{$Md.Name}}ArrayToC(cSlice []C{{$Md.Name}}, goSlice []{{$Md.Name}}) {
-	for i := 0; i < len(goSlice); i++ {
-		{{$Md.Name}}TypeSupport.AsCStruct(unsafe.Pointer(&cSlice[i]), &goSlice[i])
-	}
+	{{ $.ROSDistro }}.ArrayToC(cSlice, goSlice, _{{$Md.Name}}ToC)
+}
+
+func _{{$Md.Name}}ToGo(dst *{{$Md.Name}}, src *C{{$Md.Name}}) {
+	{{$Md.Name}}TypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _{{$Md.Name}}ToC(dst *C{{$Md.Name}}, src *{{$Md.Name}}) {
+	{{$Md.Name}}TypeSupport.AsCStruct(unsafe.Pointer(dst), src)
 }
 `),
 )
@@ -430,8 +598,32 @@ func (s _{{.Service.Name}}TypeSupport) TypeSupport() unsafe.Pointer {
 	return unsafe.Pointer(C.rosidl_typesupport_c__get_service_type_support_handle__{{.Service.Package}}__{{.Service.Type}}__{{.Service.Name}}())
 }
 
+func (s _{{.Service.Name}}TypeSupport) TypeName() string {
+	return "{{.Service.TypeName}}"
+}
+
+func (s _{{.Service.Name}}TypeSupport) TypeDescription() *{{ $.ROSDistro }}.TypeDescription {
+	return {{ $.ROSDistro }}.ServiceTypeDescription("{{.Service.TypeName}}")
+}
+
+func (s _{{.Service.Name}}TypeSupport) ReferencedTypes() []{{ $.ROSDistro }}.TypeDescriber {
+	return {{ $.ROSDistro }}.ServiceReferencedTypes("{{.Service.TypeName}}", {{.Service.Request.Name}}TypeSupport, {{.Service.Response.Name}}TypeSupport)
+}
+
+func (s _{{.Service.Name}}TypeSupport) Source() string {
+	return {{printf "%q" .Service.Source}}
+}
+
+func (s _{{.Service.Name}}TypeSupport) Definition() string {
+	return {{ $.ROSDistro }}.Definition(s)
+}
+
+func (s _{{.Service.Name}}TypeSupport) TypeHash() string {
+	return {{ $.ROSDistro }}.TypeHash(s)
+}
+
 // Modifying this variable is undefined behavior.
-var {{ .Service.Name }}TypeSupport {{ $.ROSDistro }}.ServiceTypeSupport = _{{.Service.Name}}TypeSupport{}
+var {{ .Service.Name }}TypeSupport {{ $.ROSDistro }}.DescribedServiceTypeSupport = _{{.Service.Name}}TypeSupport{}
 
 // {{.Service.Name}}Client wraps {{ $.ROSDistro }}.Client to provide type safe helper
 // functions
@@ -514,8 +706,8 @@ import (
 
 	"{{.Config.RclgoImportPath}}"
 
-	action_msgs_msg "{{.Config.MessageModulePrefix}}/action_msgs/msg"
-	action_msgs_srv "{{.Config.MessageModulePrefix}}/action_msgs/srv"
+	action_msgs_msg "{{.Config.GoPackagePath "action_msgs"}}/msg"
+	action_msgs_srv "{{.Config.GoPackagePath "action_msgs"}}/srv"
 )
 
 func init() {
@@ -536,9 +728,11 @@ func (s _{{.Action.Name}}TypeSupport) SendGoal() {{ $.ROSDistro }}.ServiceTypeSu
 func (s _{{.Action.Name}}TypeSupport) NewSendGoalResponse(accepted bool, stamp time.Duration) {{ $.ROSDistro }}.Message {
 	msg := New{{.Action.Name}}_SendGoal_Response()
 	msg.Accepted = accepted
-	secs := stamp.Truncate(time.Second)
-	msg.Stamp.Sec = int32(secs)
-	msg.Stamp.Nanosec = uint32(stamp - secs)
+	{{- if .Config.NativeTime }}
+	msg.Stamp = time.Unix(0, int64(stamp))
+	{{- else }}
+	msg.Stamp.FromTime(time.Unix(0, int64(stamp)))
+	{{- end }}
 	return msg
 }
 
@@ -588,8 +782,39 @@ func (s _{{.Action.Name}}TypeSupport) TypeSupport() unsafe.Pointer {
 	return unsafe.Pointer(C.rosidl_typesupport_c__get_action_type_support_handle__{{.Action.Package}}__{{.Action.Type}}__{{.Action.Name}}())
 }
 
+func (s _{{.Action.Name}}TypeSupport) TypeName() string {
+	return "{{.Action.TypeName}}"
+}
+
+func (s _{{.Action.Name}}TypeSupport) TypeDescription() *{{ $.ROSDistro }}.TypeDescription {
+	return {{ $.ROSDistro }}.ActionTypeDescription("{{.Action.TypeName}}")
+}
+
+func (s _{{.Action.Name}}TypeSupport) ReferencedTypes() []{{ $.ROSDistro }}.TypeDescriber {
+	return []{{ $.ROSDistro }}.TypeDescriber{
+		{{.Action.Goal.Name}}TypeSupport,
+		{{.Action.Result.Name}}TypeSupport,
+		{{.Action.Feedback.Name}}TypeSupport,
+		{{.Action.SendGoal.Name}}TypeSupport,
+		{{.Action.GetResult.Name}}TypeSupport,
+		{{.Action.FeedbackMessage.Name}}TypeSupport,
+	}
+}
+
+func (s _{{.Action.Name}}TypeSupport) Source() string {
+	return {{printf "%q" .Action.Source}}
+}
+
+func (s _{{.Action.Name}}TypeSupport) Definition() string {
+	return {{ $.ROSDistro }}.Definition(s)
+}
+
+func (s _{{.Action.Name}}TypeSupport) TypeHash() string {
+	return {{ $.ROSDistro }}.TypeHash(s)
+}
+
 // Modifying this variable is undefined behavior.
-var {{.Action.Name}}TypeSupport {{ $.ROSDistro }}.ActionTypeSupport = _{{.Action.Name}}TypeSupport{}
+var {{.Action.Name}}TypeSupport {{ $.ROSDistro }}.DescribedActionTypeSupport = _{{.Action.Name}}TypeSupport{}
 
 type {{.Action.Name}}FeedbackSender struct {
 	sender {{ $.ROSDistro }}.FeedbackSender
@@ -783,80 +1008,46 @@ type CString = C.rosidl_runtime_c__String
 type CStringSequence = C.rosidl_runtime_c__String__Sequence
 
 func StringSequenceToGo(goSlice *[]string, cSlice CStringSequence) {
-	if cSlice.size == 0 {
-		return
-	}
-	*goSlice = make([]string, int64(cSlice.size))
-	src := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := 0; i < int(cSlice.size); i++ {
-		StringAsGoStruct(&(*goSlice)[i], unsafe.Pointer(&src[i]))
-	}
+	SequenceToGo(goSlice, (*CSequence[CString])(unsafe.Pointer(&cSlice)), stringToGo)
 }
 
 func StringSequenceToC(cSlice *CStringSequence, goSlice []string) {
-	if len(goSlice) == 0 {
-		cSlice.data = nil
-		cSlice.capacity = 0
-		cSlice.size = 0
-		return
-	}
-	cSlice.data = (*C.rosidl_runtime_c__String)(C.malloc((C.size_t)(C.sizeof_struct_rosidl_runtime_c__String * uintptr(len(goSlice)))))
-	cSlice.capacity = C.size_t(len(goSlice))
-	cSlice.size = cSlice.capacity
-	dst := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range goSlice {
-		StringAsCStruct(unsafe.Pointer(&dst[i]), goSlice[i])
-	}
+	SequenceToC((*CSequence[CString])(unsafe.Pointer(cSlice)), goSlice, stringToC)
 }
 
 func StringArrayToGo(goSlice []string, cSlice []CString) {
-	for i := 0; i < len(cSlice); i++ {
-		StringAsGoStruct(&goSlice[i], unsafe.Pointer(&cSlice[i]))
-	}
+	ArrayToGo(goSlice, cSlice, stringToGo)
 }
 
 func StringArrayToC(cSlice []CString, goSlice []string) {
-	for i := 0; i < len(goSlice); i++ {
-		StringAsCStruct(unsafe.Pointer(&cSlice[i]), goSlice[i])
-	}
+	ArrayToC(cSlice, goSlice, stringToC)
+}
+
+func stringToGo(dst *string, src *CString) {
+	StringAsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func stringToC(dst *CString, src *string) {
+	StringAsCStruct(unsafe.Pointer(dst), *src)
 }
 
 type CChar = C.schar
 type CcharSequence = C.rosidl_runtime_c__char__Sequence
 
 func CharSequenceToGo(goSlice *[]byte, cSlice CcharSequence) {
-	if cSlice.size == 0 {
-		return
-	}
-	*goSlice = make([]byte, cSlice.size)
-	src := unsafe.Slice((*byte)(unsafe.Pointer(cSlice.data)), cSlice.size)
-	copy(*goSlice, src)
+	CopySequenceToGo(goSlice, (*CSequence[CChar])(unsafe.Pointer(&cSlice)))
 }
 
 func CharSequenceToC(cSlice *CcharSequence, goSlice []byte) {
-	if len(goSlice) == 0 {
-		cSlice.data = nil
-		cSlice.capacity = 0
-		cSlice.size = 0
-		return
-	}
-	cSlice.data = (*C.schar)(C.malloc(C.sizeof_schar * C.size_t(len(goSlice))))
-	cSlice.capacity = C.size_t(len(goSlice))
-	cSlice.size = cSlice.capacity
-	dst := unsafe.Slice((*byte)(unsafe.Pointer(cSlice.data)), cSlice.size)
-	copy(dst, goSlice)
+	CopySequenceToC((*CSequence[CChar])(unsafe.Pointer(cSlice)), goSlice)
 }
 
 func CharArrayToGo(goSlice []byte, cSlice []CChar) {
-	for i := 0; i < len(cSlice); i++ {
-		goSlice[i] = byte(cSlice[i])
-	}
+	CopyArrayToGo(goSlice, cSlice)
 }
 
 func CharArrayToC(cSlice []CChar, goSlice []byte) {
-	for i := 0; i < len(goSlice); i++ {
-		cSlice[i] = C.schar(goSlice[i])
-	}
+	CopyArrayToC(cSlice, goSlice)
 }
 
 func U16StringAsCStruct(dst unsafe.Pointer, m string) {
@@ -883,43 +1074,29 @@ type CU16String = C.rosidl_runtime_c__U16String
 type Cu16stringSequence = C.rosidl_runtime_c__U16String__Sequence
 
 func U16stringSequenceToGo(goSlice *[]string, cSlice Cu16stringSequence) {
-	if cSlice.size == 0 {
-		return
-	}
-	*goSlice = make([]string, int64(cSlice.size))
-	src := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := 0; i < int(cSlice.size); i++ {
-		U16StringAsGoStruct(&(*goSlice)[i], unsafe.Pointer(&src[i]))
-	}
+	SequenceToGo(goSlice, (*CSequence[CU16String])(unsafe.Pointer(&cSlice)), u16StringToGo)
 }
 
 func U16stringSequenceToC(cSlice *Cu16stringSequence, goSlice []string) {
-	if len(goSlice) == 0 {
-		cSlice.data = nil
-		cSlice.capacity = 0
-		cSlice.size = 0
-		return
-	}
-	cSlice.data = (*C.rosidl_runtime_c__U16String)(C.malloc((C.size_t)(C.sizeof_struct_rosidl_runtime_c__U16String * uintptr(len(goSlice)))))
-	cSlice.capacity = C.size_t(len(goSlice))
-	cSlice.size = cSlice.capacity
-	dst := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range goSlice {
-		U16StringAsCStruct(unsafe.Pointer(&dst[i]), goSlice[i])
-	}
+	SequenceToC((*CSequence[CU16String])(unsafe.Pointer(cSlice)), goSlice, u16StringToC)
 }
 
 func U16stringArrayToGo(goSlice []string, cSlice []CU16String) {
-	for i := 0; i < len(cSlice); i++ {
-		U16StringAsGoStruct(&goSlice[i], unsafe.Pointer(&cSlice[i]))
-	}
+	ArrayToGo(goSlice, cSlice, u16StringToGo)
 }
 
 func U16stringArrayToC(cSlice []CU16String, goSlice []string) {
-	for i := 0; i < len(goSlice); i++ {
-		U16StringAsCStruct(unsafe.Pointer(&cSlice[i]), goSlice[i])
-	}
-}{{range $k, $v := .PMap -}}{{if .SkipAutogen}}{{- else -}}
+	ArrayToC(cSlice, goSlice, u16StringToC)
+}
+
+func u16StringToGo(dst *string, src *CU16String) {
+	U16StringAsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func u16StringToC(dst *CU16String, src *string) {
+	U16StringAsCStruct(unsafe.Pointer(dst), *src)
+}
+{{range $k, $v := .PMap -}}{{if .SkipAutogen}}{{- else -}}
 {{""}}
 {{""}}
 // {{.RosType | ucFirst}}
@@ -927,42 +1104,19 @@ type C{{.RosType | ucFirst}} = C.{{.CType}}
 type C{{.RosType | ucFirst}}Sequence = C.rosidl_runtime_c__{{.CStructName}}__Sequence
 
 func {{.RosType | ucFirst}}SequenceToGo(goSlice *[]{{.GoType}}, cSlice C{{.RosType | ucFirst}}Sequence) {
-	if cSlice.size == 0 {
-		return
-	}
-	*goSlice = make([]{{.GoType}}, cSlice.size)
-	src := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range src {
-		(*goSlice)[i] = {{.GoType}}(src[i])
-	}
+	CopySequenceToGo(goSlice, (*CSequence[C{{.RosType | ucFirst}}])(unsafe.Pointer(&cSlice)))
 }
 
 func {{.RosType | ucFirst}}SequenceToC(cSlice *C{{.RosType | ucFirst}}Sequence, goSlice []{{.GoType}}) {
-	if len(goSlice) == 0 {
-		cSlice.data = nil
-		cSlice.capacity = 0
-		cSlice.size = 0
-		return
-	}
-	cSlice.data = (*C.{{.CType}})(C.malloc(C.sizeof_{{.CType}} * C.size_t(len(goSlice))))
-	cSlice.capacity = C.size_t(len(goSlice))
-	cSlice.size = cSlice.capacity
-	dst := unsafe.Slice(cSlice.data, cSlice.size)
-	for i := range goSlice {
-		dst[i] = C.{{.CType}}(goSlice[i])
-	}
+	CopySequenceToC((*CSequence[C{{.RosType | ucFirst}}])(unsafe.Pointer(cSlice)), goSlice)
 }
 
 func {{.RosType | ucFirst}}ArrayToGo(goSlice []{{.GoType}}, cSlice []C{{.RosType | ucFirst}}) {
-	for i := 0; i < len(cSlice); i++ {
-		goSlice[i] = {{.GoType}}(cSlice[i])
-	}
+	CopyArrayToGo(goSlice, cSlice)
 }
 
 func {{.RosType | ucFirst}}ArrayToC(cSlice []C{{.RosType | ucFirst}}, goSlice []{{.GoType}}) {
-	for i := 0; i < len(goSlice); i++ {
-		cSlice[i] = C.{{.CType}}(goSlice[i])
-	}
+	CopyArrayToC(cSlice, goSlice)
 }
 {{- end}}{{- end}}
 `),
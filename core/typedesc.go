@@ -0,0 +1,129 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Type IDs of type_description_interfaces/msg/FieldType used in the
+// generated type descriptions.
+const (
+	fieldTypeNestedType        = 1
+	fieldTypeString            = 17
+	fieldTypeWString           = 18
+	fieldTypeBoundedString     = 21
+	fieldTypeBoundedWString    = 22
+	fieldTypeArray             = 48
+	fieldTypeBoundedSequence   = 96
+	fieldTypeUnboundedSequence = 144
+)
+
+// primitiveFieldTypeIDs maps the ROS types of primitive fields to their type
+// IDs. Like in rosidl_adapter, char is an alias of uint8.
+var primitiveFieldTypeIDs = map[string]int{
+	"int8":    2,
+	"uint8":   3,
+	"char":    3,
+	"int16":   4,
+	"uint16":  5,
+	"int32":   6,
+	"uint32":  7,
+	"int64":   8,
+	"uint64":  9,
+	"float32": 10,
+	"float64": 11,
+	"bool":    15,
+	"byte":    16,
+}
+
+// isNestedField reports whether f is a message field, including the
+// builtin_interfaces fields generated as native time types.
+func isNestedField(f *ROS2Field) bool {
+	return isMessageField(f) || f.PkgName == "time"
+}
+
+// nestedTypeName returns the full name of the type of the message field f of
+// msg, e.g. "std_msgs/msg/Header".
+func nestedTypeName(f *ROS2Field, msg *ROS2Message) string {
+	switch {
+	case f.PkgName == "time":
+		return "builtin_interfaces/msg/" + f.RosType
+	case f.PkgName == ".":
+		return msg.Package + "/msg/" + f.RosType
+	case f.IsPkgLocal:
+		// The goal, result and feedback of the messages wrapping them in
+		// actions.
+		return msg.Package + "/" + msg.Type + "/" + f.RosType
+	default:
+		return f.PkgName + "/msg/" + f.RosType
+	}
+}
+
+// fieldTypeID returns the type ID of f.
+func fieldTypeID(f *ROS2Field) int {
+	var id int
+	switch {
+	case isNestedField(f):
+		id = fieldTypeNestedType
+	case f.RosType == "string" && f.StringBound > 0:
+		id = fieldTypeBoundedString
+	case f.RosType == "string":
+		id = fieldTypeString
+	case f.RosType == "U16String" && f.StringBound > 0:
+		id = fieldTypeBoundedWString
+	case f.RosType == "U16String":
+		id = fieldTypeWString
+	default:
+		id = primitiveFieldTypeIDs[f.RosType]
+	}
+	switch {
+	case f.ArraySize > 0:
+		id += fieldTypeArray
+	case f.TypeArray != "" && f.ArrayBound > 0:
+		id += fieldTypeBoundedSequence
+	case f.TypeArray != "":
+		id += fieldTypeUnboundedSequence
+	}
+	return id
+}
+
+// fieldDescriptionCode returns the FieldDescription literal of the field f of
+// msg.
+func (p *parser) fieldDescriptionCode(f *ROS2Field, msg *ROS2Message) string {
+	code := "{Name: " + strconv.Quote(f.RosName) + ", Type: " + p.config.Distro + ".FieldType{TypeID: " + strconv.Itoa(fieldTypeID(f))
+	if f.ArraySize > 0 {
+		code += ", Capacity: " + strconv.Itoa(f.ArraySize)
+	} else if f.ArrayBound > 0 {
+		code += ", Capacity: " + strconv.Itoa(f.ArrayBound)
+	}
+	if f.StringBound > 0 {
+		code += ", StringCapacity: " + strconv.Itoa(f.StringBound)
+	}
+	if isNestedField(f) {
+		code += ", NestedTypeName: " + strconv.Quote(nestedTypeName(f, msg))
+	}
+	code += "}"
+	if f.DefaultValue != "" {
+		code += ", DefaultValue: " + strconv.Quote(f.DefaultValue)
+	}
+	return code + "}"
+}
+
+// referencedTypeSupports returns the type support variables of the types of
+// the message fields of msg, without duplicates.
+func referencedTypeSupports(msg *ROS2Message) []string {
+	var refs []string
+	for _, f := range msg.Fields {
+		if !isNestedField(f) {
+			continue
+		}
+		ref := f.GoPkgReference() + f.GoType + "TypeSupport"
+		if f.PkgName == "time" {
+			ref = "builtin_interfaces_msg." + f.RosType + "TypeSupport"
+		}
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
@@ -18,14 +18,21 @@ func (m *Metadata) GoPackage() string {
 	return m.Package + "_" + m.Type
 }
 
+// TypeName returns the full ROS 2 name of the type, e.g. "std_msgs/msg/Header".
+func (m *Metadata) TypeName() string {
+	return m.Package + "/" + m.Type + "/" + m.Name
+}
+
 // ROS2Message is a message definition.
 // Use ROS2MessageNew() to initialize the struct
 type ROS2Message struct {
 	*Metadata
 	Fields    []*ROS2Field
 	Constants []*ROS2Constant
+	Enums     []*ROS2Enum
 	GoImports map[string]string
 	CImports  utilities.StringSet
+	Source    string // the text the message is defined by
 }
 
 func ROS2MessageNew(pkg, name string) *ROS2Message {
@@ -66,6 +73,8 @@ func (t *ROS2Constant) GoPkgReference() string {
 type ROS2Field struct {
 	TypeArray    string
 	ArrayBounded string
+	ArrayBound   int // upper bound of a bounded sequence, 0 if unbounded
+	StringBound  int // upper bound of a bounded string, 0 if unbounded
 	ArraySize    int
 	DefaultValue string
 	PkgName      string
@@ -95,6 +104,7 @@ type ROS2Service struct {
 	*Metadata
 	Request  *ROS2Message
 	Response *ROS2Message
+	Source   string
 }
 
 func newServiceWithType(pkg, name, typ string) *ROS2Service {
@@ -121,6 +131,7 @@ type ROS2Action struct {
 	SendGoal        *ROS2Service
 	GetResult       *ROS2Service
 	FeedbackMessage *ROS2Message
+	Source          string
 }
 
 func NewROS2Action(pkg, name string) *ROS2Action {
@@ -158,9 +169,11 @@ type rosIDLRuntimeCTypeMapping struct {
 }
 
 var primitiveTypeMappings = map[string]rosIDLRuntimeCTypeMapping{
-	"string":   {RosType: "string", GoType: "string", CStructName: "String", CType: "String", SkipAutogen: true},
-	"time":     {RosType: "time", GoType: "Time", CStructName: "Time", CType: "time", SkipAutogen: true},
-	"duration": {RosType: "duration", GoType: "Duration", CStructName: "Duration", CType: "duration", SkipAutogen: true},
+	"string": {RosType: "string", GoType: "string", CStructName: "String", CType: "String", SkipAutogen: true},
+	// The ROS 1 time types are aliases of the builtin_interfaces messages,
+	// like in rosidl_adapter.
+	"time":     {RosType: "Time", GoType: "Time", CStructName: "Time", CType: "Time", PackageName: "builtin_interfaces", SkipAutogen: true},
+	"duration": {RosType: "Duration", GoType: "Duration", CStructName: "Duration", CType: "Duration", PackageName: "builtin_interfaces", SkipAutogen: true},
 	"float32":  {RosType: "float32", GoType: "float32", CStructName: "float", CType: "float"},
 	"float64":  {RosType: "float64", GoType: "float64", CStructName: "double", CType: "double"},
 	"bool":     {RosType: "bool", GoType: "bool", CStructName: "boolean", CType: "bool"},
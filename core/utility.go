@@ -1,10 +1,12 @@
 package core
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/okieraised/rclgo/core/internal/utilities"
+	"golang.org/x/mod/module"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -44,3 +46,24 @@ func loadGoPkgDeps(pkgPaths ...string) (utilities.StringSet, error) {
 	}
 	return deps, nil
 }
+
+// loadGoModules returns the modules containing the Go packages with
+// pkgPaths, as found by the go command in the working directory, by package
+// path.
+func loadGoModules(pkgPaths ...string) (map[string]module.Version, error) {
+	mods := map[string]module.Version{}
+	if len(pkgPaths) == 0 {
+		return mods, nil
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedModule}, pkgPaths...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if pkg.Module == nil {
+			return nil, fmt.Errorf("failed to find the module of package %s", pkg.PkgPath)
+		}
+		mods[pkg.PkgPath] = module.Version{Path: pkg.Module.Path, Version: pkg.Module.Version}
+	}
+	return mods, nil
+}
@@ -0,0 +1,140 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+	std_msgs_msg "github.com/okieraised/rclgo/humble/internal/testmsgs/std_msgs/msg"
+)
+
+// newTestContext returns a Context using domainID. The test is skipped if rcl
+// cannot be initialized.
+func newTestContext(t *testing.T, domainID uint) *humble.Context {
+	t.Helper()
+	opts := humble.NewDefaultContextOptions()
+	opts.DomainID = domainID
+	rclctx, err := humble.NewContextWithOpts(nil, opts)
+	if err != nil {
+		t.Skipf("failed to initialize rcl: %v", err)
+	}
+	t.Cleanup(func() { _ = rclctx.Close() })
+	return rclctx
+}
+
+// spin runs f until the end of the test.
+func spin(t *testing.T, f func(ctx context.Context) error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+		}
+	})
+}
+
+// waitFor waits until count returns at least want.
+func waitFor(t *testing.T, what string, want int, count func() (int, error)) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		n, err := count()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n >= want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("want %d matched %s, got %d", want, what, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func newReceiver(t *testing.T, node *humble.Node, topic string) (*std_msgs_msg.StringSubscription, <-chan string) {
+	t.Helper()
+	received := make(chan string, 16)
+	sub, err := std_msgs_msg.NewStringSubscription(node, topic, nil, func(msg *std_msgs_msg.String, _ *humble.MessageInfo, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		received <- msg.Data
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sub, received
+}
+
+func TestBridgeBidirectionalTopic(t *testing.T) {
+	domainA := uint(100 + os.Getpid()%60)
+	a := newTestContext(t, domainA)
+	b := newTestContext(t, domainA+60)
+	topic := fmt.Sprintf("/bridge_test_%d/chat", os.Getpid())
+	br, err := New(a, b, &Config{Topics: []TopicConfig{
+		{Topic: topic, Type: "std_msgs/msg/String", Direction: DirectionBidirectional},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = br.Close() })
+	spin(t, br.Spin)
+
+	// The publisher shares Context A with the bridge, so its messages must
+	// not be mistaken for echoes of the bridge.
+	nodeA, err := a.NewNode("bridge_test_a", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeB, err := b.NewNode("bridge_test_b", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := std_msgs_msg.NewStringPublisher(nodeA, topic, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subA, receivedA := newReceiver(t, nodeA, topic)
+	subB, receivedB := newReceiver(t, nodeB, topic)
+	spin(t, nodeA.Spin)
+	spin(t, nodeB.Spin)
+	// The subscriptions of the test and the bridge on side A, and the
+	// publisher of the bridge on side B.
+	waitFor(t, "subscriptions", 2, pub.GetSubscriptionCount)
+	waitFor(t, "publishers", 2, subA.GetPublisherCount)
+	waitFor(t, "publishers", 1, subB.GetPublisherCount)
+
+	if err := pub.Publish(&std_msgs_msg.String{Data: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-receivedB:
+		if got != "hello" {
+			t.Fatalf("want %q on side B, got %q", "hello", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the message was not forwarded to side B")
+	}
+	select {
+	case got := <-receivedA:
+		if got != "hello" {
+			t.Fatalf("want %q on side A, got %q", "hello", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the message was not received on side A")
+	}
+	select {
+	case got := <-receivedA:
+		t.Fatalf("the bridge echoed %q back to side A", got)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
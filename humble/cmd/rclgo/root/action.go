@@ -0,0 +1,246 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "Various action related sub-commands",
+}
+
+// graphActions returns the action servers of all nodes in the graph.
+func graphActions(node *humble.Node) (map[string][]string, error) {
+	return collectByNode(node, node.GetActionServerNamesAndTypesByNode)
+}
+
+var actionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Output a list of action names",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			actions, err := graphActions(node)
+			if err != nil {
+				return err
+			}
+			if count, _ := cmd.Flags().GetBool("count-actions"); count {
+				fmt.Fprintln(cmd.OutOrStdout(), len(actions))
+				return nil
+			}
+			showTypes, _ := cmd.Flags().GetBool("show-types")
+			printNamesAndTypes(cmd, actions, true, showTypes)
+			return nil
+		})
+	},
+}
+
+var actionInfoCmd = &cobra.Command{
+	Use:   "info <action>",
+	Short: "Print information about an action",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action := absoluteName(args[0])
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			nodes, err := graphNodes(node)
+			if err != nil {
+				return err
+			}
+			type endpoint struct {
+				node  string
+				types []string
+			}
+			var clients, servers []endpoint
+			for _, n := range nodes {
+				name := joinNodeName(n.namespace, n.name)
+				namesAndTypes, err := node.GetActionClientNamesAndTypesByNode(n.name, n.namespace)
+				if err != nil {
+					return err
+				}
+				if types, ok := namesAndTypes[action]; ok {
+					clients = append(clients, endpoint{name, types})
+				}
+				namesAndTypes, err = node.GetActionServerNamesAndTypesByNode(n.name, n.namespace)
+				if err != nil {
+					return err
+				}
+				if types, ok := namesAndTypes[action]; ok {
+					servers = append(servers, endpoint{name, types})
+				}
+			}
+			showTypes, _ := cmd.Flags().GetBool("show-types")
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Action: %s\n", action)
+			for _, section := range []struct {
+				title     string
+				endpoints []endpoint
+			}{{"Action clients", clients}, {"Action servers", servers}} {
+				fmt.Fprintf(w, "%s: %d\n", section.title, len(section.endpoints))
+				for _, e := range section.endpoints {
+					if showTypes {
+						fmt.Fprintf(w, "    %s [%s]\n", e.node, joinTypes(e.types))
+					} else {
+						fmt.Fprintf(w, "    %s\n", e.node)
+					}
+				}
+			}
+			return nil
+		})
+	},
+}
+
+var actionSendGoalCmd = &cobra.Command{
+	Use:   "send_goal <action> <type> [goal]",
+	Short: "Send an action goal",
+	Long: `Send an action goal and wait for its result.
+
+The values of the goal are given in YAML, e.g. "{order: 5}". Fields that are
+not set keep their default values. The goal is canceled if the command is
+interrupted.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action, typ := absoluteName(args[0]), args[1]
+		ts, err := actionTypeSupport(typ)
+		if err != nil {
+			return err
+		}
+		goal := ts.Goal().New()
+		if len(args) > 2 {
+			if err := parseMessage(args[2], goal); err != nil {
+				return err
+			}
+		}
+		feedback, _ := cmd.Flags().GetBool("feedback")
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			client, err := node.NewActionClient(action, ts, nil)
+			if err != nil {
+				return err
+			}
+			stop := spinInBackground(ctx, node)
+			err = sendGoal(ctx, cmd, node, client, ts, action, goal, feedback)
+			return errors.Join(ignoreCanceled(ctx, err), stop())
+		})
+	},
+}
+
+func sendGoal(
+	ctx context.Context,
+	cmd *cobra.Command,
+	node *humble.Node,
+	client *humble.ActionClient,
+	ts humble.ActionTypeSupport,
+	action string,
+	goal humble.Message,
+	feedback bool,
+) error {
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "Waiting for an action server to become available...")
+	err := pollGraph(ctx, func() (bool, error) {
+		actions, err := graphActions(node)
+		_, ok := actions[action]
+		return ok, err
+	})
+	if err != nil {
+		return err
+	}
+	text, err := formatMessage(goal)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Sending goal:\n%s\n", indent(text, "  "))
+	resp, goalID, err := client.SendGoal(ctx, goal)
+	if err != nil {
+		return err
+	}
+	if accepted, ok := resp.(interface{ GetGoalAccepted() bool }); !ok || !accepted.GetGoalAccepted() {
+		fmt.Fprintln(w, "Goal was rejected.")
+		return nil
+	}
+	fmt.Fprintf(w, "Goal accepted with ID: %s\n\n", goalID)
+	if feedback {
+		client.WatchFeedback(ctx, goalID, func(_ context.Context, msg humble.Message) {
+			var fb struct {
+				Feedback yaml.Node `yaml:"feedback"`
+			}
+			if err := convertMessage(msg, &fb); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "failed to format feedback: %v\n", err)
+				return
+			}
+			text, err := formatMessage(&fb.Feedback)
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "failed to format feedback: %v\n", err)
+				return
+			}
+			fmt.Fprintf(w, "Feedback:\n%s\n", indent(text, "  "))
+		})
+	}
+	resp, err = client.GetResult(ctx, goalID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return errors.Join(err, cancelGoal(client, ts, goalID))
+		}
+		return err
+	}
+	var result struct {
+		Status humble.GoalStatus `yaml:"status"`
+		Result yaml.Node         `yaml:"result"`
+	}
+	if err := convertMessage(resp, &result); err != nil {
+		return err
+	}
+	if text, err = formatMessage(&result.Result); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Result:\n%s\n", indent(text, "  "))
+	fmt.Fprintf(w, "Goal finished with status: %s\n", strings.ToUpper(result.Status.String()))
+	return nil
+}
+
+// cancelGoal cancels the goal with goalID. It is used after the command has
+// been interrupted, so it does not use the context of the command.
+func cancelGoal(client *humble.ActionClient, ts humble.ActionTypeSupport, goalID *humble.GoalID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	req := ts.CancelGoal().Request().New()
+	req.(interface{ SetGoalID(*humble.GoalID) }).SetGoalID(goalID)
+	_, err := client.CancelGoal(ctx, req)
+	return err
+}
+
+// convertMessage decodes the YAML encoding of msg into out, which allows
+// reading fields of generated structs without knowing their Go type.
+func convertMessage(msg humble.Message, out any) error {
+	data, err := yaml.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
+
+func init() {
+	rootCmd.AddCommand(actionCmd)
+
+	actionCmd.AddCommand(actionListCmd)
+	actionListCmd.Flags().BoolP("show-types", "t", false, "Additionally show the action type.")
+	actionListCmd.Flags().BoolP("count-actions", "c", false, "Only display the number of actions discovered.")
+
+	actionCmd.AddCommand(actionInfoCmd)
+	actionInfoCmd.Flags().BoolP("show-types", "t", false, "Additionally show the action type.")
+
+	actionCmd.AddCommand(actionSendGoalCmd)
+	actionSendGoalCmd.Flags().BoolP("feedback", "f", false, "Echo feedback messages for the goal.")
+}
@@ -0,0 +1,94 @@
+package root
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+)
+
+func endpointTypeName(t humble.EndpointType) string {
+	switch t {
+	case humble.EndpointPublisher:
+		return "PUBLISHER"
+	case humble.EndpointSubscription:
+		return "SUBSCRIPTION"
+	}
+	return "INVALID"
+}
+
+func historyName(p humble.HistoryPolicy) string {
+	switch p {
+	case humble.HistorySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case humble.HistoryKeepLast:
+		return "KEEP_LAST"
+	case humble.HistoryKeepAll:
+		return "KEEP_ALL"
+	}
+	return "UNKNOWN"
+}
+
+func reliabilityName(p humble.ReliabilityPolicy) string {
+	switch p {
+	case humble.ReliabilitySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case humble.ReliabilityReliable:
+		return "RELIABLE"
+	case humble.ReliabilityBestEffort:
+		return "BEST_EFFORT"
+	}
+	return "UNKNOWN"
+}
+
+func durabilityName(p humble.DurabilityPolicy) string {
+	switch p {
+	case humble.DurabilitySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case humble.DurabilityTransientLocal:
+		return "TRANSIENT_LOCAL"
+	case humble.DurabilityVolatile:
+		return "VOLATILE"
+	}
+	return "UNKNOWN"
+}
+
+func livelinessName(p humble.LivelinessPolicy) string {
+	switch p {
+	case humble.LivelinessSystemDefault:
+		return "SYSTEM_DEFAULT"
+	case humble.LivelinessAutomatic:
+		return "AUTOMATIC"
+	case humble.LivelinessManualByTopic:
+		return "MANUAL_BY_TOPIC"
+	}
+	return "UNKNOWN"
+}
+
+func qosDuration(d time.Duration) string {
+	if d == humble.DurationInfinite || d == humble.DurationUnspecified {
+		return "Infinite"
+	}
+	return fmt.Sprintf("%d nanoseconds", d.Nanoseconds())
+}
+
+// formatGID formats a GID as dot-separated hexadecimal bytes.
+func formatGID(gid humble.GID) string {
+	parts := make([]string, len(gid))
+	for i, b := range gid {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ".")
+}
+
+// formatBytes formats a number of bytes using decimal prefixes.
+func formatBytes(n float64) string {
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if n < 1000 {
+			return fmt.Sprintf("%.2f %s", n, unit)
+		}
+		n /= 1000
+	}
+	return fmt.Sprintf("%.2f GB", n)
+}
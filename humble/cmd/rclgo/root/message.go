@@ -0,0 +1,172 @@
+package root
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/bridge"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const notRegisteredHint = "the generated Go package of the interface must be imported, see the documentation of rclgo"
+
+func messageTypeSupport(typ string) (humble.MessageTypeSupport, error) {
+	ts, ok := humble.GetMessage(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered: %s", typ, notRegisteredHint)
+	}
+	return ts, nil
+}
+
+func serviceTypeSupport(typ string) (humble.ServiceTypeSupport, error) {
+	ts, ok := humble.GetService(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered: %s", typ, notRegisteredHint)
+	}
+	return ts, nil
+}
+
+func actionTypeSupport(typ string) (humble.ActionTypeSupport, error) {
+	ts, ok := humble.GetAction(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered: %s", typ, notRegisteredHint)
+	}
+	return ts, nil
+}
+
+// serializedTypeSupport returns a type support of a message type that can be
+// used to handle serialized messages. Type support that is not registered is
+// loaded dynamically.
+func serializedTypeSupport(typ string) (humble.MessageTypeSupport, error) {
+	if ts, ok := humble.GetMessage(typ); ok {
+		return ts, nil
+	}
+	pkg, iface, err := splitInterfaceType(typ, "msg")
+	if err != nil {
+		return nil, err
+	}
+	return humble.LoadDynamicMessageTypeSupport(pkg, iface)
+}
+
+// splitInterfaceType splits "pkg/kind/Name" or "pkg/Name" into the package and
+// interface names. The interface kind, if present, must equal kind.
+func splitInterfaceType(typ, kind string) (pkg, iface string, err error) {
+	parts := strings.Split(typ, "/")
+	switch {
+	case len(parts) == 3 && parts[1] == kind && parts[0] != "" && parts[2] != "":
+		return parts[0], parts[2], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("invalid %s type name %q", kind, typ)
+}
+
+// absoluteName expands a name relative to the namespace of the node created by
+// runWithNode, which is the root namespace.
+func absoluteName(name string) string {
+	if strings.HasPrefix(name, "/") {
+		return name
+	}
+	return "/" + name
+}
+
+// topicType returns the type of topic. If the topic has no publishers or
+// subscriptions within the spin time, an error is returned.
+func topicType(ctx context.Context, cmd *cobra.Command, node *humble.Node, topic string) (string, error) {
+	spinTime, err := cmd.Flags().GetDuration("spin-time")
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, spinTime)
+	defer cancel()
+	var typ string
+	err = pollGraph(ctx, func() (bool, error) {
+		namesAndTypes, err := node.GetTopicNamesAndTypes(true)
+		if err != nil {
+			return false, err
+		}
+		if types := namesAndTypes[topic]; len(types) > 0 {
+			typ = types[0]
+			return true, nil
+		}
+		return false, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "", fmt.Errorf("could not determine the type of topic %s: the topic does not appear to be published", topic)
+	}
+	return typ, err
+}
+
+// parseMessage decodes YAML-formatted values into msg. Fields that are not
+// set in values keep their current values.
+func parseMessage(values string, msg humble.Message) error {
+	dec := yaml.NewDecoder(strings.NewReader(values))
+	dec.KnownFields(true)
+	if err := dec.Decode(msg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid message values: %w", err)
+	}
+	return nil
+}
+
+// formatMessage formats msg as YAML.
+func formatMessage(msg any) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(msg); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// indent prefixes every non-empty line of s with prefix.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func addQosFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("qos-depth", humble.NewDefaultQosProfile().Depth, "Queue size setting of the QoS profile.")
+	cmd.Flags().String("qos-history", "", "History setting of the QoS profile: keep_last, keep_all or system_default.")
+	cmd.Flags().String("qos-reliability", "", "Reliability setting of the QoS profile: reliable, best_effort or system_default.")
+	cmd.Flags().String("qos-durability", "", "Durability setting of the QoS profile: volatile, transient_local or system_default.")
+}
+
+// qosProfile returns the default QoS profile overridden by the flags added by
+// addQosFlags.
+func qosProfile(cmd *cobra.Command) (humble.QosProfile, error) {
+	var q bridge.QosConfig
+	depth, err := cmd.Flags().GetInt("qos-depth")
+	if err != nil {
+		return humble.QosProfile{}, err
+	}
+	q.Depth = &depth
+	if q.History, err = cmd.Flags().GetString("qos-history"); err != nil {
+		return humble.QosProfile{}, err
+	}
+	if q.Reliability, err = cmd.Flags().GetString("qos-reliability"); err != nil {
+		return humble.QosProfile{}, err
+	}
+	if q.Durability, err = cmd.Flags().GetString("qos-durability"); err != nil {
+		return humble.QosProfile{}, err
+	}
+	p := humble.NewDefaultQosProfile()
+	if err := q.Apply(&p); err != nil {
+		return humble.QosProfile{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,122 @@
+package root
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/spf13/cobra"
+)
+
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Various node related sub-commands",
+}
+
+var nodeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Output a list of available nodes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			nodes, err := graphNodes(node)
+			if err != nil {
+				return err
+			}
+			all, _ := cmd.Flags().GetBool("all")
+			var names []string
+			for _, n := range nodes {
+				name := joinNodeName(n.namespace, n.name)
+				if all || !isHidden(name) {
+					names = append(names, name)
+				}
+			}
+			w := cmd.OutOrStdout()
+			if count, _ := cmd.Flags().GetBool("count-nodes"); count {
+				fmt.Fprintln(w, len(names))
+				return nil
+			}
+			for _, name := range names {
+				fmt.Fprintln(w, name)
+			}
+			return nil
+		})
+	},
+}
+
+var nodeInfoCmd = &cobra.Command{
+	Use:   "info <node>",
+	Short: "Output information about a node",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fullName := absoluteName(args[0])
+		namespace, name := splitNodeName(fullName)
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			nodes, err := graphNodes(node)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(nodes, graphNode{namespace: namespace, name: name}) {
+				return fmt.Errorf("unable to find node %s", fullName)
+			}
+			includeHidden, _ := cmd.Flags().GetBool("include-hidden")
+			sections := []struct {
+				title string
+				get   func() (map[string][]string, error)
+			}{
+				{"Subscribers", func() (map[string][]string, error) {
+					return node.GetSubscriberNamesAndTypesByNode(true, name, namespace)
+				}},
+				{"Publishers", func() (map[string][]string, error) {
+					return node.GetPublisherNamesAndTypesByNode(true, name, namespace)
+				}},
+				{"Service Servers", func() (map[string][]string, error) {
+					return node.GetServiceNamesAndTypesByNode(name, namespace)
+				}},
+				{"Service Clients", func() (map[string][]string, error) {
+					return node.GetClientNamesAndTypesByNode(name, namespace)
+				}},
+				{"Action Servers", func() (map[string][]string, error) {
+					return node.GetActionServerNamesAndTypesByNode(name, namespace)
+				}},
+				{"Action Clients", func() (map[string][]string, error) {
+					return node.GetActionClientNamesAndTypesByNode(name, namespace)
+				}},
+			}
+			w := cmd.OutOrStdout()
+			fmt.Fprintln(w, fullName)
+			for _, section := range sections {
+				namesAndTypes, err := section.get()
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "  %s:\n", section.title)
+				for _, name := range slices.Sorted(maps.Keys(namesAndTypes)) {
+					if includeHidden || !isHidden(name) {
+						fmt.Fprintf(w, "    %s: %s\n", name, joinTypes(namesAndTypes[name]))
+					}
+				}
+			}
+			return nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nodeCmd)
+
+	nodeCmd.AddCommand(nodeListCmd)
+	nodeListCmd.Flags().BoolP("all", "a", false, "Display all nodes even hidden ones.")
+	nodeListCmd.Flags().BoolP("count-nodes", "c", false, "Only display the number of nodes discovered.")
+
+	nodeCmd.AddCommand(nodeInfoCmd)
+	nodeInfoCmd.Flags().Bool("include-hidden", false, "Display hidden topics, services, and actions as well.")
+}
@@ -0,0 +1,230 @@
+/*
+Package root implements rclgo, a command line tool for inspecting and
+interacting with the ROS graph, modelled after the topic, node, service,
+action and interface verbs of the ros2 command line tool.
+
+Commands that only inspect the graph or handle serialized messages, such as
+topic list, topic hz and node info, work with any interface. Commands that
+decode or encode messages, such as topic echo, topic pub and service call,
+need the type support of the interface to be registered, i.e. the generated Go
+package must be imported. To use them with your interfaces, build a binary
+that imports the package generated by ros2gen:
+
+	package main
+
+	import (
+		"github.com/okieraised/rclgo/humble/cmd/rclgo/root"
+
+		_ "example.com/robot/ros2_msgs"
+	)
+
+	func main() {
+		root.Execute()
+	}
+
+Messages are read and printed as YAML using the ROS field names.
+*/
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "rclgo",
+	Short: "ROS2 client library in Golang - command line tool",
+	Long:  `Inspect and interact with the ROS2 graph`,
+
+	SilenceErrors: true,
+}
+
+func Execute() {
+	cobra.CheckErr(rootCmd.Execute())
+}
+
+func init() {
+	rootCmd.PersistentFlags().Duration("spin-time", time.Second, "Time to wait for discovery before inspecting the ROS graph.")
+}
+
+// runWithNode creates a Context and a hidden node, and calls run with them.
+// The context passed to run is canceled on SIGINT and SIGTERM.
+func runWithNode(cmd *cobra.Command, run func(ctx context.Context, node *humble.Node) error) (err error) {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	rclContext, err := humble.NewContextWithOpts(nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ROS: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, rclContext.Close())
+	}()
+	node, err := rclContext.NewNode(fmt.Sprintf("_rclgo_%d", os.Getpid()), "")
+	if err != nil {
+		return fmt.Errorf("failed to create node: %w", err)
+	}
+	return run(ctx, node)
+}
+
+// waitForDiscovery waits for the time given by the spin-time flag so that the
+// graph of the node is populated.
+func waitForDiscovery(ctx context.Context, cmd *cobra.Command) error {
+	spinTime, err := cmd.Flags().GetDuration("spin-time")
+	if err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(spinTime):
+		return nil
+	}
+}
+
+// pollGraph calls f until it returns true, an error or ctx is done.
+func pollGraph(ctx context.Context, f func() (bool, error)) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		done, err := f()
+		if done || err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// spinUntilDone spins node until ctx is done. Cancellation of ctx is not
+// reported as an error.
+func spinUntilDone(ctx context.Context, node *humble.Node) error {
+	err := node.Spin(ctx)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// spinInBackground spins node in a new goroutine. The returned function stops
+// spinning and returns the error Spin failed with, if any.
+func spinInBackground(ctx context.Context, node *humble.Node) (stop func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- spinUntilDone(ctx, node) }()
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// isHidden reports whether a name has a token starting with an underscore.
+func isHidden(name string) bool {
+	for _, token := range strings.Split(name, "/") {
+		if strings.HasPrefix(token, "_") {
+			return true
+		}
+	}
+	return false
+}
+
+// joinNodeName returns the fully qualified name of a node.
+func joinNodeName(namespace, name string) string {
+	if strings.HasSuffix(namespace, "/") {
+		return namespace + name
+	}
+	return namespace + "/" + name
+}
+
+// splitNodeName splits a fully qualified node name into the namespace and the
+// name of the node.
+func splitNodeName(fullName string) (namespace, name string) {
+	if !strings.HasPrefix(fullName, "/") {
+		fullName = "/" + fullName
+	}
+	i := strings.LastIndex(fullName, "/")
+	namespace, name = fullName[:i], fullName[i+1:]
+	if namespace == "" {
+		namespace = "/"
+	}
+	return namespace, name
+}
+
+type graphNode struct {
+	namespace, name string
+}
+
+// graphNodes returns the nodes in the graph sorted by their fully qualified
+// names.
+func graphNodes(node *humble.Node) ([]graphNode, error) {
+	names, namespaces, err := node.GetNodeNames()
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]graphNode, len(names))
+	for i := range names {
+		nodes[i] = graphNode{namespace: namespaces[i], name: names[i]}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return joinNodeName(nodes[i].namespace, nodes[i].name) < joinNodeName(nodes[j].namespace, nodes[j].name)
+	})
+	return nodes, nil
+}
+
+// collectByNode merges the names and types returned by get for every node in
+// the graph.
+func collectByNode(node *humble.Node, get func(name, namespace string) (map[string][]string, error)) (map[string][]string, error) {
+	nodes, err := graphNodes(node)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string][]string{}
+	for _, n := range nodes {
+		namesAndTypes, err := get(n.name, n.namespace)
+		if err != nil {
+			return nil, err
+		}
+		for name, types := range namesAndTypes {
+			for _, typ := range types {
+				if !slices.Contains(result[name], typ) {
+					result[name] = append(result[name], typ)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// printNamesAndTypes prints the names in namesAndTypes, optionally followed by
+// their types, in the format of "ros2 topic list -t".
+func printNamesAndTypes(cmd *cobra.Command, namesAndTypes map[string][]string, includeHidden, showTypes bool) {
+	w := cmd.OutOrStdout()
+	for _, name := range slices.Sorted(maps.Keys(namesAndTypes)) {
+		if !includeHidden && isHidden(name) {
+			continue
+		}
+		if showTypes {
+			fmt.Fprintf(w, "%s [%s]\n", name, joinTypes(namesAndTypes[name]))
+		} else {
+			fmt.Fprintln(w, name)
+		}
+	}
+}
+
+func joinTypes(types []string) string {
+	return strings.Join(types, ", ")
+}
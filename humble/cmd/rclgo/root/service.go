@@ -0,0 +1,172 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/spf13/cobra"
+)
+
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Various service related sub-commands",
+}
+
+// graphServices returns the services of all nodes in the graph.
+func graphServices(node *humble.Node) (map[string][]string, error) {
+	return collectByNode(node, node.GetServiceNamesAndTypesByNode)
+}
+
+var serviceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Output a list of available services",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			services, err := graphServices(node)
+			if err != nil {
+				return err
+			}
+			includeHidden, _ := cmd.Flags().GetBool("include-hidden-services")
+			if count, _ := cmd.Flags().GetBool("count-services"); count {
+				n := 0
+				for name := range services {
+					if includeHidden || !isHidden(name) {
+						n++
+					}
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), n)
+				return nil
+			}
+			showTypes, _ := cmd.Flags().GetBool("show-types")
+			printNamesAndTypes(cmd, services, includeHidden, showTypes)
+			return nil
+		})
+	},
+}
+
+var serviceTypeCmd = &cobra.Command{
+	Use:   "type <service>",
+	Short: "Output the type of a service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := absoluteName(args[0])
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			services, err := graphServices(node)
+			if err != nil {
+				return err
+			}
+			types, ok := services[service]
+			if !ok {
+				return fmt.Errorf("unknown service %s", service)
+			}
+			for _, typ := range types {
+				fmt.Fprintln(cmd.OutOrStdout(), typ)
+			}
+			return nil
+		})
+	},
+}
+
+var serviceCallCmd = &cobra.Command{
+	Use:   "call <service> <type> [values]",
+	Short: "Call a service",
+	Long: `Call a service.
+
+The values of the request are given in YAML, e.g. "{a: 1, b: 2}". Fields that
+are not set keep their default values.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, typ := absoluteName(args[0]), args[1]
+		ts, err := serviceTypeSupport(typ)
+		if err != nil {
+			return err
+		}
+		req := ts.Request().New()
+		if len(args) > 2 {
+			if err := parseMessage(args[2], req); err != nil {
+				return err
+			}
+		}
+		text, err := formatMessage(req)
+		if err != nil {
+			return err
+		}
+		rate, _ := cmd.Flags().GetFloat64("rate")
+		if rate < 0 {
+			return fmt.Errorf("invalid rate %v", rate)
+		}
+		return runWithNode(cmd, func(ctx context.Context, node *humble.Node) error {
+			client, err := node.NewClient(service, ts, nil)
+			if err != nil {
+				return err
+			}
+			stop := spinInBackground(ctx, node)
+			err = callService(ctx, cmd, node, client, service, req, text, rate)
+			return errors.Join(ignoreCanceled(ctx, err), stop())
+		})
+	},
+}
+
+// callService sends req to service, once if rate is zero and at rate Hz
+// otherwise.
+func callService(ctx context.Context, cmd *cobra.Command, node *humble.Node, client *humble.Client, service string, req humble.Message, text string, rate float64) error {
+	w := cmd.OutOrStdout()
+	fmt.Fprintln(w, "waiting for service to become available...")
+	err := pollGraph(ctx, func() (bool, error) {
+		services, err := graphServices(node)
+		_, ok := services[service]
+		return ok, err
+	})
+	if err != nil {
+		return err
+	}
+	var ticker *time.Ticker
+	if rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+	}
+	for {
+		fmt.Fprintf(w, "requester: making request:\n%s\n", indent(text, "  "))
+		resp, _, err := client.Send(ctx, req)
+		if err != nil {
+			return err
+		}
+		respText, err := formatMessage(resp)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "response:\n%s\n", indent(respText, "  "))
+		if ticker == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(serviceCmd)
+
+	serviceCmd.AddCommand(serviceListCmd)
+	serviceListCmd.Flags().BoolP("show-types", "t", false, "Additionally show the service type.")
+	serviceListCmd.Flags().BoolP("count-services", "c", false, "Only display the number of services discovered.")
+	serviceListCmd.Flags().Bool("include-hidden-services", false, "Consider hidden services as well.")
+
+	serviceCmd.AddCommand(serviceTypeCmd)
+
+	serviceCmd.AddCommand(serviceCallCmd)
+	serviceCallCmd.Flags().Float64P("rate", "r", 0, "Repeat the call at a specific rate in Hz. Zero calls the service once.")
+}
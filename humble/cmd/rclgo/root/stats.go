@@ -0,0 +1,92 @@
+package root
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// window keeps the most recent samples of a measurement. It is safe for
+// concurrent use.
+type window struct {
+	size int
+
+	mu     sync.Mutex
+	times  []time.Time
+	values []float64
+}
+
+func (w *window) add(t time.Time, value float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.times = append(w.times, t)
+	w.values = append(w.values, value)
+	if n := len(w.times) - w.size; n > 0 {
+		w.times = append(w.times[:0], w.times[n:]...)
+		w.values = append(w.values[:0], w.values[n:]...)
+	}
+}
+
+// snapshot returns copies of the samples in the window.
+func (w *window) snapshot() ([]time.Time, []float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]time.Time(nil), w.times...), append([]float64(nil), w.values...)
+}
+
+type summary struct {
+	sum, mean, min, max, stdDev float64
+}
+
+func summarize(values []float64) summary {
+	if len(values) == 0 {
+		return summary{}
+	}
+	s := summary{min: math.Inf(1), max: math.Inf(-1)}
+	for _, v := range values {
+		s.sum += v
+		s.min = math.Min(s.min, v)
+		s.max = math.Max(s.max, v)
+	}
+	s.mean = s.sum / float64(len(values))
+	for _, v := range values {
+		s.stdDev += (v - s.mean) * (v - s.mean)
+	}
+	s.stdDev = math.Sqrt(s.stdDev / float64(len(values)))
+	return s
+}
+
+// headerStamp returns the value of the header.stamp field of a generated
+// message struct.
+func headerStamp(msg any) (time.Time, bool) {
+	v := reflect.ValueOf(msg)
+	for _, name := range []string{"header", "stamp"} {
+		if v = fieldByROSName(v, name); !v.IsValid() {
+			return time.Time{}, false
+		}
+	}
+	sec, nanosec := fieldByROSName(v, "sec"), fieldByROSName(v, "nanosec")
+	if !sec.CanInt() || !nanosec.CanUint() {
+		return time.Time{}, false
+	}
+	return time.Unix(sec.Int(), int64(nanosec.Uint())), true
+}
+
+// fieldByROSName returns the field of the struct v, or the struct v points to,
+// whose ROS name is name. The ROS field names are stored in the yaml tags of
+// generated structs.
+func fieldByROSName(v reflect.Value, name string) reflect.Value {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("yaml") == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
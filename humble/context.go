@@ -137,6 +137,16 @@ type ContextOptions struct {
 	// The DDS domain ID of the Context. Should be in range [0, 101] or
 	// DefaultDomainID.
 	DomainID uint
+
+	// IntraProcess enables intra-process communication: messages published
+	// in the Context are delivered to the subscriptions of the Context as
+	// copies made by CloneMsg, without going through the middleware.
+	// Subscriptions receive them with MessageInfo.FromIntraProcess set, and
+	// drop the copies of the same messages received through the middleware.
+	// Transient local publishers and subscriptions and subscriptions with
+	// IgnoreLocalPublications set don't take part in intra-process
+	// communication.
+	IntraProcess bool
 }
 
 // NewDefaultContextOptions returns the default options for a Context.
@@ -153,6 +163,7 @@ type Context struct {
 	rclContextT   *C.rcl_context_t
 	defaultClock  *Clock
 	clock         *Clock
+	intraProcess  *intraProcessManager
 
 	rosResourceStore
 }
@@ -225,6 +236,9 @@ func NewContextWithOpts(rclArgs *Args, opts *ContextOptions) (ctx *Context, err
 		return nil, err
 	}
 	ctx.clock = ctx.defaultClock
+	if opts.IntraProcess {
+		ctx.intraProcess = &intraProcessManager{}
+	}
 
 	return ctx, nil
 }
@@ -250,6 +264,17 @@ func (c *Context) Close() error {
 	return errs
 }
 
+// DomainID returns the DDS domain ID the context is actually using, which may
+// differ from ContextOptions.DomainID if DefaultDomainID was requested.
+func (c *Context) DomainID() (uint, error) {
+	var domainID C.size_t
+	rc := C.rcl_context_get_domain_id(c.rclContextT, &domainID)
+	if rc != C.RCL_RET_OK {
+		return 0, errorsCastC(rc, "failed to get domain ID")
+	}
+	return uint(domainID), nil
+}
+
 func (c *Context) Clock() *Clock {
 	return c.clock
 }
@@ -0,0 +1,101 @@
+package humble
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func checkDiffs(t *testing.T, d *Differ, want ...string) {
+	t.Helper()
+	if len(d.Diffs) != len(want) {
+		t.Fatalf("want diffs %v, got %v", want, d.Diffs)
+	}
+	for i := range want {
+		if d.Diffs[i].Field != want[i] {
+			t.Fatalf("want diffs %v, got %v", want, d.Diffs)
+		}
+	}
+}
+
+func TestDiffLen(t *testing.T) {
+	d := NewDiffer()
+	if !DiffLen[int](d, "data", nil, []int{}) {
+		t.Fatal("nil and empty sequences must be equal")
+	}
+	checkDiffs(t, d)
+	if DiffLen(d, "data", nil, []int{1}) {
+		t.Fatal("sequences of different lengths must differ")
+	}
+	checkDiffs(t, d, "data")
+}
+
+func TestDiffFloat(t *testing.T) {
+	nan := math.NaN()
+	d := NewDiffer()
+	DiffFloat(d, "x", nan, nan)
+	DiffFloat(d, "y", float32(1), float32(1))
+	checkDiffs(t, d)
+	DiffFloat(d, "z", nan, 0)
+	DiffFloat(d, "w", 1, 1+1e-12)
+	checkDiffs(t, d, "z", "w")
+
+	d = NewDiffer(FloatTolerance(1e-9))
+	DiffFloat(d, "x", 1, 1+1e-12)
+	DiffFloat(d, "y", 1, 1-1e-12)
+	DiffFloat(d, "z", math.Inf(1), math.Inf(1))
+	checkDiffs(t, d)
+	DiffFloat(d, "w", 1, 1+1e-6)
+	DiffFloat(d, "v", 0, nan)
+	checkDiffs(t, d, "w", "v")
+}
+
+func TestDiffFloatSlice(t *testing.T) {
+	nan := math.NaN()
+	d := NewDiffer(FloatTolerance(0.5))
+	DiffFloatSlice(d, "ranges", []float32{1, float32(nan), 3}, []float32{1.25, float32(nan), 4})
+	checkDiffs(t, d, "ranges[2]")
+	DiffFloatSlice[float64](d, "intensities", nil, []float64{})
+	checkDiffs(t, d, "ranges[2]")
+	DiffFloatSlice(d, "intensities", []float64{1}, nil)
+	checkDiffs(t, d, "ranges[2]", "intensities")
+}
+
+func TestDiffTime(t *testing.T) {
+	utc := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	d := NewDiffer()
+	DiffTime(d, "stamp", utc, utc.In(time.FixedZone("UTC+2", 2*60*60)))
+	checkDiffs(t, d)
+	DiffTime(d, "stamp", utc, utc.Add(time.Nanosecond))
+	checkDiffs(t, d, "stamp")
+}
+
+func TestDifferPath(t *testing.T) {
+	d := NewDiffer()
+	DiffValue(d, "frame_id", "map", "map")
+	d.Enter("header")
+	DiffValue(d, "frame_id", "map", "odom")
+	d.Leave()
+	d.EnterIndex("poses", 2)
+	d.Enter("position")
+	DiffFloat(d, "x", 1.0, 2.0)
+	d.Leave()
+	DiffSlice(d, "covariance", []int{1, 2, 3}, []int{1, 5, 3})
+	d.Leave()
+	DiffValue(d, "id", 1, 2)
+	checkDiffs(t, d, "header.frame_id", "poses[2].position.x", "poses[2].covariance[1]", "id")
+	if got, want := d.Diffs[1].String(), "poses[2].position.x: 1 != 2"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestDifferFirstOnly(t *testing.T) {
+	d := NewDiffer()
+	d.FirstOnly = true
+	DiffSlice(d, "data", []int{1, 2, 3}, []int{0, 0, 0})
+	DiffValue(d, "id", 1, 2)
+	checkDiffs(t, d, "data[0]")
+	if !d.Done() {
+		t.Fatal("comparison must be done after the first difference")
+	}
+}
@@ -0,0 +1,271 @@
+package foxglove
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/humble"
+)
+
+// conn is a connected client.
+type conn struct {
+	server *Server
+	ws     *websocket.Conn
+
+	// Control messages are never dropped, while message data is dropped
+	// when the client cannot keep up.
+	mu      sync.Mutex
+	control []frame
+	data    chan []byte
+	notify  chan struct{}
+	closed  chan struct{}
+
+	// Accessed only by the read loop.
+	subscriptions map[uint32]*channel
+	publishers    map[uint32]*clientPublisher
+}
+
+type frame struct {
+	typ  int
+	data []byte
+}
+
+// clientPublisher publishes messages sent by the client to a channel it has
+// advertised.
+type clientPublisher struct {
+	topic string
+	pub   *humble.Publisher
+}
+
+func newConn(server *Server, ws *websocket.Conn) *conn {
+	return &conn{
+		server:        server,
+		ws:            ws,
+		data:          make(chan []byte, server.options.SendBufferLength),
+		notify:        make(chan struct{}, 1),
+		closed:        make(chan struct{}),
+		subscriptions: map[uint32]*channel{},
+		publishers:    map[uint32]*clientPublisher{},
+	}
+}
+
+func (c *conn) serve() {
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		c.writeLoop()
+	}()
+	defer func() {
+		close(c.closed)
+		_ = c.ws.Close()
+		<-writerDone
+		c.server.removeConn(c)
+		for id := range c.publishers {
+			c.unadvertise(id)
+		}
+	}()
+	go func() {
+		// Unblock ReadMessage when the server is closed.
+		select {
+		case <-c.server.ctx.Done():
+			_ = c.ws.Close()
+		case <-c.closed:
+		}
+	}()
+	for {
+		typ, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		switch typ {
+		case websocket.TextMessage:
+			err = c.handleJSON(data)
+		case websocket.BinaryMessage:
+			err = c.handleBinary(data)
+		}
+		if err != nil {
+			c.status(statusError, err.Error())
+		}
+	}
+}
+
+func (c *conn) writeLoop() {
+	for {
+		c.mu.Lock()
+		control := c.control
+		c.control = nil
+		c.mu.Unlock()
+		for _, f := range control {
+			if err := c.ws.WriteMessage(f.typ, f.data); err != nil {
+				return
+			}
+		}
+		select {
+		case <-c.closed:
+			return
+		case <-c.notify:
+		case msg := <-c.data:
+			if err := c.ws.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendJSON queues a control message.
+func (c *conn) sendJSON(v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.server.logf("failed to encode %T: %v", v, err)
+		return
+	}
+	c.sendControl(websocket.TextMessage, data)
+}
+
+// sendControl queues a message that must not be dropped.
+func (c *conn) sendControl(typ int, data []byte) {
+	c.mu.Lock()
+	c.control = append(c.control, frame{typ: typ, data: data})
+	c.mu.Unlock()
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// sendData queues message data. The message is dropped if the send buffer is
+// full.
+func (c *conn) sendData(data []byte) {
+	select {
+	case c.data <- data:
+	default:
+	}
+}
+
+func (c *conn) status(level int, msg string) {
+	c.sendJSON(statusOp{Op: "status", Level: level, Message: msg})
+}
+
+func (c *conn) handleJSON(data []byte) error {
+	var op clientOp
+	if err := json.Unmarshal(data, &op); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+	switch op.Op {
+	case "subscribe":
+		var errs []error
+		for _, sub := range op.Subscriptions {
+			errs = append(errs, c.subscribe(sub))
+		}
+		return errors.Join(errs...)
+	case "unsubscribe":
+		var errs []error
+		for _, id := range op.SubscriptionIDs {
+			errs = append(errs, c.unsubscribe(id))
+		}
+		return errors.Join(errs...)
+	case "advertise":
+		var errs []error
+		for _, ch := range op.Channels {
+			errs = append(errs, c.advertise(ch))
+		}
+		return errors.Join(errs...)
+	case "unadvertise":
+		for _, id := range op.ChannelIDs {
+			c.unadvertise(id)
+		}
+		return nil
+	case "getParameters":
+		c.server.goTask(func() { c.getParameters(op.ParameterNames, op.ID) })
+		return nil
+	case "setParameters":
+		c.server.goTask(func() { c.setParameters(op.Parameters, op.ID) })
+		return nil
+	}
+	return fmt.Errorf("unsupported operation %q", op.Op)
+}
+
+func (c *conn) handleBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty binary message")
+	}
+	switch data[0] {
+	case opClientMessageData:
+		if len(data) < 5 {
+			return errors.New("truncated client message")
+		}
+		return c.publish(binary.LittleEndian.Uint32(data[1:]), data[5:])
+	case opServiceCallRequest:
+		req, ok := decodeServiceCallRequest(data[1:])
+		if !ok {
+			return errors.New("truncated service call request")
+		}
+		c.server.goTask(func() { c.callService(req) })
+		return nil
+	}
+	return fmt.Errorf("unsupported binary opcode %d", data[0])
+}
+
+func (c *conn) subscribe(req subscriptionRequest) error {
+	if _, exists := c.subscriptions[req.ID]; exists {
+		return fmt.Errorf("subscription %d already exists", req.ID)
+	}
+	ch, err := c.server.subscribe(c, req.ID, req.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to channel %d: %w", req.ChannelID, err)
+	}
+	c.subscriptions[req.ID] = ch
+	return nil
+}
+
+func (c *conn) unsubscribe(id uint32) error {
+	ch := c.subscriptions[id]
+	if ch == nil {
+		return fmt.Errorf("unknown subscription %d", id)
+	}
+	delete(c.subscriptions, id)
+	c.server.unsubscribe(c, ch)
+	return nil
+}
+
+func (c *conn) advertise(ch clientChannel) error {
+	if _, exists := c.publishers[ch.ID]; exists {
+		return fmt.Errorf("channel %d is already advertised", ch.ID)
+	}
+	if ch.Encoding != "cdr" {
+		return fmt.Errorf("unsupported encoding %q of channel %d", ch.Encoding, ch.ID)
+	}
+	ts, err := humble.LoadMessageTypeSupport(ch.SchemaName)
+	if err != nil {
+		return fmt.Errorf("failed to advertise channel %d: %w", ch.ID, err)
+	}
+	pub, err := c.server.node.NewPublisher(ch.Topic, ts, nil)
+	if err != nil {
+		return fmt.Errorf("failed to advertise channel %d: %w", ch.ID, err)
+	}
+	c.publishers[ch.ID] = &clientPublisher{topic: ch.Topic, pub: pub}
+	return nil
+}
+
+func (c *conn) unadvertise(id uint32) {
+	p := c.publishers[id]
+	if p == nil {
+		return
+	}
+	delete(c.publishers, id)
+	if err := p.pub.Close(); err != nil {
+		c.server.logf("failed to close publisher of %s: %v", p.topic, err)
+	}
+}
+
+func (c *conn) publish(channelID uint32, payload []byte) error {
+	p := c.publishers[channelID]
+	if p == nil {
+		return fmt.Errorf("channel %d is not advertised", channelID)
+	}
+	return p.pub.PublishSerialized(payload)
+}
@@ -1,3 +1,14 @@
 module github.com/okieraised/rclgo/humble
 
 go 1.25.1
+
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/spf13/cobra v1.10.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.9 // indirect
+)
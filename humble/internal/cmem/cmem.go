@@ -0,0 +1,15 @@
+// Package cmem frees C memory for the tests of the runtime, which cannot use
+// cgo themselves.
+package cmem
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// Free frees p, which must have been allocated with malloc.
+func Free(p unsafe.Pointer) {
+	C.free(p)
+}
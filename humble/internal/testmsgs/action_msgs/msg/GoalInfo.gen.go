@@ -0,0 +1,282 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package action_msgs_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	builtin_interfaces_msg "github.com/okieraised/rclgo/humble/internal/testmsgs/builtin_interfaces/msg"
+	unique_identifier_msgs_msg "github.com/okieraised/rclgo/humble/internal/testmsgs/unique_identifier_msgs/msg"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <action_msgs/msg/goal_info.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("action_msgs/GoalInfo", GoalInfoTypeSupport)
+	humble.RegisterMessage("action_msgs/msg/GoalInfo", GoalInfoTypeSupport)
+}
+
+type GoalInfo struct {
+	GoalId unique_identifier_msgs_msg.UUID `yaml:"goal_id"` // Goal ID
+	Stamp  builtin_interfaces_msg.Time     `yaml:"stamp"`   // Time when the goal was accepted
+}
+
+// NewGoalInfo creates a new GoalInfo with default values.
+func NewGoalInfo() *GoalInfo {
+	self := GoalInfo{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *GoalInfo) Clone() *GoalInfo {
+	c := &GoalInfo{}
+	c.GoalId = *t.GoalId.Clone()
+	c.Stamp = *t.Stamp.Clone()
+	return c
+}
+
+func (t *GoalInfo) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *GoalInfo) DeepCopyInto(dst *GoalInfo) {
+	t.GoalId.DeepCopyInto(&dst.GoalId)
+	t.Stamp.DeepCopyInto(&dst.Stamp)
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *GoalInfo) Equal(other *GoalInfo, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *GoalInfo) Diff(other *GoalInfo, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *GoalInfo) DiffWith(d *humble.Differ, other *GoalInfo) {
+	d.Enter("goal_id")
+	t.GoalId.DiffWith(d, &other.GoalId)
+	d.Leave()
+	d.Enter("stamp")
+	t.Stamp.DiffWith(d, &other.Stamp)
+	d.Leave()
+}
+
+func (t *GoalInfo) SetDefaults() {
+	t.GoalId.SetDefaults()
+	t.Stamp.SetDefaults()
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *GoalInfo) Validate() error {
+	if err := t.GoalId.Validate(); err != nil {
+		return humble.ValidateField("goal_id", err)
+	}
+	if err := t.Stamp.Validate(); err != nil {
+		return humble.ValidateField("stamp", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *GoalInfo) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *GoalInfo) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *GoalInfo) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *GoalInfo) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *GoalInfo) GetTypeSupport() humble.MessageTypeSupport {
+	return GoalInfoTypeSupport
+}
+
+// GoalInfoPublisher wraps humble.Publisher to provide type safe helper
+// functions
+type GoalInfoPublisher struct {
+	*humble.Publisher
+}
+
+// NewGoalInfoPublisher creates and returns a new publisher for the
+// GoalInfo
+func NewGoalInfoPublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*GoalInfoPublisher, error) {
+	pub, err := node.NewPublisher(topicName, GoalInfoTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &GoalInfoPublisher{pub}, nil
+}
+
+func (p *GoalInfoPublisher) Publish(msg *GoalInfo) error {
+	return p.Publisher.Publish(msg)
+}
+
+// GoalInfoSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type GoalInfoSubscription struct {
+	*humble.Subscription
+}
+
+// GoalInfoSubscriptionCallback type is used to provide a subscription
+// handler function for a GoalInfoSubscription.
+type GoalInfoSubscriptionCallback func(msg *GoalInfo, info *humble.MessageInfo, err error)
+
+// NewGoalInfoSubscription creates and returns a new subscription for the
+// GoalInfo
+func NewGoalInfoSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback GoalInfoSubscriptionCallback) (*GoalInfoSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg GoalInfo
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, GoalInfoTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &GoalInfoSubscription{sub}, nil
+}
+
+func (s *GoalInfoSubscription) TakeMessage(out *GoalInfo) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneGoalInfoSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneGoalInfoSlice(dst, src []GoalInfo) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var GoalInfoTypeSupport humble.DescribedMessageTypeSupport = _GoalInfoTypeSupport{}
+
+type _GoalInfoTypeSupport struct{}
+
+func (t _GoalInfoTypeSupport) New() humble.Message {
+	return NewGoalInfo()
+}
+
+func (t _GoalInfoTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.action_msgs__msg__GoalInfo
+	return (unsafe.Pointer)(C.action_msgs__msg__GoalInfo__create())
+}
+
+func (t _GoalInfoTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.action_msgs__msg__GoalInfo__destroy((*C.action_msgs__msg__GoalInfo)(pointer_to_free))
+}
+
+func (t _GoalInfoTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.action_msgs__msg__GoalInfo__fini((*C.action_msgs__msg__GoalInfo)(pointer_to_reset))
+}
+
+func (t _GoalInfoTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*GoalInfo)
+	mem := (*C.action_msgs__msg__GoalInfo)(dst)
+	unique_identifier_msgs_msg.UUIDTypeSupport.AsCStruct(unsafe.Pointer(&mem.goal_id), &m.GoalId)
+	builtin_interfaces_msg.TimeTypeSupport.AsCStruct(unsafe.Pointer(&mem.stamp), &m.Stamp)
+}
+
+func (t _GoalInfoTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*GoalInfo)
+	mem := (*C.action_msgs__msg__GoalInfo)(ros2_message_buffer)
+	unique_identifier_msgs_msg.UUIDTypeSupport.AsGoStruct(&m.GoalId, unsafe.Pointer(&mem.goal_id))
+	builtin_interfaces_msg.TimeTypeSupport.AsGoStruct(&m.Stamp, unsafe.Pointer(&mem.stamp))
+}
+
+func (t _GoalInfoTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__action_msgs__msg__GoalInfo())
+}
+
+func (t _GoalInfoTypeSupport) TypeName() string {
+	return "action_msgs/msg/GoalInfo"
+}
+
+func (t _GoalInfoTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "action_msgs/msg/GoalInfo",
+		Fields: []humble.FieldDescription{
+			{Name: "goal_id", Type: humble.FieldType{TypeID: 1, NestedTypeName: "unique_identifier_msgs/msg/UUID"}},
+			{Name: "stamp", Type: humble.FieldType{TypeID: 1, NestedTypeName: "builtin_interfaces/msg/Time"}},
+		},
+	}
+}
+
+func (t _GoalInfoTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{
+		unique_identifier_msgs_msg.UUIDTypeSupport,
+		builtin_interfaces_msg.TimeTypeSupport,
+	}
+}
+
+func (t _GoalInfoTypeSupport) Source() string {
+	return "# Goal ID\nunique_identifier_msgs/UUID goal_id\n\n# Time when the goal was accepted\nbuiltin_interfaces/Time stamp\n"
+}
+
+func (t _GoalInfoTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _GoalInfoTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CGoalInfo = C.action_msgs__msg__GoalInfo
+type CGoalInfoSequence = C.action_msgs__msg__GoalInfo__Sequence
+
+func GoalInfoSequenceToGo(goSlice *[]GoalInfo, cSlice CGoalInfoSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CGoalInfo])(unsafe.Pointer(&cSlice)), _GoalInfoToGo)
+}
+
+func GoalInfoSequenceToC(cSlice *CGoalInfoSequence, goSlice []GoalInfo) {
+	humble.SequenceToC((*humble.CSequence[CGoalInfo])(unsafe.Pointer(cSlice)), goSlice, _GoalInfoToC)
+}
+
+func GoalInfoArrayToGo(goSlice []GoalInfo, cSlice []CGoalInfo) {
+	humble.ArrayToGo(goSlice, cSlice, _GoalInfoToGo)
+}
+
+func GoalInfoArrayToC(cSlice []CGoalInfo, goSlice []GoalInfo) {
+	humble.ArrayToC(cSlice, goSlice, _GoalInfoToC)
+}
+
+func _GoalInfoToGo(dst *GoalInfo, src *CGoalInfo) {
+	GoalInfoTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _GoalInfoToC(dst *CGoalInfo, src *GoalInfo) {
+	GoalInfoTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,331 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package action_msgs_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+	"strconv"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <action_msgs/msg/goal_status.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("action_msgs/GoalStatus", GoalStatusTypeSupport)
+	humble.RegisterMessage("action_msgs/msg/GoalStatus", GoalStatusTypeSupport)
+}
+
+const (
+	GoalStatus_STATUS_UNKNOWN   GoalStatus_Status = 0 // Indicates status has not been properly set.
+	GoalStatus_STATUS_ACCEPTED  GoalStatus_Status = 1 // The goal has been accepted and is awaiting execution.
+	GoalStatus_STATUS_EXECUTING GoalStatus_Status = 2 // The goal is currently being executed by the action server.
+	GoalStatus_STATUS_CANCELING GoalStatus_Status = 3 // The client has requested that the goal be canceled and the action server has accepted the cancel request.
+	GoalStatus_STATUS_SUCCEEDED GoalStatus_Status = 4 // The goal was achieved successfully by the action server.
+	GoalStatus_STATUS_CANCELED  GoalStatus_Status = 5 // The goal was canceled after an external request from an action client.
+	GoalStatus_STATUS_ABORTED   GoalStatus_Status = 6 // The goal was terminated by the action server without an external request.
+)
+
+// GoalStatus_Status is the type of the STATUS_* constants of GoalStatus.
+type GoalStatus_Status int8
+
+// String returns the name of the constant e, or the value of e if it is not
+// one of the constants.
+func (e GoalStatus_Status) String() string {
+	switch e {
+	case GoalStatus_Status(GoalStatus_STATUS_UNKNOWN):
+		return "STATUS_UNKNOWN"
+	case GoalStatus_Status(GoalStatus_STATUS_ACCEPTED):
+		return "STATUS_ACCEPTED"
+	case GoalStatus_Status(GoalStatus_STATUS_EXECUTING):
+		return "STATUS_EXECUTING"
+	case GoalStatus_Status(GoalStatus_STATUS_CANCELING):
+		return "STATUS_CANCELING"
+	case GoalStatus_Status(GoalStatus_STATUS_SUCCEEDED):
+		return "STATUS_SUCCEEDED"
+	case GoalStatus_Status(GoalStatus_STATUS_CANCELED):
+		return "STATUS_CANCELED"
+	case GoalStatus_Status(GoalStatus_STATUS_ABORTED):
+		return "STATUS_ABORTED"
+	}
+	return "GoalStatus_Status(" + strconv.FormatInt(int64(e), 10) + ")"
+}
+
+// IsValid reports whether e is one of the STATUS_* constants.
+func (e GoalStatus_Status) IsValid() bool {
+	switch e {
+	case GoalStatus_Status(GoalStatus_STATUS_UNKNOWN), GoalStatus_Status(GoalStatus_STATUS_ACCEPTED), GoalStatus_Status(GoalStatus_STATUS_EXECUTING), GoalStatus_Status(GoalStatus_STATUS_CANCELING), GoalStatus_Status(GoalStatus_STATUS_SUCCEEDED), GoalStatus_Status(GoalStatus_STATUS_CANCELED), GoalStatus_Status(GoalStatus_STATUS_ABORTED):
+		return true
+	}
+	return false
+}
+
+// Values returns the distinct values of the STATUS_* constants.
+func (GoalStatus_Status) Values() []GoalStatus_Status {
+	return []GoalStatus_Status{GoalStatus_Status(GoalStatus_STATUS_UNKNOWN), GoalStatus_Status(GoalStatus_STATUS_ACCEPTED), GoalStatus_Status(GoalStatus_STATUS_EXECUTING), GoalStatus_Status(GoalStatus_STATUS_CANCELING), GoalStatus_Status(GoalStatus_STATUS_SUCCEEDED), GoalStatus_Status(GoalStatus_STATUS_CANCELED), GoalStatus_Status(GoalStatus_STATUS_ABORTED)}
+}
+
+type GoalStatus struct {
+	GoalInfo GoalInfo          `yaml:"goal_info"` // Goal info (contains ID and timestamp).
+	Status   GoalStatus_Status `yaml:"status"`    // Action goal state-machine status.
+}
+
+// NewGoalStatus creates a new GoalStatus with default values.
+func NewGoalStatus() *GoalStatus {
+	self := GoalStatus{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *GoalStatus) Clone() *GoalStatus {
+	c := &GoalStatus{}
+	c.GoalInfo = *t.GoalInfo.Clone()
+	c.Status = t.Status
+	return c
+}
+
+func (t *GoalStatus) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *GoalStatus) DeepCopyInto(dst *GoalStatus) {
+	t.GoalInfo.DeepCopyInto(&dst.GoalInfo)
+	dst.Status = t.Status
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *GoalStatus) Equal(other *GoalStatus, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *GoalStatus) Diff(other *GoalStatus, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *GoalStatus) DiffWith(d *humble.Differ, other *GoalStatus) {
+	d.Enter("goal_info")
+	t.GoalInfo.DiffWith(d, &other.GoalInfo)
+	d.Leave()
+	humble.DiffValue(d, "status", t.Status, other.Status)
+}
+
+func (t *GoalStatus) SetDefaults() {
+	t.GoalInfo.SetDefaults()
+	t.Status = 0
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *GoalStatus) Validate() error {
+	if err := t.GoalInfo.Validate(); err != nil {
+		return humble.ValidateField("goal_info", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *GoalStatus) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *GoalStatus) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *GoalStatus) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *GoalStatus) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *GoalStatus) GetTypeSupport() humble.MessageTypeSupport {
+	return GoalStatusTypeSupport
+}
+func (t *GoalStatus) GetGoalID() *humble.GoalID {
+	return (*humble.GoalID)(&t.GoalInfo.GoalId.Uuid)
+}
+
+func (t *GoalStatus) SetGoalID(id *humble.GoalID) {
+	t.GoalInfo.GoalId.Uuid = *id
+}
+
+// GoalStatusPublisher wraps humble.Publisher to provide type safe helper
+// functions
+type GoalStatusPublisher struct {
+	*humble.Publisher
+}
+
+// NewGoalStatusPublisher creates and returns a new publisher for the
+// GoalStatus
+func NewGoalStatusPublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*GoalStatusPublisher, error) {
+	pub, err := node.NewPublisher(topicName, GoalStatusTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &GoalStatusPublisher{pub}, nil
+}
+
+func (p *GoalStatusPublisher) Publish(msg *GoalStatus) error {
+	return p.Publisher.Publish(msg)
+}
+
+// GoalStatusSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type GoalStatusSubscription struct {
+	*humble.Subscription
+}
+
+// GoalStatusSubscriptionCallback type is used to provide a subscription
+// handler function for a GoalStatusSubscription.
+type GoalStatusSubscriptionCallback func(msg *GoalStatus, info *humble.MessageInfo, err error)
+
+// NewGoalStatusSubscription creates and returns a new subscription for the
+// GoalStatus
+func NewGoalStatusSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback GoalStatusSubscriptionCallback) (*GoalStatusSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg GoalStatus
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, GoalStatusTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &GoalStatusSubscription{sub}, nil
+}
+
+func (s *GoalStatusSubscription) TakeMessage(out *GoalStatus) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneGoalStatusSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneGoalStatusSlice(dst, src []GoalStatus) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var GoalStatusTypeSupport humble.DescribedMessageTypeSupport = _GoalStatusTypeSupport{}
+
+type _GoalStatusTypeSupport struct{}
+
+func (t _GoalStatusTypeSupport) New() humble.Message {
+	return NewGoalStatus()
+}
+
+func (t _GoalStatusTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.action_msgs__msg__GoalStatus
+	return (unsafe.Pointer)(C.action_msgs__msg__GoalStatus__create())
+}
+
+func (t _GoalStatusTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.action_msgs__msg__GoalStatus__destroy((*C.action_msgs__msg__GoalStatus)(pointer_to_free))
+}
+
+func (t _GoalStatusTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.action_msgs__msg__GoalStatus__fini((*C.action_msgs__msg__GoalStatus)(pointer_to_reset))
+}
+
+func (t _GoalStatusTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*GoalStatus)
+	mem := (*C.action_msgs__msg__GoalStatus)(dst)
+	GoalInfoTypeSupport.AsCStruct(unsafe.Pointer(&mem.goal_info), &m.GoalInfo)
+	mem.status = C.int8_t(m.Status)
+}
+
+func (t _GoalStatusTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*GoalStatus)
+	mem := (*C.action_msgs__msg__GoalStatus)(ros2_message_buffer)
+	GoalInfoTypeSupport.AsGoStruct(&m.GoalInfo, unsafe.Pointer(&mem.goal_info))
+	m.Status = GoalStatus_Status(mem.status)
+}
+
+func (t _GoalStatusTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__action_msgs__msg__GoalStatus())
+}
+
+func (t _GoalStatusTypeSupport) TypeName() string {
+	return "action_msgs/msg/GoalStatus"
+}
+
+func (t _GoalStatusTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "action_msgs/msg/GoalStatus",
+		Fields: []humble.FieldDescription{
+			{Name: "goal_info", Type: humble.FieldType{TypeID: 1, NestedTypeName: "action_msgs/msg/GoalInfo"}},
+			{Name: "status", Type: humble.FieldType{TypeID: 2}},
+		},
+	}
+}
+
+func (t _GoalStatusTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{
+		GoalInfoTypeSupport,
+	}
+}
+
+func (t _GoalStatusTypeSupport) Source() string {
+	return "# An action goal can be in one of these states after it is accepted by an action\n# server.\n#\n# For more information, see http://design.ros2.org/articles/actions.html\n\n# Indicates status has not been properly set.\nint8 STATUS_UNKNOWN   = 0\n\n# The goal has been accepted and is awaiting execution.\nint8 STATUS_ACCEPTED  = 1\n\n# The goal is currently being executed by the action server.\nint8 STATUS_EXECUTING = 2\n\n# The client has requested that the goal be canceled and the action server has\n# accepted the cancel request.\nint8 STATUS_CANCELING = 3\n\n# The goal was achieved successfully by the action server.\nint8 STATUS_SUCCEEDED = 4\n\n# The goal was canceled after an external request from an action client.\nint8 STATUS_CANCELED  = 5\n\n# The goal was terminated by the action server without an external request.\nint8 STATUS_ABORTED   = 6\n\n# Goal info (contains ID and timestamp).\nGoalInfo goal_info\n\n# Action goal state-machine status.\nint8 status\n"
+}
+
+func (t _GoalStatusTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _GoalStatusTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CGoalStatus = C.action_msgs__msg__GoalStatus
+type CGoalStatusSequence = C.action_msgs__msg__GoalStatus__Sequence
+
+func GoalStatusSequenceToGo(goSlice *[]GoalStatus, cSlice CGoalStatusSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CGoalStatus])(unsafe.Pointer(&cSlice)), _GoalStatusToGo)
+}
+
+func GoalStatusSequenceToC(cSlice *CGoalStatusSequence, goSlice []GoalStatus) {
+	humble.SequenceToC((*humble.CSequence[CGoalStatus])(unsafe.Pointer(cSlice)), goSlice, _GoalStatusToC)
+}
+
+func GoalStatusArrayToGo(goSlice []GoalStatus, cSlice []CGoalStatus) {
+	humble.ArrayToGo(goSlice, cSlice, _GoalStatusToGo)
+}
+
+func GoalStatusArrayToC(cSlice []CGoalStatus, goSlice []GoalStatus) {
+	humble.ArrayToC(cSlice, goSlice, _GoalStatusToC)
+}
+
+func _GoalStatusToGo(dst *GoalStatus, src *CGoalStatus) {
+	GoalStatusTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _GoalStatusToC(dst *CGoalStatus, src *GoalStatus) {
+	GoalStatusTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
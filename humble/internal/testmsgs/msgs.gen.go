@@ -0,0 +1,13 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package msgs
+
+import (
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/action_msgs/msg"            //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/builtin_interfaces/msg"     //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/rcl_interfaces/msg"         //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/rcl_interfaces/srv"         //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/std_msgs/msg"               //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/std_srvs/srv"               //
+	_ "github.com/okieraised/rclgo/humble/internal/testmsgs/unique_identifier_msgs/msg" //
+)
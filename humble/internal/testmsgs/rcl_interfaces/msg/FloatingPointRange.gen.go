@@ -0,0 +1,275 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/msg/floating_point_range.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("rcl_interfaces/FloatingPointRange", FloatingPointRangeTypeSupport)
+	humble.RegisterMessage("rcl_interfaces/msg/FloatingPointRange", FloatingPointRangeTypeSupport)
+}
+
+type FloatingPointRange struct {
+	FromValue float64 `yaml:"from_value"` // Start value for valid values, inclusive.
+	ToValue   float64 `yaml:"to_value"`   // End value for valid values, inclusive.
+	Step      float64 `yaml:"step"`       // Size of valid steps between the from and to bound. Step is considered to be a magnitude, therefore negative values are treated the same as positive values, and a step value of zero implies a continuous range of values. Ideally, the step would be less than or equal to the distance between the bounds, as well as an even multiple of the distance between the bounds, but neither are required. If the absolute value of the step is larger than or equal to the distance between the two bounds, then the bounds will be the only valid values. e.g. if the range is defined as {from_value: 1.0, to_value: 2.0, step: 5.0} then the valid values will be 1.0 and 2.0. If the step is less than the distance between the bounds, but the distance is not a multiple of the step, then the "to" bound will always be a valid value, e.g. if the range is defined as {from_value: 2.0, to_value: 5.0, step: 2.0} then the valid values will be 2.0, 4.0, and 5.0.
+}
+
+// NewFloatingPointRange creates a new FloatingPointRange with default values.
+func NewFloatingPointRange() *FloatingPointRange {
+	self := FloatingPointRange{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *FloatingPointRange) Clone() *FloatingPointRange {
+	c := &FloatingPointRange{}
+	c.FromValue = t.FromValue
+	c.ToValue = t.ToValue
+	c.Step = t.Step
+	return c
+}
+
+func (t *FloatingPointRange) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *FloatingPointRange) DeepCopyInto(dst *FloatingPointRange) {
+	dst.FromValue = t.FromValue
+	dst.ToValue = t.ToValue
+	dst.Step = t.Step
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *FloatingPointRange) Equal(other *FloatingPointRange, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *FloatingPointRange) Diff(other *FloatingPointRange, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *FloatingPointRange) DiffWith(d *humble.Differ, other *FloatingPointRange) {
+	humble.DiffFloat(d, "from_value", t.FromValue, other.FromValue)
+	humble.DiffFloat(d, "to_value", t.ToValue, other.ToValue)
+	humble.DiffFloat(d, "step", t.Step, other.Step)
+}
+
+func (t *FloatingPointRange) SetDefaults() {
+	t.FromValue = 0
+	t.ToValue = 0
+	t.Step = 0
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *FloatingPointRange) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *FloatingPointRange) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *FloatingPointRange) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *FloatingPointRange) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *FloatingPointRange) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *FloatingPointRange) GetTypeSupport() humble.MessageTypeSupport {
+	return FloatingPointRangeTypeSupport
+}
+
+// FloatingPointRangePublisher wraps humble.Publisher to provide type safe helper
+// functions
+type FloatingPointRangePublisher struct {
+	*humble.Publisher
+}
+
+// NewFloatingPointRangePublisher creates and returns a new publisher for the
+// FloatingPointRange
+func NewFloatingPointRangePublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*FloatingPointRangePublisher, error) {
+	pub, err := node.NewPublisher(topicName, FloatingPointRangeTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &FloatingPointRangePublisher{pub}, nil
+}
+
+func (p *FloatingPointRangePublisher) Publish(msg *FloatingPointRange) error {
+	return p.Publisher.Publish(msg)
+}
+
+// FloatingPointRangeSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type FloatingPointRangeSubscription struct {
+	*humble.Subscription
+}
+
+// FloatingPointRangeSubscriptionCallback type is used to provide a subscription
+// handler function for a FloatingPointRangeSubscription.
+type FloatingPointRangeSubscriptionCallback func(msg *FloatingPointRange, info *humble.MessageInfo, err error)
+
+// NewFloatingPointRangeSubscription creates and returns a new subscription for the
+// FloatingPointRange
+func NewFloatingPointRangeSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback FloatingPointRangeSubscriptionCallback) (*FloatingPointRangeSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg FloatingPointRange
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, FloatingPointRangeTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &FloatingPointRangeSubscription{sub}, nil
+}
+
+func (s *FloatingPointRangeSubscription) TakeMessage(out *FloatingPointRange) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneFloatingPointRangeSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneFloatingPointRangeSlice(dst, src []FloatingPointRange) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var FloatingPointRangeTypeSupport humble.DescribedMessageTypeSupport = _FloatingPointRangeTypeSupport{}
+
+type _FloatingPointRangeTypeSupport struct{}
+
+func (t _FloatingPointRangeTypeSupport) New() humble.Message {
+	return NewFloatingPointRange()
+}
+
+func (t _FloatingPointRangeTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__msg__FloatingPointRange
+	return (unsafe.Pointer)(C.rcl_interfaces__msg__FloatingPointRange__create())
+}
+
+func (t _FloatingPointRangeTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__msg__FloatingPointRange__destroy((*C.rcl_interfaces__msg__FloatingPointRange)(pointer_to_free))
+}
+
+func (t _FloatingPointRangeTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__msg__FloatingPointRange__fini((*C.rcl_interfaces__msg__FloatingPointRange)(pointer_to_reset))
+}
+
+func (t _FloatingPointRangeTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*FloatingPointRange)
+	mem := (*C.rcl_interfaces__msg__FloatingPointRange)(dst)
+	mem.from_value = C.double(m.FromValue)
+	mem.to_value = C.double(m.ToValue)
+	mem.step = C.double(m.Step)
+}
+
+func (t _FloatingPointRangeTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*FloatingPointRange)
+	mem := (*C.rcl_interfaces__msg__FloatingPointRange)(ros2_message_buffer)
+	m.FromValue = float64(mem.from_value)
+	m.ToValue = float64(mem.to_value)
+	m.Step = float64(mem.step)
+}
+
+func (t _FloatingPointRangeTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__msg__FloatingPointRange())
+}
+
+func (t _FloatingPointRangeTypeSupport) TypeName() string {
+	return "rcl_interfaces/msg/FloatingPointRange"
+}
+
+func (t _FloatingPointRangeTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "rcl_interfaces/msg/FloatingPointRange",
+		Fields: []humble.FieldDescription{
+			{Name: "from_value", Type: humble.FieldType{TypeID: 11}},
+			{Name: "to_value", Type: humble.FieldType{TypeID: 11}},
+			{Name: "step", Type: humble.FieldType{TypeID: 11}},
+		},
+	}
+}
+
+func (t _FloatingPointRangeTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{}
+}
+
+func (t _FloatingPointRangeTypeSupport) Source() string {
+	return "# Represents bounds and a step value for a floating point typed parameter.\n\n# Start value for valid values, inclusive.\nfloat64 from_value\n\n# End value for valid values, inclusive.\nfloat64 to_value\n\n# Size of valid steps between the from and to bound.\n#\n# Step is considered to be a magnitude, therefore negative values are treated\n# the same as positive values, and a step value of zero implies a continuous\n# range of values.\n#\n# Ideally, the step would be less than or equal to the distance between the\n# bounds, as well as an even multiple of the distance between the bounds, but\n# neither are required.\n#\n# If the absolute value of the step is larger than or equal to the distance\n# between the two bounds, then the bounds will be the only valid values. e.g. if\n# the range is defined as {from_value: 1.0, to_value: 2.0, step: 5.0} then the\n# valid values will be 1.0 and 2.0.\n#\n# If the step is less than the distance between the bounds, but the distance is\n# not a multiple of the step, then the \"to\" bound will always be a valid value,\n# e.g. if the range is defined as {from_value: 2.0, to_value: 5.0, step: 2.0}\n# then the valid values will be 2.0, 4.0, and 5.0.\nfloat64 step\n"
+}
+
+func (t _FloatingPointRangeTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _FloatingPointRangeTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CFloatingPointRange = C.rcl_interfaces__msg__FloatingPointRange
+type CFloatingPointRangeSequence = C.rcl_interfaces__msg__FloatingPointRange__Sequence
+
+func FloatingPointRangeSequenceToGo(goSlice *[]FloatingPointRange, cSlice CFloatingPointRangeSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CFloatingPointRange])(unsafe.Pointer(&cSlice)), _FloatingPointRangeToGo)
+}
+
+func FloatingPointRangeSequenceToC(cSlice *CFloatingPointRangeSequence, goSlice []FloatingPointRange) {
+	humble.SequenceToC((*humble.CSequence[CFloatingPointRange])(unsafe.Pointer(cSlice)), goSlice, _FloatingPointRangeToC)
+}
+
+func FloatingPointRangeArrayToGo(goSlice []FloatingPointRange, cSlice []CFloatingPointRange) {
+	humble.ArrayToGo(goSlice, cSlice, _FloatingPointRangeToGo)
+}
+
+func FloatingPointRangeArrayToC(cSlice []CFloatingPointRange, goSlice []FloatingPointRange) {
+	humble.ArrayToC(cSlice, goSlice, _FloatingPointRangeToC)
+}
+
+func _FloatingPointRangeToGo(dst *FloatingPointRange, src *CFloatingPointRange) {
+	FloatingPointRangeTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _FloatingPointRangeToC(dst *CFloatingPointRange, src *FloatingPointRange) {
+	FloatingPointRangeTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,364 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/msg/parameter_descriptor.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("rcl_interfaces/ParameterDescriptor", ParameterDescriptorTypeSupport)
+	humble.RegisterMessage("rcl_interfaces/msg/ParameterDescriptor", ParameterDescriptorTypeSupport)
+}
+
+type ParameterDescriptor struct {
+	Name                  string               `yaml:"name"`                   // The name of the parameter.
+	Type                  uint8                `yaml:"type"`                   // Enum values are defined in the `ParameterType.msg` message.
+	Description           string               `yaml:"description"`            // Description of the parameter, visible from introspection tools.
+	AdditionalConstraints string               `yaml:"additional_constraints"` // Plain English description of additional constraints which cannot be expressed with the available constraints, e.g. "only prime numbers". By convention, this should only be used to clarify constraints which cannot be completely expressed with the parameter constraints below.
+	ReadOnly              bool                 `yaml:"read_only"`              // If 'true' then the value cannot change after it has been initialized.
+	DynamicTyping         bool                 `yaml:"dynamic_typing"`         // If true, the parameter is allowed to change type.
+	FloatingPointRange    []FloatingPointRange `yaml:"floating_point_range"`   // FloatingPointRange consists of a from_value, a to_value, and a step.
+	IntegerRange          []IntegerRange       `yaml:"integer_range"`          // IntegerRange consists of a from_value, a to_value, and a step.
+}
+
+// NewParameterDescriptor creates a new ParameterDescriptor with default values.
+func NewParameterDescriptor() *ParameterDescriptor {
+	self := ParameterDescriptor{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *ParameterDescriptor) Clone() *ParameterDescriptor {
+	c := &ParameterDescriptor{}
+	c.Name = t.Name
+	c.Type = t.Type
+	c.Description = t.Description
+	c.AdditionalConstraints = t.AdditionalConstraints
+	c.ReadOnly = t.ReadOnly
+	c.DynamicTyping = t.DynamicTyping
+	if t.FloatingPointRange != nil {
+		c.FloatingPointRange = make([]FloatingPointRange, len(t.FloatingPointRange))
+		CloneFloatingPointRangeSlice(c.FloatingPointRange, t.FloatingPointRange)
+	}
+	if t.IntegerRange != nil {
+		c.IntegerRange = make([]IntegerRange, len(t.IntegerRange))
+		CloneIntegerRangeSlice(c.IntegerRange, t.IntegerRange)
+	}
+	return c
+}
+
+func (t *ParameterDescriptor) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *ParameterDescriptor) DeepCopyInto(dst *ParameterDescriptor) {
+	dst.Name = t.Name
+	dst.Type = t.Type
+	dst.Description = t.Description
+	dst.AdditionalConstraints = t.AdditionalConstraints
+	dst.ReadOnly = t.ReadOnly
+	dst.DynamicTyping = t.DynamicTyping
+	if cap(dst.FloatingPointRange) < len(t.FloatingPointRange) {
+		dst.FloatingPointRange = make([]FloatingPointRange, len(t.FloatingPointRange))
+	}
+	dst.FloatingPointRange = dst.FloatingPointRange[:len(t.FloatingPointRange)]
+	for i := range t.FloatingPointRange {
+		t.FloatingPointRange[i].DeepCopyInto(&dst.FloatingPointRange[i])
+	}
+	if cap(dst.IntegerRange) < len(t.IntegerRange) {
+		dst.IntegerRange = make([]IntegerRange, len(t.IntegerRange))
+	}
+	dst.IntegerRange = dst.IntegerRange[:len(t.IntegerRange)]
+	for i := range t.IntegerRange {
+		t.IntegerRange[i].DeepCopyInto(&dst.IntegerRange[i])
+	}
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *ParameterDescriptor) Equal(other *ParameterDescriptor, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *ParameterDescriptor) Diff(other *ParameterDescriptor, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *ParameterDescriptor) DiffWith(d *humble.Differ, other *ParameterDescriptor) {
+	humble.DiffValue(d, "name", t.Name, other.Name)
+	humble.DiffValue(d, "type", t.Type, other.Type)
+	humble.DiffValue(d, "description", t.Description, other.Description)
+	humble.DiffValue(d, "additional_constraints", t.AdditionalConstraints, other.AdditionalConstraints)
+	humble.DiffValue(d, "read_only", t.ReadOnly, other.ReadOnly)
+	humble.DiffValue(d, "dynamic_typing", t.DynamicTyping, other.DynamicTyping)
+	if humble.DiffLen(d, "floating_point_range", t.FloatingPointRange, other.FloatingPointRange) {
+		for i := range t.FloatingPointRange {
+			d.EnterIndex("floating_point_range", i)
+			t.FloatingPointRange[i].DiffWith(d, &other.FloatingPointRange[i])
+			d.Leave()
+		}
+	}
+	if humble.DiffLen(d, "integer_range", t.IntegerRange, other.IntegerRange) {
+		for i := range t.IntegerRange {
+			d.EnterIndex("integer_range", i)
+			t.IntegerRange[i].DiffWith(d, &other.IntegerRange[i])
+			d.Leave()
+		}
+	}
+}
+
+func (t *ParameterDescriptor) SetDefaults() {
+	t.Name = ""
+	t.Type = 0
+	t.Description = ""
+	t.AdditionalConstraints = ""
+	t.ReadOnly = false
+	t.DynamicTyping = false
+	t.FloatingPointRange = nil
+	t.IntegerRange = nil
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *ParameterDescriptor) Validate() error {
+	if err := humble.ValidateSequenceBound("floating_point_range", len(t.FloatingPointRange), 1); err != nil {
+		return err
+	}
+	for i := range t.FloatingPointRange {
+		if err := t.FloatingPointRange[i].Validate(); err != nil {
+			return humble.ValidateField(humble.FieldIndex("floating_point_range", i), err)
+		}
+	}
+	if err := humble.ValidateSequenceBound("integer_range", len(t.IntegerRange), 1); err != nil {
+		return err
+	}
+	for i := range t.IntegerRange {
+		if err := t.IntegerRange[i].Validate(); err != nil {
+			return humble.ValidateField(humble.FieldIndex("integer_range", i), err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *ParameterDescriptor) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *ParameterDescriptor) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *ParameterDescriptor) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *ParameterDescriptor) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *ParameterDescriptor) GetTypeSupport() humble.MessageTypeSupport {
+	return ParameterDescriptorTypeSupport
+}
+
+// ParameterDescriptorPublisher wraps humble.Publisher to provide type safe helper
+// functions
+type ParameterDescriptorPublisher struct {
+	*humble.Publisher
+}
+
+// NewParameterDescriptorPublisher creates and returns a new publisher for the
+// ParameterDescriptor
+func NewParameterDescriptorPublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*ParameterDescriptorPublisher, error) {
+	pub, err := node.NewPublisher(topicName, ParameterDescriptorTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterDescriptorPublisher{pub}, nil
+}
+
+func (p *ParameterDescriptorPublisher) Publish(msg *ParameterDescriptor) error {
+	return p.Publisher.Publish(msg)
+}
+
+// ParameterDescriptorSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type ParameterDescriptorSubscription struct {
+	*humble.Subscription
+}
+
+// ParameterDescriptorSubscriptionCallback type is used to provide a subscription
+// handler function for a ParameterDescriptorSubscription.
+type ParameterDescriptorSubscriptionCallback func(msg *ParameterDescriptor, info *humble.MessageInfo, err error)
+
+// NewParameterDescriptorSubscription creates and returns a new subscription for the
+// ParameterDescriptor
+func NewParameterDescriptorSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback ParameterDescriptorSubscriptionCallback) (*ParameterDescriptorSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg ParameterDescriptor
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, ParameterDescriptorTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterDescriptorSubscription{sub}, nil
+}
+
+func (s *ParameterDescriptorSubscription) TakeMessage(out *ParameterDescriptor) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneParameterDescriptorSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneParameterDescriptorSlice(dst, src []ParameterDescriptor) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var ParameterDescriptorTypeSupport humble.DescribedMessageTypeSupport = _ParameterDescriptorTypeSupport{}
+
+type _ParameterDescriptorTypeSupport struct{}
+
+func (t _ParameterDescriptorTypeSupport) New() humble.Message {
+	return NewParameterDescriptor()
+}
+
+func (t _ParameterDescriptorTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__msg__ParameterDescriptor
+	return (unsafe.Pointer)(C.rcl_interfaces__msg__ParameterDescriptor__create())
+}
+
+func (t _ParameterDescriptorTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterDescriptor__destroy((*C.rcl_interfaces__msg__ParameterDescriptor)(pointer_to_free))
+}
+
+func (t _ParameterDescriptorTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterDescriptor__fini((*C.rcl_interfaces__msg__ParameterDescriptor)(pointer_to_reset))
+}
+
+func (t _ParameterDescriptorTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*ParameterDescriptor)
+	mem := (*C.rcl_interfaces__msg__ParameterDescriptor)(dst)
+	humble.StringAsCStruct(unsafe.Pointer(&mem.name), m.Name)
+	mem._type = C.uint8_t(m.Type)
+	humble.StringAsCStruct(unsafe.Pointer(&mem.description), m.Description)
+	humble.StringAsCStruct(unsafe.Pointer(&mem.additional_constraints), m.AdditionalConstraints)
+	mem.read_only = C.bool(m.ReadOnly)
+	mem.dynamic_typing = C.bool(m.DynamicTyping)
+	FloatingPointRangeSequenceToC(&mem.floating_point_range, m.FloatingPointRange)
+	IntegerRangeSequenceToC(&mem.integer_range, m.IntegerRange)
+}
+
+func (t _ParameterDescriptorTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*ParameterDescriptor)
+	mem := (*C.rcl_interfaces__msg__ParameterDescriptor)(ros2_message_buffer)
+	humble.StringAsGoStruct(&m.Name, unsafe.Pointer(&mem.name))
+	m.Type = uint8(mem._type)
+	humble.StringAsGoStruct(&m.Description, unsafe.Pointer(&mem.description))
+	humble.StringAsGoStruct(&m.AdditionalConstraints, unsafe.Pointer(&mem.additional_constraints))
+	m.ReadOnly = bool(mem.read_only)
+	m.DynamicTyping = bool(mem.dynamic_typing)
+	FloatingPointRangeSequenceToGo(&m.FloatingPointRange, mem.floating_point_range)
+	IntegerRangeSequenceToGo(&m.IntegerRange, mem.integer_range)
+}
+
+func (t _ParameterDescriptorTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__msg__ParameterDescriptor())
+}
+
+func (t _ParameterDescriptorTypeSupport) TypeName() string {
+	return "rcl_interfaces/msg/ParameterDescriptor"
+}
+
+func (t _ParameterDescriptorTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "rcl_interfaces/msg/ParameterDescriptor",
+		Fields: []humble.FieldDescription{
+			{Name: "name", Type: humble.FieldType{TypeID: 17}},
+			{Name: "type", Type: humble.FieldType{TypeID: 3}},
+			{Name: "description", Type: humble.FieldType{TypeID: 17}},
+			{Name: "additional_constraints", Type: humble.FieldType{TypeID: 17}},
+			{Name: "read_only", Type: humble.FieldType{TypeID: 15}, DefaultValue: "false"},
+			{Name: "dynamic_typing", Type: humble.FieldType{TypeID: 15}, DefaultValue: "false"},
+			{Name: "floating_point_range", Type: humble.FieldType{TypeID: 97, Capacity: 1, NestedTypeName: "rcl_interfaces/msg/FloatingPointRange"}},
+			{Name: "integer_range", Type: humble.FieldType{TypeID: 97, Capacity: 1, NestedTypeName: "rcl_interfaces/msg/IntegerRange"}},
+		},
+	}
+}
+
+func (t _ParameterDescriptorTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{
+		FloatingPointRangeTypeSupport,
+		IntegerRangeTypeSupport,
+	}
+}
+
+func (t _ParameterDescriptorTypeSupport) Source() string {
+	return "# This is the message to communicate a parameter's descriptor.\n\n# The name of the parameter.\nstring name\n\n# Enum values are defined in the `ParameterType.msg` message.\nuint8 type\n\n# Description of the parameter, visible from introspection tools.\nstring description\n\n# Parameter constraints\n\n# Plain English description of additional constraints which cannot be expressed\n# with the available constraints, e.g. \"only prime numbers\".\n#\n# By convention, this should only be used to clarify constraints which cannot\n# be completely expressed with the parameter constraints below.\nstring additional_constraints\n\n# If 'true' then the value cannot change after it has been initialized.\nbool read_only false\n\n# If true, the parameter is allowed to change type.\nbool dynamic_typing false\n\n# If any of the following sequences are not empty, then the constraint inside of\n# them apply to this parameter.\n#\n# FloatingPointRange and IntegerRange are mutually exclusive.\n\n# FloatingPointRange consists of a from_value, a to_value, and a step.\nFloatingPointRange[<=1] floating_point_range\n\n# IntegerRange consists of a from_value, a to_value, and a step.\nIntegerRange[<=1] integer_range\n"
+}
+
+func (t _ParameterDescriptorTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _ParameterDescriptorTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CParameterDescriptor = C.rcl_interfaces__msg__ParameterDescriptor
+type CParameterDescriptorSequence = C.rcl_interfaces__msg__ParameterDescriptor__Sequence
+
+func ParameterDescriptorSequenceToGo(goSlice *[]ParameterDescriptor, cSlice CParameterDescriptorSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CParameterDescriptor])(unsafe.Pointer(&cSlice)), _ParameterDescriptorToGo)
+}
+
+func ParameterDescriptorSequenceToC(cSlice *CParameterDescriptorSequence, goSlice []ParameterDescriptor) {
+	humble.SequenceToC((*humble.CSequence[CParameterDescriptor])(unsafe.Pointer(cSlice)), goSlice, _ParameterDescriptorToC)
+}
+
+func ParameterDescriptorArrayToGo(goSlice []ParameterDescriptor, cSlice []CParameterDescriptor) {
+	humble.ArrayToGo(goSlice, cSlice, _ParameterDescriptorToGo)
+}
+
+func ParameterDescriptorArrayToC(cSlice []CParameterDescriptor, goSlice []ParameterDescriptor) {
+	humble.ArrayToC(cSlice, goSlice, _ParameterDescriptorToC)
+}
+
+func _ParameterDescriptorToGo(dst *ParameterDescriptor, src *CParameterDescriptor) {
+	ParameterDescriptorTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _ParameterDescriptorToC(dst *CParameterDescriptor, src *ParameterDescriptor) {
+	ParameterDescriptorTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,309 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+	"strconv"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/msg/parameter_type.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("rcl_interfaces/ParameterType", ParameterTypeTypeSupport)
+	humble.RegisterMessage("rcl_interfaces/msg/ParameterType", ParameterTypeTypeSupport)
+}
+
+const (
+	ParameterType_PARAMETER_NOT_SET       uint8 = 0 // Default value, which implies this is not a valid parameter.
+	ParameterType_PARAMETER_BOOL          uint8 = 1
+	ParameterType_PARAMETER_INTEGER       uint8 = 2
+	ParameterType_PARAMETER_DOUBLE        uint8 = 3
+	ParameterType_PARAMETER_STRING        uint8 = 4
+	ParameterType_PARAMETER_BYTE_ARRAY    uint8 = 5
+	ParameterType_PARAMETER_BOOL_ARRAY    uint8 = 6
+	ParameterType_PARAMETER_INTEGER_ARRAY uint8 = 7
+	ParameterType_PARAMETER_DOUBLE_ARRAY  uint8 = 8
+	ParameterType_PARAMETER_STRING_ARRAY  uint8 = 9
+)
+
+// ParameterType_Parameter is the type of the PARAMETER_* constants of ParameterType.
+type ParameterType_Parameter uint8
+
+// String returns the name of the constant e, or the value of e if it is not
+// one of the constants.
+func (e ParameterType_Parameter) String() string {
+	switch e {
+	case ParameterType_Parameter(ParameterType_PARAMETER_NOT_SET):
+		return "PARAMETER_NOT_SET"
+	case ParameterType_Parameter(ParameterType_PARAMETER_BOOL):
+		return "PARAMETER_BOOL"
+	case ParameterType_Parameter(ParameterType_PARAMETER_INTEGER):
+		return "PARAMETER_INTEGER"
+	case ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE):
+		return "PARAMETER_DOUBLE"
+	case ParameterType_Parameter(ParameterType_PARAMETER_STRING):
+		return "PARAMETER_STRING"
+	case ParameterType_Parameter(ParameterType_PARAMETER_BYTE_ARRAY):
+		return "PARAMETER_BYTE_ARRAY"
+	case ParameterType_Parameter(ParameterType_PARAMETER_BOOL_ARRAY):
+		return "PARAMETER_BOOL_ARRAY"
+	case ParameterType_Parameter(ParameterType_PARAMETER_INTEGER_ARRAY):
+		return "PARAMETER_INTEGER_ARRAY"
+	case ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE_ARRAY):
+		return "PARAMETER_DOUBLE_ARRAY"
+	case ParameterType_Parameter(ParameterType_PARAMETER_STRING_ARRAY):
+		return "PARAMETER_STRING_ARRAY"
+	}
+	return "ParameterType_Parameter(" + strconv.FormatUint(uint64(e), 10) + ")"
+}
+
+// IsValid reports whether e is one of the PARAMETER_* constants.
+func (e ParameterType_Parameter) IsValid() bool {
+	switch e {
+	case ParameterType_Parameter(ParameterType_PARAMETER_NOT_SET), ParameterType_Parameter(ParameterType_PARAMETER_BOOL), ParameterType_Parameter(ParameterType_PARAMETER_INTEGER), ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE), ParameterType_Parameter(ParameterType_PARAMETER_STRING), ParameterType_Parameter(ParameterType_PARAMETER_BYTE_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_BOOL_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_INTEGER_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_STRING_ARRAY):
+		return true
+	}
+	return false
+}
+
+// Values returns the distinct values of the PARAMETER_* constants.
+func (ParameterType_Parameter) Values() []ParameterType_Parameter {
+	return []ParameterType_Parameter{ParameterType_Parameter(ParameterType_PARAMETER_NOT_SET), ParameterType_Parameter(ParameterType_PARAMETER_BOOL), ParameterType_Parameter(ParameterType_PARAMETER_INTEGER), ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE), ParameterType_Parameter(ParameterType_PARAMETER_STRING), ParameterType_Parameter(ParameterType_PARAMETER_BYTE_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_BOOL_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_INTEGER_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_DOUBLE_ARRAY), ParameterType_Parameter(ParameterType_PARAMETER_STRING_ARRAY)}
+}
+
+type ParameterType struct {
+}
+
+// NewParameterType creates a new ParameterType with default values.
+func NewParameterType() *ParameterType {
+	self := ParameterType{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *ParameterType) Clone() *ParameterType {
+	c := &ParameterType{}
+	return c
+}
+
+func (t *ParameterType) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *ParameterType) DeepCopyInto(dst *ParameterType) {
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *ParameterType) Equal(other *ParameterType, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *ParameterType) Diff(other *ParameterType, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *ParameterType) DiffWith(d *humble.Differ, other *ParameterType) {
+}
+
+func (t *ParameterType) SetDefaults() {
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *ParameterType) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *ParameterType) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *ParameterType) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *ParameterType) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *ParameterType) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *ParameterType) GetTypeSupport() humble.MessageTypeSupport {
+	return ParameterTypeTypeSupport
+}
+
+// ParameterTypePublisher wraps humble.Publisher to provide type safe helper
+// functions
+type ParameterTypePublisher struct {
+	*humble.Publisher
+}
+
+// NewParameterTypePublisher creates and returns a new publisher for the
+// ParameterType
+func NewParameterTypePublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*ParameterTypePublisher, error) {
+	pub, err := node.NewPublisher(topicName, ParameterTypeTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterTypePublisher{pub}, nil
+}
+
+func (p *ParameterTypePublisher) Publish(msg *ParameterType) error {
+	return p.Publisher.Publish(msg)
+}
+
+// ParameterTypeSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type ParameterTypeSubscription struct {
+	*humble.Subscription
+}
+
+// ParameterTypeSubscriptionCallback type is used to provide a subscription
+// handler function for a ParameterTypeSubscription.
+type ParameterTypeSubscriptionCallback func(msg *ParameterType, info *humble.MessageInfo, err error)
+
+// NewParameterTypeSubscription creates and returns a new subscription for the
+// ParameterType
+func NewParameterTypeSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback ParameterTypeSubscriptionCallback) (*ParameterTypeSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg ParameterType
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, ParameterTypeTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterTypeSubscription{sub}, nil
+}
+
+func (s *ParameterTypeSubscription) TakeMessage(out *ParameterType) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneParameterTypeSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneParameterTypeSlice(dst, src []ParameterType) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var ParameterTypeTypeSupport humble.DescribedMessageTypeSupport = _ParameterTypeTypeSupport{}
+
+type _ParameterTypeTypeSupport struct{}
+
+func (t _ParameterTypeTypeSupport) New() humble.Message {
+	return NewParameterType()
+}
+
+func (t _ParameterTypeTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__msg__ParameterType
+	return (unsafe.Pointer)(C.rcl_interfaces__msg__ParameterType__create())
+}
+
+func (t _ParameterTypeTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterType__destroy((*C.rcl_interfaces__msg__ParameterType)(pointer_to_free))
+}
+
+func (t _ParameterTypeTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterType__fini((*C.rcl_interfaces__msg__ParameterType)(pointer_to_reset))
+}
+
+func (t _ParameterTypeTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+
+}
+
+func (t _ParameterTypeTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+
+}
+
+func (t _ParameterTypeTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__msg__ParameterType())
+}
+
+func (t _ParameterTypeTypeSupport) TypeName() string {
+	return "rcl_interfaces/msg/ParameterType"
+}
+
+func (t _ParameterTypeTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "rcl_interfaces/msg/ParameterType",
+		Fields: []humble.FieldDescription{
+			{Name: "structure_needs_at_least_one_member", Type: humble.FieldType{TypeID: humble.FieldTypeUint8}},
+		},
+	}
+}
+
+func (t _ParameterTypeTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{}
+}
+
+func (t _ParameterTypeTypeSupport) Source() string {
+	return "# These types correspond to the value that is set in the ParameterValue message.\n\n# Default value, which implies this is not a valid parameter.\nuint8 PARAMETER_NOT_SET=0\n\nuint8 PARAMETER_BOOL=1\nuint8 PARAMETER_INTEGER=2\nuint8 PARAMETER_DOUBLE=3\nuint8 PARAMETER_STRING=4\nuint8 PARAMETER_BYTE_ARRAY=5\nuint8 PARAMETER_BOOL_ARRAY=6\nuint8 PARAMETER_INTEGER_ARRAY=7\nuint8 PARAMETER_DOUBLE_ARRAY=8\nuint8 PARAMETER_STRING_ARRAY=9\n"
+}
+
+func (t _ParameterTypeTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _ParameterTypeTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CParameterType = C.rcl_interfaces__msg__ParameterType
+type CParameterTypeSequence = C.rcl_interfaces__msg__ParameterType__Sequence
+
+func ParameterTypeSequenceToGo(goSlice *[]ParameterType, cSlice CParameterTypeSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CParameterType])(unsafe.Pointer(&cSlice)), _ParameterTypeToGo)
+}
+
+func ParameterTypeSequenceToC(cSlice *CParameterTypeSequence, goSlice []ParameterType) {
+	humble.SequenceToC((*humble.CSequence[CParameterType])(unsafe.Pointer(cSlice)), goSlice, _ParameterTypeToC)
+}
+
+func ParameterTypeArrayToGo(goSlice []ParameterType, cSlice []CParameterType) {
+	humble.ArrayToGo(goSlice, cSlice, _ParameterTypeToGo)
+}
+
+func ParameterTypeArrayToC(cSlice []CParameterType, goSlice []ParameterType) {
+	humble.ArrayToC(cSlice, goSlice, _ParameterTypeToC)
+}
+
+func _ParameterTypeToGo(dst *ParameterType, src *CParameterType) {
+	ParameterTypeTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _ParameterTypeToC(dst *CParameterType, src *ParameterType) {
+	ParameterTypeTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
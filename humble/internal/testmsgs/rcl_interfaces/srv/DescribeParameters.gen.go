@@ -0,0 +1,122 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_srv
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+#include <rcl_interfaces/srv/describe_parameters.h>
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+)
+
+func init() {
+	humble.RegisterService("rcl_interfaces/DescribeParameters", DescribeParametersTypeSupport)
+	humble.RegisterService("rcl_interfaces/srv/DescribeParameters", DescribeParametersTypeSupport)
+}
+
+type _DescribeParametersTypeSupport struct{}
+
+func (s _DescribeParametersTypeSupport) Request() humble.MessageTypeSupport {
+	return DescribeParameters_RequestTypeSupport
+}
+
+func (s _DescribeParametersTypeSupport) Response() humble.MessageTypeSupport {
+	return DescribeParameters_ResponseTypeSupport
+}
+
+func (s _DescribeParametersTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_service_type_support_handle__rcl_interfaces__srv__DescribeParameters())
+}
+
+func (s _DescribeParametersTypeSupport) TypeName() string {
+	return "rcl_interfaces/srv/DescribeParameters"
+}
+
+func (s _DescribeParametersTypeSupport) TypeDescription() *humble.TypeDescription {
+	return humble.ServiceTypeDescription("rcl_interfaces/srv/DescribeParameters")
+}
+
+func (s _DescribeParametersTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return humble.ServiceReferencedTypes("rcl_interfaces/srv/DescribeParameters", DescribeParameters_RequestTypeSupport, DescribeParameters_ResponseTypeSupport)
+}
+
+func (s _DescribeParametersTypeSupport) Source() string {
+	return "# A list of parameters of which to get the descriptor.\nstring[] names\n\n---\n# A list of the descriptors of all parameters requested in the same order\n# as they were requested. This list has the same length as the list of\n# parameters requested.\nParameterDescriptor[] descriptors\n"
+}
+
+func (s _DescribeParametersTypeSupport) Definition() string {
+	return humble.Definition(s)
+}
+
+func (s _DescribeParametersTypeSupport) TypeHash() string {
+	return humble.TypeHash(s)
+}
+
+// Modifying this variable is undefined behavior.
+var DescribeParametersTypeSupport humble.DescribedServiceTypeSupport = _DescribeParametersTypeSupport{}
+
+// DescribeParametersClient wraps humble.Client to provide type safe helper
+// functions
+type DescribeParametersClient struct {
+	*humble.Client
+}
+
+// NewDescribeParametersClient creates and returns a new client for the
+// DescribeParameters
+func NewDescribeParametersClient(node *humble.Node, serviceName string, options *humble.ClientOptions) (*DescribeParametersClient, error) {
+	client, err := node.NewClient(serviceName, DescribeParametersTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParametersClient{client}, nil
+}
+
+func (s *DescribeParametersClient) Send(ctx context.Context, req *DescribeParameters_Request) (*DescribeParameters_Response, *humble.ServiceInfo, error) {
+	msg, rmw, err := s.Client.Send(ctx, req)
+	if err != nil {
+		return nil, rmw, err
+	}
+	typedMessage, ok := msg.(*DescribeParameters_Response)
+	if !ok {
+		return nil, rmw, errors.New("invalid message type returned")
+	}
+	return typedMessage, rmw, err
+}
+
+type DescribeParametersServiceResponseSender struct {
+	sender humble.ServiceResponseSender
+}
+
+func (s DescribeParametersServiceResponseSender) SendResponse(resp *DescribeParameters_Response) error {
+	return s.sender.SendResponse(resp)
+}
+
+type DescribeParametersServiceRequestHandler func(*humble.ServiceInfo, *DescribeParameters_Request, DescribeParametersServiceResponseSender)
+
+// DescribeParametersService wraps humble.Service to provide type safe helper
+// functions
+type DescribeParametersService struct {
+	*humble.Service
+}
+
+// NewDescribeParametersService creates and returns a new service for the
+// DescribeParameters
+func NewDescribeParametersService(node *humble.Node, name string, options *humble.ServiceOptions, handler DescribeParametersServiceRequestHandler) (*DescribeParametersService, error) {
+	h := func(rmw *humble.ServiceInfo, msg humble.Message, rs humble.ServiceResponseSender) {
+		m := msg.(*DescribeParameters_Request)
+		responseSender := DescribeParametersServiceResponseSender{sender: rs}
+		handler(rmw, m, responseSender)
+	}
+	service, err := node.NewService(name, DescribeParametersTypeSupport, options, h)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParametersService{service}, nil
+}
@@ -0,0 +1,262 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_srv
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/srv/describe_parameters.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("rcl_interfaces/DescribeParameters_Request", DescribeParameters_RequestTypeSupport)
+	humble.RegisterMessage("rcl_interfaces/srv/DescribeParameters_Request", DescribeParameters_RequestTypeSupport)
+}
+
+type DescribeParameters_Request struct {
+	Names []string `yaml:"names"` // A list of parameters of which to get the descriptor.
+}
+
+// NewDescribeParameters_Request creates a new DescribeParameters_Request with default values.
+func NewDescribeParameters_Request() *DescribeParameters_Request {
+	self := DescribeParameters_Request{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *DescribeParameters_Request) Clone() *DescribeParameters_Request {
+	c := &DescribeParameters_Request{}
+	if t.Names != nil {
+		c.Names = make([]string, len(t.Names))
+		copy(c.Names, t.Names)
+	}
+	return c
+}
+
+func (t *DescribeParameters_Request) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *DescribeParameters_Request) DeepCopyInto(dst *DescribeParameters_Request) {
+	dst.Names = append(dst.Names[:0], t.Names...)
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *DescribeParameters_Request) Equal(other *DescribeParameters_Request, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *DescribeParameters_Request) Diff(other *DescribeParameters_Request, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *DescribeParameters_Request) DiffWith(d *humble.Differ, other *DescribeParameters_Request) {
+	humble.DiffSlice(d, "names", t.Names, other.Names)
+}
+
+func (t *DescribeParameters_Request) SetDefaults() {
+	t.Names = nil
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *DescribeParameters_Request) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *DescribeParameters_Request) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *DescribeParameters_Request) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *DescribeParameters_Request) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *DescribeParameters_Request) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *DescribeParameters_Request) GetTypeSupport() humble.MessageTypeSupport {
+	return DescribeParameters_RequestTypeSupport
+}
+
+// DescribeParameters_RequestPublisher wraps humble.Publisher to provide type safe helper
+// functions
+type DescribeParameters_RequestPublisher struct {
+	*humble.Publisher
+}
+
+// NewDescribeParameters_RequestPublisher creates and returns a new publisher for the
+// DescribeParameters_Request
+func NewDescribeParameters_RequestPublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*DescribeParameters_RequestPublisher, error) {
+	pub, err := node.NewPublisher(topicName, DescribeParameters_RequestTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParameters_RequestPublisher{pub}, nil
+}
+
+func (p *DescribeParameters_RequestPublisher) Publish(msg *DescribeParameters_Request) error {
+	return p.Publisher.Publish(msg)
+}
+
+// DescribeParameters_RequestSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type DescribeParameters_RequestSubscription struct {
+	*humble.Subscription
+}
+
+// DescribeParameters_RequestSubscriptionCallback type is used to provide a subscription
+// handler function for a DescribeParameters_RequestSubscription.
+type DescribeParameters_RequestSubscriptionCallback func(msg *DescribeParameters_Request, info *humble.MessageInfo, err error)
+
+// NewDescribeParameters_RequestSubscription creates and returns a new subscription for the
+// DescribeParameters_Request
+func NewDescribeParameters_RequestSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback DescribeParameters_RequestSubscriptionCallback) (*DescribeParameters_RequestSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg DescribeParameters_Request
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, DescribeParameters_RequestTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParameters_RequestSubscription{sub}, nil
+}
+
+func (s *DescribeParameters_RequestSubscription) TakeMessage(out *DescribeParameters_Request) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneDescribeParameters_RequestSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneDescribeParameters_RequestSlice(dst, src []DescribeParameters_Request) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var DescribeParameters_RequestTypeSupport humble.DescribedMessageTypeSupport = _DescribeParameters_RequestTypeSupport{}
+
+type _DescribeParameters_RequestTypeSupport struct{}
+
+func (t _DescribeParameters_RequestTypeSupport) New() humble.Message {
+	return NewDescribeParameters_Request()
+}
+
+func (t _DescribeParameters_RequestTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__srv__DescribeParameters_Request
+	return (unsafe.Pointer)(C.rcl_interfaces__srv__DescribeParameters_Request__create())
+}
+
+func (t _DescribeParameters_RequestTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__srv__DescribeParameters_Request__destroy((*C.rcl_interfaces__srv__DescribeParameters_Request)(pointer_to_free))
+}
+
+func (t _DescribeParameters_RequestTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__srv__DescribeParameters_Request__fini((*C.rcl_interfaces__srv__DescribeParameters_Request)(pointer_to_reset))
+}
+
+func (t _DescribeParameters_RequestTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*DescribeParameters_Request)
+	mem := (*C.rcl_interfaces__srv__DescribeParameters_Request)(dst)
+	humble.StringSequenceToC((*humble.CStringSequence)(unsafe.Pointer(&mem.names)), m.Names)
+}
+
+func (t _DescribeParameters_RequestTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*DescribeParameters_Request)
+	mem := (*C.rcl_interfaces__srv__DescribeParameters_Request)(ros2_message_buffer)
+	humble.StringSequenceToGo(&m.Names, *(*humble.CStringSequence)(unsafe.Pointer(&mem.names)))
+}
+
+func (t _DescribeParameters_RequestTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__srv__DescribeParameters_Request())
+}
+
+func (t _DescribeParameters_RequestTypeSupport) TypeName() string {
+	return "rcl_interfaces/srv/DescribeParameters_Request"
+}
+
+func (t _DescribeParameters_RequestTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "rcl_interfaces/srv/DescribeParameters_Request",
+		Fields: []humble.FieldDescription{
+			{Name: "names", Type: humble.FieldType{TypeID: 161}},
+		},
+	}
+}
+
+func (t _DescribeParameters_RequestTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{}
+}
+
+func (t _DescribeParameters_RequestTypeSupport) Source() string {
+	return "# A list of parameters of which to get the descriptor.\nstring[] names\n\n"
+}
+
+func (t _DescribeParameters_RequestTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _DescribeParameters_RequestTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CDescribeParameters_Request = C.rcl_interfaces__srv__DescribeParameters_Request
+type CDescribeParameters_RequestSequence = C.rcl_interfaces__srv__DescribeParameters_Request__Sequence
+
+func DescribeParameters_RequestSequenceToGo(goSlice *[]DescribeParameters_Request, cSlice CDescribeParameters_RequestSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CDescribeParameters_Request])(unsafe.Pointer(&cSlice)), _DescribeParameters_RequestToGo)
+}
+
+func DescribeParameters_RequestSequenceToC(cSlice *CDescribeParameters_RequestSequence, goSlice []DescribeParameters_Request) {
+	humble.SequenceToC((*humble.CSequence[CDescribeParameters_Request])(unsafe.Pointer(cSlice)), goSlice, _DescribeParameters_RequestToC)
+}
+
+func DescribeParameters_RequestArrayToGo(goSlice []DescribeParameters_Request, cSlice []CDescribeParameters_Request) {
+	humble.ArrayToGo(goSlice, cSlice, _DescribeParameters_RequestToGo)
+}
+
+func DescribeParameters_RequestArrayToC(cSlice []CDescribeParameters_Request, goSlice []DescribeParameters_Request) {
+	humble.ArrayToC(cSlice, goSlice, _DescribeParameters_RequestToC)
+}
+
+func _DescribeParameters_RequestToGo(dst *DescribeParameters_Request, src *CDescribeParameters_Request) {
+	DescribeParameters_RequestTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _DescribeParameters_RequestToC(dst *CDescribeParameters_Request, src *DescribeParameters_Request) {
+	DescribeParameters_RequestTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
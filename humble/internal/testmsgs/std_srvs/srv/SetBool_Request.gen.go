@@ -0,0 +1,259 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package std_srvs_srv
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <std_srvs/srv/set_bool.h>
+
+*/
+import "C"
+
+func init() {
+	humble.RegisterMessage("std_srvs/SetBool_Request", SetBool_RequestTypeSupport)
+	humble.RegisterMessage("std_srvs/srv/SetBool_Request", SetBool_RequestTypeSupport)
+}
+
+type SetBool_Request struct {
+	Data bool `yaml:"data"` // e.g. for hardware enabling / disabling
+}
+
+// NewSetBool_Request creates a new SetBool_Request with default values.
+func NewSetBool_Request() *SetBool_Request {
+	self := SetBool_Request{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *SetBool_Request) Clone() *SetBool_Request {
+	c := &SetBool_Request{}
+	c.Data = t.Data
+	return c
+}
+
+func (t *SetBool_Request) CloneMsg() humble.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *SetBool_Request) DeepCopyInto(dst *SetBool_Request) {
+	dst.Data = t.Data
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *SetBool_Request) Equal(other *SetBool_Request, opts ...humble.DiffOption) bool {
+	d := humble.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *SetBool_Request) Diff(other *SetBool_Request, opts ...humble.DiffOption) []humble.FieldDiff {
+	d := humble.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *SetBool_Request) DiffWith(d *humble.Differ, other *SetBool_Request) {
+	humble.DiffValue(d, "data", t.Data, other.Data)
+}
+
+func (t *SetBool_Request) SetDefaults() {
+	t.Data = false
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *SetBool_Request) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *SetBool_Request) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *SetBool_Request) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *SetBool_Request) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *SetBool_Request) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *SetBool_Request) GetTypeSupport() humble.MessageTypeSupport {
+	return SetBool_RequestTypeSupport
+}
+
+// SetBool_RequestPublisher wraps humble.Publisher to provide type safe helper
+// functions
+type SetBool_RequestPublisher struct {
+	*humble.Publisher
+}
+
+// NewSetBool_RequestPublisher creates and returns a new publisher for the
+// SetBool_Request
+func NewSetBool_RequestPublisher(node *humble.Node, topicName string, options *humble.PublisherOptions) (*SetBool_RequestPublisher, error) {
+	pub, err := node.NewPublisher(topicName, SetBool_RequestTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBool_RequestPublisher{pub}, nil
+}
+
+func (p *SetBool_RequestPublisher) Publish(msg *SetBool_Request) error {
+	return p.Publisher.Publish(msg)
+}
+
+// SetBool_RequestSubscription wraps humble.Subscription to provide type safe helper
+// functions
+type SetBool_RequestSubscription struct {
+	*humble.Subscription
+}
+
+// SetBool_RequestSubscriptionCallback type is used to provide a subscription
+// handler function for a SetBool_RequestSubscription.
+type SetBool_RequestSubscriptionCallback func(msg *SetBool_Request, info *humble.MessageInfo, err error)
+
+// NewSetBool_RequestSubscription creates and returns a new subscription for the
+// SetBool_Request
+func NewSetBool_RequestSubscription(node *humble.Node, topicName string, opts *humble.SubscriptionOptions, subscriptionCallback SetBool_RequestSubscriptionCallback) (*SetBool_RequestSubscription, error) {
+	callback := func(s *humble.Subscription) {
+		var msg SetBool_Request
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, SetBool_RequestTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBool_RequestSubscription{sub}, nil
+}
+
+func (s *SetBool_RequestSubscription) TakeMessage(out *SetBool_Request) (*humble.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneSetBool_RequestSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneSetBool_RequestSlice(dst, src []SetBool_Request) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var SetBool_RequestTypeSupport humble.DescribedMessageTypeSupport = _SetBool_RequestTypeSupport{}
+
+type _SetBool_RequestTypeSupport struct{}
+
+func (t _SetBool_RequestTypeSupport) New() humble.Message {
+	return NewSetBool_Request()
+}
+
+func (t _SetBool_RequestTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.std_srvs__srv__SetBool_Request
+	return (unsafe.Pointer)(C.std_srvs__srv__SetBool_Request__create())
+}
+
+func (t _SetBool_RequestTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.std_srvs__srv__SetBool_Request__destroy((*C.std_srvs__srv__SetBool_Request)(pointer_to_free))
+}
+
+func (t _SetBool_RequestTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.std_srvs__srv__SetBool_Request__fini((*C.std_srvs__srv__SetBool_Request)(pointer_to_reset))
+}
+
+func (t _SetBool_RequestTypeSupport) AsCStruct(dst unsafe.Pointer, msg humble.Message) {
+	m := msg.(*SetBool_Request)
+	mem := (*C.std_srvs__srv__SetBool_Request)(dst)
+	mem.data = C.bool(m.Data)
+}
+
+func (t _SetBool_RequestTypeSupport) AsGoStruct(msg humble.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*SetBool_Request)
+	mem := (*C.std_srvs__srv__SetBool_Request)(ros2_message_buffer)
+	m.Data = bool(mem.data)
+}
+
+func (t _SetBool_RequestTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__std_srvs__srv__SetBool_Request())
+}
+
+func (t _SetBool_RequestTypeSupport) TypeName() string {
+	return "std_srvs/srv/SetBool_Request"
+}
+
+func (t _SetBool_RequestTypeSupport) TypeDescription() *humble.TypeDescription {
+	return &humble.TypeDescription{
+		TypeName: "std_srvs/srv/SetBool_Request",
+		Fields: []humble.FieldDescription{
+			{Name: "data", Type: humble.FieldType{TypeID: 15}},
+		},
+	}
+}
+
+func (t _SetBool_RequestTypeSupport) ReferencedTypes() []humble.TypeDescriber {
+	return []humble.TypeDescriber{}
+}
+
+func (t _SetBool_RequestTypeSupport) Source() string {
+	return "bool data # e.g. for hardware enabling / disabling\n"
+}
+
+func (t _SetBool_RequestTypeSupport) Definition() string {
+	return humble.Definition(t)
+}
+
+func (t _SetBool_RequestTypeSupport) TypeHash() string {
+	return humble.TypeHash(t)
+}
+
+type CSetBool_Request = C.std_srvs__srv__SetBool_Request
+type CSetBool_RequestSequence = C.std_srvs__srv__SetBool_Request__Sequence
+
+func SetBool_RequestSequenceToGo(goSlice *[]SetBool_Request, cSlice CSetBool_RequestSequence) {
+	humble.SequenceToGo(goSlice, (*humble.CSequence[CSetBool_Request])(unsafe.Pointer(&cSlice)), _SetBool_RequestToGo)
+}
+
+func SetBool_RequestSequenceToC(cSlice *CSetBool_RequestSequence, goSlice []SetBool_Request) {
+	humble.SequenceToC((*humble.CSequence[CSetBool_Request])(unsafe.Pointer(cSlice)), goSlice, _SetBool_RequestToC)
+}
+
+func SetBool_RequestArrayToGo(goSlice []SetBool_Request, cSlice []CSetBool_Request) {
+	humble.ArrayToGo(goSlice, cSlice, _SetBool_RequestToGo)
+}
+
+func SetBool_RequestArrayToC(cSlice []CSetBool_Request, goSlice []SetBool_Request) {
+	humble.ArrayToC(cSlice, goSlice, _SetBool_RequestToC)
+}
+
+func _SetBool_RequestToGo(dst *SetBool_Request, src *CSetBool_Request) {
+	SetBool_RequestTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _SetBool_RequestToC(dst *CSetBool_Request, src *SetBool_Request) {
+	SetBool_RequestTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,169 @@
+package humble
+
+import (
+	"testing"
+)
+
+type intraMsg struct{ Data []int }
+
+func (m *intraMsg) CloneMsg() Message {
+	return &intraMsg{Data: append([]int(nil), m.Data...)}
+}
+func (m *intraMsg) SetDefaults()                       { *m = intraMsg{} }
+func (m *intraMsg) GetTypeSupport() MessageTypeSupport { return intraTypeSupport{} }
+
+type intraTypeSupport struct{ MessageTypeSupport }
+
+func (intraTypeSupport) TypeName() string { return "test_msgs/msg/Intra" }
+
+func newTestIntraProcessQueue(name string, qos QosProfile) *intraProcessQueue {
+	ts := intraTypeSupport{}
+	return newIntraProcessQueue(newIntraProcessTopic(name, ts, &qos), ts, &qos)
+}
+
+func TestIntraProcessPublish(t *testing.T) {
+	keepLast := NewDefaultQosProfile()
+	keepLast.Depth = 2
+	keepAll := NewDefaultQosProfile()
+	keepAll.History = HistoryKeepAll
+	bestEffort := NewDefaultQosProfile()
+	bestEffort.Reliability = ReliabilityBestEffort
+
+	var m intraProcessManager
+	subs := []*intraProcessQueue{
+		newTestIntraProcessQueue("/chatter", keepLast),
+		newTestIntraProcessQueue("/chatter", keepAll),
+		newTestIntraProcessQueue("/chatter", bestEffort),
+		newTestIntraProcessQueue("/other", keepAll),
+	}
+	for _, q := range subs {
+		m.add(q)
+	}
+	ts := intraTypeSupport{}
+	pub := newIntraProcessTopic("/chatter", ts, &keepLast)
+	msg := &intraMsg{}
+	for i := 0; i < 3; i++ {
+		msg.Data = []int{i}
+		count := m.publish(pub, GID{3}, func(*intraProcessQueue) (Message, MessageTypeSupport) {
+			return msg.CloneMsg(), ts
+		})
+		if count != 3 {
+			t.Fatalf("want 3 matching subscriptions, got %d", count)
+		}
+	}
+	if bestEffortPub := newIntraProcessTopic("/chatter", ts, &bestEffort); m.publish(bestEffortPub, GID{3}, func(*intraProcessQueue) (Message, MessageTypeSupport) {
+		return msg.CloneMsg(), ts
+	}) != 1 {
+		t.Fatal("reliable subscriptions must not match best effort publishers")
+	}
+
+	take := func(q *intraProcessQueue) []int {
+		var got []int
+		for {
+			m, ok := q.pop()
+			if !ok {
+				return got
+			}
+			if !m.info().FromIntraProcess {
+				t.Fatal("FromIntraProcess is not set")
+			}
+			if m.info().PublisherGID != (GID{3}) {
+				t.Fatalf("want the GID of the publisher, got %v", m.info().PublisherGID)
+			}
+			var out intraMsg
+			m.copyTo(&out, ts)
+			got = append(got, out.Data...)
+		}
+	}
+	check := func(q *intraProcessQueue, want ...int) {
+		t.Helper()
+		got := take(q)
+		if len(got) != len(want) {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("want %v, got %v", want, got)
+			}
+		}
+	}
+	check(subs[0], 1, 2)
+	check(subs[1], 0, 1, 2)
+	check(subs[2], 0, 1, 2, 2)
+	check(subs[3])
+
+	m.remove(subs[1])
+	if count := m.publish(pub, GID{}, func(*intraProcessQueue) (Message, MessageTypeSupport) { return nil, nil }); count != 2 {
+		t.Fatalf("want 2 matching subscriptions after removal, got %d", count)
+	}
+	if subs[0].pending() {
+		t.Fatal("nil messages must not be delivered")
+	}
+}
+
+func TestIntraProcessTransientLocal(t *testing.T) {
+	qos := NewDefaultQosProfile()
+	qos.Durability = DurabilityTransientLocal
+	if newIntraProcessTopic("/chatter", intraTypeSupport{}, &qos) != nil {
+		t.Fatal("transient local entities must not take part in intra-process communication")
+	}
+}
+
+func TestIntraProcessPublisherGID(t *testing.T) {
+	bestEffort := NewDefaultQosProfile()
+	bestEffort.Reliability = ReliabilityBestEffort
+
+	var m intraProcessManager
+	pubGID := GID{1}
+	reliableSub := newTestIntraProcessQueue("/chatter", NewDefaultQosProfile())
+	bestEffortSub := newTestIntraProcessQueue("/chatter", bestEffort)
+	otherSub := newTestIntraProcessQueue("/other", NewDefaultQosProfile())
+	m.addPublisher(pubGID, newIntraProcessTopic("/chatter", intraTypeSupport{}, &bestEffort))
+
+	if m.delivers(GID{2}, reliableSub) {
+		t.Fatal("messages of unknown publishers must not be dropped")
+	}
+	if m.delivers(pubGID, reliableSub) {
+		t.Fatal("messages of best effort publishers are not delivered to reliable subscriptions")
+	}
+	if !m.delivers(pubGID, bestEffortSub) {
+		t.Fatal("messages of intra-process publishers must be dropped")
+	}
+	if m.delivers(pubGID, otherSub) {
+		t.Fatal("messages of publishers of other topics must not be dropped")
+	}
+	m.removePublisher(pubGID)
+	if m.delivers(pubGID, bestEffortSub) {
+		t.Fatal("messages of removed publishers must not be dropped")
+	}
+
+	var nilManager *intraProcessManager
+	nilManager.addPublisher(pubGID, newIntraProcessTopic("/chatter", intraTypeSupport{}, &bestEffort))
+	if nilManager.delivers(pubGID, bestEffortSub) {
+		t.Fatal("nil managers must not drop messages")
+	}
+}
+
+func TestIntraProcessMiddlewareMessage(t *testing.T) {
+	qos := NewDefaultQosProfile()
+	qos.Depth = 1
+	q := newTestIntraProcessQueue("/chatter", qos)
+	info := &MessageInfo{}
+	q.add(intraProcessMessage{msg: &intraMsg{Data: []int{1}}, typeSupport: intraTypeSupport{}})
+	q.add(intraProcessMessage{msg: &intraMsg{Data: []int{2}}, typeSupport: intraTypeSupport{}, middlewareInfo: info})
+	m, ok := q.pop()
+	if !ok {
+		t.Fatal("want a queued message")
+	}
+	if m.info() != info {
+		t.Fatal("want the info of the middleware")
+	}
+	var out intraMsg
+	m.copyTo(&out, intraTypeSupport{})
+	if len(out.Data) != 1 || out.Data[0] != 2 {
+		t.Fatalf("want the newest message, got %v", out.Data)
+	}
+	if q.pending() {
+		t.Fatal("the oldest message must be dropped")
+	}
+}
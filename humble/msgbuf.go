@@ -0,0 +1,90 @@
+package humble
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// maxPooledMessageBuffers is the maximum number of idle buffers kept by a
+// messageBufferPool. Buffers needed by more concurrent callers are allocated
+// and freed on demand.
+const maxPooledMessageBuffers = 4
+
+// messageBufferPool reuses the C structs of the messages of a type support
+// between calls, instead of creating and destroying one per message. A
+// messageBufferPool is safe for concurrent use.
+type messageBufferPool struct {
+	ts  MessageTypeSupport
+	toC bool
+	// reset is the ResetMemory method of ts, or nil if ts doesn't implement
+	// MemoryResetter.
+	reset  func(unsafe.Pointer)
+	mu     sync.Mutex
+	idle   []unsafe.Pointer
+	closed bool
+}
+
+// newMessageBufferPool returns a pool of buffers of ts.
+//
+// If toC is true, the buffers are filled by AsCStruct. AsCStruct overwrites the
+// strings and sequences of a buffer without freeing them, so the buffers are
+// reset before they are filled, and they are not reused if ts doesn't
+// implement MemoryResetter. Otherwise the buffers are filled by rcl, which
+// requires initialized messages and reuses or frees their contents itself.
+func newMessageBufferPool(ts MessageTypeSupport, toC bool) *messageBufferPool {
+	p := &messageBufferPool{ts: ts, toC: toC}
+	if r, ok := ts.(MemoryResetter); ok {
+		p.reset = r.ResetMemory
+	}
+	return p
+}
+
+// reusable reports whether the buffers of p can be reused.
+func (p *messageBufferPool) reusable() bool {
+	return !p.toC || p.reset != nil
+}
+
+// get returns an idle buffer or allocates a new one if there are none.
+func (p *messageBufferPool) get() unsafe.Pointer {
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		buf := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return buf
+	}
+	p.mu.Unlock()
+	buf := p.ts.PrepareMemory()
+	if p.toC && p.reset != nil {
+		// Free the default values of the strings and sequences.
+		p.reset(buf)
+	}
+	return buf
+}
+
+// put returns buf to p. buf is freed if p is full or closed.
+func (p *messageBufferPool) put(buf unsafe.Pointer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || !p.reusable() || len(p.idle) >= maxPooledMessageBuffers {
+		p.ts.ReleaseMemory(buf)
+		return
+	}
+	if p.toC {
+		// Don't hold on to the contents of the previous message.
+		p.reset(buf)
+	}
+	p.idle = append(p.idle, buf)
+}
+
+// close frees the idle buffers of p. Buffers put after closing are freed
+// immediately.
+func (p *messageBufferPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for _, buf := range p.idle {
+		p.ts.ReleaseMemory(buf)
+	}
+	p.idle = nil
+}
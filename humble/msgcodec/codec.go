@@ -0,0 +1,295 @@
+/*
+Package msgcodec converts generated message structs to and from JSON and YAML
+using reflection. The generated MarshalJSON, UnmarshalJSON, MarshalYAML and
+UnmarshalYAML methods of messages use it.
+
+The encodings follow the conventions of ROS 2 tools:
+
+  - Fields are named after the ROS field names, which are stored in the yaml
+    tags of the generated structs.
+  - In JSON, uint8 and char arrays and sequences are encoded as base64
+    strings, as rosbridge_suite does. In YAML, they are lists of numbers, as
+    printed by ros2 topic echo. When decoding, both forms are accepted.
+  - Non-finite floating point numbers are encoded as null in JSON, because
+    JSON cannot represent them, and null is decoded as NaN. YAML uses .nan,
+    .inf and -.inf.
+  - time.Time and time.Duration fields are encoded like builtin_interfaces
+    Time and Duration, as objects with the fields sec and nanosec.
+  - Fields missing from decoded objects keep their current values.
+*/
+package msgcodec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// defaulter is implemented by generated messages.
+type defaulter interface {
+	SetDefaults()
+}
+
+// messageValue returns the value of the non-nil pointer msg.
+func messageValue(msg any) (reflect.Value, error) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return reflect.Value{}, errors.New("message must be a non-nil pointer")
+	}
+	return v, nil
+}
+
+// timeValue returns the seconds and nanoseconds of v if it is a time.Time or
+// a time.Duration. Like in builtin_interfaces, nanosec is always
+// non-negative. The zero time.Time is the zero ROS time.
+func timeValue(v reflect.Value) (sec int64, nanosec int64, ok bool) {
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return 0, 0, true
+		}
+		return t.Unix(), int64(t.Nanosecond()), true
+	case durationType:
+		d := v.Int()
+		sec, nanosec = d/int64(time.Second), d%int64(time.Second)
+		if nanosec < 0 {
+			sec, nanosec = sec-1, nanosec+int64(time.Second)
+		}
+		return sec, nanosec, true
+	}
+	return 0, 0, false
+}
+
+// fieldName returns the ROS name of a generated struct field. ok is false if
+// the field is not part of the message.
+func fieldName(f reflect.StructField) (name string, ok bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	name, _, _ = strings.Cut(f.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	}
+	return name, true
+}
+
+func decodeValue(dst reflect.Value, value any, path string) error {
+	typeErr := func() error {
+		return fmt.Errorf("field %q: cannot decode %s into %s", pathOrRoot(path), jsonKind(value), dst.Type())
+	}
+	if dst.Type() == timeType || dst.Type() == durationType {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return typeErr()
+		}
+		return decodeTime(dst, obj, path)
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(dst.Elem(), value, path)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return typeErr()
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return typeErr()
+		}
+		i, err := strconv.ParseInt(n.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("field %q: %w", pathOrRoot(path), err)
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return typeErr()
+		}
+		u, err := strconv.ParseUint(n.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("field %q: %w", pathOrRoot(path), err)
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		switch value := value.(type) {
+		case nil:
+			dst.SetFloat(math.NaN())
+		case json.Number:
+			f, err := strconv.ParseFloat(value.String(), dst.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("field %q: %w", pathOrRoot(path), err)
+			}
+			dst.SetFloat(f)
+		default:
+			return typeErr()
+		}
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return typeErr()
+		}
+		dst.SetString(s)
+	case reflect.Slice, reflect.Array:
+		return decodeList(dst, value, path)
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return typeErr()
+		}
+		return decodeStruct(dst, obj, path)
+	default:
+		return fmt.Errorf("field %q: cannot decode into %s", pathOrRoot(path), dst.Type())
+	}
+	return nil
+}
+
+func decodeList(dst reflect.Value, value any, path string) error {
+	var elems []any
+	switch value := value.(type) {
+	case []any:
+		elems = value
+	case string:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("field %q: cannot decode string into %s", pathOrRoot(path), dst.Type())
+		}
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", pathOrRoot(path), err)
+		}
+		if err := prepareList(dst, len(data), path); err != nil {
+			return err
+		}
+		reflect.Copy(dst, reflect.ValueOf(data))
+		return nil
+	default:
+		return fmt.Errorf("field %q: cannot decode %s into %s", pathOrRoot(path), jsonKind(value), dst.Type())
+	}
+	if err := prepareList(dst, len(elems), path); err != nil {
+		return err
+	}
+	for i, elem := range elems {
+		if err := decodeValue(dst.Index(i), elem, path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareList makes the slice dst n elements long. New messages in it are
+// set to their defaults. If dst is an array, n must match its length.
+func prepareList(dst reflect.Value, n int, path string) error {
+	if dst.Kind() == reflect.Array {
+		if dst.Len() != n {
+			return fmt.Errorf("field %q: want %d elements, got %d", pathOrRoot(path), dst.Len(), n)
+		}
+		return nil
+	}
+	dst.Set(reflect.MakeSlice(dst.Type(), n, n))
+	if dst.Type().Elem().Kind() == reflect.Struct {
+		for i := 0; i < n; i++ {
+			if d, ok := dst.Index(i).Addr().Interface().(defaulter); ok {
+				d.SetDefaults()
+			}
+		}
+	}
+	return nil
+}
+
+func decodeStruct(dst reflect.Value, obj map[string]any, path string) error {
+	known := make(map[string]bool, len(obj))
+	for i := 0; i < dst.NumField(); i++ {
+		name, ok := fieldName(dst.Type().Field(i))
+		if !ok {
+			continue
+		}
+		value, ok := obj[name]
+		if !ok {
+			continue
+		}
+		known[name] = true
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if err := decodeValue(dst.Field(i), value, fieldPath); err != nil {
+			return err
+		}
+	}
+	for name := range obj {
+		if !known[name] {
+			return fmt.Errorf("field %q: %s has no field named %q", pathOrRoot(path), dst.Type(), name)
+		}
+	}
+	return nil
+}
+
+// decodeTime decodes an object with the fields sec and nanosec into the
+// time.Time or time.Duration dst. The zero ROS time is the zero time.Time.
+func decodeTime(dst reflect.Value, obj map[string]any, path string) error {
+	var t struct {
+		Sec     int64  `yaml:"sec"`
+		Nanosec uint32 `yaml:"nanosec"`
+	}
+	if err := decodeStruct(reflect.ValueOf(&t).Elem(), obj, path); err != nil {
+		return err
+	}
+	switch {
+	case dst.Type() == timeType && t.Sec == 0 && t.Nanosec == 0:
+		dst.Set(reflect.ValueOf(time.Time{}))
+	case dst.Type() == timeType:
+		dst.Set(reflect.ValueOf(time.Unix(t.Sec, int64(t.Nanosec))))
+	default:
+		dst.SetInt(t.Sec*int64(time.Second) + int64(t.Nanosec))
+	}
+	return nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,115 @@
+package msgcodec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// MarshalJSON encodes the generated message struct pointed to by msg as
+// JSON.
+func MarshalJSON(msg any) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, reflect.ValueOf(msg)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeValue(buf *bytes.Buffer, v reflect.Value) error {
+	if sec, nanosec, ok := timeValue(v); ok {
+		fmt.Fprintf(buf, `{"sec":%d,"nanosec":%d}`, sec, nanosec)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeValue(buf, v.Elem())
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.Write(strconv.AppendFloat(nil, f, 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		data, err := json.Marshal(v.String())
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			buf.WriteByte('"')
+			buf.WriteString(base64.StdEncoding.EncodeToString(data))
+			buf.WriteByte('"')
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		for i := 0; i < v.NumField(); i++ {
+			name, ok := fieldName(v.Type().Field(i))
+			if !ok {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			buf.WriteString(strconv.Quote(name))
+			buf.WriteByte(':')
+			if err := encodeValue(buf, v.Field(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("cannot encode value of type %s", v.Type())
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes JSON data into the generated message struct pointed
+// to by msg. Fields missing from data are left untouched.
+func UnmarshalJSON(data []byte, msg any) error {
+	v, err := messageValue(msg)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	return decodeValue(v.Elem(), value, "")
+}
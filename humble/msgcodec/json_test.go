@@ -0,0 +1,124 @@
+package msgcodec
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+type testTime struct {
+	Sec     int32  `yaml:"sec"`
+	Nanosec uint32 `yaml:"nanosec"`
+}
+
+type testMessage struct {
+	Stamp   testTime   `yaml:"stamp"`
+	Name    string     `yaml:"name"`
+	Ok      bool       `yaml:"ok"`
+	Value   float64    `yaml:"value"`
+	Data    []uint8    `yaml:"data"`
+	UUID    [4]uint8   `yaml:"uuid"`
+	Samples []int16    `yaml:"samples"`
+	Points  []testTime `yaml:"points"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	msg := &testMessage{
+		Stamp:   testTime{Sec: 1, Nanosec: 2},
+		Name:    "a\"b",
+		Ok:      true,
+		Value:   math.NaN(),
+		Data:    []uint8{1, 2, 3},
+		UUID:    [4]uint8{0xff, 0, 0, 1},
+		Samples: []int16{-1, 2},
+	}
+	data, err := MarshalJSON(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"stamp":{"sec":1,"nanosec":2},"name":"a\"b","ok":true,"value":null,` +
+		`"data":"AQID","uuid":"/wAAAQ==","samples":[-1,2],"points":[]}`
+	if string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("invalid JSON: %s", data)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	msg := &testMessage{Name: "default"}
+	err := UnmarshalJSON([]byte(`{
+		"stamp": {"sec": 5},
+		"value": null,
+		"data": [4, 5],
+		"uuid": "AQIDBA==",
+		"points": [{"sec": 1, "nanosec": 2}]
+	}`), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Name != "default" {
+		t.Errorf("missing fields must keep their value, got %q", msg.Name)
+	}
+	if msg.Stamp.Sec != 5 || !math.IsNaN(msg.Value) {
+		t.Errorf("fields not decoded: %+v", msg)
+	}
+	if string(msg.Data) != "\x04\x05" || msg.UUID != [4]uint8{1, 2, 3, 4} {
+		t.Errorf("byte fields not decoded: %v %v", msg.Data, msg.UUID)
+	}
+	if len(msg.Points) != 1 || msg.Points[0].Nanosec != 2 {
+		t.Errorf("nested sequence not decoded: %+v", msg.Points)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "Unknown field",
+			input:   `{"nmae": "x"}`,
+			wantErr: `has no field named "nmae"`,
+		},
+		{
+			name:    "Wrong type",
+			input:   `{"stamp": {"sec": "1"}}`,
+			wantErr: `field "stamp.sec": cannot decode string into int32`,
+		},
+		{
+			name:    "Overflow",
+			input:   `{"samples": [1, 40000]}`,
+			wantErr: `field "samples[1]"`,
+		},
+		{
+			name:    "Negative unsigned",
+			input:   `{"stamp": {"nanosec": -1}}`,
+			wantErr: `field "stamp.nanosec"`,
+		},
+		{
+			name:    "Array length",
+			input:   `{"uuid": [1, 2]}`,
+			wantErr: "want 4 elements, got 2",
+		},
+		{
+			name:    "Not an object",
+			input:   `[]`,
+			wantErr: "cannot decode array",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UnmarshalJSON([]byte(tc.input), &testMessage{})
+			if err == nil {
+				t.Fatalf("want error containing %q, got nil", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("want error containing %q, got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package msgcodec
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type testDefaults struct {
+	Name  string `yaml:"name"`
+	Count int32  `yaml:"count"`
+}
+
+func (t *testDefaults) SetDefaults() { t.Name = "default" }
+
+type testTimes struct {
+	Stamp time.Time      `yaml:"stamp"`
+	Dur   time.Duration  `yaml:"dur"`
+	Items []testDefaults `yaml:"items"`
+}
+
+func formatYAML(t *testing.T, msg any) string {
+	t.Helper()
+	node, err := MarshalYAML(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func parseYAML(input string, msg any) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(input), &node); err != nil {
+		return err
+	}
+	return UnmarshalYAML(node.Decode, msg)
+}
+
+func TestMarshalYAML(t *testing.T) {
+	msg := &testMessage{
+		Stamp:   testTime{Sec: 1, Nanosec: 2},
+		Name:    "true",
+		Value:   math.Inf(-1),
+		Data:    []uint8{1, 2},
+		Samples: []int16{-1},
+	}
+	want := `stamp:
+  sec: 1
+  nanosec: 2
+name: "true"
+ok: false
+value: -.inf
+data:
+  - 1
+  - 2
+uuid:
+  - 0
+  - 0
+  - 0
+  - 0
+samples:
+  - -1
+points: []
+`
+	if got := formatYAML(t, msg); got != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, got)
+	}
+	msg.Value = 2
+	if got := formatYAML(t, msg); !strings.Contains(got, "\nvalue: 2.0\n") {
+		t.Fatalf("floats must have a decimal point, got\n%s", got)
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	msg := &testMessage{Name: "default"}
+	err := parseYAML(`{stamp: {sec: 5}, value: .nan, data: [4, 5], uuid: AQIDBA==, points: [{nanosec: 0x10}]}`, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Name != "default" {
+		t.Errorf("missing fields must keep their value, got %q", msg.Name)
+	}
+	if msg.Stamp.Sec != 5 || !math.IsNaN(msg.Value) {
+		t.Errorf("fields not decoded: %+v", msg)
+	}
+	if string(msg.Data) != "\x04\x05" || msg.UUID != [4]uint8{1, 2, 3, 4} {
+		t.Errorf("byte fields not decoded: %v %v", msg.Data, msg.UUID)
+	}
+	if len(msg.Points) != 1 || msg.Points[0].Nanosec != 16 {
+		t.Errorf("nested sequence not decoded: %+v", msg.Points)
+	}
+
+	for _, input := range []string{"{nmae: x}", "{samples: [1.5]}", "{stamp: [1]}", "{1: x}"} {
+		if err := parseYAML(input, &testMessage{}); err == nil {
+			t.Errorf("want an error for %s", input)
+		}
+	}
+}
+
+func TestTimes(t *testing.T) {
+	msg := &testTimes{
+		Stamp: time.Unix(3, 500),
+		Dur:   -1500 * time.Millisecond,
+	}
+	data, err := MarshalJSON(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"stamp":{"sec":3,"nanosec":500},"dur":{"sec":-2,"nanosec":500000000},"items":[]}`
+	if string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+	var decoded testTimes
+	if err := UnmarshalJSON(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Stamp.Equal(msg.Stamp) || decoded.Dur != msg.Dur {
+		t.Fatalf("want %+v, got %+v", msg, decoded)
+	}
+
+	data, err = MarshalJSON(&testTimes{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"stamp":{"sec":0,"nanosec":0},"dur":{"sec":0,"nanosec":0},"items":[]}`; string(data) != want {
+		t.Fatalf("the zero time must be encoded as the zero ROS time: want %s, got %s", want, data)
+	}
+	if err := UnmarshalJSON(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Stamp.IsZero() {
+		t.Fatalf("the zero ROS time must be decoded as the zero time, got %v", decoded.Stamp)
+	}
+
+	if err := parseYAML("{dur: {sec: 1}, items: [{count: 1}]}", &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Dur != time.Second {
+		t.Errorf("want 1s, got %v", decoded.Dur)
+	}
+	if len(decoded.Items) != 1 || decoded.Items[0] != (testDefaults{Name: "default", Count: 1}) {
+		t.Errorf("new sequence elements must have default values, got %+v", decoded.Items)
+	}
+}
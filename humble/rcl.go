@@ -28,6 +28,8 @@ type MessageInfo struct {
 	SourceTimestamp   time.Time
 	ReceivedTimestamp time.Time
 	FromIntraProcess  bool
+	// PublisherGID is the GID of the publisher of the message.
+	PublisherGID GID
 }
 
 type ClockType uint32
@@ -386,6 +388,9 @@ func (n *Node) Spin(ctx context.Context) error {
 
 type PublisherOptions struct {
 	Qos QosProfile
+	// Validate makes Publish validate messages implementing Validator before
+	// publishing them.
+	Validate bool
 }
 
 func NewDefaultPublisherOptions() *PublisherOptions {
@@ -399,6 +404,11 @@ type Publisher struct {
 	node          *Node
 	rclPublisherT *C.rcl_publisher_t
 	topicName     *C.char
+	validate      bool
+	buffers       *messageBufferPool
+	// intraProcess is set if p takes part in intra-process communication.
+	intraProcess *intraProcessTopic
+	gid          GID
 }
 
 // NewPublisher creates a new publisher.
@@ -419,6 +429,8 @@ func (n *Node) NewPublisher(
 		node:          n,
 		rclPublisherT: (*C.rcl_publisher_t)(C.malloc(C.sizeof_rcl_publisher_t)),
 		topicName:     C.CString(topicName),
+		validate:      options.Validate,
+		buffers:       newMessageBufferPool(ros2msg, true),
 	}
 	*pub.rclPublisherT = C.rcl_get_zero_initialized_publisher()
 	defer onErr(&err, pub.Close)
@@ -436,11 +448,39 @@ func (n *Node) NewPublisher(
 	if rc != C.RCL_RET_OK {
 		return nil, errorsCast(rc)
 	}
+	if n.context.intraProcess != nil {
+		pub.intraProcess = newIntraProcessTopic(
+			C.GoString(C.rcl_publisher_get_topic_name(pub.rclPublisherT)),
+			ros2msg,
+			&options.Qos,
+		)
+	}
+	var gid C.rmw_gid_t
+	rc = C.rmw_get_gid_for_publisher(C.rcl_publisher_get_rmw_handle(pub.rclPublisherT), &gid)
+	if rc != C.RCL_RET_OK {
+		return nil, errorsCastC(rc, "failed to get publisher GID")
+	}
+	pub.gid = newGID(&gid)
+	n.context.intraProcess.addPublisher(pub.gid, pub.intraProcess)
 
 	n.addResource(pub)
 	return pub, nil
 }
 
+func newGID(gid *C.rmw_gid_t) GID {
+	var g GID
+	for i := range gid.data {
+		g[i] = byte(gid.data[i])
+	}
+	return g
+}
+
+// GID returns the GID of p, which is set in the MessageInfo of the messages
+// published by p.
+func (p *Publisher) GID() GID {
+	return p.gid
+}
+
 // Node returns the node p belongs to.
 func (p *Publisher) Node() *Node {
 	return p.node
@@ -449,8 +489,18 @@ func (p *Publisher) Node() *Node {
 func (p *Publisher) Publish(ros2msg Message) error {
 	var rc C.rcl_ret_t
 
-	ptr := p.typeSupport.PrepareMemory()
-	defer p.typeSupport.ReleaseMemory(ptr)
+	if p.validate {
+		if err := ValidateMessage(ros2msg); err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+	}
+
+	p.publishIntraProcess(func(*intraProcessQueue) (Message, MessageTypeSupport) {
+		return ros2msg.CloneMsg(), p.typeSupport
+	})
+
+	ptr := p.buffers.get()
+	defer p.buffers.put(ptr)
 	p.typeSupport.AsCStruct(ptr, ros2msg)
 
 	rc = C.rcl_publish(p.rclPublisherT, ptr, nil)
@@ -462,6 +512,14 @@ func (p *Publisher) Publish(ros2msg Message) error {
 
 // PublishSerialized publishes a message that has already been serialized.
 func (p *Publisher) PublishSerialized(msg []byte) error {
+	p.publishIntraProcess(func(q *intraProcessQueue) (Message, MessageTypeSupport) {
+		m, err := Deserialize(msg, q.typeSupport)
+		if err != nil {
+			_ = p.node.Logger().Debug(err)
+			return nil, nil
+		}
+		return m, q.typeSupport
+	})
 	rclMsg, err := newSerializedMessage(len(msg))
 	if err != nil {
 		return fmt.Errorf("failed to publish serialized message: %v", err)
@@ -475,6 +533,18 @@ func (p *Publisher) PublishSerialized(msg []byte) error {
 	return nil
 }
 
+// publishIntraProcess delivers the messages returned by newMsg to the
+// intra-process subscriptions matched to p. The message is published through
+// the middleware as well, as subscriptions of other processes may have been
+// matched to p without being counted yet. The intra-process subscriptions drop
+// the copies they receive from the middleware.
+func (p *Publisher) publishIntraProcess(newMsg func(*intraProcessQueue) (Message, MessageTypeSupport)) {
+	if p.intraProcess == nil {
+		return
+	}
+	p.node.context.intraProcess.publish(p.intraProcess, p.gid, newMsg)
+}
+
 // GetSubscriptionCount returns the number of subscriptions matched to p.
 func (p *Publisher) GetSubscriptionCount() (int, error) {
 	var count C.size_t
@@ -491,6 +561,7 @@ func (p *Publisher) Close() (err error) {
 		return closeErr("publisher")
 	}
 	p.node.removeResource(p)
+	p.node.context.intraProcess.removePublisher(p.gid)
 	rc := C.rcl_publisher_fini(p.rclPublisherT, p.node.rclNodeT)
 	if rc != C.RCL_RET_OK {
 		err = errors.Join(err, errorsCast(rc))
@@ -498,6 +569,7 @@ func (p *Publisher) Close() (err error) {
 	C.free(unsafe.Pointer(p.rclPublisherT))
 	p.rclPublisherT = nil
 	C.free(unsafe.Pointer(p.topicName))
+	p.buffers.close()
 	return err
 }
 
@@ -564,6 +636,15 @@ func (c *Clock) now() (time.Duration, error) {
 	return time.Duration(t), nil
 }
 
+// Now returns the current time of c.
+func (c *Clock) Now() (time.Time, error) {
+	t, err := c.now()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(t)), nil
+}
+
 type Timer struct {
 	rosID
 	waitable  singleUse
@@ -646,6 +727,10 @@ func (t *Timer) Close() (err error) {
 
 type SubscriptionOptions struct {
 	Qos QosProfile
+
+	// If IgnoreLocalPublications is true, messages published by publishers in
+	// the same Context are not delivered to the subscription.
+	IgnoreLocalPublications bool
 }
 
 func NewDefaultSubscriptionOptions() *SubscriptionOptions {
@@ -663,6 +748,9 @@ type Subscription struct {
 	node             *Node
 	rclSubscriptionT *C.rcl_subscription_t
 	topicName        *C.char
+	buffers          *messageBufferPool
+	// intraProcess is set if s takes part in intra-process communication.
+	intraProcess *intraProcessQueue
 }
 
 // NewSubscription creates a new subscription.
@@ -685,12 +773,22 @@ func (n *Node) NewSubscription(
 		node:             n,
 		rclSubscriptionT: (*C.rcl_subscription_t)(C.malloc(C.sizeof_rcl_subscription_t)),
 		topicName:        C.CString(topicName),
+		buffers:          newMessageBufferPool(ros2msg, false),
 	}
 	*sub.rclSubscriptionT = C.rcl_get_zero_initialized_subscription()
 	defer onErr(&err, sub.Close)
 	rclOpts := C.rcl_subscription_get_default_options()
 	rclOpts.allocator = *n.context.rclAllocatorT
 	options.Qos.asCStruct(&rclOpts.qos)
+	_, dynamic := ros2msg.(*dynamicMessageTypeSupport)
+	intraProcess := n.context.intraProcess != nil &&
+		!options.IgnoreLocalPublications &&
+		options.Qos.Durability != DurabilityTransientLocal &&
+		!dynamic
+	// Intra-process subscriptions still receive local messages from the
+	// middleware, e.g. the messages of transient local publishers. Only the
+	// duplicates of the messages of intra-process publishers are dropped.
+	rclOpts.rmw_subscription_options.ignore_local_publications = C.bool(options.IgnoreLocalPublications)
 
 	rc := C.rcl_subscription_init(
 		sub.rclSubscriptionT,
@@ -702,6 +800,20 @@ func (n *Node) NewSubscription(
 	if rc != C.RCL_RET_OK {
 		return sub, errorsCastC(rc, fmt.Sprintf("Topic name '%s'", topicName))
 	}
+	if intraProcess {
+		wake, err := n.context.newGuardCondition()
+		if err != nil {
+			// The subscription is finalized by the deferred Close.
+			return nil, err
+		}
+		sub.intraProcess = newIntraProcessQueue(
+			newIntraProcessTopic(C.GoString(C.rcl_subscription_get_topic_name(sub.rclSubscriptionT)), ros2msg, &options.Qos),
+			ros2msg,
+			&options.Qos,
+		)
+		sub.intraProcess.wake = wake
+		n.context.intraProcess.add(sub.intraProcess)
+	}
 
 	n.addResource(sub)
 	return sub, nil
@@ -713,41 +825,92 @@ func (s *Subscription) Node() *Node {
 }
 
 func (s *Subscription) TakeMessage(out Message) (*MessageInfo, error) {
-	rmwMessageInfo := C.rmw_get_zero_initialized_message_info()
-
-	ros2MsgReceiveBuffer := s.Ros2MsgType.PrepareMemory()
-	defer s.Ros2MsgType.ReleaseMemory(ros2MsgReceiveBuffer)
+	if m, ok := s.intraProcess.pop(); ok {
+		m.copyTo(out, s.Ros2MsgType)
+		return m.info(), nil
+	}
+	return s.takeMiddlewareMessage(out)
+}
 
-	rc := C.rcl_take(s.rclSubscriptionT, ros2MsgReceiveBuffer, &rmwMessageInfo, nil)
-	if rc != C.RCL_RET_OK {
-		return nil, errorsCastC(rc, fmt.Sprintf("rcl_take() failed for subscription='%+v'", s))
+// takeMiddlewareMessage takes a message of the middleware, dropping the
+// duplicates of intra-process messages.
+func (s *Subscription) takeMiddlewareMessage(out Message) (*MessageInfo, error) {
+	ros2MsgReceiveBuffer := s.buffers.get()
+	defer s.buffers.put(ros2MsgReceiveBuffer)
+	for {
+		rmwMessageInfo := C.rmw_get_zero_initialized_message_info()
+		rc := C.rcl_take(s.rclSubscriptionT, ros2MsgReceiveBuffer, &rmwMessageInfo, nil)
+		if rc != C.RCL_RET_OK {
+			return nil, errorsCastC(rc, fmt.Sprintf("rcl_take() failed for subscription='%+v'", s))
+		}
+		if s.receivedIntraProcess(&rmwMessageInfo) {
+			continue
+		}
+		s.Ros2MsgType.AsGoStruct(out, ros2MsgReceiveBuffer)
+		return newMessageInfo(&rmwMessageInfo), nil
 	}
-	s.Ros2MsgType.AsGoStruct(out, ros2MsgReceiveBuffer)
-	return &MessageInfo{
-		SourceTimestamp:   time.Unix(0, int64(rmwMessageInfo.source_timestamp)),
-		ReceivedTimestamp: time.Unix(0, int64(rmwMessageInfo.received_timestamp)),
-		FromIntraProcess:  bool(rmwMessageInfo.from_intra_process),
-	}, nil
 }
 
 // TakeSerializedMessage takes a message without deserializing it and returns it
 // as a byte slice.
 func (s *Subscription) TakeSerializedMessage() ([]byte, *MessageInfo, error) {
-	info := C.rmw_get_zero_initialized_message_info()
+	if m, ok := s.intraProcess.pop(); ok {
+		buf, err := Serialize(m.msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return buf, m.info(), nil
+	}
 	msg, err := newSerializedMessage(0)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer msg.Close()
-	rc := C.rcl_take_serialized_message(s.rclSubscriptionT, msg.c(), &info, nil)
-	if rc != C.RCL_RET_OK {
-		return nil, nil, errorsCastC(rc, fmt.Sprintf("rcl_take_serialied_message() failed for subscription='%+v'", s))
+	for {
+		info := C.rmw_get_zero_initialized_message_info()
+		rc := C.rcl_take_serialized_message(s.rclSubscriptionT, msg.c(), &info, nil)
+		if rc != C.RCL_RET_OK {
+			return nil, nil, errorsCastC(rc, fmt.Sprintf("rcl_take_serialied_message() failed for subscription='%+v'", s))
+		}
+		if s.receivedIntraProcess(&info) {
+			continue
+		}
+		return msg.ToSlice(), newMessageInfo(&info), nil
 	}
-	return msg.ToSlice(), &MessageInfo{
+}
+
+// receivedIntraProcess reports whether s received the message of the
+// middleware described by info by intra-process communication already. Like
+// in rclcpp, such messages are dropped.
+func (s *Subscription) receivedIntraProcess(info *C.rmw_message_info_t) bool {
+	return s.intraProcess != nil &&
+		s.node.context.intraProcess.delivers(newGID(&info.publisher_gid), s.intraProcess)
+}
+
+// queueMessage moves a message of the middleware to the intra-process queue of
+// s, dropping the duplicates of intra-process messages on the way. It is
+// called by wait sets before calling the callback of s, so that the callback
+// isn't called only for duplicates.
+func (s *Subscription) queueMessage() {
+	msg := s.Ros2MsgType.New()
+	info, err := s.takeMiddlewareMessage(msg)
+	if err != nil {
+		var takeFailed *SubscriptionTakeFailed
+		if !errors.As(err, &takeFailed) {
+			_ = s.node.Logger().Debug(err)
+		}
+		return
+	}
+	s.intraProcess.add(intraProcessMessage{msg: msg, typeSupport: s.Ros2MsgType, middlewareInfo: info})
+}
+
+func newMessageInfo(info *C.rmw_message_info_t) *MessageInfo {
+	return &MessageInfo{
 		SourceTimestamp:   time.Unix(0, int64(info.source_timestamp)),
 		ReceivedTimestamp: time.Unix(0, int64(info.received_timestamp)),
 		FromIntraProcess:  bool(info.from_intra_process),
-	}, nil
+		PublisherGID:      newGID(&info.publisher_gid),
+	}
 }
 
 // GetPublisherCount returns the number of publishers matched to s.
@@ -766,6 +929,14 @@ func (s *Subscription) Close() (err error) {
 		return closeErr("subscription")
 	}
 	s.node.removeResource(s)
+	if s.intraProcess != nil {
+		s.node.context.intraProcess.remove(s.intraProcess)
+		// The guard condition may have been closed by the context already.
+		var cErr closeError
+		if wakeErr := s.intraProcess.wake.Close(); wakeErr != nil && !errors.As(wakeErr, &cErr) {
+			err = errors.Join(err, wakeErr)
+		}
+	}
 	rc := C.rcl_subscription_fini(s.rclSubscriptionT, s.node.rclNodeT)
 	if rc != C.RCL_RET_OK {
 		err = errors.Join(err, errorsCast(rc))
@@ -773,6 +944,7 @@ func (s *Subscription) Close() (err error) {
 	C.free(unsafe.Pointer(s.rclSubscriptionT))
 	s.rclSubscriptionT = nil
 	C.free(unsafe.Pointer(s.topicName))
+	s.buffers.close()
 	return err
 }
 
@@ -850,6 +1022,8 @@ func NewDefaultServiceOptions() *ServiceOptions {
 	return &ServiceOptions{Qos: NewDefaultServiceQosProfile()}
 }
 
+// ServiceResponseSender sends the response to a request. SendResponse may be
+// called from any goroutine, also after the handler of the request returned.
 type ServiceResponseSender interface {
 	SendResponse(resp Message) error
 }
@@ -871,6 +1045,12 @@ type Service struct {
 	handler             ServiceRequestHandler
 	requestTypeSupport  MessageTypeSupport
 	responseTypeSupport MessageTypeSupport
+	requestBuffers      *messageBufferPool
+	responseBuffers     *messageBufferPool
+	// mu serializes taking requests, sending responses and finalizing the
+	// service, as responses may be sent from other goroutines than the one
+	// of the wait set taking the requests.
+	mu sync.Mutex
 }
 
 // NewService creates a new service.
@@ -889,6 +1069,8 @@ func (n *Node) NewService(
 	s = &Service{
 		requestTypeSupport:  typeSupport.Request(),
 		responseTypeSupport: typeSupport.Response(),
+		requestBuffers:      newMessageBufferPool(typeSupport.Request(), false),
+		responseBuffers:     newMessageBufferPool(typeSupport.Response(), true),
 		node:                n,
 		rclService:          (*C.rcl_service_t)(C.malloc(C.sizeof_rcl_service_t)),
 		name:                C.CString(name),
@@ -913,6 +1095,8 @@ func (n *Node) NewService(
 }
 
 func (s *Service) Close() (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.name == nil {
 		return closeErr("service")
 	}
@@ -922,6 +1106,8 @@ func (s *Service) Close() (err error) {
 		err = errorsCastC(rc, "failed to finalize service")
 	}
 	C.free(unsafe.Pointer(s.rclService))
+	s.requestBuffers.close()
+	s.responseBuffers.close()
 	C.free(unsafe.Pointer(s.name))
 	s.name = nil
 	return err
@@ -934,9 +1120,17 @@ func (s *Service) Node() *Node {
 
 func (s *Service) handleRequest() {
 	var reqHeader C.rmw_service_info_t
-	reqBuffer := s.requestTypeSupport.PrepareMemory()
-	defer s.requestTypeSupport.ReleaseMemory(reqBuffer)
-	switch rc := C.rcl_take_request_with_info(s.rclService, &reqHeader, reqBuffer); rc {
+	s.mu.Lock()
+	if s.name == nil {
+		// s was closed while the wait set was waiting.
+		s.mu.Unlock()
+		return
+	}
+	reqBuffer := s.requestBuffers.get()
+	defer s.requestBuffers.put(reqBuffer)
+	rc := C.rcl_take_request_with_info(s.rclService, &reqHeader, reqBuffer)
+	s.mu.Unlock()
+	switch rc {
 	case C.RCL_RET_OK:
 		info := ServiceInfo{
 			SourceTimestamp:   time.Unix(0, int64(reqHeader.source_timestamp)),
@@ -949,8 +1143,13 @@ func (s *Service) handleRequest() {
 			&info,
 			req,
 			serviceResponseSender(func(resp Message) error {
-				respBuffer := s.responseTypeSupport.PrepareMemory()
-				defer s.responseTypeSupport.ReleaseMemory(respBuffer)
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				if s.name == nil {
+					return closeErr("service")
+				}
+				respBuffer := s.responseBuffers.get()
+				defer s.responseBuffers.put(respBuffer)
 				s.responseTypeSupport.AsCStruct(respBuffer, resp)
 				rc := C.rcl_send_response(s.rclService, &reqHeader.request_id, respBuffer)
 				if rc != C.RCL_RET_OK {
@@ -967,6 +1166,9 @@ func (s *Service) handleRequest() {
 
 type ClientOptions struct {
 	Qos QosProfile
+	// Validate makes Send validate requests implementing Validator before
+	// sending them.
+	Validate bool
 }
 
 func NewDefaultClientOptions() *ClientOptions {
@@ -982,6 +1184,7 @@ type Client struct {
 	node      *Node
 	rclClient *C.rcl_client_t
 	sender    requestSender
+	validate  bool
 }
 
 // NewClient creates a new client.
@@ -999,6 +1202,7 @@ func (n *Node) NewClient(
 	c = &Client{
 		node:      n,
 		rclClient: (*C.rcl_client_t)(C.malloc(C.sizeof_rcl_client_t)),
+		validate:  options.Validate,
 	}
 	c.sender = newRequestSender(requestSenderTransport{
 		SendRequest:  c.sendRequest,
@@ -1048,6 +1252,11 @@ func (c *Client) Node() *Node {
 }
 
 func (c *Client) Send(ctx context.Context, req Message) (Message, *ServiceInfo, error) {
+	if c.validate {
+		if err := ValidateMessage(req); err != nil {
+			return nil, nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
 	resp, info, err := c.sender.Send(ctx, req)
 	if rmwInfo, ok := info.(*ServiceInfo); ok {
 		return resp, rmwInfo, err
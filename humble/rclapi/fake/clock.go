@@ -0,0 +1,169 @@
+package fake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+// Clock is a clock that only advances when told to. It is safe for concurrent
+// use.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*clockTimer
+}
+
+var _ rclapi.Clock = (*Clock)(nil)
+
+type clockTimer struct {
+	when   time.Time
+	period time.Duration // zero for one-shot timers
+	fire   func(now time.Time)
+}
+
+// NewClock returns a clock whose current time is start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time of c once c has advanced by
+// d.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.mu.Lock()
+	t := &clockTimer{when: c.now.Add(d), fire: func(now time.Time) { ch <- now }}
+	c.mu.Unlock()
+	c.add(t)
+	return ch
+}
+
+// Advance moves the time of c forward by d. Timers that expire in the
+// meantime fire in order of their expiry times, and the time of c equals the
+// expiry time while a timer fires. Advance returns after all timer callbacks
+// have returned.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	c.advanceTo(target)
+}
+
+// Set sets the time of c. If t is after the current time, Set is equivalent to
+// Advance. Otherwise the time is moved backwards without firing timers.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	if !t.After(c.now) {
+		c.now = t
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	c.advanceTo(t)
+}
+
+func (c *Clock) advanceTo(target time.Time) {
+	for {
+		c.mu.Lock()
+		next := -1
+		for i, t := range c.timers {
+			if !t.when.After(target) && (next < 0 || t.when.Before(c.timers[next].when)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers[next]
+		if t.when.After(c.now) {
+			c.now = t.when
+		}
+		now := c.now
+		if t.period > 0 {
+			t.when = t.when.Add(t.period)
+		} else {
+			c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		}
+		c.mu.Unlock()
+		t.fire(now)
+	}
+}
+
+func (c *Clock) add(t *clockTimer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timers = append(c.timers, t)
+}
+
+func (c *Clock) remove(t *clockTimer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, x := range c.timers {
+		if x == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// reset restarts the period of t at the current time of c.
+func (c *Clock) reset(t *clockTimer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t.when = c.now.Add(t.period)
+}
+
+// Timer is a timer of a fake node.
+type Timer struct {
+	node  *Node
+	clock *Clock
+	timer *clockTimer
+	once  closeOnce
+}
+
+var _ rclapi.Timer = (*Timer)(nil)
+
+// NewTimer creates a timer that calls callback every period of the clock of
+// the bus of n. A zero period defaults to one second like humble timers.
+func (n *Node) NewTimer(period time.Duration, callback func()) (rclapi.Timer, error) {
+	if period < 0 {
+		return nil, fmt.Errorf("invalid timer period %v", period)
+	}
+	if period == 0 {
+		period = time.Second
+	}
+	clock := n.bus.clock
+	t := &Timer{node: n, clock: clock}
+	clock.mu.Lock()
+	t.timer = &clockTimer{when: clock.now.Add(period), period: period, fire: func(time.Time) { callback() }}
+	clock.mu.Unlock()
+	if err := n.addEntity(t); err != nil {
+		return nil, err
+	}
+	clock.add(t.timer)
+	return t, nil
+}
+
+func (t *Timer) Reset() error {
+	t.clock.reset(t.timer)
+	return nil
+}
+
+func (t *Timer) Close() error {
+	if err := t.once.close("timer"); err != nil {
+		return err
+	}
+	t.node.removeEntity(t)
+	t.clock.remove(t.timer)
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+Package fake implements the interfaces of package rclapi in memory, which
+allows unit testing code that uses ROS without initializing rcl or a
+middleware.
+
+Nodes are created on a Bus, which connects the nodes like a ROS graph:
+
+  - Messages are delivered synchronously: Publish returns after the handlers
+    of all matching subscriptions have returned. Each subscription receives
+    its own clone of the message.
+  - Service requests are dispatched synchronously to the service handler.
+    Send returns once the handler has sent a response.
+  - Goals are executed by an ActionServer created with Node.NewActionServer.
+    Goals are always accepted and run in their own goroutines.
+  - Timers are driven by the Clock of the Bus, which only advances when
+    Clock.Advance or Clock.Set is called. Timer callbacks are called by the
+    goroutine advancing the clock.
+
+Sending a request or a goal fails with ErrNoServer if no server exists,
+instead of waiting for one to appear.
+
+QoS settings are ignored. All nodes of a Bus are treated as being in the same
+Context, so subscriptions that ignore local publications only receive
+messages injected with Bus.Publish.
+*/
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+// ErrNoServer is returned when a request is sent to a service or action that
+// has no server.
+var ErrNoServer = errors.New("no server is available")
+
+// Bus connects fake nodes.
+type Bus struct {
+	clock *Clock
+
+	mu       sync.Mutex
+	topics   map[string]*topic
+	services map[string]*Service
+	actions  map[string]*action
+}
+
+// NewBus returns a new Bus whose clock starts at the Unix epoch.
+func NewBus() *Bus {
+	return &Bus{
+		clock:    NewClock(time.Unix(0, 0)),
+		topics:   map[string]*topic{},
+		services: map[string]*Service{},
+		actions:  map[string]*action{},
+	}
+}
+
+// Clock returns the clock of b, which is used by all nodes of b.
+func (b *Bus) Clock() *Clock {
+	return b.clock
+}
+
+// Node is a fake node. In addition to rclapi.Node, it allows creating action
+// servers.
+type Node struct {
+	bus       *Bus
+	name      string
+	namespace string
+
+	mu       sync.Mutex
+	closed   bool
+	entities []io.Closer
+}
+
+var _ rclapi.Node = (*Node)(nil)
+
+// NewNode creates a node on b. The namespace defaults to the root namespace.
+func (b *Bus) NewNode(name, namespace string) (*Node, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid node name %q", name)
+	}
+	if namespace == "" {
+		namespace = "/"
+	}
+	if !strings.HasPrefix(namespace, "/") {
+		namespace = "/" + namespace
+	}
+	if len(namespace) > 1 {
+		namespace = strings.TrimSuffix(namespace, "/")
+	}
+	return &Node{bus: b, name: name, namespace: namespace}, nil
+}
+
+func (n *Node) Name() string      { return n.name }
+func (n *Node) Namespace() string { return n.namespace }
+
+func (n *Node) FullyQualifiedName() string {
+	if n.namespace == "/" {
+		return "/" + n.name
+	}
+	return n.namespace + "/" + n.name
+}
+
+// Clock returns the clock of the bus of n.
+func (n *Node) Clock() rclapi.Clock {
+	return n.bus.clock
+}
+
+// Spin blocks until ctx is canceled, because entities of fake nodes are
+// handled without spinning.
+func (n *Node) Spin(ctx context.Context) error {
+	<-ctx.Done()
+	return fmt.Errorf("failed to spin node: %w", ctx.Err())
+}
+
+func (n *Node) Close() error {
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return errors.New("tried to close a closed node")
+	}
+	n.closed = true
+	entities := n.entities
+	n.entities = nil
+	n.mu.Unlock()
+	var err error
+	for _, e := range entities {
+		err = errors.Join(err, e.Close())
+	}
+	return err
+}
+
+// addEntity registers an entity to be closed with n.
+func (n *Node) addEntity(e io.Closer) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return errors.New("node is closed")
+	}
+	n.entities = append(n.entities, e)
+	return nil
+}
+
+func (n *Node) removeEntity(e io.Closer) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.entities = slices.DeleteFunc(n.entities, func(x io.Closer) bool { return x == e })
+}
+
+// expandName expands a topic or service name relative to the namespace of n.
+func (n *Node) expandName(name string) (string, error) {
+	switch {
+	case name == "" || strings.HasSuffix(name, "/") || strings.Contains(name, "//"):
+		return "", fmt.Errorf("invalid name %q", name)
+	case strings.HasPrefix(name, "/"):
+		return name, nil
+	case name == "~":
+		return n.FullyQualifiedName(), nil
+	case strings.HasPrefix(name, "~/"):
+		return n.FullyQualifiedName() + name[1:], nil
+	case n.namespace == "/":
+		return "/" + name, nil
+	}
+	return n.namespace + "/" + name, nil
+}
+
+// validate validates msg if it implements rclapi.Validator.
+func validate(msg rclapi.Message) error {
+	if v, ok := msg.(rclapi.Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// closeOnce implements closing an entity once. Closing an entity again
+// returns an error like closing a humble entity does.
+type closeOnce struct {
+	mu     sync.Mutex
+	closed bool
+}
+
+func (c *closeOnce) close(kind string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return fmt.Errorf("tried to close a closed %s", kind)
+	}
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,367 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+// testMsg is used as every message of the test interfaces. Actions use Goal
+// as the goal ID, Inner as the goal, result or feedback, and Status for the
+// status of a result.
+type testMsg struct {
+	Data     string
+	Goal     rclapi.GoalID
+	Inner    *testMsg
+	Status   rclapi.GoalStatus
+	Accepted bool
+}
+
+func (m *testMsg) clone() *testMsg {
+	c := *m
+	if m.Inner != nil {
+		c.Inner = m.Inner.clone()
+	}
+	return &c
+}
+
+func (m *testMsg) Validate() error {
+	if m.Inner != nil {
+		if err := m.Inner.Validate(); err != nil {
+			return fmt.Errorf("inner.%w", err)
+		}
+	}
+	if len(m.Data) > 8 {
+		return fmt.Errorf("data: string has %d bytes, exceeding its bound of 8", len(m.Data))
+	}
+	return nil
+}
+
+type otherMsg struct{ testMsg }
+
+type testTypeSupport struct{ other bool }
+
+func (ts testTypeSupport) New() rclapi.Message {
+	if ts.other {
+		return &otherMsg{}
+	}
+	return &testMsg{}
+}
+
+func (testTypeSupport) Clone(msg rclapi.Message) rclapi.Message {
+	if m, ok := msg.(*otherMsg); ok {
+		return &otherMsg{*m.clone()}
+	}
+	return msg.(*testMsg).clone()
+}
+
+func (ts testTypeSupport) Request() rclapi.MessageTypeSupport         { return ts }
+func (ts testTypeSupport) Response() rclapi.MessageTypeSupport        { return ts }
+func (ts testTypeSupport) Goal() rclapi.MessageTypeSupport            { return ts }
+func (ts testTypeSupport) Result() rclapi.MessageTypeSupport          { return ts }
+func (ts testTypeSupport) Feedback() rclapi.MessageTypeSupport        { return ts }
+func (ts testTypeSupport) FeedbackMessage() rclapi.MessageTypeSupport { return ts }
+func (ts testTypeSupport) CancelGoal() rclapi.ServiceTypeSupport      { return ts }
+
+func (testTypeSupport) NewSendGoalResponse(bool, time.Duration) rclapi.Message {
+	return &testMsg{Accepted: true}
+}
+
+func (testTypeSupport) NewGetResultResponse(status rclapi.GoalStatus, result rclapi.Message) rclapi.Message {
+	return &testMsg{Status: status, Inner: result.(*testMsg)}
+}
+
+func (testTypeSupport) NewFeedbackMessage(goalID *rclapi.GoalID, feedback rclapi.Message) rclapi.Message {
+	return &testMsg{Goal: *goalID, Inner: feedback.(*testMsg)}
+}
+
+func newTestNode(t *testing.T, bus *Bus, name, namespace string) *Node {
+	t.Helper()
+	node, err := bus.NewNode(name, namespace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = node.Close() })
+	return node
+}
+
+func TestExpandName(t *testing.T) {
+	node := newTestNode(t, NewBus(), "talker", "ns")
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"chatter", "/ns/chatter"},
+		{"/chatter", "/chatter"},
+		{"~", "/ns/talker"},
+		{"~/chatter", "/ns/talker/chatter"},
+		{"a/b", "/ns/a/b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := node.expandName(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+	for _, name := range []string{"", "a/", "a//b"} {
+		if _, err := node.expandName(name); err == nil {
+			t.Fatalf("expected an error for %q", name)
+		}
+	}
+}
+
+func TestPubSub(t *testing.T) {
+	bus := NewBus()
+	talker := newTestNode(t, bus, "talker", "")
+	listener := newTestNode(t, bus, "listener", "")
+	pub, err := talker.NewPublisher("chatter", testTypeSupport{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*testMsg
+	sub, err := listener.NewSubscription("/chatter", testTypeSupport{}, nil, func(msg rclapi.Message, info *rclapi.MessageInfo, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		got = append(got, msg.(*testMsg))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotRemote []*testMsg
+	_, err = listener.NewSubscription("chatter", testTypeSupport{}, &rclapi.SubscriptionOptions{IgnoreLocalPublications: true}, func(msg rclapi.Message, info *rclapi.MessageInfo, err error) {
+		gotRemote = append(gotRemote, msg.(*testMsg))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := pub.GetSubscriptionCount(); n != 2 {
+		t.Fatalf("got %d subscriptions, want 2", n)
+	}
+	if n, _ := sub.GetPublisherCount(); n != 1 {
+		t.Fatalf("got %d publishers, want 1", n)
+	}
+	msg := &testMsg{Data: "hello"}
+	if err := pub.Publish(msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish("/chatter", &testMsg{Data: "remote"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Data != "hello" || got[1].Data != "remote" {
+		t.Fatalf("unexpected messages %+v", got)
+	}
+	if got[0] == msg {
+		t.Fatal("the published message was not cloned")
+	}
+	if len(gotRemote) != 1 || gotRemote[0].Data != "remote" {
+		t.Fatalf("unexpected messages when ignoring local publications %+v", gotRemote)
+	}
+	if _, err := listener.NewSubscription("chatter", testTypeSupport{other: true}, nil, nil); err == nil {
+		t.Fatal("expected an error for a mismatching message type")
+	}
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sub.Close(); err == nil {
+		t.Fatal("expected an error when closing twice")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bus := NewBus()
+	node := newTestNode(t, bus, "talker", "")
+	pub, err := node.NewPublisher("chatter", testTypeSupport{}, &rclapi.PublisherOptions{Validate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Publish(&testMsg{Data: "short"}); err != nil {
+		t.Fatal(err)
+	}
+	err = pub.Publish(&testMsg{Inner: &testMsg{Data: "too long data"}})
+	want := "invalid message: inner.data: string has 13 bytes, exceeding its bound of 8"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got %v, want %q", err, want)
+	}
+
+	unchecked, err := node.NewPublisher("chatter", testTypeSupport{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unchecked.Publish(&testMsg{Data: "too long data"}); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := node.NewClient("srv", testTypeSupport{}, &rclapi.ClientOptions{Validate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Send(context.Background(), &testMsg{Data: "too long data"})
+	want = "invalid request: data: string has 13 bytes, exceeding its bound of 8"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got %v, want %q", err, want)
+	}
+}
+
+func TestService(t *testing.T) {
+	bus := NewBus()
+	server := newTestNode(t, bus, "server", "")
+	client := newTestNode(t, bus, "client", "")
+	c, err := client.NewClient("echo", testTypeSupport{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Send(context.Background(), &testMsg{}); !errors.Is(err, ErrNoServer) {
+		t.Fatalf("got error %v, want ErrNoServer", err)
+	}
+	_, err = server.NewService("echo", testTypeSupport{}, nil, func(info *rclapi.ServiceInfo, req rclapi.Message, sender rclapi.ServiceResponseSender) {
+		if err := sender.SendResponse(&testMsg{Data: req.(*testMsg).Data + "!"}); err != nil {
+			t.Error(err)
+		}
+		if err := sender.SendResponse(&testMsg{}); err == nil {
+			t.Error("expected an error when responding twice")
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.NewService("echo", testTypeSupport{}, nil, nil); err == nil {
+		t.Fatal("expected an error for a duplicate service")
+	}
+	resp, info, err := c.Send(context.Background(), &testMsg{Data: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.(*testMsg).Data != "hi!" {
+		t.Fatalf("got response %q, want %q", resp.(*testMsg).Data, "hi!")
+	}
+	if info.RequestID.SequenceNumber != 1 {
+		t.Fatalf("got sequence number %d, want 1", info.RequestID.SequenceNumber)
+	}
+}
+
+func TestTimer(t *testing.T) {
+	bus := NewBus()
+	node := newTestNode(t, bus, "node", "")
+	var fired []time.Time
+	timer, err := node.NewTimer(time.Second, func() { fired = append(fired, bus.Clock().Now()) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := node.Clock().After(1500 * time.Millisecond)
+	bus.Clock().Advance(2500 * time.Millisecond)
+	if len(fired) != 2 || fired[0] != time.Unix(1, 0) || fired[1] != time.Unix(2, 0) {
+		t.Fatalf("unexpected timer calls %v", fired)
+	}
+	select {
+	case now := <-after:
+		if now != time.Unix(1, 5e8) {
+			t.Fatalf("After received %v", now)
+		}
+	default:
+		t.Fatal("After did not fire")
+	}
+	if err := timer.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	bus.Clock().Advance(900 * time.Millisecond)
+	if len(fired) != 2 {
+		t.Fatalf("the timer fired %d times after reset", len(fired)-2)
+	}
+	if err := node.Close(); err != nil {
+		t.Fatal(err)
+	}
+	bus.Clock().Advance(time.Minute)
+	if len(fired) != 2 {
+		t.Fatal("the timer fired after the node was closed")
+	}
+	if _, err := node.NewTimer(time.Second, func() {}); err == nil {
+		t.Fatal("expected an error when creating a timer on a closed node")
+	}
+}
+
+func TestAction(t *testing.T) {
+	bus := NewBus()
+	server := newTestNode(t, bus, "server", "")
+	client := newTestNode(t, bus, "client", "")
+	c, err := client.NewActionClient("count", testTypeSupport{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.SendGoal(context.Background(), &testMsg{}); !errors.Is(err, ErrNoServer) {
+		t.Fatalf("got error %v, want ErrNoServer", err)
+	}
+	_, err = server.NewActionServer("count", testTypeSupport{}, func(ctx context.Context, goal rclapi.Message, sendFeedback func(rclapi.Message)) (rclapi.Message, error) {
+		if goal.(*testMsg).Data == "forever" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		sendFeedback(&testMsg{Data: "working"})
+		return &testMsg{Data: "done"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("succeed", func(t *testing.T) {
+		var mu sync.Mutex
+		var feedback []string
+		resp, _, err := c.WatchGoal(context.Background(), &testMsg{Data: "once"}, func(ctx context.Context, msg rclapi.Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			feedback = append(feedback, msg.(*testMsg).Inner.Data)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		result := resp.(*testMsg)
+		if result.Status != rclapi.GoalSucceeded || result.Inner.Data != "done" {
+			t.Fatalf("unexpected result %+v", result)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(feedback) != 1 || feedback[0] != "working" {
+			t.Fatalf("unexpected feedback %v", feedback)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		resp, goalID, err := c.SendGoal(context.Background(), &testMsg{Data: "forever"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !resp.(*testMsg).Accepted {
+			t.Fatal("the goal was not accepted")
+		}
+		if _, err := c.CancelGoal(context.Background(), goalID); err != nil {
+			t.Fatal(err)
+		}
+		resp, err = c.GetResult(context.Background(), goalID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status := resp.(*testMsg).Status; status != rclapi.GoalCanceled {
+			t.Fatalf("got status %v, want %v", status, rclapi.GoalCanceled)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		resp, err := c.GetResult(context.Background(), &rclapi.GoalID{1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status := resp.(*testMsg).Status; status != rclapi.GoalUnknown {
+			t.Fatalf("got status %v, want %v", status, rclapi.GoalUnknown)
+		}
+	})
+}
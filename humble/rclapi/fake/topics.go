@@ -0,0 +1,193 @@
+package fake
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+type topic struct {
+	typ  reflect.Type // the Go type of the messages
+	pubs []*Publisher
+	subs []*Subscription
+}
+
+// topic returns the topic with name, creating it if it does not exist. The
+// message type of the topic must match typ. b.mu must be held.
+func (b *Bus) topic(name string, typ reflect.Type) (*topic, error) {
+	t := b.topics[name]
+	if t == nil {
+		t = &topic{typ: typ}
+		b.topics[name] = t
+	}
+	if t.typ != typ {
+		return nil, fmt.Errorf("topic %s has message type %v, not %v", name, t.typ, typ)
+	}
+	return t, nil
+}
+
+// removeTopicIfUnused removes the topic with name if it has no endpoints. b.mu
+// must be held.
+func (b *Bus) removeTopicIfUnused(name string) {
+	if t := b.topics[name]; t != nil && len(t.pubs) == 0 && len(t.subs) == 0 {
+		delete(b.topics, name)
+	}
+}
+
+// Publish delivers msg to the subscriptions of topic as if it was published
+// outside of the Context of the nodes of b. In particular, subscriptions
+// ignoring local publications receive msg.
+func (b *Bus) Publish(topic string, msg rclapi.Message) error {
+	return b.deliver(topic, msg, false)
+}
+
+func (b *Bus) deliver(topic string, msg rclapi.Message, local bool) error {
+	b.mu.Lock()
+	t := b.topics[topic]
+	var subs []*Subscription
+	if t != nil {
+		if typ := reflect.TypeOf(msg); typ != t.typ {
+			b.mu.Unlock()
+			return fmt.Errorf("topic %s has message type %v, not %v", topic, t.typ, typ)
+		}
+		subs = slices.Clone(t.subs)
+	}
+	b.mu.Unlock()
+	now := b.clock.Now()
+	for _, sub := range subs {
+		if local && sub.ignoreLocal {
+			continue
+		}
+		sub.handler(sub.typeSupport.Clone(msg), &rclapi.MessageInfo{SourceTimestamp: now, ReceivedTimestamp: now}, nil)
+	}
+	return nil
+}
+
+// Publisher is a publisher of a fake node.
+type Publisher struct {
+	node     *Node
+	topic    string
+	once     closeOnce
+	validate bool
+}
+
+var _ rclapi.Publisher = (*Publisher)(nil)
+
+func (n *Node) NewPublisher(topic string, ts rclapi.MessageTypeSupport, opts *rclapi.PublisherOptions) (rclapi.Publisher, error) {
+	name, err := n.expandName(topic)
+	if err != nil {
+		return nil, err
+	}
+	p := &Publisher{node: n, topic: name, validate: opts != nil && opts.Validate}
+	if err := n.addEntity(p); err != nil {
+		return nil, err
+	}
+	b := n.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, err := b.topic(name, reflect.TypeOf(ts.New()))
+	if err != nil {
+		n.removeEntity(p)
+		return nil, err
+	}
+	t.pubs = append(t.pubs, p)
+	return p, nil
+}
+
+// Publish delivers msg to all subscriptions of the topic of p.
+func (p *Publisher) Publish(msg rclapi.Message) error {
+	if p.validate {
+		if err := validate(msg); err != nil {
+			return fmt.Errorf("invalid message: %w", err)
+		}
+	}
+	return p.node.bus.deliver(p.topic, msg, true)
+}
+
+func (p *Publisher) GetSubscriptionCount() (int, error) {
+	b := p.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t := b.topics[p.topic]; t != nil {
+		return len(t.subs), nil
+	}
+	return 0, nil
+}
+
+func (p *Publisher) Close() error {
+	if err := p.once.close("publisher"); err != nil {
+		return err
+	}
+	p.node.removeEntity(p)
+	b := p.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t := b.topics[p.topic]; t != nil {
+		t.pubs = slices.DeleteFunc(t.pubs, func(x *Publisher) bool { return x == p })
+		b.removeTopicIfUnused(p.topic)
+	}
+	return nil
+}
+
+// Subscription is a subscription of a fake node.
+type Subscription struct {
+	node        *Node
+	topic       string
+	typeSupport rclapi.MessageTypeSupport
+	handler     rclapi.SubscriptionHandler
+	ignoreLocal bool
+	once        closeOnce
+}
+
+var _ rclapi.Subscription = (*Subscription)(nil)
+
+func (n *Node) NewSubscription(topic string, ts rclapi.MessageTypeSupport, opts *rclapi.SubscriptionOptions, handler rclapi.SubscriptionHandler) (rclapi.Subscription, error) {
+	name, err := n.expandName(topic)
+	if err != nil {
+		return nil, err
+	}
+	s := &Subscription{node: n, topic: name, typeSupport: ts, handler: handler}
+	if opts != nil {
+		s.ignoreLocal = opts.IgnoreLocalPublications
+	}
+	if err := n.addEntity(s); err != nil {
+		return nil, err
+	}
+	b := n.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, err := b.topic(name, reflect.TypeOf(ts.New()))
+	if err != nil {
+		n.removeEntity(s)
+		return nil, err
+	}
+	t.subs = append(t.subs, s)
+	return s, nil
+}
+
+func (s *Subscription) GetPublisherCount() (int, error) {
+	b := s.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t := b.topics[s.topic]; t != nil {
+		return len(t.pubs), nil
+	}
+	return 0, nil
+}
+
+func (s *Subscription) Close() error {
+	if err := s.once.close("subscription"); err != nil {
+		return err
+	}
+	s.node.removeEntity(s)
+	b := s.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t := b.topics[s.topic]; t != nil {
+		t.subs = slices.DeleteFunc(t.subs, func(x *Subscription) bool { return x == s })
+		b.removeTopicIfUnused(s.topic)
+	}
+	return nil
+}
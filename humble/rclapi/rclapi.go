@@ -0,0 +1,242 @@
+/*
+Package rclapi defines interfaces for the ROS entities used by application
+code, so that the code can run unchanged on rcl or on the in-memory
+implementation in package fake.
+
+The package does not depend on rcl or cgo. Type supports, options and
+handlers are described by the types of this package, and package rclnode
+adapts a humble.Node and the type supports of generated bindings to them:
+
+	node, err := humble.NewNode("planner", "")
+	if err != nil {
+		return err
+	}
+	defer node.Close()
+	return runPlanner(ctx, rclnode.Wrap(node), rclnode.MessageTypeSupport(nav_msgs_msg.PathTypeSupport))
+
+while tests use a fake node and any implementation of the type supports:
+
+	bus := fake.NewBus()
+	node, err := bus.NewNode("planner", "")
+	...
+	go runPlanner(ctx, node, pathTypeSupport)
+	bus.Clock().Advance(time.Second)
+
+Subscriptions receive deserialized messages through a SubscriptionHandler
+instead of taking them from a humble.Subscription.
+*/
+package rclapi
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+)
+
+// Node creates ROS entities and spins them.
+type Node interface {
+	Name() string
+	Namespace() string
+	FullyQualifiedName() string
+
+	// Clock returns the clock used by the timers of the node.
+	Clock() Clock
+
+	NewPublisher(topic string, ts MessageTypeSupport, opts *PublisherOptions) (Publisher, error)
+	NewSubscription(topic string, ts MessageTypeSupport, opts *SubscriptionOptions, handler SubscriptionHandler) (Subscription, error)
+	NewService(name string, ts ServiceTypeSupport, opts *ServiceOptions, handler ServiceRequestHandler) (Service, error)
+	NewClient(name string, ts ServiceTypeSupport, opts *ClientOptions) (Client, error)
+	NewActionClient(name string, ts ActionTypeSupport, opts *ActionClientOptions) (ActionClient, error)
+
+	// NewTimer creates a timer that calls callback every period while the
+	// node is spinning.
+	NewTimer(period time.Duration, callback func()) (Timer, error)
+
+	// Spin handles the entities created before Spin was called until an
+	// error occurs or ctx is canceled.
+	Spin(ctx context.Context) error
+
+	// Close closes the node and all entities created by it.
+	Close() error
+}
+
+// Message is a ROS message, e.g. a pointer to a message of generated bindings.
+// The type of a message is described by its MessageTypeSupport.
+type Message any
+
+// Validator is implemented by messages that can check their fields, such as
+// the messages of generated bindings.
+type Validator interface {
+	Validate() error
+}
+
+// MessageTypeSupport creates and copies messages of one type.
+type MessageTypeSupport interface {
+	// New returns a new message with default values.
+	New() Message
+	// Clone returns a deep copy of msg.
+	Clone(msg Message) Message
+}
+
+type ServiceTypeSupport interface {
+	Request() MessageTypeSupport
+	Response() MessageTypeSupport
+}
+
+// ActionTypeSupport describes the messages of an action. The constructors
+// build the messages that an action server would send.
+type ActionTypeSupport interface {
+	Goal() MessageTypeSupport
+	Result() MessageTypeSupport
+	Feedback() MessageTypeSupport
+	// FeedbackMessage describes the messages passed to FeedbackHandlers,
+	// which contain the goal ID and the feedback.
+	FeedbackMessage() MessageTypeSupport
+	CancelGoal() ServiceTypeSupport
+
+	NewSendGoalResponse(accepted bool, stamp time.Duration) Message
+	NewGetResultResponse(status GoalStatus, result Message) Message
+	NewFeedbackMessage(goalID *GoalID, feedback Message) Message
+}
+
+const GoalIDLen = 16
+
+type GoalID [GoalIDLen]byte
+
+func (id *GoalID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// GoalStatus is the status of a goal. The values are those of the GoalStatus
+// of rcl.
+type GoalStatus int8
+
+const (
+	GoalUnknown GoalStatus = iota
+	GoalAccepted
+	GoalExecuting
+	GoalCanceling
+	GoalSucceeded
+	GoalCanceled
+	GoalAborted
+)
+
+type MessageInfo struct {
+	SourceTimestamp   time.Time
+	ReceivedTimestamp time.Time
+	FromIntraProcess  bool
+}
+
+type RequestID struct {
+	WriterGUID     [16]int8
+	SequenceNumber int64
+}
+
+type ServiceInfo struct {
+	SourceTimestamp   time.Time
+	ReceivedTimestamp time.Time
+	RequestID         RequestID
+}
+
+// The options below are those of package humble without the fields that
+// depend on rcl. The QoS fields are passed to the implementation of Node: the
+// nodes of package rclnode accept a humble.QosProfile and use the default
+// profile if the field is nil, and fake nodes ignore them.
+
+type PublisherOptions struct {
+	Qos any
+	// Validate makes Publish validate messages implementing Validator before
+	// publishing them.
+	Validate bool
+}
+
+type SubscriptionOptions struct {
+	Qos any
+	// If IgnoreLocalPublications is true, messages published by publishers in
+	// the same Context are not delivered to the subscription.
+	IgnoreLocalPublications bool
+}
+
+type ServiceOptions struct {
+	Qos any
+}
+
+type ClientOptions struct {
+	Qos any
+	// Validate makes Send validate requests implementing Validator before
+	// sending them.
+	Validate bool
+}
+
+type ActionClientOptions struct {
+	GoalServiceQos   any
+	CancelServiceQos any
+	ResultServiceQos any
+	FeedbackTopicQos any
+	StatusTopicQos   any
+}
+
+// SubscriptionHandler is called for every message received by a
+// subscription. If taking the message failed, msg is nil and err is non-nil.
+type SubscriptionHandler func(msg Message, info *MessageInfo, err error)
+
+// ServiceResponseSender sends the response to a request. SendResponse may be
+// called from any goroutine, also after the handler of the request returned.
+type ServiceResponseSender interface {
+	SendResponse(resp Message) error
+}
+
+type ServiceRequestHandler func(info *ServiceInfo, request Message, sender ServiceResponseSender)
+
+// FeedbackHandler is called with the FeedbackMessages of a goal.
+type FeedbackHandler func(ctx context.Context, msg Message)
+
+type Publisher interface {
+	Publish(msg Message) error
+	GetSubscriptionCount() (int, error)
+	Close() error
+}
+
+type Subscription interface {
+	GetPublisherCount() (int, error)
+	Close() error
+}
+
+type Service interface {
+	Close() error
+}
+
+type Client interface {
+	Send(ctx context.Context, req Message) (Message, *ServiceInfo, error)
+	Close() error
+}
+
+// ActionClient sends goals to an action server. Except for CancelGoal, the
+// semantics of the methods are those of the methods of humble.ActionClient
+// with the same names.
+type ActionClient interface {
+	SendGoal(ctx context.Context, goal Message) (Message, *GoalID, error)
+	WatchGoal(ctx context.Context, goal Message, onFeedback FeedbackHandler) (Message, *GoalID, error)
+	GetResult(ctx context.Context, goalID *GoalID) (Message, error)
+	// CancelGoal requests the goal with goalID to be canceled, or all goals
+	// if goalID is the zero GoalID, and returns the CancelGoal response of
+	// the server.
+	CancelGoal(ctx context.Context, goalID *GoalID) (Message, error)
+	WatchFeedback(ctx context.Context, goalID *GoalID, handler FeedbackHandler) <-chan error
+	Close() error
+}
+
+type Timer interface {
+	// Reset restarts the period of the timer.
+	Reset() error
+	Close() error
+}
+
+// Clock tells the time.
+type Clock interface {
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
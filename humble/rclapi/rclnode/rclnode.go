@@ -0,0 +1,373 @@
+/*
+Package rclnode implements the interfaces of package rclapi on rcl. Wrap adapts
+a humble.Node, and MessageTypeSupport, ServiceTypeSupport and
+ActionTypeSupport adapt the type supports of generated bindings. The nodes
+returned by Wrap only accept type supports adapted by this package.
+
+Messages passed to the entities of the nodes must implement humble.Message.
+*/
+package rclnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+// Wrap adapts node to the Node interface. Closing the returned Node closes
+// node.
+//
+// The Clock of the returned Node uses the system time.
+func Wrap(node *humble.Node) rclapi.Node {
+	return &rclNode{node: node}
+}
+
+type rclNode struct {
+	node *humble.Node
+
+	// Timers are owned by the Context of the node, so they are tracked
+	// here to be spun and closed with the node.
+	mu     sync.Mutex
+	timers []*rclTimer
+}
+
+func (n *rclNode) Name() string               { return n.node.Name() }
+func (n *rclNode) Namespace() string          { return n.node.Namespace() }
+func (n *rclNode) FullyQualifiedName() string { return n.node.FullyQualifiedName() }
+func (n *rclNode) Clock() rclapi.Clock        { return systemClock{} }
+
+func (n *rclNode) NewPublisher(topic string, ts rclapi.MessageTypeSupport, opts *rclapi.PublisherOptions) (rclapi.Publisher, error) {
+	mts, err := unwrapMessageTypeSupport(ts)
+	if err != nil {
+		return nil, err
+	}
+	var pubOpts *humble.PublisherOptions
+	if opts != nil {
+		pubOpts = &humble.PublisherOptions{Validate: opts.Validate}
+		if pubOpts.Qos, err = qos(opts.Qos, humble.NewDefaultQosProfile()); err != nil {
+			return nil, err
+		}
+	}
+	pub, err := n.node.NewPublisher(topic, mts, pubOpts)
+	if err != nil {
+		return nil, err
+	}
+	return publisher{pub}, nil
+}
+
+func (n *rclNode) NewSubscription(topic string, ts rclapi.MessageTypeSupport, opts *rclapi.SubscriptionOptions, handler rclapi.SubscriptionHandler) (rclapi.Subscription, error) {
+	mts, err := unwrapMessageTypeSupport(ts)
+	if err != nil {
+		return nil, err
+	}
+	var subOpts *humble.SubscriptionOptions
+	if opts != nil {
+		subOpts = &humble.SubscriptionOptions{IgnoreLocalPublications: opts.IgnoreLocalPublications}
+		if subOpts.Qos, err = qos(opts.Qos, humble.NewDefaultQosProfile()); err != nil {
+			return nil, err
+		}
+	}
+	sub, err := n.node.NewSubscription(topic, mts, subOpts, func(s *humble.Subscription) {
+		msg := mts.New()
+		info, err := s.TakeMessage(msg)
+		if err != nil {
+			handler(nil, nil, err)
+			return
+		}
+		handler(msg, &rclapi.MessageInfo{
+			SourceTimestamp:   info.SourceTimestamp,
+			ReceivedTimestamp: info.ReceivedTimestamp,
+			FromIntraProcess:  info.FromIntraProcess,
+		}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (n *rclNode) NewService(name string, ts rclapi.ServiceTypeSupport, opts *rclapi.ServiceOptions, handler rclapi.ServiceRequestHandler) (rclapi.Service, error) {
+	sts, err := unwrapServiceTypeSupport(ts)
+	if err != nil {
+		return nil, err
+	}
+	var svcOpts *humble.ServiceOptions
+	if opts != nil {
+		svcOpts = &humble.ServiceOptions{}
+		if svcOpts.Qos, err = qos(opts.Qos, humble.NewDefaultServiceQosProfile()); err != nil {
+			return nil, err
+		}
+	}
+	svc, err := n.node.NewService(name, sts, svcOpts, func(info *humble.ServiceInfo, req humble.Message, sender humble.ServiceResponseSender) {
+		handler(serviceInfo(info), req, responseSender{sender})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+func (n *rclNode) NewClient(name string, ts rclapi.ServiceTypeSupport, opts *rclapi.ClientOptions) (rclapi.Client, error) {
+	sts, err := unwrapServiceTypeSupport(ts)
+	if err != nil {
+		return nil, err
+	}
+	var clientOpts *humble.ClientOptions
+	if opts != nil {
+		clientOpts = &humble.ClientOptions{Validate: opts.Validate}
+		if clientOpts.Qos, err = qos(opts.Qos, humble.NewDefaultServiceQosProfile()); err != nil {
+			return nil, err
+		}
+	}
+	client, err := n.node.NewClient(name, sts, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	return serviceClient{client}, nil
+}
+
+func (n *rclNode) NewActionClient(name string, ts rclapi.ActionTypeSupport, opts *rclapi.ActionClientOptions) (rclapi.ActionClient, error) {
+	ats, err := unwrapActionTypeSupport(ts)
+	if err != nil {
+		return nil, err
+	}
+	var clientOpts *humble.ActionClientOptions
+	if opts != nil {
+		clientOpts = humble.NewDefaultActionClientOptions()
+		for _, q := range []struct {
+			dst *humble.QosProfile
+			src any
+		}{
+			{&clientOpts.GoalServiceQos, opts.GoalServiceQos},
+			{&clientOpts.CancelServiceQos, opts.CancelServiceQos},
+			{&clientOpts.ResultServiceQos, opts.ResultServiceQos},
+			{&clientOpts.FeedbackTopicQos, opts.FeedbackTopicQos},
+			{&clientOpts.StatusTopicQos, opts.StatusTopicQos},
+		} {
+			if *q.dst, err = qos(q.src, *q.dst); err != nil {
+				return nil, err
+			}
+		}
+	}
+	client, err := n.node.NewActionClient(name, ats, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &actionClient{ActionClient: client, typeSupport: ats}, nil
+}
+
+func (n *rclNode) NewTimer(period time.Duration, callback func()) (rclapi.Timer, error) {
+	timer, err := n.node.Context().NewTimer(period, func(*humble.Timer) { callback() })
+	if err != nil {
+		return nil, err
+	}
+	t := &rclTimer{Timer: timer, node: n}
+	n.mu.Lock()
+	n.timers = append(n.timers, t)
+	n.mu.Unlock()
+	return t, nil
+}
+
+func (n *rclNode) Spin(ctx context.Context) error {
+	n.mu.Lock()
+	timers := make([]*humble.Timer, len(n.timers))
+	for i, t := range n.timers {
+		timers[i] = t.Timer
+	}
+	n.mu.Unlock()
+	if len(timers) == 0 {
+		return n.node.Spin(ctx)
+	}
+	ws, err := n.node.Context().NewWaitSet()
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	ws.AddTimers(timers...)
+	spinCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timerErrc := make(chan error, 1)
+	go func() {
+		err := ws.Run(spinCtx)
+		cancel()
+		timerErrc <- err
+	}()
+	err = n.node.Spin(spinCtx)
+	cancel()
+	// If spinning the timers failed, spinning the node was stopped because
+	// of that.
+	if timerErr := <-timerErrc; ctx.Err() == nil && timerErr != nil && !errors.Is(timerErr, context.Canceled) {
+		return timerErr
+	}
+	return err
+}
+
+func (n *rclNode) Close() error {
+	n.mu.Lock()
+	timers := n.timers
+	n.timers = nil
+	n.mu.Unlock()
+	var err error
+	for _, t := range timers {
+		err = errors.Join(err, t.Timer.Close())
+	}
+	return errors.Join(err, n.node.Close())
+}
+
+// qos returns the QoS profile set in a QoS field of the options of rclapi, or
+// def if the field is nil.
+func qos(q any, def humble.QosProfile) (humble.QosProfile, error) {
+	switch q := q.(type) {
+	case nil:
+		return def, nil
+	case humble.QosProfile:
+		return q, nil
+	case *humble.QosProfile:
+		return *q, nil
+	}
+	return humble.QosProfile{}, fmt.Errorf("QoS profile has type %T, not humble.QosProfile", q)
+}
+
+func toMessage(msg rclapi.Message) (humble.Message, error) {
+	m, ok := msg.(humble.Message)
+	if !ok {
+		return nil, fmt.Errorf("message of type %T does not implement humble.Message", msg)
+	}
+	return m, nil
+}
+
+func serviceInfo(info *humble.ServiceInfo) *rclapi.ServiceInfo {
+	if info == nil {
+		return nil
+	}
+	return &rclapi.ServiceInfo{
+		SourceTimestamp:   info.SourceTimestamp,
+		ReceivedTimestamp: info.ReceivedTimestamp,
+		RequestID: rclapi.RequestID{
+			WriterGUID:     info.RequestID.WriterGUID,
+			SequenceNumber: info.RequestID.SequenceNumber,
+		},
+	}
+}
+
+type publisher struct {
+	*humble.Publisher
+}
+
+func (p publisher) Publish(msg rclapi.Message) error {
+	m, err := toMessage(msg)
+	if err != nil {
+		return err
+	}
+	return p.Publisher.Publish(m)
+}
+
+type responseSender struct {
+	sender humble.ServiceResponseSender
+}
+
+func (s responseSender) SendResponse(resp rclapi.Message) error {
+	m, err := toMessage(resp)
+	if err != nil {
+		return err
+	}
+	return s.sender.SendResponse(m)
+}
+
+type serviceClient struct {
+	*humble.Client
+}
+
+func (c serviceClient) Send(ctx context.Context, req rclapi.Message) (rclapi.Message, *rclapi.ServiceInfo, error) {
+	m, err := toMessage(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, info, err := c.Client.Send(ctx, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, serviceInfo(info), nil
+}
+
+type goalIDSetter interface {
+	SetGoalID(id *humble.GoalID)
+}
+
+type actionClient struct {
+	*humble.ActionClient
+	typeSupport humble.ActionTypeSupport
+}
+
+func (c *actionClient) SendGoal(ctx context.Context, goal rclapi.Message) (rclapi.Message, *rclapi.GoalID, error) {
+	m, err := toMessage(goal)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, goalID, err := c.ActionClient.SendGoal(ctx, m)
+	return resp, (*rclapi.GoalID)(goalID), err
+}
+
+func (c *actionClient) WatchGoal(ctx context.Context, goal rclapi.Message, onFeedback rclapi.FeedbackHandler) (rclapi.Message, *rclapi.GoalID, error) {
+	m, err := toMessage(goal)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, goalID, err := c.ActionClient.WatchGoal(ctx, m, feedbackHandler(onFeedback))
+	return result, (*rclapi.GoalID)(goalID), err
+}
+
+func (c *actionClient) GetResult(ctx context.Context, goalID *rclapi.GoalID) (rclapi.Message, error) {
+	resp, err := c.ActionClient.GetResult(ctx, (*humble.GoalID)(goalID))
+	return resp, err
+}
+
+func (c *actionClient) CancelGoal(ctx context.Context, goalID *rclapi.GoalID) (rclapi.Message, error) {
+	req := c.typeSupport.CancelGoal().Request().New()
+	req.(goalIDSetter).SetGoalID((*humble.GoalID)(goalID))
+	resp, err := c.ActionClient.CancelGoal(ctx, req)
+	return resp, err
+}
+
+func (c *actionClient) WatchFeedback(ctx context.Context, goalID *rclapi.GoalID, handler rclapi.FeedbackHandler) <-chan error {
+	return c.ActionClient.WatchFeedback(ctx, (*humble.GoalID)(goalID), feedbackHandler(handler))
+}
+
+func feedbackHandler(handler rclapi.FeedbackHandler) humble.FeedbackHandler {
+	if handler == nil {
+		return nil
+	}
+	return func(ctx context.Context, msg humble.Message) { handler(ctx, msg) }
+}
+
+type rclTimer struct {
+	*humble.Timer
+	node *rclNode
+}
+
+func (t *rclTimer) Close() error {
+	t.node.mu.Lock()
+	t.node.timers = slices.DeleteFunc(t.node.timers, func(x *rclTimer) bool { return x == t })
+	t.node.mu.Unlock()
+	return t.Timer.Close()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+var (
+	_ rclapi.Subscription = (*humble.Subscription)(nil)
+	_ rclapi.Service      = (*humble.Service)(nil)
+	_ rclapi.Timer        = (*humble.Timer)(nil)
+	_ rclapi.Publisher    = publisher{}
+	_ rclapi.Client       = serviceClient{}
+	_ rclapi.ActionClient = (*actionClient)(nil)
+)
@@ -0,0 +1,62 @@
+package rclnode
+
+import (
+	"testing"
+
+	"github.com/okieraised/rclgo/humble"
+	std_msgs_msg "github.com/okieraised/rclgo/humble/internal/testmsgs/std_msgs/msg"
+	std_srvs_srv "github.com/okieraised/rclgo/humble/internal/testmsgs/std_srvs/srv"
+	"github.com/okieraised/rclgo/humble/rclapi"
+)
+
+func TestQos(t *testing.T) {
+	def := humble.NewDefaultQosProfile()
+	custom := def
+	custom.Depth = 1
+	for _, q := range []any{nil, custom, &custom} {
+		want := custom
+		if q == nil {
+			want = def
+		}
+		got, err := qos(q, def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("got %+v for %T, want %+v", got, q, want)
+		}
+	}
+	if _, err := qos(1, def); err == nil {
+		t.Fatal("expected an error for a QoS profile of another type")
+	}
+}
+
+func TestTypeSupports(t *testing.T) {
+	ts := MessageTypeSupport(std_msgs_msg.StringTypeSupport)
+	msg := &std_msgs_msg.String{Data: "hello"}
+	clone := ts.Clone(msg).(*std_msgs_msg.String)
+	if clone == msg || clone.Data != "hello" {
+		t.Fatalf("got clone %p %+v of %p", clone, clone, msg)
+	}
+	if _, ok := ts.New().(*std_msgs_msg.String); !ok {
+		t.Fatalf("New returned %T", ts.New())
+	}
+	if got, err := unwrapMessageTypeSupport(ts); err != nil || got != std_msgs_msg.StringTypeSupport {
+		t.Fatalf("got %v, %v", got, err)
+	}
+	sts := ServiceTypeSupport(std_srvs_srv.SetBoolTypeSupport)
+	if _, ok := sts.Request().New().(*std_srvs_srv.SetBool_Request); !ok {
+		t.Fatalf("Request().New() returned %T", sts.Request().New())
+	}
+	if _, err := unwrapServiceTypeSupport(sts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unwrapMessageTypeSupport(otherTypeSupport{}); err == nil {
+		t.Fatal("expected an error for a type support not created by rclnode")
+	}
+}
+
+type otherTypeSupport struct{}
+
+func (otherTypeSupport) New() rclapi.Message                 { return nil }
+func (otherTypeSupport) Clone(rclapi.Message) rclapi.Message { return nil }
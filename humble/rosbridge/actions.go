@@ -0,0 +1,190 @@
+package rosbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+type actionClient struct {
+	client *humble.ActionClient
+	typ    string
+	ts     humble.ActionTypeSupport
+}
+
+// goal is a goal sent by the client using send_action_goal.
+type goal struct {
+	client *actionClient
+	cancel context.CancelFunc
+	// id is nil until the goal has been accepted. Accessed while holding
+	// the session lock.
+	id *humble.GoalID
+}
+
+// sendActionGoal sends a goal to an action server. Feedback is sent to the
+// client as action_feedback operations if requested, and the result as an
+// action_result operation.
+func (s *session) sendActionGoal(op *operation) error {
+	if op.Action == "" {
+		return errors.New("action is required")
+	}
+	if op.ActionType == "" {
+		return errors.New("action_type is required")
+	}
+	ac, err := s.actionClient(op.Action, op.ActionType)
+	if err != nil {
+		return err
+	}
+	desc := ac.ts.Goal().New()
+	if err := unmarshalArgs(op.Args, desc); err != nil {
+		return fmt.Errorf("invalid %s goal: %w", ac.typ, err)
+	}
+	key := idKey(op.ID)
+	ctx, cancel := context.WithCancel(s.ctx)
+	g := &goal{client: ac, cancel: cancel}
+	s.mu.Lock()
+	if _, exists := s.goals[key]; exists {
+		s.mu.Unlock()
+		cancel()
+		return fmt.Errorf("a goal with ID %s is already active", key)
+	}
+	s.goals[key] = g
+	s.mu.Unlock()
+	s.goTask(func() {
+		defer func() {
+			cancel()
+			s.mu.Lock()
+			delete(s.goals, key)
+			s.mu.Unlock()
+		}()
+		result := actionResultOp{Op: "action_result", ID: op.ID, Action: op.Action}
+		status, values, err := s.runGoal(ctx, op, g, desc)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			result.Values = err.Error()
+		} else {
+			result.Status = status
+			result.Values = values
+			result.Result = status == int8(humble.GoalSucceeded)
+		}
+		_ = s.send(result)
+	})
+	return nil
+}
+
+func (s *session) runGoal(ctx context.Context, op *operation, g *goal, desc humble.Message) (int8, json.RawMessage, error) {
+	resp, goalID, err := g.client.client.SendGoal(ctx, desc)
+	if err != nil {
+		return 0, nil, err
+	}
+	if accepted, ok := resp.(interface{ GetGoalAccepted() bool }); !ok || !accepted.GetGoalAccepted() {
+		return 0, nil, errors.New("goal was rejected")
+	}
+	s.mu.Lock()
+	g.id = goalID
+	s.mu.Unlock()
+	if op.Feedback {
+		g.client.client.WatchFeedback(ctx, goalID, func(_ context.Context, msg humble.Message) {
+			var fb struct {
+				Feedback json.RawMessage `json:"feedback"`
+			}
+			if err := decodeFields(msg, &fb); err != nil {
+				s.server.logf("failed to encode feedback of %s: %v", op.Action, err)
+				return
+			}
+			_ = s.send(actionFeedbackOp{Op: "action_feedback", ID: op.ID, Action: op.Action, Values: fb.Feedback})
+		})
+	}
+	resp, err = g.client.client.GetResult(ctx, goalID)
+	if err != nil {
+		return 0, nil, err
+	}
+	var res struct {
+		Status int8            `json:"status"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := decodeFields(resp, &res); err != nil {
+		return 0, nil, err
+	}
+	return res.Status, res.Result, nil
+}
+
+// decodeFields encodes msg as JSON and decodes the result into out, which
+// allows reading fields of generated structs without knowing their Go type.
+func decodeFields(msg humble.Message, out any) error {
+	data, err := msgcodec.MarshalJSON(msg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// cancelActionGoal cancels a goal sent by the client. A goal that has not been
+// accepted yet is abandoned.
+func (s *session) cancelActionGoal(op *operation) error {
+	key := idKey(op.ID)
+	s.mu.Lock()
+	g := s.goals[key]
+	var goalID *humble.GoalID
+	if g != nil {
+		goalID = g.id
+	}
+	s.mu.Unlock()
+	if g == nil {
+		return fmt.Errorf("no active goal with ID %s", key)
+	}
+	if goalID == nil {
+		g.cancel()
+		return nil
+	}
+	info, err := json.Marshal(map[string]any{
+		"goal_info": map[string]any{"goal_id": map[string]any{"uuid": goalID[:]}},
+	})
+	if err != nil {
+		return err
+	}
+	req := g.client.ts.CancelGoal().Request().New()
+	if err := msgcodec.UnmarshalJSON(info, req); err != nil {
+		return err
+	}
+	s.goTask(func() {
+		if _, err := g.client.client.CancelGoal(s.ctx, req); err != nil && s.ctx.Err() == nil {
+			s.status(op.ID, levelError, fmt.Sprintf("cancel_action_goal: %v", err))
+		}
+	})
+	return nil
+}
+
+func (s *session) actionClient(action, typ string) (*actionClient, error) {
+	s.mu.Lock()
+	ac := s.actionClients[action]
+	s.mu.Unlock()
+	if ac != nil {
+		if !sameType(ac.typ, typ) {
+			return nil, fmt.Errorf("action %s has type %s", action, ac.typ)
+		}
+		return ac, nil
+	}
+	ts, ok := humble.GetAction(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered", typ)
+	}
+	client, err := s.server.node.NewActionClient(action, ts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.startWaiter(func(ws *humble.WaitSet) { ws.AddActionClients(client) }); err != nil {
+		return nil, errors.Join(err, client.Close())
+	}
+	ac = &actionClient{client: client, typ: typ, ts: ts}
+	s.mu.Lock()
+	s.actionClients[action] = ac
+	s.mu.Unlock()
+	return ac, nil
+}
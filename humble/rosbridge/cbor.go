@@ -0,0 +1,55 @@
+package rosbridge
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// encodeRawPublish encodes a publish operation for a subscriber that requested
+// the "cbor-raw" compression. The message is sent as CDR bytes together with
+// the time it was received, so no type support other than the one needed to
+// subscribe is required.
+func encodeRawPublish(topic string, received time.Time, cdr []byte) []byte {
+	var buf []byte
+	buf = cborHead(buf, majorMap, 3)
+	buf = cborText(buf, "op")
+	buf = cborText(buf, "publish")
+	buf = cborText(buf, "topic")
+	buf = cborText(buf, topic)
+	buf = cborText(buf, "msg")
+	buf = cborHead(buf, majorMap, 3)
+	buf = cborText(buf, "secs")
+	buf = cborHead(buf, majorUint, uint64(received.Unix()))
+	buf = cborText(buf, "nsecs")
+	buf = cborHead(buf, majorUint, uint64(received.Nanosecond()))
+	buf = cborText(buf, "bytes")
+	buf = cborHead(buf, majorBytes, uint64(len(cdr)))
+	return append(buf, cdr...)
+}
+
+// CBOR major types, see RFC 8949.
+const (
+	majorUint  = 0
+	majorBytes = 2
+	majorText  = 3
+	majorMap   = 5
+)
+
+func cborHead(buf []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= 0xff:
+		return append(buf, major|24, byte(n))
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+}
+
+func cborText(buf []byte, s string) []byte {
+	return append(cborHead(buf, majorText, uint64(len(s))), s...)
+}
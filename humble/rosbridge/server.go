@@ -0,0 +1,142 @@
+/*
+Package rosbridge implements the rosbridge v2 protocol over WebSocket, which
+allows browser clients such as roslibjs to use the ROS graph.
+
+The server supports the advertise, unadvertise, publish, subscribe,
+unsubscribe, call_service, advertise_service, unadvertise_service,
+service_response, send_action_goal, cancel_action_goal and set_level
+operations. Messages are encoded as JSON the same way rosbridge_suite encodes
+them, which requires the type support of the interfaces used by clients to be
+registered, i.e. the generated Go packages must be imported. Subscriptions
+using the "cbor-raw" compression receive CDR-encoded messages and work with
+any message type whose type support can be loaded dynamically.
+
+A Server is an http.Handler:
+
+	server, err := rosbridge.NewServer(rclContext, nil)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	return http.ListenAndServe(":9090", server)
+*/
+package rosbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/humble"
+)
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	// NodeName is the name of the node the server creates. Defaults to
+	// DefaultNodeName.
+	NodeName string
+	// Namespace is the namespace of the node.
+	Namespace string
+	// DefaultQueueLength is the number of messages buffered for a subscriber
+	// that does not set queue_length or throttle_rate. Older messages are
+	// dropped when the buffer is full. Defaults to 100.
+	DefaultQueueLength int
+	// CheckOrigin is called to decide whether a WebSocket connection is
+	// accepted. If nil, all origins are accepted like in rosbridge_suite.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// DefaultNodeName is used when ServerOptions.NodeName is empty.
+const DefaultNodeName = "rosbridge_websocket"
+
+// Server serves rosbridge clients connecting over WebSocket.
+//
+// The server creates its own node and waits for the entities it creates on
+// behalf of clients, so the node must not be spun by the caller.
+type Server struct {
+	node     *humble.Node
+	options  ServerOptions
+	upgrader websocket.Upgrader
+
+	ctx      context.Context //nolint:containedctx // Used to stop sessions on Close
+	cancel   context.CancelFunc
+	sessions sync.WaitGroup
+}
+
+// NewServer creates a server whose node belongs to c. If options is nil,
+// default options are used.
+func NewServer(c *humble.Context, options *ServerOptions) (*Server, error) {
+	opts := ServerOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.NodeName == "" {
+		opts.NodeName = DefaultNodeName
+	}
+	node, err := c.NewNode(opts.NodeName, opts.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rosbridge node: %w", err)
+	}
+	return newServer(node, opts), nil
+}
+
+func newServer(node *humble.Node, opts ServerOptions) *Server {
+	if opts.DefaultQueueLength <= 0 {
+		opts.DefaultQueueLength = 100
+	}
+	checkOrigin := opts.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = func(*http.Request) bool { return true }
+	}
+	s := &Server{
+		node:     node,
+		options:  opts,
+		upgrader: websocket.Upgrader{CheckOrigin: checkOrigin},
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+// Node returns the node used by s.
+func (s *Server) Node() *humble.Node {
+	return s.node
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the
+// rosbridge protocol on it until the connection is closed or s is closed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.ctx.Err() != nil {
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client.
+		return
+	}
+	s.sessions.Add(1)
+	defer s.sessions.Done()
+	newSession(s, conn).serve()
+}
+
+// Close disconnects all clients and closes the node of s.
+func (s *Server) Close() error {
+	s.cancel()
+	s.sessions.Wait()
+	if s.node == nil {
+		return nil
+	}
+	return s.node.Close()
+}
+
+func (s *Server) logf(format string, a ...any) {
+	if s.node != nil {
+		_ = s.node.Logger().Errorf(format, a...)
+	}
+}
+
+// errUnsupported is returned for operations the server does not implement.
+var errUnsupported = errors.New("unsupported operation")
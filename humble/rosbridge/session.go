@@ -0,0 +1,258 @@
+package rosbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/humble"
+)
+
+// session serves a single client. All ROS entities created on behalf of the
+// client are closed when the client disconnects.
+type session struct {
+	server *Server
+	conn   *websocket.Conn
+
+	ctx    context.Context //nolint:containedctx // Canceled when the client disconnects
+	cancel context.CancelFunc
+	tasks  sync.WaitGroup
+
+	writeMu sync.Mutex
+
+	mu            sync.Mutex
+	statusLevel   int
+	publishers    map[string]*publisher
+	subscriptions map[string]*subscription
+	clients       map[string]*client
+	services      map[string]*advertisedService
+	pendingCalls  map[string]*pendingCall
+	nextCallID    uint64
+	actionClients map[string]*actionClient
+	goals         map[string]*goal
+	waiters       []*waiter
+}
+
+func newSession(server *Server, conn *websocket.Conn) *session {
+	s := &session{
+		server:        server,
+		conn:          conn,
+		statusLevel:   statusLevels[levelError],
+		publishers:    map[string]*publisher{},
+		subscriptions: map[string]*subscription{},
+		clients:       map[string]*client{},
+		services:      map[string]*advertisedService{},
+		pendingCalls:  map[string]*pendingCall{},
+		actionClients: map[string]*actionClient{},
+		goals:         map[string]*goal{},
+	}
+	s.ctx, s.cancel = context.WithCancel(server.ctx)
+	return s
+}
+
+func (s *session) serve() {
+	defer s.close()
+	go func() {
+		// Unblock ReadMessage when the server is closed.
+		<-s.ctx.Done()
+		_ = s.conn.Close()
+	}()
+	for {
+		typ, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if typ != websocket.TextMessage {
+			s.status(nil, levelError, "binary messages are not supported")
+			continue
+		}
+		var op operation
+		if err := json.Unmarshal(data, &op); err != nil {
+			s.status(nil, levelError, fmt.Sprintf("invalid message: %v", err))
+			continue
+		}
+		if err := s.handle(&op); err != nil {
+			s.status(op.ID, levelError, fmt.Sprintf("%s: %v", op.Op, err))
+		}
+	}
+}
+
+func (s *session) handle(op *operation) error {
+	switch op.Op {
+	case "advertise":
+		return s.advertise(op)
+	case "unadvertise":
+		return s.unadvertise(op)
+	case "publish":
+		return s.publish(op)
+	case "subscribe":
+		return s.subscribe(op)
+	case "unsubscribe":
+		return s.unsubscribe(op)
+	case "call_service":
+		return s.callService(op)
+	case "advertise_service":
+		return s.advertiseService(op)
+	case "unadvertise_service":
+		return s.unadvertiseService(op)
+	case "service_response":
+		return s.serviceResponse(op)
+	case "send_action_goal":
+		return s.sendActionGoal(op)
+	case "cancel_action_goal":
+		return s.cancelActionGoal(op)
+	case "set_level":
+		return s.setLevel(op)
+	case "":
+		return errors.New("op is required")
+	}
+	return fmt.Errorf("%w %q", errUnsupported, op.Op)
+}
+
+func (s *session) setLevel(op *operation) error {
+	level, ok := statusLevels[op.Level]
+	if !ok {
+		return fmt.Errorf("invalid status level %q", op.Level)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusLevel = level
+	return nil
+}
+
+// close stops all background work of s and closes the entities it created.
+func (s *session) close() {
+	s.cancel()
+	_ = s.conn.Close()
+	s.mu.Lock()
+	waiters := s.waiters
+	s.waiters = nil
+	s.mu.Unlock()
+	for _, w := range waiters {
+		<-w.done
+	}
+	s.tasks.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for _, p := range s.publishers {
+		errs = append(errs, p.pub.Close())
+	}
+	for _, sub := range s.subscriptions {
+		errs = append(errs, sub.sub.Close())
+	}
+	for _, c := range s.clients {
+		errs = append(errs, c.client.Close())
+	}
+	for _, svc := range s.services {
+		errs = append(errs, svc.service.Close())
+	}
+	for _, ac := range s.actionClients {
+		errs = append(errs, ac.client.Close())
+	}
+	if err := errors.Join(errs...); err != nil {
+		s.server.logf("failed to close rosbridge session: %v", err)
+	}
+}
+
+// send writes v to the client as JSON.
+func (s *session) send(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.write(websocket.TextMessage, data)
+}
+
+func (s *session) write(typ int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(typ, data)
+}
+
+// status sends a status message to the client if level is at least the level
+// set by the client.
+func (s *session) status(id json.RawMessage, level, msg string) {
+	s.mu.Lock()
+	enabled := statusLevels[level] >= s.statusLevel
+	s.mu.Unlock()
+	if enabled {
+		_ = s.send(statusOp{Op: "status", ID: id, Level: level, Msg: msg})
+	}
+}
+
+// goTask runs f in the background. The session waits for f to return before
+// closing its entities.
+func (s *session) goTask(f func()) {
+	s.tasks.Add(1)
+	go func() {
+		defer s.tasks.Done()
+		f()
+	}()
+}
+
+// waiter waits for the events of entities created on behalf of a client.
+// Entities created after the node started spinning would not be waited on
+// otherwise.
+type waiter struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startWaiter creates a wait set, adds entities to it using add and runs it
+// until the session is closed or the returned waiter is stopped.
+func (s *session) startWaiter(add func(ws *humble.WaitSet)) (*waiter, error) {
+	ws, err := s.server.node.Context().NewWaitSet()
+	if err != nil {
+		return nil, err
+	}
+	add(ws)
+	ctx, cancel := context.WithCancel(s.ctx)
+	w := &waiter{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		defer ws.Close()
+		if err := ws.Run(ctx); err != nil && ctx.Err() == nil {
+			s.server.logf("rosbridge wait set failed: %v", err)
+		}
+	}()
+	s.mu.Lock()
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+	return w, nil
+}
+
+// stopWaiter stops w and waits until its entities are no longer in use, after which
+// they can be closed.
+func (s *session) stopWaiter(w *waiter) {
+	w.cancel()
+	<-w.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, other := range s.waiters {
+		if other == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			break
+		}
+	}
+}
+
+// idKey converts a client-provided ID into a map key.
+func idKey(id json.RawMessage) string {
+	return string(id)
+}
+
+// unmarshalID decodes an ID generated by the server.
+func unmarshalID(id json.RawMessage, out *string) error {
+	if id == nil {
+		return errors.New("id is required")
+	}
+	if err := json.Unmarshal(id, out); err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+package rosbridge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/humble"
+	"github.com/okieraised/rclgo/humble/msgcodec"
+)
+
+type publisher struct {
+	pub *humble.Publisher
+	typ string
+	ts  humble.MessageTypeSupport
+	ids map[string]bool
+}
+
+func (s *session) advertise(op *operation) error {
+	if op.Topic == "" {
+		return errors.New("topic is required")
+	}
+	if op.Type == "" {
+		return errors.New("type is required")
+	}
+	s.mu.Lock()
+	p := s.publishers[op.Topic]
+	s.mu.Unlock()
+	if p != nil {
+		if !sameType(p.typ, op.Type) {
+			return fmt.Errorf("topic %s is already advertised with type %s", op.Topic, p.typ)
+		}
+	} else {
+		var err error
+		if p, err = s.newPublisher(op.Topic, op.Type, op.Latch, op.QueueSize); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.ids[idKey(op.ID)] = true
+	return nil
+}
+
+func (s *session) newPublisher(topic, typ string, latch bool, queueSize int) (*publisher, error) {
+	ts, ok := humble.GetMessage(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered", typ)
+	}
+	qos := humble.NewDefaultQosProfile()
+	if latch {
+		qos.Durability = humble.DurabilityTransientLocal
+	}
+	if queueSize > 0 {
+		qos.Depth = queueSize
+	}
+	pub, err := s.server.node.NewPublisher(topic, ts, &humble.PublisherOptions{Qos: qos})
+	if err != nil {
+		return nil, err
+	}
+	p := &publisher{pub: pub, typ: typ, ts: ts, ids: map[string]bool{}}
+	s.mu.Lock()
+	s.publishers[topic] = p
+	s.mu.Unlock()
+	return p, nil
+}
+
+func (s *session) unadvertise(op *operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.publishers[op.Topic]
+	if p == nil {
+		return fmt.Errorf("topic %s is not advertised", op.Topic)
+	}
+	if op.ID != nil {
+		delete(p.ids, idKey(op.ID))
+	} else {
+		clear(p.ids)
+	}
+	if len(p.ids) > 0 {
+		return nil
+	}
+	delete(s.publishers, op.Topic)
+	return p.pub.Close()
+}
+
+// publish publishes a message. If the client has not advertised the topic, it
+// is advertised automatically using the type found in the ROS graph.
+func (s *session) publish(op *operation) error {
+	if op.Topic == "" {
+		return errors.New("topic is required")
+	}
+	s.mu.Lock()
+	p := s.publishers[op.Topic]
+	s.mu.Unlock()
+	if p == nil {
+		typ, err := s.topicType(op.Topic)
+		if err != nil {
+			return err
+		}
+		if p, err = s.newPublisher(op.Topic, typ, false, 0); err != nil {
+			return err
+		}
+	}
+	msg := p.ts.New()
+	if err := msgcodec.UnmarshalJSON(op.Msg, msg); err != nil {
+		return fmt.Errorf("invalid %s message: %w", p.typ, err)
+	}
+	return p.pub.Publish(msg)
+}
+
+// subscription is a ROS subscription shared by all subscribers of a topic in
+// a session.
+type subscription struct {
+	sub    *humble.Subscription
+	typ    string
+	ts     humble.MessageTypeSupport
+	waiter *waiter
+
+	// subscribers is accessed while holding the session lock.
+	subscribers map[string]*subscriber
+}
+
+// subscriber sends messages to the client at the rate requested in a
+// subscribe operation.
+type subscriber struct {
+	raw      bool
+	throttle time.Duration
+	capacity int
+
+	mu     sync.Mutex
+	queue  [][]byte
+	notify chan struct{}
+	stop   chan struct{}
+}
+
+func (s *session) subscribe(op *operation) error {
+	if op.Topic == "" {
+		return errors.New("topic is required")
+	}
+	if op.ThrottleRate < 0 || op.QueueLength < 0 {
+		return errors.New("throttle_rate and queue_length must not be negative")
+	}
+	var raw bool
+	switch op.Compression {
+	case "", "none":
+	case "cbor-raw":
+		raw = true
+	default:
+		return fmt.Errorf("unsupported compression %q", op.Compression)
+	}
+	s.mu.Lock()
+	sub := s.subscriptions[op.Topic]
+	s.mu.Unlock()
+	typ := op.Type
+	switch {
+	case sub != nil:
+		if typ != "" && !sameType(sub.typ, typ) {
+			return fmt.Errorf("topic %s is already subscribed with type %s", op.Topic, sub.typ)
+		}
+		typ = sub.typ
+	case typ == "":
+		var err error
+		if typ, err = s.topicType(op.Topic); err != nil {
+			return err
+		}
+	}
+	if !raw {
+		if _, ok := humble.GetMessage(typ); !ok {
+			return fmt.Errorf("type support for %s is not registered, use compression \"cbor-raw\" instead", typ)
+		}
+	}
+	if sub == nil {
+		var err error
+		if sub, err = s.newSubscription(op.Topic, typ); err != nil {
+			return err
+		}
+	}
+	sb := &subscriber{
+		raw:      raw,
+		throttle: time.Duration(op.ThrottleRate) * time.Millisecond,
+		capacity: op.QueueLength,
+		notify:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+	if sb.capacity == 0 {
+		if sb.throttle > 0 {
+			sb.capacity = 1
+		} else {
+			sb.capacity = s.server.options.DefaultQueueLength
+		}
+	}
+	s.mu.Lock()
+	if old := sub.subscribers[idKey(op.ID)]; old != nil {
+		close(old.stop)
+	}
+	sub.subscribers[idKey(op.ID)] = sb
+	s.mu.Unlock()
+	s.goTask(func() { s.runSubscriber(sb) })
+	return nil
+}
+
+func (s *session) newSubscription(topic, typ string) (*subscription, error) {
+	ts, err := humble.LoadMessageTypeSupport(typ)
+	if err != nil {
+		return nil, err
+	}
+	sub := &subscription{typ: typ, ts: ts, subscribers: map[string]*subscriber{}}
+	sub.sub, err = s.server.node.NewSubscription(topic, ts, nil, func(rs *humble.Subscription) {
+		s.forward(topic, sub, rs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sub.waiter, err = s.startWaiter(func(ws *humble.WaitSet) { ws.AddSubscriptions(sub.sub) })
+	if err != nil {
+		return nil, errors.Join(err, sub.sub.Close())
+	}
+	s.mu.Lock()
+	s.subscriptions[topic] = sub
+	s.mu.Unlock()
+	return sub, nil
+}
+
+// forward takes a message from the subscription and queues it for each
+// subscriber. The message is encoded at most once per encoding.
+func (s *session) forward(topic string, sub *subscription, rs *humble.Subscription) {
+	cdr, info, err := rs.TakeSerializedMessage()
+	if err != nil {
+		s.server.logf("failed to take message from %s: %v", topic, err)
+		return
+	}
+	s.mu.Lock()
+	subscribers := make([]*subscriber, 0, len(sub.subscribers))
+	for _, sb := range sub.subscribers {
+		subscribers = append(subscribers, sb)
+	}
+	s.mu.Unlock()
+	var jsonData, rawData []byte
+	for _, sb := range subscribers {
+		if sb.raw {
+			if rawData == nil {
+				rawData = encodeRawPublish(topic, info.ReceivedTimestamp, cdr)
+			}
+			sb.push(rawData)
+			continue
+		}
+		if jsonData == nil {
+			if jsonData, err = encodePublish(topic, cdr, sub.ts); err != nil {
+				s.server.logf("failed to encode message from %s: %v", topic, err)
+				return
+			}
+		}
+		sb.push(jsonData)
+	}
+}
+
+func encodePublish(topic string, cdr []byte, ts humble.MessageTypeSupport) ([]byte, error) {
+	msg, err := humble.Deserialize(cdr, ts)
+	if err != nil {
+		return nil, err
+	}
+	data, err := msgcodec.MarshalJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(publishOp{Op: "publish", Topic: topic, Msg: data})
+}
+
+// push queues data to be sent. If the queue is full, the oldest message is
+// dropped.
+func (sb *subscriber) push(data []byte) {
+	sb.mu.Lock()
+	if len(sb.queue) >= sb.capacity {
+		sb.queue = sb.queue[1:]
+	}
+	sb.queue = append(sb.queue, data)
+	sb.mu.Unlock()
+	select {
+	case sb.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (sb *subscriber) pop() []byte {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if len(sb.queue) == 0 {
+		return nil
+	}
+	data := sb.queue[0]
+	sb.queue = sb.queue[1:]
+	return data
+}
+
+func (s *session) runSubscriber(sb *subscriber) {
+	typ := websocket.TextMessage
+	if sb.raw {
+		typ = websocket.BinaryMessage
+	}
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-sb.stop:
+			return
+		case <-sb.notify:
+		}
+		for data := sb.pop(); data != nil; data = sb.pop() {
+			if err := s.write(typ, data); err != nil {
+				return
+			}
+			if sb.throttle > 0 {
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-sb.stop:
+					return
+				case <-time.After(sb.throttle):
+				}
+			}
+		}
+	}
+}
+
+// unsubscribe removes the subscriber with the ID of op or, if op has no ID,
+// all subscribers of the topic. The ROS subscription is closed when it has no
+// subscribers left.
+func (s *session) unsubscribe(op *operation) error {
+	s.mu.Lock()
+	sub := s.subscriptions[op.Topic]
+	if sub == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("topic %s is not subscribed", op.Topic)
+	}
+	for id, sb := range sub.subscribers {
+		if op.ID == nil || id == idKey(op.ID) {
+			close(sb.stop)
+			delete(sub.subscribers, id)
+		}
+	}
+	remaining := len(sub.subscribers)
+	if remaining == 0 {
+		delete(s.subscriptions, op.Topic)
+	}
+	s.mu.Unlock()
+	if remaining > 0 {
+		return nil
+	}
+	s.stopWaiter(sub.waiter)
+	return sub.sub.Close()
+}
+
+// topicType returns the type of topic in the ROS graph.
+func (s *session) topicType(topic string) (string, error) {
+	topics, err := s.server.node.GetTopicNamesAndTypes(true)
+	if err != nil {
+		return "", err
+	}
+	types := topics[topic]
+	switch len(types) {
+	case 0:
+		return "", fmt.Errorf("type of topic %s is unknown, set it explicitly", topic)
+	case 1:
+		return types[0], nil
+	}
+	return "", fmt.Errorf("topic %s has multiple types %v, set the type explicitly", topic, types)
+}
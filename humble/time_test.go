@@ -0,0 +1,56 @@
+package humble
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeParts(t *testing.T) {
+	tm := time.Unix(1700000000, 123456789)
+	sec, nanosec := TimeToParts(tm)
+	if sec != 1700000000 || nanosec != 123456789 {
+		t.Fatalf("want 1700000000s 123456789ns, got %ds %dns", sec, nanosec)
+	}
+	if got := TimeFromParts(sec, nanosec); !got.Equal(tm) {
+		t.Fatalf("want %v, got %v", tm, got)
+	}
+
+	if sec, nanosec := TimeToParts(time.Time{}); sec != 0 || nanosec != 0 {
+		t.Fatalf("want the zero ROS time for the zero time.Time, got %ds %dns", sec, nanosec)
+	}
+	if got := TimeFromParts(0, 0); !got.IsZero() {
+		t.Fatalf("want the zero time.Time for the zero ROS time, got %v", got)
+	}
+	// The Unix epoch is the zero ROS time and comes back as the zero
+	// time.Time.
+	if got := TimeFromParts(TimeToParts(time.Unix(0, 0))); !got.IsZero() {
+		t.Fatalf("want the zero time.Time for the Unix epoch, got %v", got)
+	}
+	if got, want := TimeFromParts(0, 1), time.Unix(0, 1); !got.Equal(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestDurationParts(t *testing.T) {
+	for _, tt := range []struct {
+		d       time.Duration
+		sec     int32
+		nanosec uint32
+	}{
+		{0, 0, 0},
+		{1500 * time.Millisecond, 1, 500000000},
+		{2 * time.Second, 2, 0},
+		{-1500 * time.Millisecond, -2, 500000000},
+		{-2 * time.Second, -2, 0},
+		{-time.Nanosecond, -1, 999999999},
+		{-999999999, -1, 1},
+	} {
+		sec, nanosec := DurationToParts(tt.d)
+		if sec != tt.sec || nanosec != tt.nanosec {
+			t.Errorf("DurationToParts(%v): want %ds %dns, got %ds %dns", tt.d, tt.sec, tt.nanosec, sec, nanosec)
+		}
+		if got := DurationFromParts(sec, nanosec); got != tt.d {
+			t.Errorf("DurationFromParts(%d, %d): want %v, got %v", sec, nanosec, tt.d, got)
+		}
+	}
+}
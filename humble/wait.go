@@ -217,9 +217,17 @@ func (w *WaitSet) Run(ctx context.Context) (err error) {
 		}
 		subs := unsafe.Slice(w.rclWaitSetT.subscriptions, len(w.Subscriptions))
 		for i, s := range w.Subscriptions {
-			if subs[i] != nil {
+			if subs[i] != nil && s.intraProcess != nil {
+				s.queueMessage()
+			}
+			if (subs[i] != nil && s.intraProcess == nil) || s.intraProcess.pending() {
 				s.Callback(s)
 			}
+			if s.intraProcess.pending() {
+				// Wake up again for the messages the callback didn't
+				// take, like for messages of the middleware.
+				s.intraProcess.trigger()
+			}
 		}
 		svc := unsafe.Slice(w.rclWaitSetT.services, len(w.Services))
 		for i, s := range w.Services {
@@ -253,7 +261,7 @@ func (w *WaitSet) initEntities() error {
 	rc = C.rcl_wait_set_resize(
 		&w.rclWaitSetT,
 		C.size_t(len(w.Subscriptions)+2*len(w.ActionClients)),
-		C.size_t(len(w.guardConditions)),
+		C.size_t(len(w.guardConditions)+w.intraProcessSubscriptionCount()),
 		C.size_t(len(w.Timers)+len(w.ActionServers)),
 		C.size_t(len(w.Clients)+3*len(w.ActionClients)),
 		C.size_t(len(w.Services)+3*len(w.ActionServers)),
@@ -292,6 +300,15 @@ func (w *WaitSet) initEntities() error {
 			return errorsCastC(rc, fmt.Sprintf("rcl_wait_set_add_guard_condition() failed for wait_set='%v'", w))
 		}
 	}
+	for _, sub := range w.Subscriptions {
+		if sub.intraProcess == nil {
+			continue
+		}
+		rc = C.rcl_wait_set_add_guard_condition(&w.rclWaitSetT, sub.intraProcess.wake.rclGuardCondition, nil)
+		if rc != C.RCL_RET_OK {
+			return errorsCastC(rc, fmt.Sprintf("rcl_wait_set_add_guard_condition() failed for wait_set='%v'", w))
+		}
+	}
 	for _, server := range w.ActionServers {
 		rc = C.rcl_action_wait_set_add_action_server(&w.rclWaitSetT, &server.rclServer, nil)
 		if rc != C.RCL_RET_OK {
@@ -307,6 +324,16 @@ func (w *WaitSet) initEntities() error {
 	return nil
 }
 
+func (w *WaitSet) intraProcessSubscriptionCount() int {
+	count := 0
+	for _, sub := range w.Subscriptions {
+		if sub.intraProcess != nil {
+			count++
+		}
+	}
+	return count
+}
+
 // Close frees the allocated memory
 func (w *WaitSet) Close() (err error) {
 	if w.context == nil {
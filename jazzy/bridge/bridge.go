@@ -0,0 +1,363 @@
+/*
+Package bridge forwards topics, services and actions between two Contexts,
+typically Contexts that use different DDS domain IDs.
+
+Topics are forwarded as serialized messages, so any message type whose type
+support can be loaded is supported, even if its Go bindings are not imported.
+Services and actions need registered type support, which is available when
+the generated Go package of the interface is imported.
+
+A bridge is configured using a YAML file:
+
+	node_name: fleet_bridge
+	topics:
+	  - topic: /robot/odom
+	    type: nav_msgs/msg/Odometry
+	    remap: /fleet/robot1/odom
+	    qos: {reliability: best_effort, depth: 5}
+	  - topic: /fleet/announcements
+	    type: std_msgs/msg/String
+	    direction: b_to_a
+	services:
+	  - service: /robot/get_map
+	    type: nav_msgs/srv/GetMap
+	    remap: /fleet/robot1/get_map
+	actions:
+	  - action: /robot/navigate_to_pose
+	    type: nav2_msgs/action/NavigateToPose
+	    remap: /fleet/robot1/navigate_to_pose
+*/
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+// Bridge forwards data between two Contexts according to a Config.
+type Bridge struct {
+	config *Config
+	a, b   *side
+
+	ctx      context.Context //nolint:containedctx // Used to cancel forwarded requests on Close
+	cancel   context.CancelFunc
+	requests sync.WaitGroup
+}
+
+type side struct {
+	name string
+	node *jazzy.Node
+}
+
+// New creates a bridge between Contexts a and b. The bridge creates a node in
+// both Contexts and all publishers, subscriptions, services and clients needed
+// to forward the entities listed in config. The Contexts must use different
+// domain IDs.
+//
+// Call Spin to start forwarding.
+func New(a, b *jazzy.Context, config *Config) (br *Bridge, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bridge config: %w", err)
+	}
+	config = config.clone()
+	config.SetDefaults()
+	domainA, err := a.DomainID()
+	if err != nil {
+		return nil, err
+	}
+	domainB, err := b.DomainID()
+	if err != nil {
+		return nil, err
+	}
+	if domainA == domainB {
+		return nil, fmt.Errorf("both contexts use domain ID %d, bridging them would create loops", domainA)
+	}
+	br = &Bridge{config: config}
+	br.ctx, br.cancel = context.WithCancel(context.Background())
+	defer func() {
+		if err != nil {
+			_ = br.Close()
+		}
+	}()
+	br.a, err = newSide("a", a, config)
+	if err != nil {
+		return nil, err
+	}
+	br.b, err = newSide("b", b, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range config.Topics {
+		if err = br.bridgeTopic(t); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range config.Services {
+		if err = br.bridgeService(s); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range config.Actions {
+		if err = br.bridgeAction(a); err != nil {
+			return nil, err
+		}
+	}
+	return br, nil
+}
+
+func newSide(name string, ctx *jazzy.Context, config *Config) (*side, error) {
+	node, err := ctx.NewNode(config.NodeName, config.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bridge node on side %s: %w", name, err)
+	}
+	return &side{name: name, node: node}, nil
+}
+
+// Spin forwards data until ctx is canceled or an error occurs. If spinning
+// either side fails, the other side is stopped as well.
+func (b *Bridge) Spin(ctx context.Context) error {
+	spinCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, 2)
+	for _, s := range []*side{b.a, b.b} {
+		go func() {
+			err := s.node.Spin(spinCtx)
+			if spinCtx.Err() != nil {
+				err = nil
+			} else {
+				err = fmt.Errorf("side %s: %w", s.name, err)
+				cancel()
+			}
+			errs <- err
+		}()
+	}
+	if err := errors.Join(<-errs, <-errs); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Close stops forwarding and closes the bridge nodes. The Contexts passed to
+// New are not closed.
+func (b *Bridge) Close() error {
+	b.cancel()
+	b.requests.Wait()
+	var err error
+	for _, s := range []*side{b.a, b.b} {
+		if s != nil {
+			err = errors.Join(err, s.node.Close())
+		}
+	}
+	return err
+}
+
+func (b *Bridge) sides(dir Direction) (src, dst *side) {
+	if dir == DirectionBToA {
+		return b.b, b.a
+	}
+	return b.a, b.b
+}
+
+func (b *Bridge) bridgeTopic(cfg TopicConfig) error {
+	ts, err := jazzy.LoadMessageTypeSupport(cfg.Type)
+	if err != nil {
+		return fmt.Errorf("topic %s: %w", cfg.Topic, err)
+	}
+	qos := jazzy.NewDefaultQosProfile()
+	if err = cfg.Qos.Apply(&qos); err != nil {
+		return fmt.Errorf("topic %s: %w", cfg.Topic, err)
+	}
+	src, dst := b.sides(cfg.Direction)
+	if cfg.Direction != DirectionBidirectional {
+		return forwardTopic(src, cfg.Topic, dst, cfg.Remap, ts, qos)
+	}
+	dstPub, err := newTopicPublisher(dst, cfg.Remap, ts, qos)
+	if err != nil {
+		return err
+	}
+	srcPub, err := newTopicPublisher(src, cfg.Topic, ts, qos)
+	if err != nil {
+		return err
+	}
+	if err = forwardMessages(src, cfg.Topic, dstPub, ts, qos, srcPub); err != nil {
+		return err
+	}
+	return forwardMessages(dst, cfg.Remap, srcPub, ts, qos, dstPub)
+}
+
+// forwardTopic forwards serialized messages from topic srcName on side src to
+// topic dstName on side dst.
+func forwardTopic(
+	src *side, srcName string,
+	dst *side, dstName string,
+	ts jazzy.MessageTypeSupport,
+	qos jazzy.QosProfile,
+) error {
+	pub, err := newTopicPublisher(dst, dstName, ts, qos)
+	if err != nil {
+		return err
+	}
+	return forwardMessages(src, srcName, pub, ts, qos, nil)
+}
+
+func newTopicPublisher(dst *side, dstName string, ts jazzy.MessageTypeSupport, qos jazzy.QosProfile) (*jazzy.Publisher, error) {
+	pub, err := dst.node.NewPublisher(dstName, ts, &jazzy.PublisherOptions{Qos: qos})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publisher for topic %s on side %s: %w", dstName, dst.name, err)
+	}
+	return pub, nil
+}
+
+// forwardMessages forwards serialized messages from topic srcName on side src
+// through pub. If echo is not nil, the messages published by echo are not
+// forwarded, which prevents echoes when the topic is forwarded both ways. Only
+// the messages of echo are dropped, messages of other publishers of the same
+// Context are forwarded.
+func forwardMessages(
+	src *side, srcName string,
+	pub *jazzy.Publisher,
+	ts jazzy.MessageTypeSupport,
+	qos jazzy.QosProfile,
+	echo *jazzy.Publisher,
+) error {
+	logger := src.node.Logger()
+	_, err := src.node.NewSubscription(
+		srcName,
+		ts,
+		&jazzy.SubscriptionOptions{Qos: qos},
+		func(s *jazzy.Subscription) {
+			msg, info, err := s.TakeSerializedMessage()
+			if err != nil {
+				_ = logger.Errorf("failed to take message from %s: %v", srcName, err)
+				return
+			}
+			if echo != nil && info.PublisherGID == echo.GID() {
+				return
+			}
+			if err := pub.PublishSerialized(msg); err != nil {
+				_ = logger.Errorf("failed to forward message from %s to %s: %v", srcName, pub.TopicName, err)
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription for topic %s on side %s: %w", srcName, src.name, err)
+	}
+	return nil
+}
+
+func (b *Bridge) bridgeService(cfg ServiceConfig) error {
+	ts, err := serviceTypeSupport(cfg.Type)
+	if err != nil {
+		return fmt.Errorf("service %s: %w", cfg.Service, err)
+	}
+	qos := jazzy.NewDefaultServiceQosProfile()
+	if err = cfg.Qos.Apply(&qos); err != nil {
+		return fmt.Errorf("service %s: %w", cfg.Service, err)
+	}
+	src, dst := b.sides(cfg.Direction)
+	return b.forwardService(src, cfg.Service, dst, cfg.Remap, ts, qos)
+}
+
+// forwardService creates a service named dstName on side dst whose requests
+// are forwarded to the service srcName on side src.
+func (b *Bridge) forwardService(
+	src *side, srcName string,
+	dst *side, dstName string,
+	ts jazzy.ServiceTypeSupport,
+	qos jazzy.QosProfile,
+) error {
+	client, err := src.node.NewClient(srcName, ts, &jazzy.ClientOptions{Qos: qos})
+	if err != nil {
+		return fmt.Errorf("failed to create client for service %s on side %s: %w", srcName, src.name, err)
+	}
+	logger := dst.node.Logger()
+	_, err = dst.node.NewService(
+		dstName,
+		ts,
+		&jazzy.ServiceOptions{Qos: qos},
+		func(_ *jazzy.ServiceInfo, req jazzy.Message, sender jazzy.ServiceResponseSender) {
+			// Requests may take arbitrarily long (e.g. action results), so
+			// they must not block the wait set of the destination side.
+			b.requests.Add(1)
+			go func() {
+				defer b.requests.Done()
+				resp, _, err := client.Send(b.ctx, req)
+				if err != nil {
+					if b.ctx.Err() == nil {
+						_ = logger.Errorf("failed to forward request from %s to %s: %v", dstName, srcName, err)
+					}
+					return
+				}
+				if err := sender.SendResponse(resp); err != nil {
+					_ = logger.Errorf("failed to forward response from %s to %s: %v", srcName, dstName, err)
+				}
+			}()
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create service %s on side %s: %w", dstName, dst.name, err)
+	}
+	return nil
+}
+
+// Actions are forwarded as the three services and two topics they consist of.
+const (
+	actionSendGoalSuffix   = "/_action/send_goal"
+	actionGetResultSuffix  = "/_action/get_result"
+	actionCancelGoalSuffix = "/_action/cancel_goal"
+	actionFeedbackSuffix   = "/_action/feedback"
+	actionStatusSuffix     = "/_action/status"
+)
+
+func (b *Bridge) bridgeAction(cfg ActionConfig) error {
+	ts, err := actionTypeSupport(cfg.Type)
+	if err != nil {
+		return fmt.Errorf("action %s: %w", cfg.Action, err)
+	}
+	serviceQos := jazzy.NewDefaultServiceQosProfile()
+	feedbackQos := jazzy.NewDefaultQosProfile()
+	statusQos := jazzy.NewDefaultStatusQosProfile()
+	for _, qos := range []*jazzy.QosProfile{&serviceQos, &feedbackQos, &statusQos} {
+		if err = cfg.Qos.Apply(qos); err != nil {
+			return fmt.Errorf("action %s: %w", cfg.Action, err)
+		}
+	}
+	src, dst := b.sides(cfg.Direction)
+	services := []struct {
+		suffix string
+		ts     jazzy.ServiceTypeSupport
+	}{
+		{actionSendGoalSuffix, ts.SendGoal()},
+		{actionGetResultSuffix, ts.GetResult()},
+		{actionCancelGoalSuffix, ts.CancelGoal()},
+	}
+	for _, s := range services {
+		err = b.forwardService(src, cfg.Action+s.suffix, dst, cfg.Remap+s.suffix, s.ts, serviceQos)
+		if err != nil {
+			return err
+		}
+	}
+	err = forwardTopic(src, cfg.Action+actionFeedbackSuffix, dst, cfg.Remap+actionFeedbackSuffix, ts.FeedbackMessage(), feedbackQos)
+	if err != nil {
+		return err
+	}
+	return forwardTopic(src, cfg.Action+actionStatusSuffix, dst, cfg.Remap+actionStatusSuffix, ts.GoalStatusArray(), statusQos)
+}
+
+func serviceTypeSupport(name string) (jazzy.ServiceTypeSupport, error) {
+	if ts, ok := jazzy.GetService(name); ok {
+		return ts, nil
+	}
+	return nil, fmt.Errorf("type support for %s is not registered, import its Go package", name)
+}
+
+func actionTypeSupport(name string) (jazzy.ActionTypeSupport, error) {
+	if ts, ok := jazzy.GetAction(name); ok {
+		return ts, nil
+	}
+	return nil, fmt.Errorf("type support for %s is not registered, import its Go package", name)
+}
@@ -0,0 +1,325 @@
+package bridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"gopkg.in/yaml.v3"
+)
+
+// Direction tells which way data flows through a bridged entity. For topics
+// the data flows from publishers to subscriptions. For services and actions
+// the server lives on the source side and is exposed on the destination side.
+type Direction string
+
+const (
+	DirectionAToB          Direction = "a_to_b"
+	DirectionBToA          Direction = "b_to_a"
+	DirectionBidirectional Direction = "bidirectional"
+)
+
+// Config describes what a Bridge forwards between Contexts A and B.
+type Config struct {
+	// NodeName is the name of the node the bridge creates in both Contexts.
+	// Defaults to DefaultNodeName.
+	NodeName string `yaml:"node_name"`
+	// Namespace is the namespace of the bridge nodes.
+	Namespace string `yaml:"namespace"`
+
+	Topics   []TopicConfig   `yaml:"topics"`
+	Services []ServiceConfig `yaml:"services"`
+	Actions  []ActionConfig  `yaml:"actions"`
+}
+
+// DefaultNodeName is used when Config.NodeName is empty.
+const DefaultNodeName = "rclgo_bridge"
+
+// TopicConfig configures forwarding of a single topic.
+type TopicConfig struct {
+	// Topic is the name of the topic on the source side.
+	Topic string `yaml:"topic"`
+	// Type is the message type, e.g. "std_msgs/msg/String".
+	Type string `yaml:"type"`
+	// Remap is the name of the topic on the destination side. Defaults to
+	// Topic.
+	Remap string `yaml:"remap"`
+	// Direction defaults to DirectionAToB.
+	Direction Direction `yaml:"direction"`
+	// Qos overrides the default QoS of both the subscription and the
+	// publisher created for the topic.
+	Qos *QosConfig `yaml:"qos"`
+}
+
+// ServiceConfig configures forwarding of a single service.
+//
+// Only services whose type support is registered (i.e. the generated Go
+// package is imported) can be bridged.
+type ServiceConfig struct {
+	Service   string     `yaml:"service"`
+	Type      string     `yaml:"type"`
+	Remap     string     `yaml:"remap"`
+	Direction Direction  `yaml:"direction"`
+	Qos       *QosConfig `yaml:"qos"`
+}
+
+// ActionConfig configures forwarding of a single action.
+//
+// Only actions whose type support is registered (i.e. the generated Go
+// package is imported) can be bridged.
+type ActionConfig struct {
+	Action    string     `yaml:"action"`
+	Type      string     `yaml:"type"`
+	Remap     string     `yaml:"remap"`
+	Direction Direction  `yaml:"direction"`
+	Qos       *QosConfig `yaml:"qos"`
+}
+
+// QosConfig is a partial QoS profile. Unset fields keep the value of the
+// profile the override is applied to.
+type QosConfig struct {
+	History     string `yaml:"history"` // keep_last, keep_all or system_default
+	Depth       *int   `yaml:"depth"`
+	Reliability string `yaml:"reliability"` // reliable, best_effort or system_default
+	Durability  string `yaml:"durability"`  // volatile, transient_local or system_default
+}
+
+// LoadConfig reads and validates a YAML bridge configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bridge config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig parses and validates a YAML bridge configuration. The defaults
+// of the returned configuration are filled in.
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.SetDefaults()
+	return cfg, nil
+}
+
+// SetDefaults fills in the defaults of the fields of c that are not set.
+func (c *Config) SetDefaults() {
+	if c.NodeName == "" {
+		c.NodeName = DefaultNodeName
+	}
+	for i := range c.Topics {
+		t := &c.Topics[i]
+		t.Remap = defaultRemap(t.Topic, t.Remap)
+		t.Direction = defaultDirection(t.Direction)
+	}
+	for i := range c.Services {
+		s := &c.Services[i]
+		s.Remap = defaultRemap(s.Service, s.Remap)
+		s.Direction = defaultDirection(s.Direction)
+	}
+	for i := range c.Actions {
+		a := &c.Actions[i]
+		a.Remap = defaultRemap(a.Action, a.Remap)
+		a.Direction = defaultDirection(a.Direction)
+	}
+}
+
+// clone returns a copy of c that can be modified without modifying c.
+func (c *Config) clone() *Config {
+	cfg := *c
+	cfg.Topics = slices.Clone(c.Topics)
+	cfg.Services = slices.Clone(c.Services)
+	cfg.Actions = slices.Clone(c.Actions)
+	return &cfg
+}
+
+func defaultRemap(name, remap string) string {
+	if remap == "" {
+		return name
+	}
+	return remap
+}
+
+func defaultDirection(dir Direction) Direction {
+	if dir == "" {
+		return DirectionAToB
+	}
+	return dir
+}
+
+// Validate checks that the configuration is consistent. Unset fields are
+// treated as if their defaults were filled in. In particular, it rejects
+// configurations in which the same name on the same side is both a source and
+// a destination of different entries, which would make the bridge forward
+// data back to where it came from.
+func (c *Config) Validate() error {
+	var errs []error
+	// endpoints are the entries using each endpoint.
+	endpoints := map[endpointKey][]string{}
+	addEndpoints := func(entry, kind, name, remap string, dir Direction) {
+		add := func(side, name string, source bool) {
+			key := endpointKey{kind, side, name, source}
+			endpoints[key] = append(endpoints[key], entry)
+		}
+		remap = defaultRemap(name, remap)
+		switch defaultDirection(dir) {
+		case DirectionAToB:
+			add("a", name, true)
+			add("b", remap, false)
+		case DirectionBToA:
+			add("b", name, true)
+			add("a", remap, false)
+		case DirectionBidirectional:
+			// Both ends are sources and destinations. Echoes of the
+			// messages forwarded by the entry itself are dropped by the
+			// GIDs of the publishers of the bridge.
+			add("a", name, true)
+			add("a", name, false)
+			add("b", remap, true)
+			add("b", remap, false)
+		}
+	}
+	for i, t := range c.Topics {
+		entry := fmt.Sprintf("topics[%d]", i)
+		if err := validateEntry("topic", t.Topic, t.Type, t.Direction, t.Qos); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+			continue
+		}
+		addEndpoints(entry, "topic", t.Topic, t.Remap, t.Direction)
+	}
+	for i, s := range c.Services {
+		entry := fmt.Sprintf("services[%d]", i)
+		if err := validateEntry("service", s.Service, s.Type, s.Direction, s.Qos); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+			continue
+		}
+		if s.Direction == DirectionBidirectional {
+			errs = append(errs, fmt.Errorf("%s: services cannot be bridged bidirectionally", entry))
+			continue
+		}
+		addEndpoints(entry, "service", s.Service, s.Remap, s.Direction)
+	}
+	for i, a := range c.Actions {
+		entry := fmt.Sprintf("actions[%d]", i)
+		if err := validateEntry("action", a.Action, a.Type, a.Direction, a.Qos); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+			continue
+		}
+		if a.Direction == DirectionBidirectional {
+			errs = append(errs, fmt.Errorf("%s: actions cannot be bridged bidirectionally", entry))
+			continue
+		}
+		addEndpoints(entry, "action", a.Action, a.Remap, a.Direction)
+	}
+	for key, entries := range endpoints {
+		if len(entries) > 1 {
+			errs = append(errs, fmt.Errorf("%s %s is bridged more than once on side %s", key.kind, key.name, key.side))
+			continue
+		}
+		if !key.source {
+			continue
+		}
+		other := key
+		other.source = false
+		if slices.ContainsFunc(endpoints[other], func(entry string) bool { return entry != entries[0] }) {
+			errs = append(errs, fmt.Errorf(
+				"%s %s on side %s is both forwarded and written to by the bridge, which would create a loop; use direction %q instead",
+				key.kind, key.name, key.side, DirectionBidirectional,
+			))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type endpointKey struct {
+	kind   string
+	side   string
+	name   string
+	source bool
+}
+
+func validateEntry(kind, name, typ string, dir Direction, qos *QosConfig) error {
+	if name == "" {
+		return fmt.Errorf("%s name is required", kind)
+	}
+	if typ == "" {
+		return fmt.Errorf("type of %s %s is required", kind, name)
+	}
+	if _, _, _, err := jazzy.SplitTypeName(typ); err != nil {
+		return err
+	}
+	switch dir {
+	case "", DirectionAToB, DirectionBToA, DirectionBidirectional:
+	default:
+		return fmt.Errorf("invalid direction %q of %s %s", dir, kind, name)
+	}
+	if qos != nil {
+		p := jazzy.NewDefaultQosProfile()
+		if err := qos.Apply(&p); err != nil {
+			return fmt.Errorf("invalid QoS of %s %s: %w", kind, name, err)
+		}
+	}
+	return nil
+}
+
+// Apply overrides the fields of p that are set in q.
+func (q *QosConfig) Apply(p *jazzy.QosProfile) error {
+	if q == nil {
+		return nil
+	}
+	switch q.History {
+	case "":
+	case "system_default":
+		p.History = jazzy.HistorySystemDefault
+	case "keep_last":
+		p.History = jazzy.HistoryKeepLast
+	case "keep_all":
+		p.History = jazzy.HistoryKeepAll
+	default:
+		return fmt.Errorf("invalid history policy %q", q.History)
+	}
+	if q.Depth != nil {
+		if *q.Depth < 0 {
+			return fmt.Errorf("invalid depth %d", *q.Depth)
+		}
+		p.Depth = *q.Depth
+	}
+	switch q.Reliability {
+	case "":
+	case "system_default":
+		p.Reliability = jazzy.ReliabilitySystemDefault
+	case "reliable":
+		p.Reliability = jazzy.ReliabilityReliable
+	case "best_effort":
+		p.Reliability = jazzy.ReliabilityBestEffort
+	default:
+		return fmt.Errorf("invalid reliability policy %q", q.Reliability)
+	}
+	switch q.Durability {
+	case "":
+	case "system_default":
+		p.Durability = jazzy.DurabilitySystemDefault
+	case "volatile":
+		p.Durability = jazzy.DurabilityVolatile
+	case "transient_local":
+		p.Durability = jazzy.DurabilityTransientLocal
+	default:
+		return fmt.Errorf("invalid durability policy %q", q.Durability)
+	}
+	return nil
+}
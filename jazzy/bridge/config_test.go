@@ -0,0 +1,154 @@
+package bridge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+topics:
+  - topic: /odom
+    type: nav_msgs/msg/Odometry
+    remap: /robot1/odom
+    qos: {reliability: best_effort, depth: 5}
+  - topic: /cmd
+    type: std_msgs/String
+    direction: b_to_a
+services:
+  - service: /get_map
+    type: nav_msgs/srv/GetMap
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.NodeName != DefaultNodeName {
+		t.Errorf("want node name %q, got %q", DefaultNodeName, cfg.NodeName)
+	}
+	if len(cfg.Topics) != 2 {
+		t.Fatalf("want 2 topics, got %d", len(cfg.Topics))
+	}
+	if cfg.Topics[0].Direction != DirectionAToB {
+		t.Errorf("want default direction %q, got %q", DirectionAToB, cfg.Topics[0].Direction)
+	}
+	if cfg.Topics[1].Remap != "/cmd" {
+		t.Errorf("want remap to default to the topic name, got %q", cfg.Topics[1].Remap)
+	}
+	if cfg.Services[0].Remap != "/get_map" {
+		t.Errorf("want remap to default to the service name, got %q", cfg.Services[0].Remap)
+	}
+
+	qos := jazzy.NewDefaultQosProfile()
+	if err := cfg.Topics[0].Qos.Apply(&qos); err != nil {
+		t.Fatal(err)
+	}
+	if qos.Reliability != jazzy.ReliabilityBestEffort || qos.Depth != 5 {
+		t.Errorf("QoS override not applied: %+v", qos)
+	}
+	if qos.Durability != jazzy.DurabilityVolatile {
+		t.Errorf("unset QoS fields must keep their value, got durability %v", qos.Durability)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "Unknown field",
+			input:   "topics:\n  - topik: /a\n",
+			wantErr: "topik",
+		},
+		{
+			name:    "Missing type",
+			input:   "topics:\n  - topic: /a\n",
+			wantErr: "type of topic /a is required",
+		},
+		{
+			name:    "Invalid type",
+			input:   "topics:\n  - {topic: /a, type: String}\n",
+			wantErr: "invalid interface type name",
+		},
+		{
+			name:    "Invalid direction",
+			input:   "topics:\n  - {topic: /a, type: std_msgs/msg/String, direction: up}\n",
+			wantErr: "invalid direction",
+		},
+		{
+			name:    "Invalid QoS",
+			input:   "topics:\n  - {topic: /a, type: std_msgs/msg/String, qos: {reliability: maybe}}\n",
+			wantErr: "invalid reliability policy",
+		},
+		{
+			name: "Loop through opposite directions",
+			input: `
+topics:
+  - {topic: /a, type: std_msgs/msg/String, remap: /b}
+  - {topic: /b, type: std_msgs/msg/String, remap: /a, direction: b_to_a}
+`,
+			wantErr: "would create a loop",
+		},
+		{
+			name: "Duplicate destination",
+			input: `
+topics:
+  - {topic: /a, type: std_msgs/msg/String, remap: /c}
+  - {topic: /b, type: std_msgs/msg/String, remap: /c}
+`,
+			wantErr: "bridged more than once",
+		},
+		{
+			name: "Bidirectional destination written by another entry",
+			input: `
+topics:
+  - {topic: /chat, type: std_msgs/msg/String, direction: bidirectional}
+  - {topic: /other, type: std_msgs/msg/String, remap: /chat, direction: b_to_a}
+`,
+			wantErr: "topic /chat is bridged more than once on side a",
+		},
+		{
+			name:    "Bidirectional service",
+			input:   "services:\n  - {service: /s, type: std_srvs/srv/Empty, direction: bidirectional}\n",
+			wantErr: "cannot be bridged bidirectionally",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.input))
+			if err == nil {
+				t.Fatalf("want error containing %q, got nil", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("want error containing %q, got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseConfigBidirectionalTopic(t *testing.T) {
+	_, err := ParseConfig([]byte(`
+topics:
+  - {topic: /chat, type: std_msgs/msg/String, direction: bidirectional}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateDoesNotModifyConfig(t *testing.T) {
+	cfg := &Config{Topics: []TopicConfig{{Topic: "/a", Type: "std_msgs/msg/String"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.NodeName != "" || cfg.Topics[0].Remap != "" || cfg.Topics[0].Direction != "" {
+		t.Fatalf("Validate must not fill in defaults, got %+v", cfg)
+	}
+	cfg.SetDefaults()
+	if cfg.NodeName != DefaultNodeName || cfg.Topics[0].Remap != "/a" || cfg.Topics[0].Direction != DirectionAToB {
+		t.Fatalf("SetDefaults did not fill in defaults, got %+v", cfg)
+	}
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/okieraised/rclgo/jazzy/cmd/rclgo/root"
+)
+
+func main() {
+	root.Execute()
+}
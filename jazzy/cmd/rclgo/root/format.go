@@ -0,0 +1,94 @@
+package root
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+func endpointTypeName(t jazzy.EndpointType) string {
+	switch t {
+	case jazzy.EndpointPublisher:
+		return "PUBLISHER"
+	case jazzy.EndpointSubscription:
+		return "SUBSCRIPTION"
+	}
+	return "INVALID"
+}
+
+func historyName(p jazzy.HistoryPolicy) string {
+	switch p {
+	case jazzy.HistorySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case jazzy.HistoryKeepLast:
+		return "KEEP_LAST"
+	case jazzy.HistoryKeepAll:
+		return "KEEP_ALL"
+	}
+	return "UNKNOWN"
+}
+
+func reliabilityName(p jazzy.ReliabilityPolicy) string {
+	switch p {
+	case jazzy.ReliabilitySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case jazzy.ReliabilityReliable:
+		return "RELIABLE"
+	case jazzy.ReliabilityBestEffort:
+		return "BEST_EFFORT"
+	}
+	return "UNKNOWN"
+}
+
+func durabilityName(p jazzy.DurabilityPolicy) string {
+	switch p {
+	case jazzy.DurabilitySystemDefault:
+		return "SYSTEM_DEFAULT"
+	case jazzy.DurabilityTransientLocal:
+		return "TRANSIENT_LOCAL"
+	case jazzy.DurabilityVolatile:
+		return "VOLATILE"
+	}
+	return "UNKNOWN"
+}
+
+func livelinessName(p jazzy.LivelinessPolicy) string {
+	switch p {
+	case jazzy.LivelinessSystemDefault:
+		return "SYSTEM_DEFAULT"
+	case jazzy.LivelinessAutomatic:
+		return "AUTOMATIC"
+	case jazzy.LivelinessManualByTopic:
+		return "MANUAL_BY_TOPIC"
+	}
+	return "UNKNOWN"
+}
+
+func qosDuration(d time.Duration) string {
+	if d == jazzy.DurationInfinite || d == jazzy.DurationUnspecified {
+		return "Infinite"
+	}
+	return fmt.Sprintf("%d nanoseconds", d.Nanoseconds())
+}
+
+// formatGID formats a GID as dot-separated hexadecimal bytes.
+func formatGID(gid jazzy.GID) string {
+	parts := make([]string, len(gid))
+	for i, b := range gid {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ".")
+}
+
+// formatBytes formats a number of bytes using decimal prefixes.
+func formatBytes(n float64) string {
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if n < 1000 {
+			return fmt.Sprintf("%.2f %s", n, unit)
+		}
+		n /= 1000
+	}
+	return fmt.Sprintf("%.2f GB", n)
+}
@@ -0,0 +1,214 @@
+package root
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var interfaceCmd = &cobra.Command{
+	Use:   "interface",
+	Short: "Show information about ROS interfaces",
+}
+
+var interfaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all interface types available",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interfaces, err := listInterfaces(interfacePrefixes())
+		if err != nil {
+			return err
+		}
+		sections := []struct {
+			flag, title, kind string
+		}{
+			{"only-msgs", "Messages", "msg"},
+			{"only-srvs", "Services", "srv"},
+			{"only-actions", "Actions", "action"},
+		}
+		var selected []string
+		for _, s := range sections {
+			if only, _ := cmd.Flags().GetBool(s.flag); only {
+				selected = append(selected, s.kind)
+			}
+		}
+		w := cmd.OutOrStdout()
+		for _, s := range sections {
+			if len(selected) > 0 && !slices.Contains(selected, s.kind) {
+				continue
+			}
+			fmt.Fprintf(w, "%s:\n", s.title)
+			for _, iface := range interfaces[s.kind] {
+				fmt.Fprintf(w, "    %s\n", iface)
+			}
+		}
+		return nil
+	},
+}
+
+var interfaceShowCmd = &cobra.Command{
+	Use:   "show <type>",
+	Short: "Output the interface definition",
+	Long: `Output the interface definition of a message, service or action type,
+e.g. "std_msgs/msg/String". The definitions of nested message types are
+included, indented below the fields using them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		parts := strings.Split(args[0], "/")
+		if len(parts) != 3 || !slices.Contains([]string{"msg", "srv", "action"}, parts[1]) {
+			return fmt.Errorf("invalid interface type %q, expected pkg/msg/Name, pkg/srv/Name or pkg/action/Name", args[0])
+		}
+		noComments, _ := cmd.Flags().GetBool("no-comments")
+		l := &definitionLoader{prefixes: interfacePrefixes()}
+		def, err := l.definition(parts[0], parts[1], parts[2])
+		if err != nil {
+			return err
+		}
+		var b strings.Builder
+		if err := l.expand(&b, parts[0], def, "", noComments, nil); err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), b.String())
+		return nil
+	},
+}
+
+// interfacePrefixes returns the install prefixes listed in AMENT_PREFIX_PATH.
+func interfacePrefixes() []string {
+	return filepath.SplitList(os.Getenv("AMENT_PREFIX_PATH"))
+}
+
+// listInterfaces returns the interfaces registered in the ament resource
+// indexes of prefixes, grouped by interface kind and sorted by name.
+func listInterfaces(prefixes []string) (map[string][]string, error) {
+	seen := map[string]bool{}
+	interfaces := map[string][]string{}
+	for _, prefix := range prefixes {
+		dir := filepath.Join(prefix, "share", "ament_index", "resource_index", "rosidl_interfaces")
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			for _, line := range strings.Split(string(data), "\n") {
+				kind, file, ok := strings.Cut(strings.TrimSpace(line), "/")
+				if !ok {
+					continue
+				}
+				name := entry.Name() + "/" + kind + "/" + strings.TrimSuffix(file, path.Ext(file))
+				if !seen[name] {
+					seen[name] = true
+					interfaces[kind] = append(interfaces[kind], name)
+				}
+			}
+		}
+	}
+	for _, names := range interfaces {
+		slices.Sort(names)
+	}
+	return interfaces, nil
+}
+
+// definitionLoader reads interface definitions from the share directories of
+// ROS packages.
+type definitionLoader struct {
+	prefixes []string
+}
+
+func (l *definitionLoader) definition(pkg, kind, name string) (string, error) {
+	for _, prefix := range l.prefixes {
+		data, err := os.ReadFile(filepath.Join(prefix, "share", pkg, kind, name+"."+kind))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("unknown interface type %s/%s/%s", pkg, kind, name)
+}
+
+var primitiveTypes = []string{
+	"bool", "byte", "char",
+	"float32", "float64",
+	"int8", "uint8", "int16", "uint16", "int32", "uint32", "int64", "uint64",
+	"string", "wstring",
+}
+
+// expand writes def, which is defined in package pkg, to b. Each line is
+// prefixed with prefix and followed by the expanded definition of the message
+// type of the field on the line, if any. parents holds the types being
+// expanded, which guards against recursive definitions.
+func (l *definitionLoader) expand(b *strings.Builder, pkg, def, prefix string, noComments bool, parents []string) error {
+	scanner := bufio.NewScanner(strings.NewReader(def))
+	for scanner.Scan() {
+		line := scanner.Text()
+		content, _, _ := strings.Cut(line, "#")
+		if noComments {
+			line = strings.TrimRight(content, " \t")
+			if line == "" {
+				continue
+			}
+		}
+		b.WriteString(prefix + line + "\n")
+		fields := strings.Fields(content)
+		if len(fields) < 2 {
+			continue
+		}
+		typ := fields[0]
+		if i := strings.IndexAny(typ, "[<"); i >= 0 {
+			typ = typ[:i]
+		}
+		if slices.Contains(primitiveTypes, typ) {
+			continue
+		}
+		depPkg, depName := pkg, typ
+		if parts := strings.Split(typ, "/"); len(parts) > 1 {
+			depPkg, depName = parts[0], parts[len(parts)-1]
+		}
+		full := depPkg + "/msg/" + depName
+		if slices.Contains(parents, full) {
+			return fmt.Errorf("recursive definition of %s", full)
+		}
+		depDef, err := l.definition(depPkg, "msg", depName)
+		if err != nil {
+			return err
+		}
+		if err := l.expand(b, depPkg, depDef, prefix+"\t", noComments, append(parents, full)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func init() {
+	rootCmd.AddCommand(interfaceCmd)
+
+	interfaceCmd.AddCommand(interfaceListCmd)
+	interfaceListCmd.Flags().BoolP("only-msgs", "m", false, "Print out only the messages.")
+	interfaceListCmd.Flags().BoolP("only-srvs", "s", false, "Print out only the services.")
+	interfaceListCmd.Flags().BoolP("only-actions", "a", false, "Print out only the actions.")
+
+	interfaceCmd.AddCommand(interfaceShowCmd)
+	interfaceShowCmd.Flags().Bool("no-comments", false, "Show the definition without comments and blank lines.")
+}
@@ -0,0 +1,206 @@
+package root
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+type testTime struct {
+	Sec     int32  `yaml:"sec"`
+	Nanosec uint32 `yaml:"nanosec"`
+}
+
+type testHeader struct {
+	Stamp   testTime `yaml:"stamp"`
+	FrameID string   `yaml:"frame_id"`
+}
+
+type testMessage struct {
+	Header testHeader `yaml:"header"`
+	Points [2]float64 `yaml:"points"`
+	Data   []uint8    `yaml:"data"`
+	Name   string     `yaml:"name"`
+}
+
+func (m *testMessage) CloneMsg() jazzy.Message                  { c := *m; return &c }
+func (m *testMessage) SetDefaults()                             { *m = testMessage{Name: "default"} }
+func (m *testMessage) GetTypeSupport() jazzy.MessageTypeSupport { return nil }
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  string
+		want    testMessage
+		wantErr bool
+	}{
+		{"Empty", "", testMessage{Name: "default"}, false},
+		{"Flow", "{header: {frame_id: map}, data: [1, 2]}", testMessage{
+			Header: testHeader{FrameID: "map"},
+			Data:   []uint8{1, 2},
+			Name:   "default",
+		}, false},
+		{"Block", "points: [1.5, 2]\nname: x\n", testMessage{Points: [2]float64{1.5, 2}, Name: "x"}, false},
+		{"Unknown field", "{nmae: x}", testMessage{}, true},
+		{"Wrong type", "{data: x}", testMessage{}, true},
+		{"Scalar", "hello", testMessage{}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &testMessage{}
+			msg.SetDefaults()
+			err := parseMessage(tc.values, msg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*msg, tc.want) {
+				t.Fatalf("want %+v, got %+v", tc.want, *msg)
+			}
+		})
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	msg := &testMessage{Header: testHeader{FrameID: "map"}, Points: [2]float64{1, 2.5}, Name: "x"}
+	text, err := formatMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `header:
+  stamp:
+    sec: 0
+    nanosec: 0
+  frame_id: map
+points:
+  - 1
+  - 2.5
+data: []
+name: x
+`
+	if text != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, text)
+	}
+	parsed := &testMessage{}
+	if err := parseMessage(text, parsed); err != nil {
+		t.Fatal(err)
+	}
+	parsed.Data = nil
+	if !reflect.DeepEqual(parsed, msg) {
+		t.Fatalf("round trip changed the message: want %+v, got %+v", msg, parsed)
+	}
+}
+
+func TestHeaderStamp(t *testing.T) {
+	stamp, ok := headerStamp(&testMessage{Header: testHeader{Stamp: testTime{Sec: 3, Nanosec: 5}}})
+	if !ok || !stamp.Equal(time.Unix(3, 5)) {
+		t.Fatalf("unexpected stamp %v, %v", stamp, ok)
+	}
+	if _, ok := headerStamp(&testHeader{}); ok {
+		t.Fatal("want no stamp for a message without a header")
+	}
+}
+
+func TestWindow(t *testing.T) {
+	w := window{size: 3}
+	start := time.Unix(0, 0)
+	for i := 0; i < 5; i++ {
+		w.add(start.Add(time.Duration(i)*time.Second), float64(i))
+	}
+	times, values := w.snapshot()
+	if len(times) != 3 || !times[0].Equal(start.Add(2*time.Second)) {
+		t.Fatalf("unexpected times %v", times)
+	}
+	s := summarize(values)
+	if s.sum != 9 || s.mean != 3 || s.min != 2 || s.max != 4 {
+		t.Fatalf("unexpected summary %+v", s)
+	}
+}
+
+func TestNodeNames(t *testing.T) {
+	tests := []struct {
+		fullName, namespace, name string
+	}{
+		{"/talker", "/", "talker"},
+		{"talker", "/", "talker"},
+		{"/ns/sub/talker", "/ns/sub", "talker"},
+	}
+	for _, tc := range tests {
+		namespace, name := splitNodeName(tc.fullName)
+		if namespace != tc.namespace || name != tc.name {
+			t.Errorf("splitNodeName(%q) = %q, %q", tc.fullName, namespace, name)
+		}
+		if full := joinNodeName(namespace, name); full != absoluteName(tc.fullName) {
+			t.Errorf("joinNodeName(%q, %q) = %q", namespace, name, full)
+		}
+	}
+	for name, want := range map[string]bool{
+		"/chatter":              false,
+		"/_hidden":              true,
+		"/ns/_private/chatter":  true,
+		"/robot/cmd_vel_smooth": false,
+	} {
+		if got := isHidden(name); got != want {
+			t.Errorf("isHidden(%q) = %v", name, got)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInterfaceCommands(t *testing.T) {
+	prefix1, prefix2 := t.TempDir(), t.TempDir()
+	index := filepath.Join("share", "ament_index", "resource_index", "rosidl_interfaces")
+	writeFile(t, filepath.Join(prefix1, index, "geo"), "msg/Point.idl\nmsg/Point.msg\nmsg/Pose.msg\nsrv/Locate.srv\n")
+	writeFile(t, filepath.Join(prefix2, index, "nav"), "action/Go.action\n")
+	writeFile(t, filepath.Join(prefix1, "share", "geo", "msg", "Point.msg"), "float64 x # meters\nfloat64 y\n")
+	writeFile(t, filepath.Join(prefix1, "share", "geo", "msg", "Pose.msg"), "# A pose.\nPoint position\ngeo/Point[<=2] extra\n")
+	writeFile(t, filepath.Join(prefix1, "share", "geo", "srv", "Locate.srv"), "string name\n---\nPose pose\n")
+	t.Setenv("AMENT_PREFIX_PATH", prefix1+string(os.PathListSeparator)+prefix2)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"List", []string{"interface", "list"}, "Messages:\n    geo/msg/Point\n    geo/msg/Pose\nServices:\n    geo/srv/Locate\nActions:\n    nav/action/Go\n"},
+		{"List services", []string{"interface", "list", "-s"}, "Services:\n    geo/srv/Locate\n"},
+		{"Show", []string{"interface", "show", "geo/msg/Pose"}, "# A pose.\nPoint position\n\tfloat64 x # meters\n\tfloat64 y\ngeo/Point[<=2] extra\n\tfloat64 x # meters\n\tfloat64 y\n"},
+		{"Show without comments", []string{"interface", "show", "--no-comments", "geo/srv/Locate"}, "string name\n---\nPose pose\n\tPoint position\n\t\tfloat64 x\n\t\tfloat64 y\n\tgeo/Point[<=2] extra\n\t\tfloat64 x\n\t\tfloat64 y\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(tc.args)
+			t.Cleanup(func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetArgs(nil)
+			})
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tc.want {
+				t.Fatalf("want\n%s\ngot\n%s", tc.want, out.String())
+			}
+		})
+	}
+}
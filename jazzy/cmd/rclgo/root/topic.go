@@ -0,0 +1,429 @@
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/spf13/cobra"
+)
+
+var topicCmd = &cobra.Command{
+	Use:   "topic",
+	Short: "Various topic related sub-commands",
+}
+
+var topicListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Output a list of available topics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWithNode(cmd, func(ctx context.Context, node *jazzy.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			namesAndTypes, err := node.GetTopicNamesAndTypes(true)
+			if err != nil {
+				return err
+			}
+			includeHidden, _ := cmd.Flags().GetBool("include-hidden-topics")
+			if count, _ := cmd.Flags().GetBool("count-topics"); count {
+				n := 0
+				for name := range namesAndTypes {
+					if includeHidden || !isHidden(name) {
+						n++
+					}
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), n)
+				return nil
+			}
+			showTypes, _ := cmd.Flags().GetBool("show-types")
+			printNamesAndTypes(cmd, namesAndTypes, includeHidden, showTypes)
+			return nil
+		})
+	},
+}
+
+var topicInfoCmd = &cobra.Command{
+	Use:   "info <topic>",
+	Short: "Print information about a topic",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		topic := absoluteName(args[0])
+		return runWithNode(cmd, func(ctx context.Context, node *jazzy.Node) error {
+			if err := waitForDiscovery(ctx, cmd); err != nil {
+				return err
+			}
+			namesAndTypes, err := node.GetTopicNamesAndTypes(true)
+			if err != nil {
+				return err
+			}
+			types, ok := namesAndTypes[topic]
+			if !ok {
+				return fmt.Errorf("unknown topic %s", topic)
+			}
+			pubs, err := node.GetPublishersInfoByTopic(topic, false)
+			if err != nil {
+				return err
+			}
+			subs, err := node.GetSubscriptionsInfoByTopic(topic, false)
+			if err != nil {
+				return err
+			}
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Type: %s\n", joinTypes(types))
+			if verbose {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "Publisher count: %d\n", len(pubs))
+			if verbose {
+				fmt.Fprintln(w)
+				printEndpoints(w, pubs)
+			}
+			fmt.Fprintf(w, "Subscription count: %d\n", len(subs))
+			if verbose {
+				fmt.Fprintln(w)
+				printEndpoints(w, subs)
+			}
+			return nil
+		})
+	},
+}
+
+func printEndpoints(w io.Writer, infos []jazzy.TopicEndpointInfo) {
+	for _, info := range infos {
+		fmt.Fprintf(w, "Node name: %s\n", info.NodeName)
+		fmt.Fprintf(w, "Node namespace: %s\n", info.NodeNamespace)
+		fmt.Fprintf(w, "Topic type: %s\n", info.TopicType)
+		fmt.Fprintf(w, "Endpoint type: %s\n", endpointTypeName(info.EndpointType))
+		fmt.Fprintf(w, "GID: %s\n", formatGID(info.EndpointGID))
+		fmt.Fprintln(w, "QoS profile:")
+		fmt.Fprintf(w, "  Reliability: %s\n", reliabilityName(info.QosProfile.Reliability))
+		fmt.Fprintf(w, "  History (Depth): %s (%d)\n", historyName(info.QosProfile.History), info.QosProfile.Depth)
+		fmt.Fprintf(w, "  Durability: %s\n", durabilityName(info.QosProfile.Durability))
+		fmt.Fprintf(w, "  Lifespan: %s\n", qosDuration(info.QosProfile.Lifespan))
+		fmt.Fprintf(w, "  Deadline: %s\n", qosDuration(info.QosProfile.Deadline))
+		fmt.Fprintf(w, "  Liveliness: %s\n", livelinessName(info.QosProfile.Liveliness))
+		fmt.Fprintf(w, "  Liveliness lease duration: %s\n", qosDuration(info.QosProfile.LivelinessLeaseDuration))
+		fmt.Fprintln(w)
+	}
+}
+
+var topicEchoCmd = &cobra.Command{
+	Use:   "echo <topic> [type]",
+	Short: "Output messages from a topic",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		topic := absoluteName(args[0])
+		qos, err := qosProfile(cmd)
+		if err != nil {
+			return err
+		}
+		once, _ := cmd.Flags().GetBool("once")
+		return runWithNode(cmd, func(ctx context.Context, node *jazzy.Node) error {
+			typ, err := argOrTopicType(ctx, cmd, node, args, topic)
+			if err != nil {
+				return err
+			}
+			ts, err := messageTypeSupport(typ)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			w := cmd.OutOrStdout()
+			opts := &jazzy.SubscriptionOptions{Qos: qos}
+			_, err = node.NewSubscription(topic, ts, opts, func(s *jazzy.Subscription) {
+				msg := ts.New()
+				if _, err := s.TakeMessage(msg); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "failed to take message: %v\n", err)
+					return
+				}
+				text, err := formatMessage(msg)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "failed to format message: %v\n", err)
+					return
+				}
+				fmt.Fprint(w, text+"---\n")
+				if once {
+					cancel()
+				}
+			})
+			if err != nil {
+				return err
+			}
+			return spinUntilDone(ctx, node)
+		})
+	},
+}
+
+// argOrTopicType returns the type given as the second argument or, if it is
+// missing, the type of topic in the graph.
+func argOrTopicType(ctx context.Context, cmd *cobra.Command, node *jazzy.Node, args []string, topic string) (string, error) {
+	if len(args) > 1 {
+		return args[1], nil
+	}
+	return topicType(ctx, cmd, node, topic)
+}
+
+var topicPubCmd = &cobra.Command{
+	Use:   "pub <topic> <type> [values]",
+	Short: "Publish a message to a topic",
+	Long: `Publish a message to a topic.
+
+The values of the message are given in YAML, e.g. "{data: hello}". Fields that
+are not set keep their default values.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		topic, typ := absoluteName(args[0]), args[1]
+		ts, err := messageTypeSupport(typ)
+		if err != nil {
+			return err
+		}
+		msg := ts.New()
+		if len(args) > 2 {
+			if err := parseMessage(args[2], msg); err != nil {
+				return err
+			}
+		}
+		qos, err := qosProfile(cmd)
+		if err != nil {
+			return err
+		}
+		rate, _ := cmd.Flags().GetFloat64("rate")
+		if rate <= 0 {
+			return fmt.Errorf("invalid rate %v", rate)
+		}
+		times, _ := cmd.Flags().GetInt("times")
+		if once, _ := cmd.Flags().GetBool("once"); once {
+			times = 1
+		}
+		wait, _ := cmd.Flags().GetInt("wait-matching-subscriptions")
+		if !cmd.Flags().Changed("wait-matching-subscriptions") && times > 0 {
+			wait = 1
+		}
+		keepAlive, _ := cmd.Flags().GetDuration("keep-alive")
+		text, err := formatMessage(msg)
+		if err != nil {
+			return err
+		}
+		return runWithNode(cmd, func(ctx context.Context, node *jazzy.Node) error {
+			pub, err := node.NewPublisher(topic, ts, &jazzy.PublisherOptions{Qos: qos})
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			if wait > 0 {
+				fmt.Fprintf(w, "Waiting for at least %d matching subscription(s)...\n", wait)
+				err = pollGraph(ctx, func() (bool, error) {
+					n, err := pub.GetSubscriptionCount()
+					return n >= wait, err
+				})
+				if err != nil {
+					return ignoreCanceled(ctx, err)
+				}
+			}
+			fmt.Fprintln(w, "publisher: beginning loop")
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+			defer ticker.Stop()
+			for i := 1; times <= 0 || i <= times; i++ {
+				if i > 1 {
+					select {
+					case <-ctx.Done():
+						return nil
+					case <-ticker.C:
+					}
+				}
+				if err := pub.Publish(msg); err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "publishing #%d:\n%s\n", i, indent(text, "  "))
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(keepAlive):
+			}
+			return nil
+		})
+	},
+}
+
+// ignoreCanceled returns nil if err is caused by ctx being done.
+func ignoreCanceled(ctx context.Context, err error) error {
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return nil
+	}
+	return err
+}
+
+var topicHzCmd = &cobra.Command{
+	Use:   "hz <topic>",
+	Short: "Print the average publishing rate of a topic",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var stats window
+		return monitorTopic(cmd, args[0], serializedTypeSupport, &stats, func(_ *jazzy.Subscription, msg []byte, received time.Time) {
+			stats.add(received, 0)
+		}, func() string {
+			times, _ := stats.snapshot()
+			if len(times) < 2 {
+				return ""
+			}
+			intervals := make([]float64, len(times)-1)
+			for i := range intervals {
+				intervals[i] = times[i+1].Sub(times[i]).Seconds()
+			}
+			s := summarize(intervals)
+			if s.mean == 0 {
+				return ""
+			}
+			return fmt.Sprintf("average rate: %.3f\n\tmin: %.3fs max: %.3fs std dev: %.5fs window: %d\n",
+				1/s.mean, s.min, s.max, s.stdDev, len(times))
+		})
+	},
+}
+
+var topicBwCmd = &cobra.Command{
+	Use:   "bw <topic>",
+	Short: "Display the bandwidth used by a topic",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var stats window
+		return monitorTopic(cmd, args[0], serializedTypeSupport, &stats, func(_ *jazzy.Subscription, msg []byte, received time.Time) {
+			stats.add(received, float64(len(msg)))
+		}, func() string {
+			times, sizes := stats.snapshot()
+			if len(times) < 2 {
+				return ""
+			}
+			elapsed := time.Since(times[0]).Seconds()
+			s := summarize(sizes)
+			return fmt.Sprintf("%s/s from %d messages\n\tMessage size mean: %s min: %s max: %s\n",
+				formatBytes(s.sum/elapsed), len(sizes), formatBytes(s.mean), formatBytes(s.min), formatBytes(s.max))
+		})
+	},
+}
+
+var topicDelayCmd = &cobra.Command{
+	Use:   "delay <topic>",
+	Short: "Display the delay of a topic from the timestamp in its header",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var stats window
+		var warned bool
+		return monitorTopic(cmd, args[0], messageTypeSupport, &stats, func(s *jazzy.Subscription, data []byte, received time.Time) {
+			msg, err := jazzy.Deserialize(data, s.Ros2MsgType)
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "failed to deserialize message: %v\n", err)
+				return
+			}
+			stamp, ok := headerStamp(msg)
+			if !ok {
+				if !warned {
+					warned = true
+					fmt.Fprintln(cmd.ErrOrStderr(), "message has no header.stamp field")
+				}
+				return
+			}
+			stats.add(received, received.Sub(stamp).Seconds())
+		}, func() string {
+			_, delays := stats.snapshot()
+			if len(delays) == 0 {
+				return ""
+			}
+			s := summarize(delays)
+			return fmt.Sprintf("average delay: %.3f\n\tmin: %.3fs max: %.3fs std dev: %.5fs window: %d\n",
+				s.mean, s.min, s.max, s.stdDev, len(delays))
+		})
+	},
+}
+
+// monitorTopic subscribes to serialized messages of topic and passes them to
+// onMessage. The string returned by report is printed every second until the
+// command is interrupted. The type support of the topic is looked up using
+// typeSupport.
+func monitorTopic(
+	cmd *cobra.Command,
+	topic string,
+	typeSupport func(typ string) (jazzy.MessageTypeSupport, error),
+	stats *window,
+	onMessage func(s *jazzy.Subscription, msg []byte, received time.Time),
+	report func() string,
+) error {
+	topic = absoluteName(topic)
+	size, _ := cmd.Flags().GetInt("window")
+	if size < 1 {
+		return fmt.Errorf("invalid window size %d", size)
+	}
+	stats.size = size
+	qos, err := qosProfile(cmd)
+	if err != nil {
+		return err
+	}
+	return runWithNode(cmd, func(ctx context.Context, node *jazzy.Node) error {
+		typ, err := topicType(ctx, cmd, node, topic)
+		if err != nil {
+			return err
+		}
+		ts, err := typeSupport(typ)
+		if err != nil {
+			return err
+		}
+		_, err = node.NewSubscription(topic, ts, &jazzy.SubscriptionOptions{Qos: qos}, func(s *jazzy.Subscription) {
+			msg, _, err := s.TakeSerializedMessage()
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "failed to take message: %v\n", err)
+				return
+			}
+			onMessage(s, msg, time.Now())
+		})
+		if err != nil {
+			return err
+		}
+		stop := spinInBackground(ctx, node)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return stop()
+			case <-ticker.C:
+				fmt.Fprint(cmd.OutOrStdout(), report())
+			}
+		}
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(topicCmd)
+
+	topicCmd.AddCommand(topicListCmd)
+	topicListCmd.Flags().BoolP("show-types", "t", false, "Additionally show the topic type.")
+	topicListCmd.Flags().BoolP("count-topics", "c", false, "Only display the number of topics discovered.")
+	topicListCmd.Flags().Bool("include-hidden-topics", false, "Consider hidden topics as well.")
+
+	topicCmd.AddCommand(topicInfoCmd)
+	topicInfoCmd.Flags().BoolP("verbose", "v", false, "Print detailed information like the node name, node namespace, topic type, GUID and QoS profile of the publishers and subscribers to this topic.")
+
+	topicCmd.AddCommand(topicEchoCmd)
+	addQosFlags(topicEchoCmd)
+	topicEchoCmd.Flags().Bool("once", false, "Print the first message received and then exit.")
+
+	topicCmd.AddCommand(topicPubCmd)
+	addQosFlags(topicPubCmd)
+	topicPubCmd.Flags().Float64P("rate", "r", 1, "Publishing rate in Hz.")
+	topicPubCmd.Flags().BoolP("once", "1", false, "Publish one message and exit.")
+	topicPubCmd.Flags().IntP("times", "t", 0, "Publish this number of times and then exit. Zero publishes until interrupted.")
+	topicPubCmd.Flags().IntP("wait-matching-subscriptions", "w", 0, "Wait until finding the specified number of matching subscriptions before publishing. Defaults to 1 when --once or --times is used.")
+	topicPubCmd.Flags().Duration("keep-alive", 100*time.Millisecond, "Keep the publisher alive for this duration after publishing the last message.")
+
+	for _, cmd := range []*cobra.Command{topicHzCmd, topicBwCmd, topicDelayCmd} {
+		topicCmd.AddCommand(cmd)
+		addQosFlags(cmd)
+		cmd.Flags().IntP("window", "w", 100, "Window size, in number of messages, for calculating the statistics.")
+	}
+}
@@ -0,0 +1,168 @@
+package jazzy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldDiff is a difference between two messages found by the Diff method of
+// generated messages.
+type FieldDiff struct {
+	// Field is the path of the field, e.g. "poses[2].position.x".
+	Field string
+	// A and B are the values of the field in the compared messages. For
+	// sequences of different lengths, they are the sequences.
+	A, B any
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %v != %v", d.Field, d.A, d.B)
+}
+
+// DiffOption configures the comparison of messages.
+type DiffOption func(*Differ)
+
+// FloatTolerance makes floats that differ by at most tolerance equal.
+func FloatTolerance(tolerance float64) DiffOption {
+	return func(d *Differ) { d.Tolerance = tolerance }
+}
+
+// Differ collects the differences between messages. It is used by the
+// generated Equal, Diff and DiffWith methods.
+type Differ struct {
+	// Tolerance is the largest difference of floats considered equal. NaNs
+	// are equal to each other.
+	Tolerance float64
+	// FirstOnly stops the comparison after the first difference.
+	FirstOnly bool
+	// Diffs are the differences found so far.
+	Diffs []FieldDiff
+
+	path []pathElem
+}
+
+type pathElem struct {
+	name  string
+	index int // -1 for fields
+}
+
+// NewDiffer returns a Differ configured with opts.
+func NewDiffer(opts ...DiffOption) *Differ {
+	d := &Differ{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Done reports whether the comparison can be stopped.
+func (d *Differ) Done() bool {
+	return d.FirstOnly && len(d.Diffs) > 0
+}
+
+// Enter descends into the message in field. It must be paired with Leave.
+func (d *Differ) Enter(field string) {
+	d.path = append(d.path, pathElem{name: field, index: -1})
+}
+
+// EnterIndex descends into the message at index i of the sequence or array
+// field. It must be paired with Leave.
+func (d *Differ) EnterIndex(field string, i int) {
+	d.path = append(d.path, pathElem{name: field, index: i})
+}
+
+// Leave returns from the message entered last.
+func (d *Differ) Leave() {
+	d.path = d.path[:len(d.path)-1]
+}
+
+func (d *Differ) add(field string, index int, a, b any) {
+	var sb strings.Builder
+	for _, e := range append(d.path, pathElem{name: field, index: index}) {
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(e.name)
+		if e.index >= 0 {
+			sb.WriteString("[" + strconv.Itoa(e.index) + "]")
+		}
+	}
+	d.Diffs = append(d.Diffs, FieldDiff{Field: sb.String(), A: a, B: b})
+}
+
+// DiffValue compares the values a and b of field.
+func DiffValue[T comparable](d *Differ, field string, a, b T) {
+	if !d.Done() && a != b {
+		d.add(field, -1, a, b)
+	}
+}
+
+// DiffFloat compares the float values a and b of field.
+func DiffFloat[T ~float32 | ~float64](d *Differ, field string, a, b T) {
+	if !d.Done() && !floatsEqual(d, float64(a), float64(b)) {
+		d.add(field, -1, a, b)
+	}
+}
+
+// DiffTime compares the times a and b of field. Times of different
+// locations are equal if they are the same instant.
+func DiffTime(d *Differ, field string, a, b time.Time) {
+	if !d.Done() && !a.Equal(b) {
+		d.add(field, -1, a, b)
+	}
+}
+
+// DiffLen compares the lengths of the sequence field and reports whether
+// they are equal. Nil and empty sequences are equal.
+func DiffLen[T any](d *Differ, field string, a, b []T) bool {
+	if d.Done() {
+		return false
+	}
+	if len(a) != len(b) {
+		d.add(field, -1, a, b)
+		return false
+	}
+	return true
+}
+
+// DiffSlice compares the sequences or arrays a and b of field element by
+// element.
+func DiffSlice[T comparable](d *Differ, field string, a, b []T) {
+	if !DiffLen(d, field, a, b) {
+		return
+	}
+	for i := range a {
+		if d.Done() {
+			return
+		}
+		if a[i] != b[i] {
+			d.add(field, i, a[i], b[i])
+		}
+	}
+}
+
+// DiffFloatSlice compares the float sequences or arrays a and b of field
+// element by element.
+func DiffFloatSlice[T ~float32 | ~float64](d *Differ, field string, a, b []T) {
+	if !DiffLen(d, field, a, b) {
+		return
+	}
+	for i := range a {
+		if d.Done() {
+			return
+		}
+		if !floatsEqual(d, float64(a[i]), float64(b[i])) {
+			d.add(field, i, a[i], b[i])
+		}
+	}
+}
+
+func floatsEqual(d *Differ, a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b || math.Abs(a-b) <= d.Tolerance
+}
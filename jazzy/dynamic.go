@@ -77,6 +77,7 @@ import (
 )
 
 type dynamicMessageTypeSupport struct {
+	typeName    string
 	lib         unsafe.Pointer // void*
 	typeSupport unsafe.Pointer // rosidl_message_type_support_t*
 }
@@ -86,7 +87,8 @@ type dynamicMessageTypeSupport struct {
 //
 // MessageTypeSupport instances returned by LoadDynamicMessageTypeSupport
 // support use cases related to handling only serialized messages. Methods New,
-// PrepareMemory, ReleaseMemory, AsCStruct and AsGoStruct will panic.
+// PrepareMemory, ReleaseMemory, AsCStruct and AsGoStruct will panic. They have
+// a TypeName method, but don't implement TypeDescriber or MemoryResetter.
 //
 // Backward compatibility is not guaranteed for this API. Use it only if
 // necessary.
@@ -95,7 +97,7 @@ func LoadDynamicMessageTypeSupport(pkgName, msgName string) (MessageTypeSupport,
 	defer C.free(unsafe.Pointer(cPkgName))
 	cIFaceName := C.CString(msgName)
 	defer C.free(unsafe.Pointer(cIFaceName))
-	ts := new(dynamicMessageTypeSupport)
+	ts := &dynamicMessageTypeSupport{typeName: pkgName + "/msg/" + msgName}
 	err := C.loadTypeSupport(cPkgName, cIFaceName, &ts.lib, &ts.typeSupport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load type support: %v", C.GoString(err))
@@ -126,6 +128,10 @@ func (g *dynamicMessageTypeSupport) AsGoStruct(Message, unsafe.Pointer) {
 	panic("not supported")
 }
 
+func (g *dynamicMessageTypeSupport) TypeName() string {
+	return g.typeName
+}
+
 func (g *dynamicMessageTypeSupport) TypeSupport() unsafe.Pointer {
 	// *C.rosidl_message_type_support_t
 	return g.typeSupport
@@ -0,0 +1,15 @@
+package jazzy
+
+import "unsafe"
+
+// MessageBufferPool exposes messageBufferPool to the tests of package
+// jazzy_test, which can use generated type supports.
+type MessageBufferPool struct{ p *messageBufferPool }
+
+func NewMessageBufferPool(ts MessageTypeSupport) MessageBufferPool {
+	return MessageBufferPool{newMessageBufferPool(ts, true)}
+}
+
+func (p MessageBufferPool) Get() unsafe.Pointer    { return p.p.get() }
+func (p MessageBufferPool) Put(buf unsafe.Pointer) { p.p.put(buf) }
+func (p MessageBufferPool) Close()                 { p.p.close() }
@@ -0,0 +1,465 @@
+package foxglove
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+// Parameters are accessed using the parameter services of nodes. Foxglove
+// names parameters "<fully qualified node name>.<parameter name>".
+const (
+	getParametersType  = "rcl_interfaces/srv/GetParameters"
+	setParametersType  = "rcl_interfaces/srv/SetParameters"
+	listParametersType = "rcl_interfaces/srv/ListParameters"
+)
+
+// parametersSupported reports whether the type support of the parameter
+// services is registered.
+func parametersSupported() bool {
+	for _, typ := range []string{getParametersType, setParametersType, listParametersType} {
+		if _, ok := jazzy.GetService(typ); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Values of rcl_interfaces/msg/ParameterType.
+const (
+	parameterNotSet       = 0
+	parameterBool         = 1
+	parameterInteger      = 2
+	parameterDouble       = 3
+	parameterString       = 4
+	parameterByteArray    = 5
+	parameterBoolArray    = 6
+	parameterIntegerArray = 7
+	parameterDoubleArray  = 8
+	parameterStringArray  = 9
+)
+
+// rosParameterValue mirrors rcl_interfaces/msg/ParameterValue. Messages are
+// converted using msgcodec, so the generated Go types are not needed.
+type rosParameterValue struct {
+	Type              uint8     `json:"type"`
+	BoolValue         bool      `json:"bool_value"`
+	IntegerValue      int64     `json:"integer_value"`
+	DoubleValue       float64   `json:"double_value"`
+	StringValue       string    `json:"string_value"`
+	ByteArrayValue    []byte    `json:"byte_array_value,omitempty"`
+	BoolArrayValue    []bool    `json:"bool_array_value,omitempty"`
+	IntegerArrayValue []int64   `json:"integer_array_value,omitempty"`
+	DoubleArrayValue  []float64 `json:"double_array_value,omitempty"`
+	StringArrayValue  []string  `json:"string_array_value,omitempty"`
+}
+
+type rosParameter struct {
+	Name  string            `json:"name"`
+	Value rosParameterValue `json:"value"`
+}
+
+// parameterClients are the clients of the parameter services of a node.
+type parameterClients struct {
+	get, set, list *jazzy.Client
+}
+
+func (s *Server) parameterClients(node string) (*parameterClients, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pc := s.params[node]; pc != nil {
+		return pc, nil
+	}
+	pc := &parameterClients{}
+	var clients []*jazzy.Client
+	for _, c := range []struct {
+		client **jazzy.Client
+		name   string
+		typ    string
+	}{
+		{&pc.get, node + "/get_parameters", getParametersType},
+		{&pc.set, node + "/set_parameters", setParametersType},
+		{&pc.list, node + "/list_parameters", listParametersType},
+	} {
+		ts, _ := jazzy.GetService(c.typ)
+		client, err := s.node.NewClient(c.name, ts, nil)
+		if err != nil {
+			for _, client := range clients {
+				err = errors.Join(err, client.Close())
+			}
+			return nil, err
+		}
+		*c.client = client
+		clients = append(clients, client)
+	}
+	if _, err := s.startWaiter(func(ws *jazzy.WaitSet) { ws.AddClients(clients...) }); err != nil {
+		for _, client := range clients {
+			err = errors.Join(err, client.Close())
+		}
+		return nil, err
+	}
+	s.params[node] = pc
+	return pc, nil
+}
+
+// callParameterService sends req to client, whose service type is typ, and
+// decodes the response into resp. req and resp are converted using their JSON
+// representation.
+func (s *Server) callParameterService(client *jazzy.Client, typ string, req, resp any) error {
+	ts, ok := jazzy.GetService(typ)
+	if !ok {
+		return fmt.Errorf("type support for %s is not registered", typ)
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	msg := ts.Request().New()
+	if err = msgcodec.UnmarshalJSON(data, msg); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.options.ServiceTimeout)
+	defer cancel()
+	out, _, err := client.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if data, err = msgcodec.MarshalJSON(out); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, resp)
+}
+
+// parameterNodes returns the fully qualified names of the nodes that provide
+// parameter services.
+func (s *Server) parameterNodes() ([]string, error) {
+	services, err := s.graphServices()
+	if err != nil {
+		return nil, err
+	}
+	var nodes []string
+	for name, typ := range services {
+		if node, ok := strings.CutSuffix(name, "/get_parameters"); ok && typ == getParametersType {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// getNodeParameters returns the values of the parameters of node. If names is
+// empty, all parameters are returned.
+func (s *Server) getNodeParameters(node string, names []string) ([]parameter, error) {
+	pc, err := s.parameterClients(node)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		var listed struct {
+			Result struct {
+				Names []string `json:"names"`
+			} `json:"result"`
+		}
+		err := s.callParameterService(pc.list, listParametersType, map[string]any{"prefixes": []string{}, "depth": 0}, &listed)
+		if err != nil {
+			return nil, err
+		}
+		names = listed.Result.Names
+	}
+	var got struct {
+		Values []rosParameterValue `json:"values"`
+	}
+	if err := s.callParameterService(pc.get, getParametersType, map[string]any{"names": names}, &got); err != nil {
+		return nil, err
+	}
+	if len(got.Values) != len(names) {
+		return nil, fmt.Errorf("%s returned %d values for %d parameters", node, len(got.Values), len(names))
+	}
+	params := make([]parameter, 0, len(names))
+	for i, name := range names {
+		if p, ok := toParameter(node+"."+name, got.Values[i]); ok {
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+func (c *conn) getParameters(names []string, id string) {
+	params, err := c.server.getParameters(names)
+	if err != nil {
+		c.status(statusError, fmt.Sprintf("failed to get parameters: %v", err))
+	}
+	if params == nil {
+		params = []parameter{}
+	}
+	c.sendJSON(parameterValuesOp{Op: "parameterValues", Parameters: params, ID: id})
+}
+
+// getParameters returns the parameters with the given names. If names is
+// empty, the parameters of all nodes are returned. Errors of individual nodes
+// are joined, and the parameters of other nodes are still returned.
+func (s *Server) getParameters(names []string) ([]parameter, error) {
+	if !parametersSupported() {
+		return nil, errors.New("type support for rcl_interfaces is not registered")
+	}
+	byNode := map[string][]string{}
+	if len(names) == 0 {
+		nodes, err := s.parameterNodes()
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			byNode[node] = nil
+		}
+	} else {
+		for _, name := range names {
+			node, param, ok := splitParameterName(name)
+			if !ok {
+				return nil, fmt.Errorf("invalid parameter name %q", name)
+			}
+			byNode[node] = append(byNode[node], param)
+		}
+	}
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		params []parameter
+		errs   []error
+	)
+	for node, names := range byNode {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := s.getNodeParameters(node, names)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", node, err))
+				return
+			}
+			params = append(params, p...)
+		}()
+	}
+	wg.Wait()
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params, errors.Join(errs...)
+}
+
+func (c *conn) setParameters(params []parameter, id string) {
+	if err := c.server.setParameters(params); err != nil {
+		c.status(statusError, fmt.Sprintf("failed to set parameters: %v", err))
+	}
+	if id == "" {
+		return
+	}
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+	c.getParameters(names, id)
+}
+
+// setParameters sets parameters. A null value unsets a parameter.
+func (s *Server) setParameters(params []parameter) error {
+	if !parametersSupported() {
+		return errors.New("type support for rcl_interfaces is not registered")
+	}
+	byNode := map[string][]rosParameter{}
+	var errs []error
+	for _, p := range params {
+		node, name, ok := splitParameterName(p.Name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid parameter name %q", p.Name))
+			continue
+		}
+		value, err := fromParameter(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		byNode[node] = append(byNode[node], rosParameter{Name: name, Value: value})
+	}
+	for node, params := range byNode {
+		pc, err := s.parameterClients(node)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var resp struct {
+			Results []struct {
+				Successful bool   `json:"successful"`
+				Reason     string `json:"reason"`
+			} `json:"results"`
+		}
+		if err := s.callParameterService(pc.set, setParametersType, map[string]any{"parameters": params}, &resp); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", node, err))
+			continue
+		}
+		for i, result := range resp.Results {
+			if !result.Successful && i < len(params) {
+				errs = append(errs, fmt.Errorf("%s.%s: %s", node, params[i].Name, result.Reason))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitParameterName splits a Foxglove parameter name into the node and
+// parameter names. Node names cannot contain dots, so the first dot separates
+// them.
+func splitParameterName(name string) (node, param string, ok bool) {
+	node, param, ok = strings.Cut(name, ".")
+	if !ok || !strings.HasPrefix(node, "/") || param == "" {
+		return "", "", false
+	}
+	return node, param, true
+}
+
+// toParameter converts a ROS parameter value to the Foxglove representation.
+// ok is false if the parameter is not set.
+func toParameter(name string, v rosParameterValue) (p parameter, ok bool) {
+	var value any
+	switch v.Type {
+	case parameterBool:
+		value = v.BoolValue
+	case parameterInteger:
+		value = v.IntegerValue
+	case parameterDouble:
+		value, p.Type = v.DoubleValue, "float64"
+	case parameterString:
+		value = v.StringValue
+	case parameterByteArray:
+		value, p.Type = base64.StdEncoding.EncodeToString(v.ByteArrayValue), "byte_array"
+	case parameterBoolArray:
+		value = v.BoolArrayValue
+	case parameterIntegerArray:
+		value = v.IntegerArrayValue
+	case parameterDoubleArray:
+		value, p.Type = v.DoubleArrayValue, "float64_array"
+	case parameterStringArray:
+		value = v.StringArrayValue
+	default:
+		return p, false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		// Non-finite doubles cannot be represented.
+		return p, false
+	}
+	p.Name, p.Value = name, data
+	return p, true
+}
+
+// fromParameter converts a Foxglove parameter to a ROS parameter value. The
+// ROS type is inferred from the JSON value and the optional type hint.
+func fromParameter(p parameter) (v rosParameterValue, err error) {
+	dec := json.NewDecoder(bytes.NewReader(p.Value))
+	dec.UseNumber()
+	var value any
+	if len(bytes.TrimSpace(p.Value)) > 0 {
+		if err := dec.Decode(&value); err != nil {
+			return v, fmt.Errorf("invalid value of %s: %w", p.Name, err)
+		}
+	}
+	invalid := func() error {
+		return fmt.Errorf("unsupported value of %s: %s", p.Name, p.Value)
+	}
+	switch value := value.(type) {
+	case nil:
+		v.Type = parameterNotSet
+	case bool:
+		v.Type, v.BoolValue = parameterBool, value
+	case json.Number:
+		if i, err := value.Int64(); err == nil && p.Type != "float64" {
+			v.Type, v.IntegerValue = parameterInteger, i
+		} else if f, err := value.Float64(); err == nil {
+			v.Type, v.DoubleValue = parameterDouble, f
+		} else {
+			return v, invalid()
+		}
+	case string:
+		if p.Type == "byte_array" {
+			data, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return v, fmt.Errorf("invalid value of %s: %w", p.Name, err)
+			}
+			v.Type, v.ByteArrayValue = parameterByteArray, data
+		} else {
+			v.Type, v.StringValue = parameterString, value
+		}
+	case []any:
+		return fromParameterArray(p, value, invalid)
+	default:
+		return v, invalid()
+	}
+	return v, nil
+}
+
+func fromParameterArray(p parameter, elems []any, invalid func() error) (v rosParameterValue, err error) {
+	if len(elems) == 0 {
+		if p.Type == "float64_array" {
+			return rosParameterValue{Type: parameterDoubleArray, DoubleArrayValue: []float64{}}, nil
+		}
+		return v, fmt.Errorf("cannot infer the type of empty array %s", p.Name)
+	}
+	switch elems[0].(type) {
+	case bool:
+		v.Type = parameterBoolArray
+		for _, e := range elems {
+			b, ok := e.(bool)
+			if !ok {
+				return v, invalid()
+			}
+			v.BoolArrayValue = append(v.BoolArrayValue, b)
+		}
+	case string:
+		v.Type = parameterStringArray
+		for _, e := range elems {
+			s, ok := e.(string)
+			if !ok {
+				return v, invalid()
+			}
+			v.StringArrayValue = append(v.StringArrayValue, s)
+		}
+	case json.Number:
+		v.Type = parameterIntegerArray
+		for _, e := range elems {
+			n, ok := e.(json.Number)
+			if !ok {
+				return v, invalid()
+			}
+			i, err := n.Int64()
+			if err != nil || p.Type == "float64_array" {
+				v.Type = parameterDoubleArray
+				break
+			}
+			v.IntegerArrayValue = append(v.IntegerArrayValue, i)
+		}
+		if v.Type == parameterDoubleArray {
+			v.IntegerArrayValue = nil
+			for _, e := range elems {
+				n, ok := e.(json.Number)
+				if !ok {
+					return v, invalid()
+				}
+				f, err := n.Float64()
+				if err != nil {
+					return v, invalid()
+				}
+				v.DoubleArrayValue = append(v.DoubleArrayValue, f)
+			}
+		}
+	default:
+		return v, invalid()
+	}
+	return v, nil
+}
@@ -0,0 +1,73 @@
+package foxglove
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSplitParameterName(t *testing.T) {
+	node, param, ok := splitParameterName("/ns/talker.qos_overrides./chatter.publisher.depth")
+	if !ok || node != "/ns/talker" || param != "qos_overrides./chatter.publisher.depth" {
+		t.Errorf("unexpected split: %q %q %v", node, param, ok)
+	}
+	for _, name := range []string{"talker.x", "/talker", "/talker."} {
+		if _, _, ok := splitParameterName(name); ok {
+			t.Errorf("want %q to be invalid", name)
+		}
+	}
+}
+
+func TestParameterConversion(t *testing.T) {
+	tests := []struct {
+		name  string
+		param parameter
+		want  rosParameterValue
+	}{
+		{"Unset", parameter{Value: json.RawMessage(`null`)}, rosParameterValue{Type: parameterNotSet}},
+		{"Bool", parameter{Value: json.RawMessage(`true`)}, rosParameterValue{Type: parameterBool, BoolValue: true}},
+		{"Integer", parameter{Value: json.RawMessage(`3`)}, rosParameterValue{Type: parameterInteger, IntegerValue: 3}},
+		{"Double", parameter{Value: json.RawMessage(`0.5`)}, rosParameterValue{Type: parameterDouble, DoubleValue: 0.5}},
+		{"Integral double", parameter{Value: json.RawMessage(`2`), Type: "float64"}, rosParameterValue{Type: parameterDouble, DoubleValue: 2}},
+		{"String", parameter{Value: json.RawMessage(`"a"`)}, rosParameterValue{Type: parameterString, StringValue: "a"}},
+		{"Bytes", parameter{Value: json.RawMessage(`"AQI="`), Type: "byte_array"}, rosParameterValue{Type: parameterByteArray, ByteArrayValue: []byte{1, 2}}},
+		{"Bool array", parameter{Value: json.RawMessage(`[true, false]`)}, rosParameterValue{Type: parameterBoolArray, BoolArrayValue: []bool{true, false}}},
+		{"Integer array", parameter{Value: json.RawMessage(`[1, 2]`)}, rosParameterValue{Type: parameterIntegerArray, IntegerArrayValue: []int64{1, 2}}},
+		{"Mixed number array", parameter{Value: json.RawMessage(`[1, 2.5]`)}, rosParameterValue{Type: parameterDoubleArray, DoubleArrayValue: []float64{1, 2.5}}},
+		{"String array", parameter{Value: json.RawMessage(`["a"]`)}, rosParameterValue{Type: parameterStringArray, StringArrayValue: []string{"a"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.param.Name = "/node.param"
+			got, err := fromParameter(tc.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("want %+v, got %+v", tc.want, got)
+			}
+			if got.Type == parameterNotSet {
+				return
+			}
+			back, ok := toParameter(tc.param.Name, got)
+			if !ok {
+				t.Fatal("want parameter to be converted back")
+			}
+			again, err := fromParameter(back)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(again, tc.want) {
+				t.Fatalf("round trip changed the value: want %+v, got %+v", tc.want, again)
+			}
+		})
+	}
+}
+
+func TestParameterConversionErrors(t *testing.T) {
+	for _, value := range []string{`[]`, `[1, "a"]`, `{}`, `[[1]]`} {
+		if _, err := fromParameter(parameter{Name: "/n.p", Value: json.RawMessage(value)}); err == nil {
+			t.Errorf("want error for value %s", value)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package foxglove
+
+import (
+	"encoding/binary"
+	"encoding/json"
+)
+
+// Subprotocol is the WebSocket subprotocol implemented by Server.
+const Subprotocol = "foxglove.websocket.v1"
+
+// Capabilities announced in serverInfo.
+const (
+	capabilityClientPublish = "clientPublish"
+	capabilityServices      = "services"
+	capabilityParameters    = "parameters"
+)
+
+// Opcodes of binary messages sent by the server.
+const (
+	opMessageData         = 0x01
+	opServiceCallResponse = 0x03
+)
+
+// Opcodes of binary messages sent by clients.
+const (
+	opClientMessageData  = 0x01
+	opServiceCallRequest = 0x02
+)
+
+// Status levels.
+const (
+	statusInfo    = 0
+	statusWarning = 1
+	statusError   = 2
+)
+
+// clientOp is a JSON message sent by a client. Fields that are not used by an
+// operation are left empty.
+type clientOp struct {
+	Op string `json:"op"`
+
+	// subscribe and unsubscribe
+	Subscriptions   []subscriptionRequest `json:"subscriptions"`
+	SubscriptionIDs []uint32              `json:"subscriptionIds"`
+
+	// advertise and unadvertise
+	Channels   []clientChannel `json:"channels"`
+	ChannelIDs []uint32        `json:"channelIds"`
+
+	// getParameters and setParameters
+	ParameterNames []string    `json:"parameterNames"`
+	Parameters     []parameter `json:"parameters"`
+	ID             string      `json:"id"`
+}
+
+type subscriptionRequest struct {
+	ID        uint32 `json:"id"`
+	ChannelID uint32 `json:"channelId"`
+}
+
+// clientChannel is a channel advertised by a client.
+type clientChannel struct {
+	ID         uint32 `json:"id"`
+	Topic      string `json:"topic"`
+	Encoding   string `json:"encoding"`
+	SchemaName string `json:"schemaName"`
+}
+
+type parameter struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+	Type  string          `json:"type,omitempty"`
+}
+
+// JSON messages sent by the server.
+
+type serverInfoOp struct {
+	Op                 string            `json:"op"`
+	Name               string            `json:"name"`
+	Capabilities       []string          `json:"capabilities"`
+	SupportedEncodings []string          `json:"supportedEncodings"`
+	Metadata           map[string]string `json:"metadata"`
+	SessionID          string            `json:"sessionId"`
+}
+
+type statusOp struct {
+	Op      string `json:"op"`
+	Level   int    `json:"level"`
+	Message string `json:"message"`
+}
+
+type channelInfo struct {
+	ID             uint32 `json:"id"`
+	Topic          string `json:"topic"`
+	Encoding       string `json:"encoding"`
+	SchemaName     string `json:"schemaName"`
+	Schema         string `json:"schema"`
+	SchemaEncoding string `json:"schemaEncoding"`
+}
+
+type advertiseOp struct {
+	Op       string        `json:"op"`
+	Channels []channelInfo `json:"channels"`
+}
+
+type unadvertiseOp struct {
+	Op         string   `json:"op"`
+	ChannelIDs []uint32 `json:"channelIds"`
+}
+
+type serviceInfo struct {
+	ID             uint32 `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	RequestSchema  string `json:"requestSchema"`
+	ResponseSchema string `json:"responseSchema"`
+}
+
+type advertiseServicesOp struct {
+	Op       string        `json:"op"`
+	Services []serviceInfo `json:"services"`
+}
+
+type unadvertiseServicesOp struct {
+	Op         string   `json:"op"`
+	ServiceIDs []uint32 `json:"serviceIds"`
+}
+
+type serviceCallFailureOp struct {
+	Op        string `json:"op"`
+	ServiceID uint32 `json:"serviceId"`
+	CallID    uint32 `json:"callId"`
+	Message   string `json:"message"`
+}
+
+type parameterValuesOp struct {
+	Op         string      `json:"op"`
+	Parameters []parameter `json:"parameters"`
+	ID         string      `json:"id,omitempty"`
+}
+
+// encodeMessageData encodes a message data frame.
+func encodeMessageData(subscriptionID uint32, timestamp uint64, payload []byte) []byte {
+	buf := make([]byte, 0, 13+len(payload))
+	buf = append(buf, opMessageData)
+	buf = binary.LittleEndian.AppendUint32(buf, subscriptionID)
+	buf = binary.LittleEndian.AppendUint64(buf, timestamp)
+	return append(buf, payload...)
+}
+
+// encodeServiceCallResponse encodes a service call response frame.
+func encodeServiceCallResponse(serviceID, callID uint32, encoding string, payload []byte) []byte {
+	buf := make([]byte, 0, 13+len(encoding)+len(payload))
+	buf = append(buf, opServiceCallResponse)
+	buf = binary.LittleEndian.AppendUint32(buf, serviceID)
+	buf = binary.LittleEndian.AppendUint32(buf, callID)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(encoding)))
+	buf = append(buf, encoding...)
+	return append(buf, payload...)
+}
+
+// serviceCallRequest is a decoded service call request frame.
+type serviceCallRequest struct {
+	serviceID uint32
+	callID    uint32
+	encoding  string
+	payload   []byte
+}
+
+func decodeServiceCallRequest(data []byte) (req serviceCallRequest, ok bool) {
+	if len(data) < 12 {
+		return req, false
+	}
+	req.serviceID = binary.LittleEndian.Uint32(data)
+	req.callID = binary.LittleEndian.Uint32(data[4:])
+	n := binary.LittleEndian.Uint32(data[8:])
+	if uint64(len(data)-12) < uint64(n) {
+		return req, false
+	}
+	req.encoding = string(data[12 : 12+n])
+	req.payload = data[12+n:]
+	return req, true
+}
@@ -0,0 +1,192 @@
+package foxglove
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// schemaLoader builds ros2msg schemas from the interface definitions installed
+// in the share directories of ROS packages.
+type schemaLoader struct {
+	prefixes []string
+
+	mu          sync.Mutex
+	definitions map[string]string // "pkg/msg/Name" -> .msg file contents
+}
+
+func newSchemaLoader(prefixes []string) *schemaLoader {
+	return &schemaLoader{prefixes: prefixes, definitions: map[string]string{}}
+}
+
+// defaultSchemaPaths returns the install prefixes listed in
+// AMENT_PREFIX_PATH.
+func defaultSchemaPaths() []string {
+	return filepath.SplitList(os.Getenv("AMENT_PREFIX_PATH"))
+}
+
+// schemaSeparator separates the definitions of dependencies in a ros2msg
+// schema.
+const schemaSeparator = "================================================================================\n"
+
+// messageSchema returns the ros2msg schema of the message type typ, which
+// contains the definition of typ followed by the definitions of all message
+// types it depends on.
+func (l *schemaLoader) messageSchema(typ string) (string, error) {
+	pkg, iface, err := splitInterfaceType(typ, "msg")
+	if err != nil {
+		return "", err
+	}
+	def, err := l.definition(pkg, "msg", iface)
+	if err != nil {
+		return "", err
+	}
+	return l.withDependencies(pkg, def)
+}
+
+// serviceSchemas returns the ros2msg schemas of the request and response of
+// the service type typ.
+func (l *schemaLoader) serviceSchemas(typ string) (req, resp string, err error) {
+	pkg, iface, err := splitInterfaceType(typ, "srv")
+	if err != nil {
+		return "", "", err
+	}
+	def, err := l.definition(pkg, "srv", iface)
+	if err != nil {
+		return "", "", err
+	}
+	reqDef, respDef, ok := splitServiceDefinition(def)
+	if !ok {
+		return "", "", fmt.Errorf("invalid definition of %s: missing separator", typ)
+	}
+	if req, err = l.withDependencies(pkg, reqDef); err != nil {
+		return "", "", err
+	}
+	if resp, err = l.withDependencies(pkg, respDef); err != nil {
+		return "", "", err
+	}
+	return req, resp, nil
+}
+
+func (l *schemaLoader) withDependencies(pkg, def string) (string, error) {
+	var b strings.Builder
+	b.WriteString(def)
+	seen := map[string]bool{}
+	var add func(pkg, def string) error
+	add = func(pkg, def string) error {
+		for _, dep := range definitionDependencies(pkg, def) {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			depPkg, depIface, _ := splitInterfaceType(dep, "msg")
+			depDef, err := l.definition(depPkg, "msg", depIface)
+			if err != nil {
+				return err
+			}
+			if !strings.HasSuffix(b.String(), "\n") {
+				b.WriteByte('\n')
+			}
+			b.WriteString(schemaSeparator)
+			b.WriteString("MSG: " + dep + "\n")
+			b.WriteString(depDef)
+			if err := add(depPkg, depDef); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(pkg, def); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (l *schemaLoader) definition(pkg, kind, iface string) (string, error) {
+	key := pkg + "/" + kind + "/" + iface
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if def, ok := l.definitions[key]; ok {
+		return def, nil
+	}
+	for _, prefix := range l.prefixes {
+		data, err := os.ReadFile(filepath.Join(prefix, "share", pkg, kind, iface+"."+kind))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		l.definitions[key] = string(data)
+		return string(data), nil
+	}
+	return "", fmt.Errorf("definition of %s not found in %v", key, l.prefixes)
+}
+
+// splitInterfaceType splits "pkg/kind/Name" or "pkg/Name" into the package
+// and interface names. The interface kind, if present, must equal kind.
+func splitInterfaceType(typ, kind string) (pkg, iface string, err error) {
+	parts := strings.Split(typ, "/")
+	switch {
+	case len(parts) == 3 && parts[1] == kind && parts[0] != "" && parts[2] != "":
+		return parts[0], parts[2], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("invalid %s type name %q", kind, typ)
+}
+
+// splitServiceDefinition splits a .srv file into the request and response
+// definitions.
+func splitServiceDefinition(def string) (req, resp string, ok bool) {
+	lines := strings.SplitAfter(def, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			return strings.Join(lines[:i], ""), strings.Join(lines[i+1:], ""), true
+		}
+	}
+	return "", "", false
+}
+
+var primitiveTypes = map[string]bool{
+	"bool": true, "byte": true, "char": true,
+	"float32": true, "float64": true,
+	"int8": true, "uint8": true, "int16": true, "uint16": true,
+	"int32": true, "uint32": true, "int64": true, "uint64": true,
+	"string": true, "wstring": true,
+}
+
+// definitionDependencies returns the full names of the message types used by
+// the fields of a .msg definition in package pkg, in order of appearance.
+func definitionDependencies(pkg, def string) []string {
+	var deps []string
+	scanner := bufio.NewScanner(strings.NewReader(def))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		typ := fields[0]
+		if i := strings.IndexAny(typ, "[<"); i >= 0 {
+			typ = typ[:i]
+		}
+		if primitiveTypes[typ] {
+			continue
+		}
+		switch parts := strings.Split(typ, "/"); len(parts) {
+		case 1:
+			deps = append(deps, pkg+"/msg/"+typ)
+		case 2:
+			deps = append(deps, parts[0]+"/msg/"+parts[1])
+		case 3:
+			deps = append(deps, typ)
+		}
+	}
+	return deps
+}
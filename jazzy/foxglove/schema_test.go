@@ -0,0 +1,60 @@
+package foxglove
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeDefinition(t *testing.T, prefix, pkg, kind, name, def string) {
+	t.Helper()
+	dir := filepath.Join(prefix, "share", pkg, kind)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+"."+kind), []byte(def), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchemaLoader(t *testing.T) {
+	prefix1, prefix2 := t.TempDir(), t.TempDir()
+	writeDefinition(t, prefix1, "geo", "msg", "Pose", "Point position # where\nPoint[<=2] extra\n")
+	writeDefinition(t, prefix1, "geo", "msg", "Point", "float64 x\nfloat64 y\n")
+	writeDefinition(t, prefix2, "nav", "msg", "Path", "std_msgs/Header header\ngeo/Pose[] poses\nstring<=8 FRAME=\"map\"\n")
+	writeDefinition(t, prefix2, "std_msgs", "msg", "Header", "builtin_interfaces/Time stamp\nstring frame_id\n")
+	writeDefinition(t, prefix2, "builtin_interfaces", "msg", "Time", "int32 sec\nuint32 nanosec\n")
+	writeDefinition(t, prefix2, "nav", "srv", "GetPath", "string goal\n---\nPath path\n")
+
+	l := newSchemaLoader([]string{prefix1, prefix2})
+	schema, err := l.messageSchema("nav/msg/Path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "std_msgs/Header header\ngeo/Pose[] poses\nstring<=8 FRAME=\"map\"\n" +
+		schemaSeparator + "MSG: std_msgs/msg/Header\nbuiltin_interfaces/Time stamp\nstring frame_id\n" +
+		schemaSeparator + "MSG: builtin_interfaces/msg/Time\nint32 sec\nuint32 nanosec\n" +
+		schemaSeparator + "MSG: geo/msg/Pose\nPoint position # where\nPoint[<=2] extra\n" +
+		schemaSeparator + "MSG: geo/msg/Point\nfloat64 x\nfloat64 y\n"
+	if schema != want {
+		t.Errorf("want schema\n%s\ngot\n%s", want, schema)
+	}
+
+	req, resp, err := l.serviceSchemas("nav/srv/GetPath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req != "string goal\n" {
+		t.Errorf("unexpected request schema %q", req)
+	}
+	if want := "Path path\n" + schemaSeparator + "MSG: nav/msg/Path\n"; len(resp) < len(want) || resp[:len(want)] != want {
+		t.Errorf("unexpected response schema %q", resp)
+	}
+
+	if _, err := l.messageSchema("nav/msg/Missing"); err == nil {
+		t.Error("want error for a missing definition")
+	}
+	if _, err := l.messageSchema("nav/srv/GetPath"); err == nil {
+		t.Error("want error for a service passed as a message")
+	}
+}
@@ -0,0 +1,571 @@
+/*
+Package foxglove implements the foxglove.websocket.v1 protocol, which allows
+Foxglove to visualize a live ROS graph.
+
+Every topic in the ROS graph is advertised as a channel with a ros2msg schema,
+and messages are streamed to subscribed clients as CDR without re-encoding.
+Clients may publish CDR messages, call services and get and set parameters of
+nodes. The graph is polled for changes, so channels and services appear and
+disappear while clients are connected.
+
+Schemas are built from the interface definitions installed in the share
+directories of the prefixes in AMENT_PREFIX_PATH. Calling services and
+accessing parameters requires the type support of the services to be
+registered, i.e. the generated Go packages of the services and of
+rcl_interfaces must be imported.
+
+A Server is an http.Handler:
+
+	server, err := foxglove.NewServer(rclContext, nil)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	return http.ListenAndServe(":8765", server)
+*/
+package foxglove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	// Name is the server name sent to clients. Defaults to "rclgo".
+	Name string
+	// NodeName is the name of the node the server creates. Defaults to
+	// DefaultNodeName.
+	NodeName string
+	// Namespace is the namespace of the node.
+	Namespace string
+	// SchemaPaths are the install prefixes searched for interface
+	// definitions. Defaults to the prefixes in AMENT_PREFIX_PATH.
+	SchemaPaths []string
+	// GraphPollInterval is how often the ROS graph is checked for new and
+	// removed topics and services. Defaults to one second.
+	GraphPollInterval time.Duration
+	// SendBufferLength is the number of messages buffered for a client.
+	// Messages are dropped if the client cannot keep up. Defaults to 1024.
+	SendBufferLength int
+	// ServiceTimeout limits the duration of service calls and parameter
+	// requests. Defaults to 10 seconds.
+	ServiceTimeout time.Duration
+	// CheckOrigin is called to decide whether a WebSocket connection is
+	// accepted. If nil, all origins are accepted.
+	CheckOrigin func(r *http.Request) bool
+}
+
+// DefaultNodeName is used when ServerOptions.NodeName is empty.
+const DefaultNodeName = "foxglove_bridge"
+
+// Server serves Foxglove clients connecting over WebSocket.
+//
+// The server creates its own node and waits for the entities it creates, so
+// the node must not be spun by the caller.
+type Server struct {
+	node         *jazzy.Node
+	options      ServerOptions
+	upgrader     websocket.Upgrader
+	schemas      *schemaLoader
+	capabilities []string
+	sessionID    string
+
+	ctx    context.Context //nolint:containedctx // Used to stop background work on Close
+	cancel context.CancelFunc
+	tasks  sync.WaitGroup
+
+	mu             sync.Mutex
+	conns          map[*conn]bool
+	channels       map[string]*channel // by topic
+	channelsByID   map[uint32]*channel
+	nextChannelID  uint32
+	services       map[string]*service // by name
+	servicesByID   map[uint32]*service
+	nextServiceID  uint32
+	missingSchemas map[string]bool
+	params         map[string]*parameterClients // by node name
+}
+
+// channel is a topic advertised to clients.
+type channel struct {
+	info channelInfo
+	typ  string
+
+	// The fields below are accessed while holding the server lock.
+	sub         *jazzy.Subscription
+	waiter      *waiter
+	subscribers map[*conn]uint32 // subscription IDs
+}
+
+// service is a service advertised to clients.
+type service struct {
+	info serviceInfo
+
+	mu     sync.Mutex
+	client *jazzy.Client
+	waiter *waiter
+	closed bool
+}
+
+// NewServer creates a server whose node belongs to c and starts tracking the
+// ROS graph. If options is nil, default options are used.
+func NewServer(c *jazzy.Context, options *ServerOptions) (*Server, error) {
+	opts := ServerOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.NodeName == "" {
+		opts.NodeName = DefaultNodeName
+	}
+	node, err := c.NewNode(opts.NodeName, opts.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create foxglove node: %w", err)
+	}
+	s := newServer(node, opts)
+	if err := s.refreshGraph(); err != nil {
+		return nil, errors.Join(err, s.Close())
+	}
+	s.tasks.Add(1)
+	go func() {
+		defer s.tasks.Done()
+		s.trackGraph()
+	}()
+	return s, nil
+}
+
+func newServer(node *jazzy.Node, opts ServerOptions) *Server {
+	if opts.Name == "" {
+		opts.Name = "rclgo"
+	}
+	if opts.SchemaPaths == nil {
+		opts.SchemaPaths = defaultSchemaPaths()
+	}
+	if opts.GraphPollInterval <= 0 {
+		opts.GraphPollInterval = time.Second
+	}
+	if opts.SendBufferLength <= 0 {
+		opts.SendBufferLength = 1024
+	}
+	if opts.ServiceTimeout <= 0 {
+		opts.ServiceTimeout = 10 * time.Second
+	}
+	checkOrigin := opts.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = func(*http.Request) bool { return true }
+	}
+	s := &Server{
+		node:    node,
+		options: opts,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{Subprotocol},
+			CheckOrigin:  checkOrigin,
+		},
+		schemas:        newSchemaLoader(opts.SchemaPaths),
+		capabilities:   []string{capabilityClientPublish, capabilityServices},
+		sessionID:      strconv.FormatInt(time.Now().UnixNano(), 10),
+		conns:          map[*conn]bool{},
+		channels:       map[string]*channel{},
+		channelsByID:   map[uint32]*channel{},
+		services:       map[string]*service{},
+		servicesByID:   map[uint32]*service{},
+		missingSchemas: map[string]bool{},
+		params:         map[string]*parameterClients{},
+	}
+	if parametersSupported() {
+		s.capabilities = append(s.capabilities, capabilityParameters)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+// Node returns the node used by s.
+func (s *Server) Node() *jazzy.Node {
+	return s.node
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the
+// protocol on it until the connection is closed or s is closed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.ctx.Err() != nil {
+		http.Error(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(r) {
+		http.Error(w, "expected a WebSocket connection", http.StatusUpgradeRequired)
+		return
+	}
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client.
+		return
+	}
+	s.tasks.Add(1)
+	defer s.tasks.Done()
+	c := newConn(s, ws)
+	s.addConn(c)
+	c.serve()
+}
+
+// Close disconnects all clients and closes the node of s.
+func (s *Server) Close() error {
+	s.cancel()
+	s.tasks.Wait()
+	if s.node == nil {
+		return nil
+	}
+	// Closing the node closes all entities created by the server.
+	return s.node.Close()
+}
+
+func (s *Server) logf(format string, a ...any) {
+	if s.node != nil {
+		_ = s.node.Logger().Errorf(format, a...)
+	}
+}
+
+// goTask runs f in the background. Close waits for f to return.
+func (s *Server) goTask(f func()) {
+	s.tasks.Add(1)
+	go func() {
+		defer s.tasks.Done()
+		f()
+	}()
+}
+
+// addConn registers c and sends it the current state of the graph.
+func (s *Server) addConn(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns[c] = true
+	c.sendJSON(serverInfoOp{
+		Op:                 "serverInfo",
+		Name:               s.options.Name,
+		Capabilities:       s.capabilities,
+		SupportedEncodings: []string{"cdr"},
+		Metadata:           map[string]string{},
+		SessionID:          s.sessionID,
+	})
+	channels := make([]channelInfo, 0, len(s.channels))
+	for _, ch := range s.channels {
+		channels = append(channels, ch.info)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].ID < channels[j].ID })
+	c.sendJSON(advertiseOp{Op: "advertise", Channels: channels})
+	services := make([]serviceInfo, 0, len(s.services))
+	for _, svc := range s.services {
+		services = append(services, svc.info)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+	c.sendJSON(advertiseServicesOp{Op: "advertiseServices", Services: services})
+}
+
+// removeConn unregisters c and releases the subscriptions only it used.
+func (s *Server) removeConn(c *conn) {
+	s.mu.Lock()
+	delete(s.conns, c)
+	var unused []*channel
+	for _, ch := range s.channels {
+		if _, ok := ch.subscribers[c]; ok {
+			delete(ch.subscribers, c)
+			if len(ch.subscribers) == 0 {
+				unused = append(unused, ch)
+			}
+		}
+	}
+	s.mu.Unlock()
+	s.releaseChannels(unused)
+}
+
+// broadcast sends v to all clients. The server lock must be held.
+func (s *Server) broadcast(v any) {
+	for c := range s.conns {
+		c.sendJSON(v)
+	}
+}
+
+func (s *Server) trackGraph() {
+	ticker := time.NewTicker(s.options.GraphPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.refreshGraph(); err != nil && s.ctx.Err() == nil {
+			s.logf("failed to refresh ROS graph: %v", err)
+		}
+	}
+}
+
+// refreshGraph advertises new topics and services and unadvertises the ones
+// that no longer exist.
+func (s *Server) refreshGraph() error {
+	topics, err := s.node.GetTopicNamesAndTypes(true)
+	if err != nil {
+		return err
+	}
+	services, err := s.graphServices()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	added, removed := s.updateChannels(topics)
+	addedServices, removedServices := s.updateServices(services)
+	if len(removed) > 0 {
+		ids := make([]uint32, len(removed))
+		for i, ch := range removed {
+			ids[i] = ch.info.ID
+		}
+		s.broadcast(unadvertiseOp{Op: "unadvertise", ChannelIDs: ids})
+	}
+	if len(added) > 0 {
+		s.broadcast(advertiseOp{Op: "advertise", Channels: added})
+	}
+	if len(removedServices) > 0 {
+		s.broadcast(unadvertiseServicesOp{Op: "unadvertiseServices", ServiceIDs: removedServices})
+	}
+	if len(addedServices) > 0 {
+		s.broadcast(advertiseServicesOp{Op: "advertiseServices", Services: addedServices})
+	}
+	s.mu.Unlock()
+	s.releaseChannels(removed)
+	return nil
+}
+
+// updateChannels updates the channels to match topics. The server lock must be
+// held.
+func (s *Server) updateChannels(topics map[string][]string) (added []channelInfo, removed []*channel) {
+	for topic, ch := range s.channels {
+		if types := topics[topic]; len(types) != 1 || types[0] != ch.typ {
+			delete(s.channels, topic)
+			delete(s.channelsByID, ch.info.ID)
+			clear(ch.subscribers)
+			removed = append(removed, ch)
+		}
+	}
+	for topic, types := range topics {
+		if len(types) != 1 || s.channels[topic] != nil {
+			continue
+		}
+		schema, err := s.schemas.messageSchema(types[0])
+		if err != nil {
+			if !s.missingSchemas[types[0]] {
+				s.missingSchemas[types[0]] = true
+				s.logf("not advertising topics of type %s: %v", types[0], err)
+			}
+			continue
+		}
+		s.nextChannelID++
+		ch := &channel{
+			info: channelInfo{
+				ID:             s.nextChannelID,
+				Topic:          topic,
+				Encoding:       "cdr",
+				SchemaName:     types[0],
+				Schema:         schema,
+				SchemaEncoding: "ros2msg",
+			},
+			typ:         types[0],
+			subscribers: map[*conn]uint32{},
+		}
+		s.channels[topic] = ch
+		s.channelsByID[ch.info.ID] = ch
+		added = append(added, ch.info)
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].ID < added[j].ID })
+	return added, removed
+}
+
+// graphServices returns the names and types of all services in the graph.
+func (s *Server) graphServices() (map[string]string, error) {
+	names, namespaces, err := s.node.GetNodeNames()
+	if err != nil {
+		return nil, err
+	}
+	services := map[string]string{}
+	for i := range names {
+		byNode, err := s.node.GetServiceNamesAndTypesByNode(names[i], namespaces[i])
+		if err != nil {
+			// The node may have disappeared after listing the nodes.
+			continue
+		}
+		for name, types := range byNode {
+			if len(types) == 1 {
+				services[name] = types[0]
+			}
+		}
+	}
+	return services, nil
+}
+
+// updateServices updates the advertised services to match services. The
+// server lock must be held.
+func (s *Server) updateServices(services map[string]string) (added []serviceInfo, removed []uint32) {
+	for name, svc := range s.services {
+		if services[name] != svc.info.Type {
+			delete(s.services, name)
+			delete(s.servicesByID, svc.info.ID)
+			removed = append(removed, svc.info.ID)
+			s.goTask(func() { s.closeService(svc) })
+		}
+	}
+	for name, typ := range services {
+		if s.services[name] != nil {
+			continue
+		}
+		req, resp, err := s.schemas.serviceSchemas(typ)
+		if err != nil {
+			if !s.missingSchemas[typ] {
+				s.missingSchemas[typ] = true
+				s.logf("not advertising services of type %s: %v", typ, err)
+			}
+			continue
+		}
+		s.nextServiceID++
+		svc := &service{info: serviceInfo{
+			ID:             s.nextServiceID,
+			Name:           name,
+			Type:           typ,
+			RequestSchema:  req,
+			ResponseSchema: resp,
+		}}
+		s.services[name] = svc
+		s.servicesByID[svc.info.ID] = svc
+		added = append(added, svc.info)
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].ID < added[j].ID })
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	return added, removed
+}
+
+// subscribe adds a subscription of c to the channel with channelID. The ROS
+// subscription of the channel is created when the first client subscribes.
+func (s *Server) subscribe(c *conn, subscriptionID, channelID uint32) (*channel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channelsByID[channelID]
+	if ch == nil {
+		return nil, fmt.Errorf("unknown channel %d", channelID)
+	}
+	if ch.sub == nil {
+		if err := s.startSubscription(ch); err != nil {
+			return nil, err
+		}
+	}
+	ch.subscribers[c] = subscriptionID
+	return ch, nil
+}
+
+// startSubscription creates the ROS subscription of ch. The server lock must
+// be held.
+func (s *Server) startSubscription(ch *channel) error {
+	ts, err := jazzy.LoadMessageTypeSupport(ch.typ)
+	if err != nil {
+		return err
+	}
+	sub, err := s.node.NewSubscription(ch.info.Topic, ts, nil, func(sub *jazzy.Subscription) {
+		s.forward(ch, sub)
+	})
+	if err != nil {
+		return err
+	}
+	w, err := s.startWaiter(func(ws *jazzy.WaitSet) { ws.AddSubscriptions(sub) })
+	if err != nil {
+		return errors.Join(err, sub.Close())
+	}
+	ch.sub, ch.waiter = sub, w
+	return nil
+}
+
+// forward sends a message received on ch to the subscribed clients as is.
+func (s *Server) forward(ch *channel, sub *jazzy.Subscription) {
+	msg, info, err := sub.TakeSerializedMessage()
+	if err != nil {
+		s.logf("failed to take message from %s: %v", ch.info.Topic, err)
+		return
+	}
+	timestamp := uint64(info.ReceivedTimestamp.UnixNano())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c, subscriptionID := range ch.subscribers {
+		c.sendData(encodeMessageData(subscriptionID, timestamp, msg))
+	}
+}
+
+// unsubscribe removes the subscription of c to ch.
+func (s *Server) unsubscribe(c *conn, ch *channel) {
+	s.mu.Lock()
+	delete(ch.subscribers, c)
+	var unused []*channel
+	if len(ch.subscribers) == 0 {
+		unused = append(unused, ch)
+	}
+	s.mu.Unlock()
+	s.releaseChannels(unused)
+}
+
+// releaseChannels closes the ROS subscriptions of channels without
+// subscribers. It must be called without holding the server lock, because
+// stopping a wait set waits for running callbacks, which take the lock.
+func (s *Server) releaseChannels(channels []*channel) {
+	for _, ch := range channels {
+		s.mu.Lock()
+		if len(ch.subscribers) > 0 || ch.sub == nil {
+			s.mu.Unlock()
+			continue
+		}
+		sub, w := ch.sub, ch.waiter
+		ch.sub, ch.waiter = nil, nil
+		s.mu.Unlock()
+		w.stop()
+		if err := sub.Close(); err != nil {
+			s.logf("failed to close subscription to %s: %v", ch.info.Topic, err)
+		}
+	}
+}
+
+// waiter waits for the events of entities created while the server is
+// running.
+type waiter struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startWaiter creates a wait set, adds entities to it using add and runs it
+// until the server is closed or the returned waiter is stopped.
+func (s *Server) startWaiter(add func(ws *jazzy.WaitSet)) (*waiter, error) {
+	ws, err := s.node.Context().NewWaitSet()
+	if err != nil {
+		return nil, err
+	}
+	add(ws)
+	ctx, cancel := context.WithCancel(s.ctx)
+	w := &waiter{cancel: cancel, done: make(chan struct{})}
+	s.tasks.Add(1)
+	go func() {
+		defer s.tasks.Done()
+		defer close(w.done)
+		defer ws.Close()
+		if err := ws.Run(ctx); err != nil && ctx.Err() == nil {
+			s.logf("foxglove wait set failed: %v", err)
+		}
+	}()
+	return w, nil
+}
+
+// stop stops w and waits until its entities are no longer in use.
+func (w *waiter) stop() {
+	w.cancel()
+	<-w.done
+}
@@ -0,0 +1,111 @@
+package foxglove
+
+import (
+	"encoding/binary"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dialTestServer(t *testing.T) *websocket.Conn {
+	t.Helper()
+	server := newServer(nil, ServerOptions{SchemaPaths: []string{}})
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		_ = server.Close()
+		httpServer.Close()
+	})
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	if conn.Subprotocol() != Subprotocol {
+		t.Fatalf("want subprotocol %q, got %q", Subprotocol, conn.Subprotocol())
+	}
+	return conn
+}
+
+func readJSON[T any](t *testing.T, conn *websocket.Conn) T {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var v T
+	if err := conn.ReadJSON(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestServerHandshake(t *testing.T) {
+	conn := dialTestServer(t)
+	info := readJSON[serverInfoOp](t, conn)
+	if info.Op != "serverInfo" || info.Name != "rclgo" {
+		t.Fatalf("unexpected server info %+v", info)
+	}
+	if len(info.SupportedEncodings) != 1 || info.SupportedEncodings[0] != "cdr" {
+		t.Errorf("unexpected encodings %v", info.SupportedEncodings)
+	}
+	if adv := readJSON[advertiseOp](t, conn); adv.Op != "advertise" {
+		t.Errorf("want advertise, got %+v", adv)
+	}
+	if adv := readJSON[advertiseServicesOp](t, conn); adv.Op != "advertiseServices" {
+		t.Errorf("want advertiseServices, got %+v", adv)
+	}
+}
+
+func TestServerStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     int
+		input   []byte
+		wantMsg string
+	}{
+		{"Unsupported operation", websocket.TextMessage, []byte(`{"op": "fetchAsset"}`), `unsupported operation "fetchAsset"`},
+		{"Unknown channel", websocket.TextMessage, []byte(`{"op": "subscribe", "subscriptions": [{"id": 1, "channelId": 9}]}`), "unknown channel 9"},
+		{"Unknown subscription", websocket.TextMessage, []byte(`{"op": "unsubscribe", "subscriptionIds": [3]}`), "unknown subscription 3"},
+		{"Unsupported encoding", websocket.TextMessage, []byte(`{"op": "advertise", "channels": [{"id": 1, "topic": "/a", "encoding": "json"}]}`), `unsupported encoding "json"`},
+		{"Unadvertised channel", websocket.BinaryMessage, binary.LittleEndian.AppendUint32([]byte{opClientMessageData}, 5), "channel 5 is not advertised"},
+		{"Truncated call", websocket.BinaryMessage, []byte{opServiceCallRequest, 1}, "truncated service call request"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := dialTestServer(t)
+			for i := 0; i < 3; i++ {
+				readJSON[map[string]any](t, conn)
+			}
+			if err := conn.WriteMessage(tc.typ, tc.input); err != nil {
+				t.Fatal(err)
+			}
+			status := readJSON[statusOp](t, conn)
+			if status.Op != "status" || status.Level != statusError {
+				t.Fatalf("want an error status, got %+v", status)
+			}
+			if !strings.Contains(status.Message, tc.wantMsg) {
+				t.Fatalf("want message containing %q, got %q", tc.wantMsg, status.Message)
+			}
+		})
+	}
+}
+
+func TestServiceCallFailure(t *testing.T) {
+	conn := dialTestServer(t)
+	for i := 0; i < 3; i++ {
+		readJSON[map[string]any](t, conn)
+	}
+	req := []byte{opServiceCallRequest}
+	req = binary.LittleEndian.AppendUint32(req, 4)
+	req = binary.LittleEndian.AppendUint32(req, 8)
+	req = binary.LittleEndian.AppendUint32(req, 3)
+	req = append(req, "cdr"...)
+	if err := conn.WriteMessage(websocket.BinaryMessage, req); err != nil {
+		t.Fatal(err)
+	}
+	failure := readJSON[serviceCallFailureOp](t, conn)
+	if failure.Op != "serviceCallFailure" || failure.ServiceID != 4 || failure.CallID != 8 {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+}
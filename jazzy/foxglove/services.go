@@ -0,0 +1,96 @@
+package foxglove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+// callService calls a ROS service on behalf of c. The request and response
+// are CDR-encoded, but the type support of the service must be registered
+// because clients only send and receive Go messages.
+func (c *conn) callService(req serviceCallRequest) {
+	resp, err := c.server.callService(req)
+	if err != nil {
+		c.sendJSON(serviceCallFailureOp{
+			Op:        "serviceCallFailure",
+			ServiceID: req.serviceID,
+			CallID:    req.callID,
+			Message:   err.Error(),
+		})
+		return
+	}
+	c.sendControl(websocket.BinaryMessage, encodeServiceCallResponse(req.serviceID, req.callID, req.encoding, resp))
+}
+
+func (s *Server) callService(req serviceCallRequest) ([]byte, error) {
+	if req.encoding != "cdr" {
+		return nil, fmt.Errorf("unsupported encoding %q", req.encoding)
+	}
+	s.mu.Lock()
+	svc := s.servicesByID[req.serviceID]
+	s.mu.Unlock()
+	if svc == nil {
+		return nil, fmt.Errorf("unknown service %d", req.serviceID)
+	}
+	ts, ok := jazzy.GetService(svc.info.Type)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered", svc.info.Type)
+	}
+	client, err := s.serviceClient(svc, ts)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := jazzy.Deserialize(req.payload, ts.Request())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, s.options.ServiceTimeout)
+	defer cancel()
+	resp, _, err := client.Send(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return jazzy.Serialize(resp)
+}
+
+// serviceClient returns the client used to call svc, creating it if needed.
+func (s *Server) serviceClient(svc *service, ts jazzy.ServiceTypeSupport) (*jazzy.Client, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if svc.closed {
+		return nil, fmt.Errorf("service %s no longer exists", svc.info.Name)
+	}
+	if svc.client != nil {
+		return svc.client, nil
+	}
+	client, err := s.node.NewClient(svc.info.Name, ts, nil)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.startWaiter(func(ws *jazzy.WaitSet) { ws.AddClients(client) })
+	if err != nil {
+		return nil, errors.Join(err, client.Close())
+	}
+	svc.client, svc.waiter = client, w
+	return client, nil
+}
+
+// closeService closes the client of a service that has disappeared from the
+// graph.
+func (s *Server) closeService(svc *service) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.closed = true
+	if svc.client == nil {
+		return
+	}
+	svc.waiter.stop()
+	if err := svc.client.Close(); err != nil {
+		s.logf("failed to close client of %s: %v", svc.info.Name, err)
+	}
+	svc.client, svc.waiter = nil, nil
+}
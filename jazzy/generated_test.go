@@ -0,0 +1,344 @@
+package jazzy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+	action_msgs_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/action_msgs/msg"
+	builtin_interfaces_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/builtin_interfaces/msg"
+	rcl_interfaces_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/rcl_interfaces/msg"
+	rcl_interfaces_srv "github.com/okieraised/rclgo/jazzy/internal/testmsgs/rcl_interfaces/srv"
+	std_msgs_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_msgs/msg"
+	"gopkg.in/yaml.v3"
+)
+
+// The tests in this file check the behavior of the code generated for the
+// messages in package testmsgs.
+
+func TestGeneratedValidate(t *testing.T) {
+	resp := &rcl_interfaces_srv.DescribeParameters_Response{
+		Descriptors: []rcl_interfaces_msg.ParameterDescriptor{
+			{Name: "a", FloatingPointRange: []rcl_interfaces_msg.FloatingPointRange{{ToValue: 1}}},
+			{Name: "b", IntegerRange: []rcl_interfaces_msg.IntegerRange{{ToValue: 1}, {ToValue: 2}}},
+		},
+	}
+	err := resp.Validate()
+	var verr *jazzy.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("want a ValidationError, got %v", err)
+	}
+	if verr.Field != "descriptors[1].integer_range" {
+		t.Fatalf("want the path of the bounded sequence, got %q", verr.Field)
+	}
+	if want := "descriptors[1].integer_range: sequence has 2 elements, exceeding its bound of 1"; err.Error() != want {
+		t.Fatalf("want error %q, got %q", want, err)
+	}
+	resp.Descriptors[1].IntegerRange = resp.Descriptors[1].IntegerRange[:1]
+	if err := resp.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := jazzy.ValidateMessage(resp); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGeneratedEnum(t *testing.T) {
+	status := action_msgs_msg.GoalStatus_STATUS_SUCCEEDED
+	if got := status.String(); got != "STATUS_SUCCEEDED" {
+		t.Fatalf("want STATUS_SUCCEEDED, got %q", got)
+	}
+	if !status.IsValid() {
+		t.Fatal("a constant must be valid")
+	}
+	invalid := action_msgs_msg.GoalStatus_Status(7)
+	if got := invalid.String(); got != "GoalStatus_Status(7)" {
+		t.Fatalf("want GoalStatus_Status(7), got %q", got)
+	}
+	if invalid.IsValid() {
+		t.Fatal("7 is not a STATUS_* constant")
+	}
+	values := status.Values()
+	if len(values) != 7 || values[0] != action_msgs_msg.GoalStatus_STATUS_UNKNOWN || values[6] != action_msgs_msg.GoalStatus_STATUS_ABORTED {
+		t.Fatalf("got values %v", values)
+	}
+	// The status field is typed with --typed-enum-package, and the enum type
+	// is encoded as its number.
+	msg := &action_msgs_msg.GoalStatus{Status: action_msgs_msg.GoalStatus_STATUS_ABORTED}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), `"status":6}`) {
+		t.Fatalf("got %s", data)
+	}
+	// The constants of packages that are not typed keep the type of their
+	// field, but get an enum type as well.
+	if rcl_interfaces_msg.ParameterType_Parameter(rcl_interfaces_msg.ParameterType_PARAMETER_STRING).String() != "PARAMETER_STRING" {
+		t.Fatal("wrong name of PARAMETER_STRING")
+	}
+}
+
+func TestGeneratedEqualDiff(t *testing.T) {
+	a := &rcl_interfaces_msg.ParameterValue{
+		DoubleValue:       math.NaN(),
+		IntegerArrayValue: []int64{1, 2, 3},
+		StringArrayValue:  []string{},
+	}
+	b := a.Clone()
+	b.StringArrayValue = nil
+	if !a.Equal(b) {
+		t.Fatalf("NaNs and nil and empty sequences must be equal, got diffs %v", a.Diff(b))
+	}
+	if reflect.DeepEqual(a, b) {
+		t.Fatal("the messages must differ for reflect.DeepEqual")
+	}
+
+	b.IntegerArrayValue[1] = 5
+	b.StringValue = "x"
+	b.DoubleArrayValue = []float64{1}
+	diffs := a.Diff(b)
+	var fields []string
+	for _, d := range diffs {
+		fields = append(fields, d.Field)
+	}
+	if want := []string{"string_value", "integer_array_value[1]", "double_array_value"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("want diffs of %v, got %v", want, diffs)
+	}
+	if diffs[1].A != int64(2) || diffs[1].B != int64(5) {
+		t.Fatalf("want the values of the elements, got %+v", diffs[1])
+	}
+
+	r1 := &rcl_interfaces_msg.FloatingPointRange{FromValue: 1, ToValue: 2}
+	r2 := &rcl_interfaces_msg.FloatingPointRange{FromValue: 1 + 1e-9, ToValue: 2}
+	if r1.Equal(r2) {
+		t.Fatal("the ranges must differ without tolerance")
+	}
+	if !r1.Equal(r2, jazzy.FloatTolerance(1e-6)) {
+		t.Fatal("the ranges must be equal with tolerance")
+	}
+}
+
+func TestGeneratedDeepCopyInto(t *testing.T) {
+	src := &rcl_interfaces_srv.DescribeParameters_Response{
+		Descriptors: []rcl_interfaces_msg.ParameterDescriptor{
+			{Name: "a", FloatingPointRange: []rcl_interfaces_msg.FloatingPointRange{{ToValue: 1}}},
+			{Name: "b", IntegerRange: []rcl_interfaces_msg.IntegerRange{{Step: 2}}},
+		},
+	}
+	dst := &rcl_interfaces_srv.DescribeParameters_Response{}
+	src.DeepCopyInto(dst)
+	if !src.Equal(dst) {
+		t.Fatalf("got diffs %v", src.Diff(dst))
+	}
+	src.Descriptors[0].FloatingPointRange[0].ToValue = 3
+	if dst.Descriptors[0].FloatingPointRange[0].ToValue != 1 {
+		t.Fatal("the copy must not share memory with the source")
+	}
+
+	descriptors := &dst.Descriptors[0]
+	if allocs := testing.AllocsPerRun(100, func() { src.DeepCopyInto(dst) }); allocs != 0 {
+		t.Fatalf("want no allocations when the capacities allow, got %v", allocs)
+	}
+	if &dst.Descriptors[0] != descriptors {
+		t.Fatal("the sequence of the destination must be reused")
+	}
+}
+
+func TestGeneratedJSON(t *testing.T) {
+	msg := &rcl_interfaces_msg.ParameterValue{
+		Type:             rcl_interfaces_msg.ParameterType_PARAMETER_BYTE_ARRAY,
+		DoubleValue:      math.Inf(1),
+		ByteArrayValue:   []byte{1, 2, 3},
+		DoubleArrayValue: []float64{0.5, math.NaN()},
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":5,"bool_value":false,"integer_value":0,"double_value":null,"string_value":"",` +
+		`"byte_array_value":"AQID","bool_array_value":[],"integer_array_value":[],` +
+		`"double_array_value":[0.5,null],"string_array_value":[]}`
+	if string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+
+	header := &std_msgs_msg.Header{Stamp: builtin_interfaces_msg.Time{Sec: 1, Nanosec: 2}, FrameId: "map"}
+	if data, err = json.Marshal(header); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"stamp":{"sec":1,"nanosec":2},"frame_id":"map"}`; string(data) != want {
+		t.Fatalf("want %s, got %s", want, data)
+	}
+
+	// Partial input sets the other fields to their defaults.
+	desc := &rcl_interfaces_msg.ParameterDescriptor{Name: "old", Type: 3, ReadOnly: true}
+	if err := json.Unmarshal([]byte(`{"name":"new","integer_range":[{"step":2}]}`), desc); err != nil {
+		t.Fatal(err)
+	}
+	wantDesc := &rcl_interfaces_msg.ParameterDescriptor{Name: "new", IntegerRange: []rcl_interfaces_msg.IntegerRange{{Step: 2}}}
+	if !desc.Equal(wantDesc) {
+		t.Fatalf("got diffs %v", desc.Diff(wantDesc))
+	}
+
+	var value rcl_interfaces_msg.ParameterValue
+	if err := json.Unmarshal([]byte(`{"byte_array_value":"AQID"}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(value.ByteArrayValue, []byte{1, 2, 3}) {
+		t.Fatalf("got bytes %v", value.ByteArrayValue)
+	}
+}
+
+func TestGeneratedYAML(t *testing.T) {
+	msg := &action_msgs_msg.GoalStatus{Status: action_msgs_msg.GoalStatus_STATUS_EXECUTING}
+	msg.GoalInfo.Stamp = builtin_interfaces_msg.Time{Sec: 1, Nanosec: 2}
+	msg.GoalInfo.GoalId.Uuid[15] = 7
+	data, err := yaml.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `goal_info:
+    goal_id:
+        uuid:
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 0
+            - 7
+    stamp:
+        sec: 1
+        nanosec: 2
+status: 2
+`
+	if string(data) != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, data)
+	}
+
+	// The syntax of ros2 topic pub, with the other fields set to defaults.
+	var got action_msgs_msg.GoalStatus
+	got.Status = action_msgs_msg.GoalStatus_STATUS_ABORTED
+	if err := yaml.Unmarshal([]byte("{goal_info: {stamp: {sec: 3}}}"), &got); err != nil {
+		t.Fatal(err)
+	}
+	wantMsg := &action_msgs_msg.GoalStatus{}
+	wantMsg.GoalInfo.Stamp.Sec = 3
+	if !got.Equal(wantMsg) {
+		t.Fatalf("got diffs %v", got.Diff(wantMsg))
+	}
+
+	value := &rcl_interfaces_msg.ParameterValue{DoubleValue: math.NaN(), DoubleArrayValue: []float64{1}}
+	if data, err = yaml.Marshal(value); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"double_value: .nan\n", "double_array_value:\n    - 1.0\n", "string_array_value: []\n"} {
+		if !strings.Contains(string(data), line) {
+			t.Fatalf("want %q in\n%s", line, data)
+		}
+	}
+}
+
+func TestGeneratedTime(t *testing.T) {
+	tm := time.Unix(-2, 300_000_000)
+	var stamp builtin_interfaces_msg.Time
+	stamp.FromTime(tm)
+	if stamp.Sec != -2 || stamp.Nanosec != 300_000_000 {
+		t.Fatalf("want {-2 300000000}, got %+v", stamp)
+	}
+	if got := stamp.ToTime(); !got.Equal(tm) {
+		t.Fatalf("want %v, got %v", tm, got)
+	}
+	stamp.FromTime(time.Time{})
+	if stamp != (builtin_interfaces_msg.Time{}) || !stamp.ToTime().IsZero() {
+		t.Fatalf("the zero time must map to the zero ROS time, got %+v", stamp)
+	}
+
+	var d builtin_interfaces_msg.Duration
+	d.FromDuration(-1700 * time.Millisecond)
+	if d.Sec != -2 || d.Nanosec != 300_000_000 {
+		t.Fatalf("want {-2 300000000}, got %+v", d)
+	}
+	if got := d.ToDuration(); got != -1700*time.Millisecond {
+		t.Fatalf("want -1.7s, got %v", got)
+	}
+
+	before := time.Now()
+	now, err := builtin_interfaces_msg.Now(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := now.ToTime(); got.Before(before) || got.After(time.Now()) {
+		t.Fatalf("Now returned %v, which is not the system time", got)
+	}
+}
+
+func TestGeneratedTypeHash(t *testing.T) {
+	// The hashes of rosidl_generator_type_description, see typedesc_test.go.
+	for ts, want := range map[jazzy.DescribedMessageTypeSupport]string{
+		std_msgs_msg.StringTypeSupport:         "RIHS01_df668c740482bbd48fb39d76a70dfd4bd59db1288021743503259e948f6b1a18",
+		builtin_interfaces_msg.TimeTypeSupport: "RIHS01_b106235e25a4c5ed35098aa0a61a3ee9c9b18d197f398b0e4206cea9acf9c197",
+		std_msgs_msg.HeaderTypeSupport:         "RIHS01_f49fb3ae2cf070f793645ff749683ac6b06203e41c891e17701b1cb597ce6a01",
+	} {
+		if got := ts.TypeHash(); got != want {
+			t.Errorf("want hash %s of %s, got %s", want, ts.TypeName(), got)
+		}
+	}
+	def := std_msgs_msg.HeaderTypeSupport.Definition()
+	if !strings.HasPrefix(def, std_msgs_msg.HeaderTypeSupport.Source()) ||
+		!strings.Contains(def, "\nMSG: builtin_interfaces/Time\n") {
+		t.Fatalf("got definition\n%s", def)
+	}
+}
+
+// TestGeneratedTypeSupportRoundTrip converts messages to their C structs and
+// back, which uses the sequence and array conversion helpers.
+func TestGeneratedTypeSupportRoundTrip(t *testing.T) {
+	status := &action_msgs_msg.GoalStatus{Status: action_msgs_msg.GoalStatus_STATUS_CANCELED}
+	status.GoalInfo.GoalId.Uuid = [16]uint8{1, 2, 3, 15: 16}
+	status.GoalInfo.Stamp = builtin_interfaces_msg.Time{Sec: 5, Nanosec: 6}
+	for _, msg := range []jazzy.Message{
+		&rcl_interfaces_srv.DescribeParameters_Response{
+			Descriptors: []rcl_interfaces_msg.ParameterDescriptor{
+				{Name: "a", FloatingPointRange: []rcl_interfaces_msg.FloatingPointRange{{FromValue: -1, ToValue: 1, Step: 0.5}}},
+				{Name: "b", Description: "c", IntegerRange: []rcl_interfaces_msg.IntegerRange{{FromValue: -2, ToValue: 2, Step: 1}}},
+				{},
+			},
+		},
+		&rcl_interfaces_srv.DescribeParameters_Response{},
+		&rcl_interfaces_msg.ParameterValue{
+			ByteArrayValue:    []byte{1, 2},
+			BoolArrayValue:    []bool{true, false, true},
+			IntegerArrayValue: []int64{math.MinInt64, math.MaxInt64},
+			DoubleArrayValue:  []float64{math.Inf(-1), 0.25},
+			StringArrayValue:  []string{"", "x", "yz"},
+		},
+		status,
+	} {
+		ts := msg.GetTypeSupport()
+		buf := ts.PrepareMemory()
+		ts.AsCStruct(buf, msg)
+		got := ts.New()
+		ts.AsGoStruct(got, buf)
+		ts.ReleaseMemory(buf)
+		want := reflect.ValueOf(msg).MethodByName("Equal").Call([]reflect.Value{reflect.ValueOf(got)})
+		if !want[0].Bool() {
+			t.Errorf("%s: got %+v, want %+v", ts.(jazzy.TypeDescriber).TypeName(), got, msg)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package builtin_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+	"time"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <builtin_interfaces/msg/duration.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("builtin_interfaces/Duration", DurationTypeSupport)
+	jazzy.RegisterMessage("builtin_interfaces/msg/Duration", DurationTypeSupport)
+}
+
+type Duration struct {
+	Sec     int32  `yaml:"sec"`     // The seconds component, valid over all int32 values.
+	Nanosec uint32 `yaml:"nanosec"` // The nanoseconds component, valid in the range [0, 1e9), to be added to the seconds component. e.g. The duration -1.7 seconds is represented as {sec: -2, nanosec: 3e8} The duration 1.7 seconds is represented as {sec: 1, nanosec: 7e8}
+}
+
+// NewDuration creates a new Duration with default values.
+func NewDuration() *Duration {
+	self := Duration{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *Duration) Clone() *Duration {
+	c := &Duration{}
+	c.Sec = t.Sec
+	c.Nanosec = t.Nanosec
+	return c
+}
+
+func (t *Duration) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *Duration) DeepCopyInto(dst *Duration) {
+	dst.Sec = t.Sec
+	dst.Nanosec = t.Nanosec
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *Duration) Equal(other *Duration, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *Duration) Diff(other *Duration, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *Duration) DiffWith(d *jazzy.Differ, other *Duration) {
+	jazzy.DiffValue(d, "sec", t.Sec, other.Sec)
+	jazzy.DiffValue(d, "nanosec", t.Nanosec, other.Nanosec)
+}
+
+func (t *Duration) SetDefaults() {
+	t.Sec = 0
+	t.Nanosec = 0
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *Duration) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *Duration) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *Duration) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *Duration) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *Duration) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *Duration) GetTypeSupport() jazzy.MessageTypeSupport {
+	return DurationTypeSupport
+}
+
+// ToDuration returns t as a time.Duration.
+func (t *Duration) ToDuration() time.Duration {
+	return jazzy.DurationFromParts(t.Sec, t.Nanosec)
+}
+
+// FromDuration sets t to d. See jazzy.DurationToParts.
+func (t *Duration) FromDuration(d time.Duration) {
+	t.Sec, t.Nanosec = jazzy.DurationToParts(d)
+}
+
+// DurationPublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type DurationPublisher struct {
+	*jazzy.Publisher
+}
+
+// NewDurationPublisher creates and returns a new publisher for the
+// Duration
+func NewDurationPublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*DurationPublisher, error) {
+	pub, err := node.NewPublisher(topicName, DurationTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &DurationPublisher{pub}, nil
+}
+
+func (p *DurationPublisher) Publish(msg *Duration) error {
+	return p.Publisher.Publish(msg)
+}
+
+// DurationSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type DurationSubscription struct {
+	*jazzy.Subscription
+}
+
+// DurationSubscriptionCallback type is used to provide a subscription
+// handler function for a DurationSubscription.
+type DurationSubscriptionCallback func(msg *Duration, info *jazzy.MessageInfo, err error)
+
+// NewDurationSubscription creates and returns a new subscription for the
+// Duration
+func NewDurationSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback DurationSubscriptionCallback) (*DurationSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg Duration
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, DurationTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &DurationSubscription{sub}, nil
+}
+
+func (s *DurationSubscription) TakeMessage(out *Duration) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneDurationSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneDurationSlice(dst, src []Duration) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var DurationTypeSupport jazzy.DescribedMessageTypeSupport = _DurationTypeSupport{}
+
+type _DurationTypeSupport struct{}
+
+func (t _DurationTypeSupport) New() jazzy.Message {
+	return NewDuration()
+}
+
+func (t _DurationTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.builtin_interfaces__msg__Duration
+	return (unsafe.Pointer)(C.builtin_interfaces__msg__Duration__create())
+}
+
+func (t _DurationTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.builtin_interfaces__msg__Duration__destroy((*C.builtin_interfaces__msg__Duration)(pointer_to_free))
+}
+
+func (t _DurationTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.builtin_interfaces__msg__Duration__fini((*C.builtin_interfaces__msg__Duration)(pointer_to_reset))
+}
+
+func (t _DurationTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*Duration)
+	mem := (*C.builtin_interfaces__msg__Duration)(dst)
+	mem.sec = C.int32_t(m.Sec)
+	mem.nanosec = C.uint32_t(m.Nanosec)
+}
+
+func (t _DurationTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*Duration)
+	mem := (*C.builtin_interfaces__msg__Duration)(ros2_message_buffer)
+	m.Sec = int32(mem.sec)
+	m.Nanosec = uint32(mem.nanosec)
+}
+
+func (t _DurationTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__builtin_interfaces__msg__Duration())
+}
+
+func (t _DurationTypeSupport) TypeName() string {
+	return "builtin_interfaces/msg/Duration"
+}
+
+func (t _DurationTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "builtin_interfaces/msg/Duration",
+		Fields: []jazzy.FieldDescription{
+			{Name: "sec", Type: jazzy.FieldType{TypeID: 6}},
+			{Name: "nanosec", Type: jazzy.FieldType{TypeID: 7}},
+		},
+	}
+}
+
+func (t _DurationTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _DurationTypeSupport) Source() string {
+	return "# Duration defines a period between two time points.\n# Messages of this datatype are of ROS Time following this design:\n# https://design.ros2.org/articles/clock_and_time.html\n\n# The seconds component, valid over all int32 values.\nint32 sec\n\n# The nanoseconds component, valid in the range [0, 1e9), to be added to the seconds component.\n# e.g.\n# The duration -1.7 seconds is represented as {sec: -2, nanosec: 3e8}\n# The duration 1.7 seconds is represented as {sec: 1, nanosec: 7e8}\nuint32 nanosec\n"
+}
+
+func (t _DurationTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _DurationTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CDuration = C.builtin_interfaces__msg__Duration
+type CDurationSequence = C.builtin_interfaces__msg__Duration__Sequence
+
+func DurationSequenceToGo(goSlice *[]Duration, cSlice CDurationSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CDuration])(unsafe.Pointer(&cSlice)), _DurationToGo)
+}
+
+func DurationSequenceToC(cSlice *CDurationSequence, goSlice []Duration) {
+	jazzy.SequenceToC((*jazzy.CSequence[CDuration])(unsafe.Pointer(cSlice)), goSlice, _DurationToC)
+}
+
+func DurationArrayToGo(goSlice []Duration, cSlice []CDuration) {
+	jazzy.ArrayToGo(goSlice, cSlice, _DurationToGo)
+}
+
+func DurationArrayToC(cSlice []CDuration, goSlice []Duration) {
+	jazzy.ArrayToC(cSlice, goSlice, _DurationToC)
+}
+
+func _DurationToGo(dst *Duration, src *CDuration) {
+	DurationTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _DurationToC(dst *CDuration, src *Duration) {
+	DurationTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,296 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package builtin_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+	"time"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <builtin_interfaces/msg/time.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("builtin_interfaces/Time", TimeTypeSupport)
+	jazzy.RegisterMessage("builtin_interfaces/msg/Time", TimeTypeSupport)
+}
+
+type Time struct {
+	Sec     int32  `yaml:"sec"`     // The seconds component, valid over all int32 values.
+	Nanosec uint32 `yaml:"nanosec"` // The nanoseconds component, valid in the range [0, 1e9), to be added to the seconds component. e.g. The time -1.7 seconds is represented as {sec: -2, nanosec: 3e8} The time 1.7 seconds is represented as {sec: 1, nanosec: 7e8}
+}
+
+// NewTime creates a new Time with default values.
+func NewTime() *Time {
+	self := Time{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *Time) Clone() *Time {
+	c := &Time{}
+	c.Sec = t.Sec
+	c.Nanosec = t.Nanosec
+	return c
+}
+
+func (t *Time) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *Time) DeepCopyInto(dst *Time) {
+	dst.Sec = t.Sec
+	dst.Nanosec = t.Nanosec
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *Time) Equal(other *Time, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *Time) Diff(other *Time, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *Time) DiffWith(d *jazzy.Differ, other *Time) {
+	jazzy.DiffValue(d, "sec", t.Sec, other.Sec)
+	jazzy.DiffValue(d, "nanosec", t.Nanosec, other.Nanosec)
+}
+
+func (t *Time) SetDefaults() {
+	t.Sec = 0
+	t.Nanosec = 0
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *Time) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *Time) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *Time) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *Time) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *Time) GetTypeSupport() jazzy.MessageTypeSupport {
+	return TimeTypeSupport
+}
+
+// ToTime returns t as a time.Time. The zero ROS time, which usually means
+// that the time is not set, is returned as the zero time.Time instead of the
+// Unix epoch. See jazzy.TimeFromParts.
+func (t *Time) ToTime() time.Time {
+	return jazzy.TimeFromParts(t.Sec, t.Nanosec)
+}
+
+// FromTime sets t to tm. The zero time.Time sets t to the zero ROS time, so
+// time.Unix(0, 0) doesn't round-trip through ToTime. See
+// jazzy.TimeToParts.
+func (t *Time) FromTime(tm time.Time) {
+	t.Sec, t.Nanosec = jazzy.TimeToParts(tm)
+}
+
+// Now returns the current time of clock, or the system time if clock is nil.
+func Now(clock *jazzy.Clock) (*Time, error) {
+	now := time.Now()
+	if clock != nil {
+		var err error
+		if now, err = clock.Now(); err != nil {
+			return nil, err
+		}
+	}
+	t := &Time{}
+	t.FromTime(now)
+	return t, nil
+}
+
+// TimePublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type TimePublisher struct {
+	*jazzy.Publisher
+}
+
+// NewTimePublisher creates and returns a new publisher for the
+// Time
+func NewTimePublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*TimePublisher, error) {
+	pub, err := node.NewPublisher(topicName, TimeTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &TimePublisher{pub}, nil
+}
+
+func (p *TimePublisher) Publish(msg *Time) error {
+	return p.Publisher.Publish(msg)
+}
+
+// TimeSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type TimeSubscription struct {
+	*jazzy.Subscription
+}
+
+// TimeSubscriptionCallback type is used to provide a subscription
+// handler function for a TimeSubscription.
+type TimeSubscriptionCallback func(msg *Time, info *jazzy.MessageInfo, err error)
+
+// NewTimeSubscription creates and returns a new subscription for the
+// Time
+func NewTimeSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback TimeSubscriptionCallback) (*TimeSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg Time
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, TimeTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &TimeSubscription{sub}, nil
+}
+
+func (s *TimeSubscription) TakeMessage(out *Time) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneTimeSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneTimeSlice(dst, src []Time) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var TimeTypeSupport jazzy.DescribedMessageTypeSupport = _TimeTypeSupport{}
+
+type _TimeTypeSupport struct{}
+
+func (t _TimeTypeSupport) New() jazzy.Message {
+	return NewTime()
+}
+
+func (t _TimeTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.builtin_interfaces__msg__Time
+	return (unsafe.Pointer)(C.builtin_interfaces__msg__Time__create())
+}
+
+func (t _TimeTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.builtin_interfaces__msg__Time__destroy((*C.builtin_interfaces__msg__Time)(pointer_to_free))
+}
+
+func (t _TimeTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.builtin_interfaces__msg__Time__fini((*C.builtin_interfaces__msg__Time)(pointer_to_reset))
+}
+
+func (t _TimeTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*Time)
+	mem := (*C.builtin_interfaces__msg__Time)(dst)
+	mem.sec = C.int32_t(m.Sec)
+	mem.nanosec = C.uint32_t(m.Nanosec)
+}
+
+func (t _TimeTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*Time)
+	mem := (*C.builtin_interfaces__msg__Time)(ros2_message_buffer)
+	m.Sec = int32(mem.sec)
+	m.Nanosec = uint32(mem.nanosec)
+}
+
+func (t _TimeTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__builtin_interfaces__msg__Time())
+}
+
+func (t _TimeTypeSupport) TypeName() string {
+	return "builtin_interfaces/msg/Time"
+}
+
+func (t _TimeTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "builtin_interfaces/msg/Time",
+		Fields: []jazzy.FieldDescription{
+			{Name: "sec", Type: jazzy.FieldType{TypeID: 6}},
+			{Name: "nanosec", Type: jazzy.FieldType{TypeID: 7}},
+		},
+	}
+}
+
+func (t _TimeTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _TimeTypeSupport) Source() string {
+	return "# This message communicates ROS Time defined here:\n# https://design.ros2.org/articles/clock_and_time.html\n\n# The seconds component, valid over all int32 values.\nint32 sec\n\n# The nanoseconds component, valid in the range [0, 1e9), to be added to the seconds component.\n# e.g.\n# The time -1.7 seconds is represented as {sec: -2, nanosec: 3e8}\n# The time 1.7 seconds is represented as {sec: 1, nanosec: 7e8}\nuint32 nanosec\n"
+}
+
+func (t _TimeTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _TimeTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CTime = C.builtin_interfaces__msg__Time
+type CTimeSequence = C.builtin_interfaces__msg__Time__Sequence
+
+func TimeSequenceToGo(goSlice *[]Time, cSlice CTimeSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CTime])(unsafe.Pointer(&cSlice)), _TimeToGo)
+}
+
+func TimeSequenceToC(cSlice *CTimeSequence, goSlice []Time) {
+	jazzy.SequenceToC((*jazzy.CSequence[CTime])(unsafe.Pointer(cSlice)), goSlice, _TimeToC)
+}
+
+func TimeArrayToGo(goSlice []Time, cSlice []CTime) {
+	jazzy.ArrayToGo(goSlice, cSlice, _TimeToGo)
+}
+
+func TimeArrayToC(cSlice []CTime, goSlice []Time) {
+	jazzy.ArrayToC(cSlice, goSlice, _TimeToC)
+}
+
+func _TimeToGo(dst *Time, src *CTime) {
+	TimeTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _TimeToC(dst *CTime, src *Time) {
+	TimeTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
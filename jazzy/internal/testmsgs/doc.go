@@ -0,0 +1,13 @@
+// Package msgs imports the bindings used by the tests of the runtime, which
+// use std_msgs/msg/String and std_srvs/srv/SetBool with rcl and the other
+// messages to check the behavior of generated code. They were generated from
+// the copies of the interface definitions of ROS 2 Jazzy in testdata. The
+// copies were placed in /opt/ros/jazzy, so that the cgo flags refer to the ROS 2
+// installation, and generated with
+//
+//	ros2gen generate --distro jazzy -r /opt/ros/jazzy -d ./internal/testmsgs \
+//		--module-prefix github.com/okieraised/rclgo/jazzy/internal/testmsgs --cgo-flags-path "" \
+//		--typed-enum-package action_msgs
+//
+// and formatted with gofmt.
+package msgs
@@ -0,0 +1,13 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package msgs
+
+import (
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/action_msgs/msg"            //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/builtin_interfaces/msg"     //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/rcl_interfaces/msg"         //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/rcl_interfaces/srv"         //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_msgs/msg"               //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_srvs/srv"               //
+	_ "github.com/okieraised/rclgo/jazzy/internal/testmsgs/unique_identifier_msgs/msg" //
+)
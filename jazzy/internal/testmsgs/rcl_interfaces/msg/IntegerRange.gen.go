@@ -0,0 +1,275 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/msg/integer_range.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("rcl_interfaces/IntegerRange", IntegerRangeTypeSupport)
+	jazzy.RegisterMessage("rcl_interfaces/msg/IntegerRange", IntegerRangeTypeSupport)
+}
+
+type IntegerRange struct {
+	FromValue int64  `yaml:"from_value"` // Start value for valid values, inclusive.
+	ToValue   int64  `yaml:"to_value"`   // End value for valid values, inclusive.
+	Step      uint64 `yaml:"step"`       // Size of valid steps between the from and to bound. A step value of zero implies a continuous range of values. Ideally, the step would be less than or equal to the distance between the bounds, as well as an even multiple of the distance between the bounds, but neither are required. If the absolute value of the step is larger than or equal to the distance between the two bounds, then the bounds will be the only valid values. e.g. if the range is defined as {from_value: 1, to_value: 2, step: 5} then the valid values will be 1 and 2. If the step is less than the distance between the bounds, but the distance is not a multiple of the step, then the "to" bound will always be a valid value, e.g. if the range is defined as {from_value: 2, to_value: 5, step: 2} then the valid values will be 2, 4, and 5.
+}
+
+// NewIntegerRange creates a new IntegerRange with default values.
+func NewIntegerRange() *IntegerRange {
+	self := IntegerRange{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *IntegerRange) Clone() *IntegerRange {
+	c := &IntegerRange{}
+	c.FromValue = t.FromValue
+	c.ToValue = t.ToValue
+	c.Step = t.Step
+	return c
+}
+
+func (t *IntegerRange) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *IntegerRange) DeepCopyInto(dst *IntegerRange) {
+	dst.FromValue = t.FromValue
+	dst.ToValue = t.ToValue
+	dst.Step = t.Step
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *IntegerRange) Equal(other *IntegerRange, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *IntegerRange) Diff(other *IntegerRange, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *IntegerRange) DiffWith(d *jazzy.Differ, other *IntegerRange) {
+	jazzy.DiffValue(d, "from_value", t.FromValue, other.FromValue)
+	jazzy.DiffValue(d, "to_value", t.ToValue, other.ToValue)
+	jazzy.DiffValue(d, "step", t.Step, other.Step)
+}
+
+func (t *IntegerRange) SetDefaults() {
+	t.FromValue = 0
+	t.ToValue = 0
+	t.Step = 0
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *IntegerRange) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *IntegerRange) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *IntegerRange) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *IntegerRange) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *IntegerRange) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *IntegerRange) GetTypeSupport() jazzy.MessageTypeSupport {
+	return IntegerRangeTypeSupport
+}
+
+// IntegerRangePublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type IntegerRangePublisher struct {
+	*jazzy.Publisher
+}
+
+// NewIntegerRangePublisher creates and returns a new publisher for the
+// IntegerRange
+func NewIntegerRangePublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*IntegerRangePublisher, error) {
+	pub, err := node.NewPublisher(topicName, IntegerRangeTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &IntegerRangePublisher{pub}, nil
+}
+
+func (p *IntegerRangePublisher) Publish(msg *IntegerRange) error {
+	return p.Publisher.Publish(msg)
+}
+
+// IntegerRangeSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type IntegerRangeSubscription struct {
+	*jazzy.Subscription
+}
+
+// IntegerRangeSubscriptionCallback type is used to provide a subscription
+// handler function for a IntegerRangeSubscription.
+type IntegerRangeSubscriptionCallback func(msg *IntegerRange, info *jazzy.MessageInfo, err error)
+
+// NewIntegerRangeSubscription creates and returns a new subscription for the
+// IntegerRange
+func NewIntegerRangeSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback IntegerRangeSubscriptionCallback) (*IntegerRangeSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg IntegerRange
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, IntegerRangeTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &IntegerRangeSubscription{sub}, nil
+}
+
+func (s *IntegerRangeSubscription) TakeMessage(out *IntegerRange) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneIntegerRangeSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneIntegerRangeSlice(dst, src []IntegerRange) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var IntegerRangeTypeSupport jazzy.DescribedMessageTypeSupport = _IntegerRangeTypeSupport{}
+
+type _IntegerRangeTypeSupport struct{}
+
+func (t _IntegerRangeTypeSupport) New() jazzy.Message {
+	return NewIntegerRange()
+}
+
+func (t _IntegerRangeTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__msg__IntegerRange
+	return (unsafe.Pointer)(C.rcl_interfaces__msg__IntegerRange__create())
+}
+
+func (t _IntegerRangeTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__msg__IntegerRange__destroy((*C.rcl_interfaces__msg__IntegerRange)(pointer_to_free))
+}
+
+func (t _IntegerRangeTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__msg__IntegerRange__fini((*C.rcl_interfaces__msg__IntegerRange)(pointer_to_reset))
+}
+
+func (t _IntegerRangeTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*IntegerRange)
+	mem := (*C.rcl_interfaces__msg__IntegerRange)(dst)
+	mem.from_value = C.int64_t(m.FromValue)
+	mem.to_value = C.int64_t(m.ToValue)
+	mem.step = C.uint64_t(m.Step)
+}
+
+func (t _IntegerRangeTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*IntegerRange)
+	mem := (*C.rcl_interfaces__msg__IntegerRange)(ros2_message_buffer)
+	m.FromValue = int64(mem.from_value)
+	m.ToValue = int64(mem.to_value)
+	m.Step = uint64(mem.step)
+}
+
+func (t _IntegerRangeTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__msg__IntegerRange())
+}
+
+func (t _IntegerRangeTypeSupport) TypeName() string {
+	return "rcl_interfaces/msg/IntegerRange"
+}
+
+func (t _IntegerRangeTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "rcl_interfaces/msg/IntegerRange",
+		Fields: []jazzy.FieldDescription{
+			{Name: "from_value", Type: jazzy.FieldType{TypeID: 8}},
+			{Name: "to_value", Type: jazzy.FieldType{TypeID: 8}},
+			{Name: "step", Type: jazzy.FieldType{TypeID: 9}},
+		},
+	}
+}
+
+func (t _IntegerRangeTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _IntegerRangeTypeSupport) Source() string {
+	return "# Represents bounds and a step value for an integer typed parameter.\n\n# Start value for valid values, inclusive.\nint64 from_value\n\n# End value for valid values, inclusive.\nint64 to_value\n\n# Size of valid steps between the from and to bound.\n#\n# A step value of zero implies a continuous range of values. Ideally, the step\n# would be less than or equal to the distance between the bounds, as well as an\n# even multiple of the distance between the bounds, but neither are required.\n#\n# If the absolute value of the step is larger than or equal to the distance\n# between the two bounds, then the bounds will be the only valid values. e.g. if\n# the range is defined as {from_value: 1, to_value: 2, step: 5} then the valid\n# values will be 1 and 2.\n#\n# If the step is less than the distance between the bounds, but the distance is\n# not a multiple of the step, then the \"to\" bound will always be a valid value,\n# e.g. if the range is defined as {from_value: 2, to_value: 5, step: 2} then\n# the valid values will be 2, 4, and 5.\nuint64 step\n"
+}
+
+func (t _IntegerRangeTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _IntegerRangeTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CIntegerRange = C.rcl_interfaces__msg__IntegerRange
+type CIntegerRangeSequence = C.rcl_interfaces__msg__IntegerRange__Sequence
+
+func IntegerRangeSequenceToGo(goSlice *[]IntegerRange, cSlice CIntegerRangeSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CIntegerRange])(unsafe.Pointer(&cSlice)), _IntegerRangeToGo)
+}
+
+func IntegerRangeSequenceToC(cSlice *CIntegerRangeSequence, goSlice []IntegerRange) {
+	jazzy.SequenceToC((*jazzy.CSequence[CIntegerRange])(unsafe.Pointer(cSlice)), goSlice, _IntegerRangeToC)
+}
+
+func IntegerRangeArrayToGo(goSlice []IntegerRange, cSlice []CIntegerRange) {
+	jazzy.ArrayToGo(goSlice, cSlice, _IntegerRangeToGo)
+}
+
+func IntegerRangeArrayToC(cSlice []CIntegerRange, goSlice []IntegerRange) {
+	jazzy.ArrayToC(cSlice, goSlice, _IntegerRangeToC)
+}
+
+func _IntegerRangeToGo(dst *IntegerRange, src *CIntegerRange) {
+	IntegerRangeTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _IntegerRangeToC(dst *CIntegerRange, src *IntegerRange) {
+	IntegerRangeTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,346 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/msg/parameter_value.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("rcl_interfaces/ParameterValue", ParameterValueTypeSupport)
+	jazzy.RegisterMessage("rcl_interfaces/msg/ParameterValue", ParameterValueTypeSupport)
+}
+
+type ParameterValue struct {
+	Type              uint8     `yaml:"type"`                // The type of this parameter, which corresponds to the appropriate field below.
+	BoolValue         bool      `yaml:"bool_value"`          // Boolean value, can be either true or false.
+	IntegerValue      int64     `yaml:"integer_value"`       // Integer value ranging from -9,223,372,036,854,775,808 to 9,223,372,036,854,775,807.
+	DoubleValue       float64   `yaml:"double_value"`        // A double precision floating point value following IEEE 754.
+	StringValue       string    `yaml:"string_value"`        // A textual value with no practical length limit.
+	ByteArrayValue    []byte    `yaml:"byte_array_value"`    // An array of bytes, used for non-textual information.
+	BoolArrayValue    []bool    `yaml:"bool_array_value"`    // An array of boolean values.
+	IntegerArrayValue []int64   `yaml:"integer_array_value"` // An array of 64-bit integer values.
+	DoubleArrayValue  []float64 `yaml:"double_array_value"`  // An array of 64-bit floating point values.
+	StringArrayValue  []string  `yaml:"string_array_value"`  // An array of string values.
+}
+
+// NewParameterValue creates a new ParameterValue with default values.
+func NewParameterValue() *ParameterValue {
+	self := ParameterValue{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *ParameterValue) Clone() *ParameterValue {
+	c := &ParameterValue{}
+	c.Type = t.Type
+	c.BoolValue = t.BoolValue
+	c.IntegerValue = t.IntegerValue
+	c.DoubleValue = t.DoubleValue
+	c.StringValue = t.StringValue
+	if t.ByteArrayValue != nil {
+		c.ByteArrayValue = make([]byte, len(t.ByteArrayValue))
+		copy(c.ByteArrayValue, t.ByteArrayValue)
+	}
+	if t.BoolArrayValue != nil {
+		c.BoolArrayValue = make([]bool, len(t.BoolArrayValue))
+		copy(c.BoolArrayValue, t.BoolArrayValue)
+	}
+	if t.IntegerArrayValue != nil {
+		c.IntegerArrayValue = make([]int64, len(t.IntegerArrayValue))
+		copy(c.IntegerArrayValue, t.IntegerArrayValue)
+	}
+	if t.DoubleArrayValue != nil {
+		c.DoubleArrayValue = make([]float64, len(t.DoubleArrayValue))
+		copy(c.DoubleArrayValue, t.DoubleArrayValue)
+	}
+	if t.StringArrayValue != nil {
+		c.StringArrayValue = make([]string, len(t.StringArrayValue))
+		copy(c.StringArrayValue, t.StringArrayValue)
+	}
+	return c
+}
+
+func (t *ParameterValue) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *ParameterValue) DeepCopyInto(dst *ParameterValue) {
+	dst.Type = t.Type
+	dst.BoolValue = t.BoolValue
+	dst.IntegerValue = t.IntegerValue
+	dst.DoubleValue = t.DoubleValue
+	dst.StringValue = t.StringValue
+	dst.ByteArrayValue = append(dst.ByteArrayValue[:0], t.ByteArrayValue...)
+	dst.BoolArrayValue = append(dst.BoolArrayValue[:0], t.BoolArrayValue...)
+	dst.IntegerArrayValue = append(dst.IntegerArrayValue[:0], t.IntegerArrayValue...)
+	dst.DoubleArrayValue = append(dst.DoubleArrayValue[:0], t.DoubleArrayValue...)
+	dst.StringArrayValue = append(dst.StringArrayValue[:0], t.StringArrayValue...)
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *ParameterValue) Equal(other *ParameterValue, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *ParameterValue) Diff(other *ParameterValue, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *ParameterValue) DiffWith(d *jazzy.Differ, other *ParameterValue) {
+	jazzy.DiffValue(d, "type", t.Type, other.Type)
+	jazzy.DiffValue(d, "bool_value", t.BoolValue, other.BoolValue)
+	jazzy.DiffValue(d, "integer_value", t.IntegerValue, other.IntegerValue)
+	jazzy.DiffFloat(d, "double_value", t.DoubleValue, other.DoubleValue)
+	jazzy.DiffValue(d, "string_value", t.StringValue, other.StringValue)
+	jazzy.DiffSlice(d, "byte_array_value", t.ByteArrayValue, other.ByteArrayValue)
+	jazzy.DiffSlice(d, "bool_array_value", t.BoolArrayValue, other.BoolArrayValue)
+	jazzy.DiffSlice(d, "integer_array_value", t.IntegerArrayValue, other.IntegerArrayValue)
+	jazzy.DiffFloatSlice(d, "double_array_value", t.DoubleArrayValue, other.DoubleArrayValue)
+	jazzy.DiffSlice(d, "string_array_value", t.StringArrayValue, other.StringArrayValue)
+}
+
+func (t *ParameterValue) SetDefaults() {
+	t.Type = 0
+	t.BoolValue = false
+	t.IntegerValue = 0
+	t.DoubleValue = 0
+	t.StringValue = ""
+	t.ByteArrayValue = nil
+	t.BoolArrayValue = nil
+	t.IntegerArrayValue = nil
+	t.DoubleArrayValue = nil
+	t.StringArrayValue = nil
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *ParameterValue) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *ParameterValue) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *ParameterValue) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *ParameterValue) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *ParameterValue) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *ParameterValue) GetTypeSupport() jazzy.MessageTypeSupport {
+	return ParameterValueTypeSupport
+}
+
+// ParameterValuePublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type ParameterValuePublisher struct {
+	*jazzy.Publisher
+}
+
+// NewParameterValuePublisher creates and returns a new publisher for the
+// ParameterValue
+func NewParameterValuePublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*ParameterValuePublisher, error) {
+	pub, err := node.NewPublisher(topicName, ParameterValueTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterValuePublisher{pub}, nil
+}
+
+func (p *ParameterValuePublisher) Publish(msg *ParameterValue) error {
+	return p.Publisher.Publish(msg)
+}
+
+// ParameterValueSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type ParameterValueSubscription struct {
+	*jazzy.Subscription
+}
+
+// ParameterValueSubscriptionCallback type is used to provide a subscription
+// handler function for a ParameterValueSubscription.
+type ParameterValueSubscriptionCallback func(msg *ParameterValue, info *jazzy.MessageInfo, err error)
+
+// NewParameterValueSubscription creates and returns a new subscription for the
+// ParameterValue
+func NewParameterValueSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback ParameterValueSubscriptionCallback) (*ParameterValueSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg ParameterValue
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, ParameterValueTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &ParameterValueSubscription{sub}, nil
+}
+
+func (s *ParameterValueSubscription) TakeMessage(out *ParameterValue) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneParameterValueSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneParameterValueSlice(dst, src []ParameterValue) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var ParameterValueTypeSupport jazzy.DescribedMessageTypeSupport = _ParameterValueTypeSupport{}
+
+type _ParameterValueTypeSupport struct{}
+
+func (t _ParameterValueTypeSupport) New() jazzy.Message {
+	return NewParameterValue()
+}
+
+func (t _ParameterValueTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__msg__ParameterValue
+	return (unsafe.Pointer)(C.rcl_interfaces__msg__ParameterValue__create())
+}
+
+func (t _ParameterValueTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterValue__destroy((*C.rcl_interfaces__msg__ParameterValue)(pointer_to_free))
+}
+
+func (t _ParameterValueTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__msg__ParameterValue__fini((*C.rcl_interfaces__msg__ParameterValue)(pointer_to_reset))
+}
+
+func (t _ParameterValueTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*ParameterValue)
+	mem := (*C.rcl_interfaces__msg__ParameterValue)(dst)
+	mem._type = C.uint8_t(m.Type)
+	mem.bool_value = C.bool(m.BoolValue)
+	mem.integer_value = C.int64_t(m.IntegerValue)
+	mem.double_value = C.double(m.DoubleValue)
+	jazzy.StringAsCStruct(unsafe.Pointer(&mem.string_value), m.StringValue)
+	jazzy.ByteSequenceToC((*jazzy.CByteSequence)(unsafe.Pointer(&mem.byte_array_value)), m.ByteArrayValue)
+	jazzy.BoolSequenceToC((*jazzy.CBoolSequence)(unsafe.Pointer(&mem.bool_array_value)), m.BoolArrayValue)
+	jazzy.Int64SequenceToC((*jazzy.CInt64Sequence)(unsafe.Pointer(&mem.integer_array_value)), m.IntegerArrayValue)
+	jazzy.Float64SequenceToC((*jazzy.CFloat64Sequence)(unsafe.Pointer(&mem.double_array_value)), m.DoubleArrayValue)
+	jazzy.StringSequenceToC((*jazzy.CStringSequence)(unsafe.Pointer(&mem.string_array_value)), m.StringArrayValue)
+}
+
+func (t _ParameterValueTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*ParameterValue)
+	mem := (*C.rcl_interfaces__msg__ParameterValue)(ros2_message_buffer)
+	m.Type = uint8(mem._type)
+	m.BoolValue = bool(mem.bool_value)
+	m.IntegerValue = int64(mem.integer_value)
+	m.DoubleValue = float64(mem.double_value)
+	jazzy.StringAsGoStruct(&m.StringValue, unsafe.Pointer(&mem.string_value))
+	jazzy.ByteSequenceToGo(&m.ByteArrayValue, *(*jazzy.CByteSequence)(unsafe.Pointer(&mem.byte_array_value)))
+	jazzy.BoolSequenceToGo(&m.BoolArrayValue, *(*jazzy.CBoolSequence)(unsafe.Pointer(&mem.bool_array_value)))
+	jazzy.Int64SequenceToGo(&m.IntegerArrayValue, *(*jazzy.CInt64Sequence)(unsafe.Pointer(&mem.integer_array_value)))
+	jazzy.Float64SequenceToGo(&m.DoubleArrayValue, *(*jazzy.CFloat64Sequence)(unsafe.Pointer(&mem.double_array_value)))
+	jazzy.StringSequenceToGo(&m.StringArrayValue, *(*jazzy.CStringSequence)(unsafe.Pointer(&mem.string_array_value)))
+}
+
+func (t _ParameterValueTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__msg__ParameterValue())
+}
+
+func (t _ParameterValueTypeSupport) TypeName() string {
+	return "rcl_interfaces/msg/ParameterValue"
+}
+
+func (t _ParameterValueTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "rcl_interfaces/msg/ParameterValue",
+		Fields: []jazzy.FieldDescription{
+			{Name: "type", Type: jazzy.FieldType{TypeID: 3}},
+			{Name: "bool_value", Type: jazzy.FieldType{TypeID: 15}},
+			{Name: "integer_value", Type: jazzy.FieldType{TypeID: 8}},
+			{Name: "double_value", Type: jazzy.FieldType{TypeID: 11}},
+			{Name: "string_value", Type: jazzy.FieldType{TypeID: 17}},
+			{Name: "byte_array_value", Type: jazzy.FieldType{TypeID: 160}},
+			{Name: "bool_array_value", Type: jazzy.FieldType{TypeID: 159}},
+			{Name: "integer_array_value", Type: jazzy.FieldType{TypeID: 152}},
+			{Name: "double_array_value", Type: jazzy.FieldType{TypeID: 155}},
+			{Name: "string_array_value", Type: jazzy.FieldType{TypeID: 161}},
+		},
+	}
+}
+
+func (t _ParameterValueTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _ParameterValueTypeSupport) Source() string {
+	return "# Used to determine which of the next *_value fields are set.\n# ParameterType.PARAMETER_NOT_SET indicates that the parameter was not set\n# (if gotten) or is uninitialized.\n# Values are enumerated in `ParameterType.msg`.\n\n# The type of this parameter, which corresponds to the appropriate field below.\nuint8 type\n\n# \"Variant\" style storage of the parameter value. Only the value corresponding\n# the type field will have valid information.\n\n# Boolean value, can be either true or false.\nbool bool_value\n\n# Integer value ranging from -9,223,372,036,854,775,808 to\n# 9,223,372,036,854,775,807.\nint64 integer_value\n\n# A double precision floating point value following IEEE 754.\nfloat64 double_value\n\n# A textual value with no practical length limit.\nstring string_value\n\n# An array of bytes, used for non-textual information.\nbyte[] byte_array_value\n\n# An array of boolean values.\nbool[] bool_array_value\n\n# An array of 64-bit integer values.\nint64[] integer_array_value\n\n# An array of 64-bit floating point values.\nfloat64[] double_array_value\n\n# An array of string values.\nstring[] string_array_value\n"
+}
+
+func (t _ParameterValueTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _ParameterValueTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CParameterValue = C.rcl_interfaces__msg__ParameterValue
+type CParameterValueSequence = C.rcl_interfaces__msg__ParameterValue__Sequence
+
+func ParameterValueSequenceToGo(goSlice *[]ParameterValue, cSlice CParameterValueSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CParameterValue])(unsafe.Pointer(&cSlice)), _ParameterValueToGo)
+}
+
+func ParameterValueSequenceToC(cSlice *CParameterValueSequence, goSlice []ParameterValue) {
+	jazzy.SequenceToC((*jazzy.CSequence[CParameterValue])(unsafe.Pointer(cSlice)), goSlice, _ParameterValueToC)
+}
+
+func ParameterValueArrayToGo(goSlice []ParameterValue, cSlice []CParameterValue) {
+	jazzy.ArrayToGo(goSlice, cSlice, _ParameterValueToGo)
+}
+
+func ParameterValueArrayToC(cSlice []CParameterValue, goSlice []ParameterValue) {
+	jazzy.ArrayToC(cSlice, goSlice, _ParameterValueToC)
+}
+
+func _ParameterValueToGo(dst *ParameterValue, src *CParameterValue) {
+	ParameterValueTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _ParameterValueToC(dst *CParameterValue, src *ParameterValue) {
+	ParameterValueTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
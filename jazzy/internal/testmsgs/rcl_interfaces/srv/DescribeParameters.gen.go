@@ -0,0 +1,122 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_srv
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+#include <rcl_interfaces/srv/describe_parameters.h>
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+func init() {
+	jazzy.RegisterService("rcl_interfaces/DescribeParameters", DescribeParametersTypeSupport)
+	jazzy.RegisterService("rcl_interfaces/srv/DescribeParameters", DescribeParametersTypeSupport)
+}
+
+type _DescribeParametersTypeSupport struct{}
+
+func (s _DescribeParametersTypeSupport) Request() jazzy.MessageTypeSupport {
+	return DescribeParameters_RequestTypeSupport
+}
+
+func (s _DescribeParametersTypeSupport) Response() jazzy.MessageTypeSupport {
+	return DescribeParameters_ResponseTypeSupport
+}
+
+func (s _DescribeParametersTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_service_type_support_handle__rcl_interfaces__srv__DescribeParameters())
+}
+
+func (s _DescribeParametersTypeSupport) TypeName() string {
+	return "rcl_interfaces/srv/DescribeParameters"
+}
+
+func (s _DescribeParametersTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return jazzy.ServiceTypeDescription("rcl_interfaces/srv/DescribeParameters")
+}
+
+func (s _DescribeParametersTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return jazzy.ServiceReferencedTypes("rcl_interfaces/srv/DescribeParameters", DescribeParameters_RequestTypeSupport, DescribeParameters_ResponseTypeSupport)
+}
+
+func (s _DescribeParametersTypeSupport) Source() string {
+	return "# A list of parameters of which to get the descriptor.\nstring[] names\n\n---\n# A list of the descriptors of all parameters requested in the same order\n# as they were requested. This list has the same length as the list of\n# parameters requested.\nParameterDescriptor[] descriptors\n"
+}
+
+func (s _DescribeParametersTypeSupport) Definition() string {
+	return jazzy.Definition(s)
+}
+
+func (s _DescribeParametersTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(s)
+}
+
+// Modifying this variable is undefined behavior.
+var DescribeParametersTypeSupport jazzy.DescribedServiceTypeSupport = _DescribeParametersTypeSupport{}
+
+// DescribeParametersClient wraps jazzy.Client to provide type safe helper
+// functions
+type DescribeParametersClient struct {
+	*jazzy.Client
+}
+
+// NewDescribeParametersClient creates and returns a new client for the
+// DescribeParameters
+func NewDescribeParametersClient(node *jazzy.Node, serviceName string, options *jazzy.ClientOptions) (*DescribeParametersClient, error) {
+	client, err := node.NewClient(serviceName, DescribeParametersTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParametersClient{client}, nil
+}
+
+func (s *DescribeParametersClient) Send(ctx context.Context, req *DescribeParameters_Request) (*DescribeParameters_Response, *jazzy.ServiceInfo, error) {
+	msg, rmw, err := s.Client.Send(ctx, req)
+	if err != nil {
+		return nil, rmw, err
+	}
+	typedMessage, ok := msg.(*DescribeParameters_Response)
+	if !ok {
+		return nil, rmw, errors.New("invalid message type returned")
+	}
+	return typedMessage, rmw, err
+}
+
+type DescribeParametersServiceResponseSender struct {
+	sender jazzy.ServiceResponseSender
+}
+
+func (s DescribeParametersServiceResponseSender) SendResponse(resp *DescribeParameters_Response) error {
+	return s.sender.SendResponse(resp)
+}
+
+type DescribeParametersServiceRequestHandler func(*jazzy.ServiceInfo, *DescribeParameters_Request, DescribeParametersServiceResponseSender)
+
+// DescribeParametersService wraps jazzy.Service to provide type safe helper
+// functions
+type DescribeParametersService struct {
+	*jazzy.Service
+}
+
+// NewDescribeParametersService creates and returns a new service for the
+// DescribeParameters
+func NewDescribeParametersService(node *jazzy.Node, name string, options *jazzy.ServiceOptions, handler DescribeParametersServiceRequestHandler) (*DescribeParametersService, error) {
+	h := func(rmw *jazzy.ServiceInfo, msg jazzy.Message, rs jazzy.ServiceResponseSender) {
+		m := msg.(*DescribeParameters_Request)
+		responseSender := DescribeParametersServiceResponseSender{sender: rs}
+		handler(rmw, m, responseSender)
+	}
+	service, err := node.NewService(name, DescribeParametersTypeSupport, options, h)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParametersService{service}, nil
+}
@@ -0,0 +1,282 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package rcl_interfaces_srv
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	rcl_interfaces_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/rcl_interfaces/msg"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <rcl_interfaces/srv/describe_parameters.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("rcl_interfaces/DescribeParameters_Response", DescribeParameters_ResponseTypeSupport)
+	jazzy.RegisterMessage("rcl_interfaces/srv/DescribeParameters_Response", DescribeParameters_ResponseTypeSupport)
+}
+
+type DescribeParameters_Response struct {
+	Descriptors []rcl_interfaces_msg.ParameterDescriptor `yaml:"descriptors"` // A list of the descriptors of all parameters requested in the same order as they were requested. This list has the same length as the list of parameters requested.
+}
+
+// NewDescribeParameters_Response creates a new DescribeParameters_Response with default values.
+func NewDescribeParameters_Response() *DescribeParameters_Response {
+	self := DescribeParameters_Response{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *DescribeParameters_Response) Clone() *DescribeParameters_Response {
+	c := &DescribeParameters_Response{}
+	if t.Descriptors != nil {
+		c.Descriptors = make([]rcl_interfaces_msg.ParameterDescriptor, len(t.Descriptors))
+		rcl_interfaces_msg.CloneParameterDescriptorSlice(c.Descriptors, t.Descriptors)
+	}
+	return c
+}
+
+func (t *DescribeParameters_Response) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *DescribeParameters_Response) DeepCopyInto(dst *DescribeParameters_Response) {
+	if cap(dst.Descriptors) < len(t.Descriptors) {
+		dst.Descriptors = make([]rcl_interfaces_msg.ParameterDescriptor, len(t.Descriptors))
+	}
+	dst.Descriptors = dst.Descriptors[:len(t.Descriptors)]
+	for i := range t.Descriptors {
+		t.Descriptors[i].DeepCopyInto(&dst.Descriptors[i])
+	}
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *DescribeParameters_Response) Equal(other *DescribeParameters_Response, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *DescribeParameters_Response) Diff(other *DescribeParameters_Response, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *DescribeParameters_Response) DiffWith(d *jazzy.Differ, other *DescribeParameters_Response) {
+	if jazzy.DiffLen(d, "descriptors", t.Descriptors, other.Descriptors) {
+		for i := range t.Descriptors {
+			d.EnterIndex("descriptors", i)
+			t.Descriptors[i].DiffWith(d, &other.Descriptors[i])
+			d.Leave()
+		}
+	}
+}
+
+func (t *DescribeParameters_Response) SetDefaults() {
+	t.Descriptors = nil
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *DescribeParameters_Response) Validate() error {
+	for i := range t.Descriptors {
+		if err := t.Descriptors[i].Validate(); err != nil {
+			return jazzy.ValidateField(jazzy.FieldIndex("descriptors", i), err)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *DescribeParameters_Response) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *DescribeParameters_Response) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *DescribeParameters_Response) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *DescribeParameters_Response) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *DescribeParameters_Response) GetTypeSupport() jazzy.MessageTypeSupport {
+	return DescribeParameters_ResponseTypeSupport
+}
+
+// DescribeParameters_ResponsePublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type DescribeParameters_ResponsePublisher struct {
+	*jazzy.Publisher
+}
+
+// NewDescribeParameters_ResponsePublisher creates and returns a new publisher for the
+// DescribeParameters_Response
+func NewDescribeParameters_ResponsePublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*DescribeParameters_ResponsePublisher, error) {
+	pub, err := node.NewPublisher(topicName, DescribeParameters_ResponseTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParameters_ResponsePublisher{pub}, nil
+}
+
+func (p *DescribeParameters_ResponsePublisher) Publish(msg *DescribeParameters_Response) error {
+	return p.Publisher.Publish(msg)
+}
+
+// DescribeParameters_ResponseSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type DescribeParameters_ResponseSubscription struct {
+	*jazzy.Subscription
+}
+
+// DescribeParameters_ResponseSubscriptionCallback type is used to provide a subscription
+// handler function for a DescribeParameters_ResponseSubscription.
+type DescribeParameters_ResponseSubscriptionCallback func(msg *DescribeParameters_Response, info *jazzy.MessageInfo, err error)
+
+// NewDescribeParameters_ResponseSubscription creates and returns a new subscription for the
+// DescribeParameters_Response
+func NewDescribeParameters_ResponseSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback DescribeParameters_ResponseSubscriptionCallback) (*DescribeParameters_ResponseSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg DescribeParameters_Response
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, DescribeParameters_ResponseTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &DescribeParameters_ResponseSubscription{sub}, nil
+}
+
+func (s *DescribeParameters_ResponseSubscription) TakeMessage(out *DescribeParameters_Response) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneDescribeParameters_ResponseSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneDescribeParameters_ResponseSlice(dst, src []DescribeParameters_Response) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var DescribeParameters_ResponseTypeSupport jazzy.DescribedMessageTypeSupport = _DescribeParameters_ResponseTypeSupport{}
+
+type _DescribeParameters_ResponseTypeSupport struct{}
+
+func (t _DescribeParameters_ResponseTypeSupport) New() jazzy.Message {
+	return NewDescribeParameters_Response()
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.rcl_interfaces__srv__DescribeParameters_Response
+	return (unsafe.Pointer)(C.rcl_interfaces__srv__DescribeParameters_Response__create())
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.rcl_interfaces__srv__DescribeParameters_Response__destroy((*C.rcl_interfaces__srv__DescribeParameters_Response)(pointer_to_free))
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.rcl_interfaces__srv__DescribeParameters_Response__fini((*C.rcl_interfaces__srv__DescribeParameters_Response)(pointer_to_reset))
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*DescribeParameters_Response)
+	mem := (*C.rcl_interfaces__srv__DescribeParameters_Response)(dst)
+	rcl_interfaces_msg.ParameterDescriptorSequenceToC((*rcl_interfaces_msg.CParameterDescriptorSequence)(unsafe.Pointer(&mem.descriptors)), m.Descriptors)
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*DescribeParameters_Response)
+	mem := (*C.rcl_interfaces__srv__DescribeParameters_Response)(ros2_message_buffer)
+	rcl_interfaces_msg.ParameterDescriptorSequenceToGo(&m.Descriptors, *(*rcl_interfaces_msg.CParameterDescriptorSequence)(unsafe.Pointer(&mem.descriptors)))
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__rcl_interfaces__srv__DescribeParameters_Response())
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) TypeName() string {
+	return "rcl_interfaces/srv/DescribeParameters_Response"
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "rcl_interfaces/srv/DescribeParameters_Response",
+		Fields: []jazzy.FieldDescription{
+			{Name: "descriptors", Type: jazzy.FieldType{TypeID: 145, NestedTypeName: "rcl_interfaces/msg/ParameterDescriptor"}},
+		},
+	}
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{
+		rcl_interfaces_msg.ParameterDescriptorTypeSupport,
+	}
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) Source() string {
+	return "# A list of the descriptors of all parameters requested in the same order\n# as they were requested. This list has the same length as the list of\n# parameters requested.\nParameterDescriptor[] descriptors\n"
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _DescribeParameters_ResponseTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CDescribeParameters_Response = C.rcl_interfaces__srv__DescribeParameters_Response
+type CDescribeParameters_ResponseSequence = C.rcl_interfaces__srv__DescribeParameters_Response__Sequence
+
+func DescribeParameters_ResponseSequenceToGo(goSlice *[]DescribeParameters_Response, cSlice CDescribeParameters_ResponseSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CDescribeParameters_Response])(unsafe.Pointer(&cSlice)), _DescribeParameters_ResponseToGo)
+}
+
+func DescribeParameters_ResponseSequenceToC(cSlice *CDescribeParameters_ResponseSequence, goSlice []DescribeParameters_Response) {
+	jazzy.SequenceToC((*jazzy.CSequence[CDescribeParameters_Response])(unsafe.Pointer(cSlice)), goSlice, _DescribeParameters_ResponseToC)
+}
+
+func DescribeParameters_ResponseArrayToGo(goSlice []DescribeParameters_Response, cSlice []CDescribeParameters_Response) {
+	jazzy.ArrayToGo(goSlice, cSlice, _DescribeParameters_ResponseToGo)
+}
+
+func DescribeParameters_ResponseArrayToC(cSlice []CDescribeParameters_Response, goSlice []DescribeParameters_Response) {
+	jazzy.ArrayToC(cSlice, goSlice, _DescribeParameters_ResponseToC)
+}
+
+func _DescribeParameters_ResponseToGo(dst *DescribeParameters_Response, src *CDescribeParameters_Response) {
+	DescribeParameters_ResponseTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _DescribeParameters_ResponseToC(dst *CDescribeParameters_Response, src *DescribeParameters_Response) {
+	DescribeParameters_ResponseTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
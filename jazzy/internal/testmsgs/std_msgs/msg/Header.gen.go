@@ -0,0 +1,275 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package std_msgs_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	builtin_interfaces_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/builtin_interfaces/msg"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <std_msgs/msg/header.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("std_msgs/Header", HeaderTypeSupport)
+	jazzy.RegisterMessage("std_msgs/msg/Header", HeaderTypeSupport)
+}
+
+type Header struct {
+	Stamp   builtin_interfaces_msg.Time `yaml:"stamp"`    // Two-integer timestamp that is expressed as seconds and nanoseconds.
+	FrameId string                      `yaml:"frame_id"` // Transform frame with which this data is associated.
+}
+
+// NewHeader creates a new Header with default values.
+func NewHeader() *Header {
+	self := Header{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *Header) Clone() *Header {
+	c := &Header{}
+	c.Stamp = *t.Stamp.Clone()
+	c.FrameId = t.FrameId
+	return c
+}
+
+func (t *Header) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *Header) DeepCopyInto(dst *Header) {
+	t.Stamp.DeepCopyInto(&dst.Stamp)
+	dst.FrameId = t.FrameId
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *Header) Equal(other *Header, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *Header) Diff(other *Header, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *Header) DiffWith(d *jazzy.Differ, other *Header) {
+	d.Enter("stamp")
+	t.Stamp.DiffWith(d, &other.Stamp)
+	d.Leave()
+	jazzy.DiffValue(d, "frame_id", t.FrameId, other.FrameId)
+}
+
+func (t *Header) SetDefaults() {
+	t.Stamp.SetDefaults()
+	t.FrameId = ""
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *Header) Validate() error {
+	if err := t.Stamp.Validate(); err != nil {
+		return jazzy.ValidateField("stamp", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *Header) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *Header) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *Header) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *Header) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *Header) GetTypeSupport() jazzy.MessageTypeSupport {
+	return HeaderTypeSupport
+}
+
+// HeaderPublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type HeaderPublisher struct {
+	*jazzy.Publisher
+}
+
+// NewHeaderPublisher creates and returns a new publisher for the
+// Header
+func NewHeaderPublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*HeaderPublisher, error) {
+	pub, err := node.NewPublisher(topicName, HeaderTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderPublisher{pub}, nil
+}
+
+func (p *HeaderPublisher) Publish(msg *Header) error {
+	return p.Publisher.Publish(msg)
+}
+
+// HeaderSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type HeaderSubscription struct {
+	*jazzy.Subscription
+}
+
+// HeaderSubscriptionCallback type is used to provide a subscription
+// handler function for a HeaderSubscription.
+type HeaderSubscriptionCallback func(msg *Header, info *jazzy.MessageInfo, err error)
+
+// NewHeaderSubscription creates and returns a new subscription for the
+// Header
+func NewHeaderSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback HeaderSubscriptionCallback) (*HeaderSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg Header
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, HeaderTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderSubscription{sub}, nil
+}
+
+func (s *HeaderSubscription) TakeMessage(out *Header) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneHeaderSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneHeaderSlice(dst, src []Header) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var HeaderTypeSupport jazzy.DescribedMessageTypeSupport = _HeaderTypeSupport{}
+
+type _HeaderTypeSupport struct{}
+
+func (t _HeaderTypeSupport) New() jazzy.Message {
+	return NewHeader()
+}
+
+func (t _HeaderTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.std_msgs__msg__Header
+	return (unsafe.Pointer)(C.std_msgs__msg__Header__create())
+}
+
+func (t _HeaderTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.std_msgs__msg__Header__destroy((*C.std_msgs__msg__Header)(pointer_to_free))
+}
+
+func (t _HeaderTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.std_msgs__msg__Header__fini((*C.std_msgs__msg__Header)(pointer_to_reset))
+}
+
+func (t _HeaderTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*Header)
+	mem := (*C.std_msgs__msg__Header)(dst)
+	builtin_interfaces_msg.TimeTypeSupport.AsCStruct(unsafe.Pointer(&mem.stamp), &m.Stamp)
+	jazzy.StringAsCStruct(unsafe.Pointer(&mem.frame_id), m.FrameId)
+}
+
+func (t _HeaderTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*Header)
+	mem := (*C.std_msgs__msg__Header)(ros2_message_buffer)
+	builtin_interfaces_msg.TimeTypeSupport.AsGoStruct(&m.Stamp, unsafe.Pointer(&mem.stamp))
+	jazzy.StringAsGoStruct(&m.FrameId, unsafe.Pointer(&mem.frame_id))
+}
+
+func (t _HeaderTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__std_msgs__msg__Header())
+}
+
+func (t _HeaderTypeSupport) TypeName() string {
+	return "std_msgs/msg/Header"
+}
+
+func (t _HeaderTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "std_msgs/msg/Header",
+		Fields: []jazzy.FieldDescription{
+			{Name: "stamp", Type: jazzy.FieldType{TypeID: 1, NestedTypeName: "builtin_interfaces/msg/Time"}},
+			{Name: "frame_id", Type: jazzy.FieldType{TypeID: 17}},
+		},
+	}
+}
+
+func (t _HeaderTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{
+		builtin_interfaces_msg.TimeTypeSupport,
+	}
+}
+
+func (t _HeaderTypeSupport) Source() string {
+	return "# Standard metadata for higher-level stamped data types.\n# This is generally used to communicate timestamped data\n# in a particular coordinate frame.\n\n# Two-integer timestamp that is expressed as seconds and nanoseconds.\nbuiltin_interfaces/Time stamp\n\n# Transform frame with which this data is associated.\nstring frame_id\n"
+}
+
+func (t _HeaderTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _HeaderTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CHeader = C.std_msgs__msg__Header
+type CHeaderSequence = C.std_msgs__msg__Header__Sequence
+
+func HeaderSequenceToGo(goSlice *[]Header, cSlice CHeaderSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CHeader])(unsafe.Pointer(&cSlice)), _HeaderToGo)
+}
+
+func HeaderSequenceToC(cSlice *CHeaderSequence, goSlice []Header) {
+	jazzy.SequenceToC((*jazzy.CSequence[CHeader])(unsafe.Pointer(cSlice)), goSlice, _HeaderToC)
+}
+
+func HeaderArrayToGo(goSlice []Header, cSlice []CHeader) {
+	jazzy.ArrayToGo(goSlice, cSlice, _HeaderToGo)
+}
+
+func HeaderArrayToC(cSlice []CHeader, goSlice []Header) {
+	jazzy.ArrayToC(cSlice, goSlice, _HeaderToC)
+}
+
+func _HeaderToGo(dst *Header, src *CHeader) {
+	HeaderTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _HeaderToC(dst *CHeader, src *Header) {
+	HeaderTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,259 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package std_msgs_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <std_msgs/msg/string.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("std_msgs/String", StringTypeSupport)
+	jazzy.RegisterMessage("std_msgs/msg/String", StringTypeSupport)
+}
+
+type String struct {
+	Data string `yaml:"data"`
+}
+
+// NewString creates a new String with default values.
+func NewString() *String {
+	self := String{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *String) Clone() *String {
+	c := &String{}
+	c.Data = t.Data
+	return c
+}
+
+func (t *String) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *String) DeepCopyInto(dst *String) {
+	dst.Data = t.Data
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *String) Equal(other *String, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *String) Diff(other *String, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *String) DiffWith(d *jazzy.Differ, other *String) {
+	jazzy.DiffValue(d, "data", t.Data, other.Data)
+}
+
+func (t *String) SetDefaults() {
+	t.Data = ""
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *String) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *String) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *String) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *String) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *String) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *String) GetTypeSupport() jazzy.MessageTypeSupport {
+	return StringTypeSupport
+}
+
+// StringPublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type StringPublisher struct {
+	*jazzy.Publisher
+}
+
+// NewStringPublisher creates and returns a new publisher for the
+// String
+func NewStringPublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*StringPublisher, error) {
+	pub, err := node.NewPublisher(topicName, StringTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &StringPublisher{pub}, nil
+}
+
+func (p *StringPublisher) Publish(msg *String) error {
+	return p.Publisher.Publish(msg)
+}
+
+// StringSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type StringSubscription struct {
+	*jazzy.Subscription
+}
+
+// StringSubscriptionCallback type is used to provide a subscription
+// handler function for a StringSubscription.
+type StringSubscriptionCallback func(msg *String, info *jazzy.MessageInfo, err error)
+
+// NewStringSubscription creates and returns a new subscription for the
+// String
+func NewStringSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback StringSubscriptionCallback) (*StringSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg String
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, StringTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &StringSubscription{sub}, nil
+}
+
+func (s *StringSubscription) TakeMessage(out *String) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneStringSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneStringSlice(dst, src []String) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var StringTypeSupport jazzy.DescribedMessageTypeSupport = _StringTypeSupport{}
+
+type _StringTypeSupport struct{}
+
+func (t _StringTypeSupport) New() jazzy.Message {
+	return NewString()
+}
+
+func (t _StringTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.std_msgs__msg__String
+	return (unsafe.Pointer)(C.std_msgs__msg__String__create())
+}
+
+func (t _StringTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.std_msgs__msg__String__destroy((*C.std_msgs__msg__String)(pointer_to_free))
+}
+
+func (t _StringTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.std_msgs__msg__String__fini((*C.std_msgs__msg__String)(pointer_to_reset))
+}
+
+func (t _StringTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*String)
+	mem := (*C.std_msgs__msg__String)(dst)
+	jazzy.StringAsCStruct(unsafe.Pointer(&mem.data), m.Data)
+}
+
+func (t _StringTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*String)
+	mem := (*C.std_msgs__msg__String)(ros2_message_buffer)
+	jazzy.StringAsGoStruct(&m.Data, unsafe.Pointer(&mem.data))
+}
+
+func (t _StringTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__std_msgs__msg__String())
+}
+
+func (t _StringTypeSupport) TypeName() string {
+	return "std_msgs/msg/String"
+}
+
+func (t _StringTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "std_msgs/msg/String",
+		Fields: []jazzy.FieldDescription{
+			{Name: "data", Type: jazzy.FieldType{TypeID: 17}},
+		},
+	}
+}
+
+func (t _StringTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _StringTypeSupport) Source() string {
+	return "# This was originally provided as an example message.\n# It is deprecated as of Foxy\n# It is recommended to create your own semantically meaningful message.\n# However if you would like to continue using this please use the equivalent in example_msgs.\n\nstring data\n"
+}
+
+func (t _StringTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _StringTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CString = C.std_msgs__msg__String
+type CStringSequence = C.std_msgs__msg__String__Sequence
+
+func StringSequenceToGo(goSlice *[]String, cSlice CStringSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CString])(unsafe.Pointer(&cSlice)), _StringToGo)
+}
+
+func StringSequenceToC(cSlice *CStringSequence, goSlice []String) {
+	jazzy.SequenceToC((*jazzy.CSequence[CString])(unsafe.Pointer(cSlice)), goSlice, _StringToC)
+}
+
+func StringArrayToGo(goSlice []String, cSlice []CString) {
+	jazzy.ArrayToGo(goSlice, cSlice, _StringToGo)
+}
+
+func StringArrayToC(cSlice []CString, goSlice []String) {
+	jazzy.ArrayToC(cSlice, goSlice, _StringToC)
+}
+
+func _StringToGo(dst *String, src *CString) {
+	StringTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _StringToC(dst *CString, src *String) {
+	StringTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
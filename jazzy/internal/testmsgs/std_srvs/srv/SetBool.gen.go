@@ -0,0 +1,122 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package std_srvs_srv
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+#include <std_srvs/srv/set_bool.h>
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+)
+
+func init() {
+	jazzy.RegisterService("std_srvs/SetBool", SetBoolTypeSupport)
+	jazzy.RegisterService("std_srvs/srv/SetBool", SetBoolTypeSupport)
+}
+
+type _SetBoolTypeSupport struct{}
+
+func (s _SetBoolTypeSupport) Request() jazzy.MessageTypeSupport {
+	return SetBool_RequestTypeSupport
+}
+
+func (s _SetBoolTypeSupport) Response() jazzy.MessageTypeSupport {
+	return SetBool_ResponseTypeSupport
+}
+
+func (s _SetBoolTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_service_type_support_handle__std_srvs__srv__SetBool())
+}
+
+func (s _SetBoolTypeSupport) TypeName() string {
+	return "std_srvs/srv/SetBool"
+}
+
+func (s _SetBoolTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return jazzy.ServiceTypeDescription("std_srvs/srv/SetBool")
+}
+
+func (s _SetBoolTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return jazzy.ServiceReferencedTypes("std_srvs/srv/SetBool", SetBool_RequestTypeSupport, SetBool_ResponseTypeSupport)
+}
+
+func (s _SetBoolTypeSupport) Source() string {
+	return "bool data # e.g. for hardware enabling / disabling\n---\nbool success   # indicate successful run of triggered service\nstring message # informational, e.g. for error messages\n"
+}
+
+func (s _SetBoolTypeSupport) Definition() string {
+	return jazzy.Definition(s)
+}
+
+func (s _SetBoolTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(s)
+}
+
+// Modifying this variable is undefined behavior.
+var SetBoolTypeSupport jazzy.DescribedServiceTypeSupport = _SetBoolTypeSupport{}
+
+// SetBoolClient wraps jazzy.Client to provide type safe helper
+// functions
+type SetBoolClient struct {
+	*jazzy.Client
+}
+
+// NewSetBoolClient creates and returns a new client for the
+// SetBool
+func NewSetBoolClient(node *jazzy.Node, serviceName string, options *jazzy.ClientOptions) (*SetBoolClient, error) {
+	client, err := node.NewClient(serviceName, SetBoolTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBoolClient{client}, nil
+}
+
+func (s *SetBoolClient) Send(ctx context.Context, req *SetBool_Request) (*SetBool_Response, *jazzy.ServiceInfo, error) {
+	msg, rmw, err := s.Client.Send(ctx, req)
+	if err != nil {
+		return nil, rmw, err
+	}
+	typedMessage, ok := msg.(*SetBool_Response)
+	if !ok {
+		return nil, rmw, errors.New("invalid message type returned")
+	}
+	return typedMessage, rmw, err
+}
+
+type SetBoolServiceResponseSender struct {
+	sender jazzy.ServiceResponseSender
+}
+
+func (s SetBoolServiceResponseSender) SendResponse(resp *SetBool_Response) error {
+	return s.sender.SendResponse(resp)
+}
+
+type SetBoolServiceRequestHandler func(*jazzy.ServiceInfo, *SetBool_Request, SetBoolServiceResponseSender)
+
+// SetBoolService wraps jazzy.Service to provide type safe helper
+// functions
+type SetBoolService struct {
+	*jazzy.Service
+}
+
+// NewSetBoolService creates and returns a new service for the
+// SetBool
+func NewSetBoolService(node *jazzy.Node, name string, options *jazzy.ServiceOptions, handler SetBoolServiceRequestHandler) (*SetBoolService, error) {
+	h := func(rmw *jazzy.ServiceInfo, msg jazzy.Message, rs jazzy.ServiceResponseSender) {
+		m := msg.(*SetBool_Request)
+		responseSender := SetBoolServiceResponseSender{sender: rs}
+		handler(rmw, m, responseSender)
+	}
+	service, err := node.NewService(name, SetBoolTypeSupport, options, h)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBoolService{service}, nil
+}
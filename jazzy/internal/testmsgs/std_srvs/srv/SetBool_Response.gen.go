@@ -0,0 +1,267 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package std_srvs_srv
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <std_srvs/srv/set_bool.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("std_srvs/SetBool_Response", SetBool_ResponseTypeSupport)
+	jazzy.RegisterMessage("std_srvs/srv/SetBool_Response", SetBool_ResponseTypeSupport)
+}
+
+type SetBool_Response struct {
+	Success bool   `yaml:"success"` // indicate successful run of triggered service
+	Message string `yaml:"message"` // informational, e.g. for error messages
+}
+
+// NewSetBool_Response creates a new SetBool_Response with default values.
+func NewSetBool_Response() *SetBool_Response {
+	self := SetBool_Response{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *SetBool_Response) Clone() *SetBool_Response {
+	c := &SetBool_Response{}
+	c.Success = t.Success
+	c.Message = t.Message
+	return c
+}
+
+func (t *SetBool_Response) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *SetBool_Response) DeepCopyInto(dst *SetBool_Response) {
+	dst.Success = t.Success
+	dst.Message = t.Message
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *SetBool_Response) Equal(other *SetBool_Response, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *SetBool_Response) Diff(other *SetBool_Response, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *SetBool_Response) DiffWith(d *jazzy.Differ, other *SetBool_Response) {
+	jazzy.DiffValue(d, "success", t.Success, other.Success)
+	jazzy.DiffValue(d, "message", t.Message, other.Message)
+}
+
+func (t *SetBool_Response) SetDefaults() {
+	t.Success = false
+	t.Message = ""
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *SetBool_Response) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *SetBool_Response) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *SetBool_Response) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *SetBool_Response) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *SetBool_Response) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *SetBool_Response) GetTypeSupport() jazzy.MessageTypeSupport {
+	return SetBool_ResponseTypeSupport
+}
+
+// SetBool_ResponsePublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type SetBool_ResponsePublisher struct {
+	*jazzy.Publisher
+}
+
+// NewSetBool_ResponsePublisher creates and returns a new publisher for the
+// SetBool_Response
+func NewSetBool_ResponsePublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*SetBool_ResponsePublisher, error) {
+	pub, err := node.NewPublisher(topicName, SetBool_ResponseTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBool_ResponsePublisher{pub}, nil
+}
+
+func (p *SetBool_ResponsePublisher) Publish(msg *SetBool_Response) error {
+	return p.Publisher.Publish(msg)
+}
+
+// SetBool_ResponseSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type SetBool_ResponseSubscription struct {
+	*jazzy.Subscription
+}
+
+// SetBool_ResponseSubscriptionCallback type is used to provide a subscription
+// handler function for a SetBool_ResponseSubscription.
+type SetBool_ResponseSubscriptionCallback func(msg *SetBool_Response, info *jazzy.MessageInfo, err error)
+
+// NewSetBool_ResponseSubscription creates and returns a new subscription for the
+// SetBool_Response
+func NewSetBool_ResponseSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback SetBool_ResponseSubscriptionCallback) (*SetBool_ResponseSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg SetBool_Response
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, SetBool_ResponseTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &SetBool_ResponseSubscription{sub}, nil
+}
+
+func (s *SetBool_ResponseSubscription) TakeMessage(out *SetBool_Response) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneSetBool_ResponseSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneSetBool_ResponseSlice(dst, src []SetBool_Response) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var SetBool_ResponseTypeSupport jazzy.DescribedMessageTypeSupport = _SetBool_ResponseTypeSupport{}
+
+type _SetBool_ResponseTypeSupport struct{}
+
+func (t _SetBool_ResponseTypeSupport) New() jazzy.Message {
+	return NewSetBool_Response()
+}
+
+func (t _SetBool_ResponseTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.std_srvs__srv__SetBool_Response
+	return (unsafe.Pointer)(C.std_srvs__srv__SetBool_Response__create())
+}
+
+func (t _SetBool_ResponseTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.std_srvs__srv__SetBool_Response__destroy((*C.std_srvs__srv__SetBool_Response)(pointer_to_free))
+}
+
+func (t _SetBool_ResponseTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.std_srvs__srv__SetBool_Response__fini((*C.std_srvs__srv__SetBool_Response)(pointer_to_reset))
+}
+
+func (t _SetBool_ResponseTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*SetBool_Response)
+	mem := (*C.std_srvs__srv__SetBool_Response)(dst)
+	mem.success = C.bool(m.Success)
+	jazzy.StringAsCStruct(unsafe.Pointer(&mem.message), m.Message)
+}
+
+func (t _SetBool_ResponseTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*SetBool_Response)
+	mem := (*C.std_srvs__srv__SetBool_Response)(ros2_message_buffer)
+	m.Success = bool(mem.success)
+	jazzy.StringAsGoStruct(&m.Message, unsafe.Pointer(&mem.message))
+}
+
+func (t _SetBool_ResponseTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__std_srvs__srv__SetBool_Response())
+}
+
+func (t _SetBool_ResponseTypeSupport) TypeName() string {
+	return "std_srvs/srv/SetBool_Response"
+}
+
+func (t _SetBool_ResponseTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "std_srvs/srv/SetBool_Response",
+		Fields: []jazzy.FieldDescription{
+			{Name: "success", Type: jazzy.FieldType{TypeID: 15}},
+			{Name: "message", Type: jazzy.FieldType{TypeID: 17}},
+		},
+	}
+}
+
+func (t _SetBool_ResponseTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _SetBool_ResponseTypeSupport) Source() string {
+	return "bool success   # indicate successful run of triggered service\nstring message # informational, e.g. for error messages\n"
+}
+
+func (t _SetBool_ResponseTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _SetBool_ResponseTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CSetBool_Response = C.std_srvs__srv__SetBool_Response
+type CSetBool_ResponseSequence = C.std_srvs__srv__SetBool_Response__Sequence
+
+func SetBool_ResponseSequenceToGo(goSlice *[]SetBool_Response, cSlice CSetBool_ResponseSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CSetBool_Response])(unsafe.Pointer(&cSlice)), _SetBool_ResponseToGo)
+}
+
+func SetBool_ResponseSequenceToC(cSlice *CSetBool_ResponseSequence, goSlice []SetBool_Response) {
+	jazzy.SequenceToC((*jazzy.CSequence[CSetBool_Response])(unsafe.Pointer(cSlice)), goSlice, _SetBool_ResponseToC)
+}
+
+func SetBool_ResponseArrayToGo(goSlice []SetBool_Response, cSlice []CSetBool_Response) {
+	jazzy.ArrayToGo(goSlice, cSlice, _SetBool_ResponseToGo)
+}
+
+func SetBool_ResponseArrayToC(cSlice []CSetBool_Response, goSlice []SetBool_Response) {
+	jazzy.ArrayToC(cSlice, goSlice, _SetBool_ResponseToC)
+}
+
+func _SetBool_ResponseToGo(dst *SetBool_Response, src *CSetBool_Response) {
+	SetBool_ResponseTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _SetBool_ResponseToC(dst *CSetBool_Response, src *SetBool_Response) {
+	SetBool_ResponseTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
@@ -0,0 +1,261 @@
+// Code generated by ros2gen. DO NOT EDIT.
+
+package unique_identifier_msgs_msg
+
+import (
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+/*
+#include <rosidl_runtime_c/message_type_support_struct.h>
+
+#include <unique_identifier_msgs/msg/uuid.h>
+
+*/
+import "C"
+
+func init() {
+	jazzy.RegisterMessage("unique_identifier_msgs/UUID", UUIDTypeSupport)
+	jazzy.RegisterMessage("unique_identifier_msgs/msg/UUID", UUIDTypeSupport)
+}
+
+type UUID struct {
+	Uuid [16]uint8 `yaml:"uuid"`
+}
+
+// NewUUID creates a new UUID with default values.
+func NewUUID() *UUID {
+	self := UUID{}
+	self.SetDefaults()
+	return &self
+}
+
+func (t *UUID) Clone() *UUID {
+	c := &UUID{}
+	c.Uuid = t.Uuid
+	return c
+}
+
+func (t *UUID) CloneMsg() jazzy.Message {
+	return t.Clone()
+}
+
+// DeepCopyInto copies t into dst. The memory of the sequences of dst is
+// reused if their capacity allows.
+func (t *UUID) DeepCopyInto(dst *UUID) {
+	dst.Uuid = t.Uuid
+}
+
+// Equal reports whether t and other are equal. Unlike reflect.DeepEqual, nil
+// and empty sequences are equal, and so are NaNs.
+func (t *UUID) Equal(other *UUID, opts ...jazzy.DiffOption) bool {
+	d := jazzy.NewDiffer(opts...)
+	d.FirstOnly = true
+	t.DiffWith(d, other)
+	return len(d.Diffs) == 0
+}
+
+// Diff returns the fields of t and other that differ. See Equal.
+func (t *UUID) Diff(other *UUID, opts ...jazzy.DiffOption) []jazzy.FieldDiff {
+	d := jazzy.NewDiffer(opts...)
+	t.DiffWith(d, other)
+	return d.Diffs
+}
+
+// DiffWith adds the fields of t and other that differ to d.
+func (t *UUID) DiffWith(d *jazzy.Differ, other *UUID) {
+	jazzy.DiffSlice(d, "uuid", t.Uuid[:], other.Uuid[:])
+}
+
+func (t *UUID) SetDefaults() {
+	t.Uuid = [16]uint8{}
+}
+
+// Validate checks the bounds of the bounded strings and sequences of t and
+// the messages in it.
+func (t *UUID) Validate() error {
+	return nil
+}
+
+// MarshalJSON encodes t as JSON like rosbridge_suite. See package msgcodec.
+func (t *UUID) MarshalJSON() ([]byte, error) {
+	return msgcodec.MarshalJSON(t)
+}
+
+// UnmarshalJSON decodes JSON into t. Fields missing from data are set to
+// their default values.
+func (t *UUID) UnmarshalJSON(data []byte) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalJSON(data, t)
+}
+
+// MarshalYAML encodes t as YAML like ros2 topic echo. See package msgcodec.
+func (t *UUID) MarshalYAML() (any, error) {
+	return msgcodec.MarshalYAML(t)
+}
+
+// UnmarshalYAML decodes YAML in the syntax of ros2 topic pub into t. Fields
+// missing from the YAML are set to their default values.
+func (t *UUID) UnmarshalYAML(unmarshal func(any) error) error {
+	t.SetDefaults()
+	return msgcodec.UnmarshalYAML(unmarshal, t)
+}
+
+func (t *UUID) GetTypeSupport() jazzy.MessageTypeSupport {
+	return UUIDTypeSupport
+}
+
+// UUIDPublisher wraps jazzy.Publisher to provide type safe helper
+// functions
+type UUIDPublisher struct {
+	*jazzy.Publisher
+}
+
+// NewUUIDPublisher creates and returns a new publisher for the
+// UUID
+func NewUUIDPublisher(node *jazzy.Node, topicName string, options *jazzy.PublisherOptions) (*UUIDPublisher, error) {
+	pub, err := node.NewPublisher(topicName, UUIDTypeSupport, options)
+	if err != nil {
+		return nil, err
+	}
+	return &UUIDPublisher{pub}, nil
+}
+
+func (p *UUIDPublisher) Publish(msg *UUID) error {
+	return p.Publisher.Publish(msg)
+}
+
+// UUIDSubscription wraps jazzy.Subscription to provide type safe helper
+// functions
+type UUIDSubscription struct {
+	*jazzy.Subscription
+}
+
+// UUIDSubscriptionCallback type is used to provide a subscription
+// handler function for a UUIDSubscription.
+type UUIDSubscriptionCallback func(msg *UUID, info *jazzy.MessageInfo, err error)
+
+// NewUUIDSubscription creates and returns a new subscription for the
+// UUID
+func NewUUIDSubscription(node *jazzy.Node, topicName string, opts *jazzy.SubscriptionOptions, subscriptionCallback UUIDSubscriptionCallback) (*UUIDSubscription, error) {
+	callback := func(s *jazzy.Subscription) {
+		var msg UUID
+		info, err := s.TakeMessage(&msg)
+		subscriptionCallback(&msg, info, err)
+	}
+	sub, err := node.NewSubscription(topicName, UUIDTypeSupport, opts, callback)
+	if err != nil {
+		return nil, err
+	}
+	return &UUIDSubscription{sub}, nil
+}
+
+func (s *UUIDSubscription) TakeMessage(out *UUID) (*jazzy.MessageInfo, error) {
+	return s.Subscription.TakeMessage(out)
+}
+
+// CloneUUIDSlice clones src to dst by calling Clone for each element in
+// src. Panics if len(dst) < len(src).
+func CloneUUIDSlice(dst, src []UUID) {
+	for i := range src {
+		dst[i] = *src[i].Clone()
+	}
+}
+
+// Modifying this variable is undefined behavior.
+var UUIDTypeSupport jazzy.DescribedMessageTypeSupport = _UUIDTypeSupport{}
+
+type _UUIDTypeSupport struct{}
+
+func (t _UUIDTypeSupport) New() jazzy.Message {
+	return NewUUID()
+}
+
+func (t _UUIDTypeSupport) PrepareMemory() unsafe.Pointer { //returns *C.unique_identifier_msgs__msg__UUID
+	return (unsafe.Pointer)(C.unique_identifier_msgs__msg__UUID__create())
+}
+
+func (t _UUIDTypeSupport) ReleaseMemory(pointer_to_free unsafe.Pointer) {
+	C.unique_identifier_msgs__msg__UUID__destroy((*C.unique_identifier_msgs__msg__UUID)(pointer_to_free))
+}
+
+func (t _UUIDTypeSupport) ResetMemory(pointer_to_reset unsafe.Pointer) {
+	C.unique_identifier_msgs__msg__UUID__fini((*C.unique_identifier_msgs__msg__UUID)(pointer_to_reset))
+}
+
+func (t _UUIDTypeSupport) AsCStruct(dst unsafe.Pointer, msg jazzy.Message) {
+	m := msg.(*UUID)
+	mem := (*C.unique_identifier_msgs__msg__UUID)(dst)
+	cSlice_uuid := mem.uuid[:]
+	jazzy.Uint8ArrayToC(*(*[]jazzy.CUint8)(unsafe.Pointer(&cSlice_uuid)), m.Uuid[:])
+}
+
+func (t _UUIDTypeSupport) AsGoStruct(msg jazzy.Message, ros2_message_buffer unsafe.Pointer) {
+	m := msg.(*UUID)
+	mem := (*C.unique_identifier_msgs__msg__UUID)(ros2_message_buffer)
+	cSlice_uuid := mem.uuid[:]
+	jazzy.Uint8ArrayToGo(m.Uuid[:], *(*[]jazzy.CUint8)(unsafe.Pointer(&cSlice_uuid)))
+}
+
+func (t _UUIDTypeSupport) TypeSupport() unsafe.Pointer {
+	return unsafe.Pointer(C.rosidl_typesupport_c__get_message_type_support_handle__unique_identifier_msgs__msg__UUID())
+}
+
+func (t _UUIDTypeSupport) TypeName() string {
+	return "unique_identifier_msgs/msg/UUID"
+}
+
+func (t _UUIDTypeSupport) TypeDescription() *jazzy.TypeDescription {
+	return &jazzy.TypeDescription{
+		TypeName: "unique_identifier_msgs/msg/UUID",
+		Fields: []jazzy.FieldDescription{
+			{Name: "uuid", Type: jazzy.FieldType{TypeID: 51, Capacity: 16}},
+		},
+	}
+}
+
+func (t _UUIDTypeSupport) ReferencedTypes() []jazzy.TypeDescriber {
+	return []jazzy.TypeDescriber{}
+}
+
+func (t _UUIDTypeSupport) Source() string {
+	return "# A universally unique identifier (UUID).\n#\n#  http://en.wikipedia.org/wiki/Universally_unique_identifier\n#  http://tools.ietf.org/html/rfc4122.html\n\nuint8[16] uuid\n"
+}
+
+func (t _UUIDTypeSupport) Definition() string {
+	return jazzy.Definition(t)
+}
+
+func (t _UUIDTypeSupport) TypeHash() string {
+	return jazzy.TypeHash(t)
+}
+
+type CUUID = C.unique_identifier_msgs__msg__UUID
+type CUUIDSequence = C.unique_identifier_msgs__msg__UUID__Sequence
+
+func UUIDSequenceToGo(goSlice *[]UUID, cSlice CUUIDSequence) {
+	jazzy.SequenceToGo(goSlice, (*jazzy.CSequence[CUUID])(unsafe.Pointer(&cSlice)), _UUIDToGo)
+}
+
+func UUIDSequenceToC(cSlice *CUUIDSequence, goSlice []UUID) {
+	jazzy.SequenceToC((*jazzy.CSequence[CUUID])(unsafe.Pointer(cSlice)), goSlice, _UUIDToC)
+}
+
+func UUIDArrayToGo(goSlice []UUID, cSlice []CUUID) {
+	jazzy.ArrayToGo(goSlice, cSlice, _UUIDToGo)
+}
+
+func UUIDArrayToC(cSlice []CUUID, goSlice []UUID) {
+	jazzy.ArrayToC(cSlice, goSlice, _UUIDToC)
+}
+
+func _UUIDToGo(dst *UUID, src *CUUID) {
+	UUIDTypeSupport.AsGoStruct(dst, unsafe.Pointer(src))
+}
+
+func _UUIDToC(dst *CUUID, src *UUID) {
+	UUIDTypeSupport.AsCStruct(unsafe.Pointer(dst), src)
+}
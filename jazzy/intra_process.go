@@ -0,0 +1,264 @@
+package jazzy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// intraProcessManager delivers the messages published in a Context directly to
+// the subscriptions in the same Context, without converting them to C and
+// passing them through the middleware.
+//
+// Publishers still publish through the middleware, as subscriptions of other
+// processes may be matched to them at any time. Like in rclcpp, subscriptions
+// taking part in intra-process communication drop the messages of the
+// middleware published by the publishers whose messages they received by
+// intra-process communication already, identified by the GIDs of the
+// publishers. Other local messages, e.g. those of transient local publishers,
+// are received through the middleware as usual.
+type intraProcessManager struct {
+	mu   sync.RWMutex
+	subs []*intraProcessQueue
+	// pubs are the topics of the publishers taking part in intra-process
+	// communication by the GIDs of the publishers.
+	pubs map[GID]*intraProcessTopic
+}
+
+func (m *intraProcessManager) add(q *intraProcessQueue) {
+	if m == nil || q == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subs = append(m.subs, q)
+}
+
+func (m *intraProcessManager) remove(q *intraProcessQueue) {
+	if m == nil || q == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.subs {
+		if m.subs[i] == q {
+			m.subs = append(m.subs[:i], m.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *intraProcessManager) addPublisher(gid GID, pub *intraProcessTopic) {
+	if m == nil || pub == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pubs == nil {
+		m.pubs = map[GID]*intraProcessTopic{}
+	}
+	m.pubs[gid] = pub
+}
+
+func (m *intraProcessManager) removePublisher(gid GID) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pubs, gid)
+}
+
+// delivers reports whether the messages of the publisher with the GID gid are
+// delivered to q by intra-process communication.
+func (m *intraProcessManager) delivers(gid GID, q *intraProcessQueue) bool {
+	if m == nil || q == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pub, ok := m.pubs[gid]
+	return ok && q.matches(pub)
+}
+
+// publish delivers msg to the queues matching the topic, type and QoS of pub
+// and returns the number of matching queues. gid is the GID of the publisher.
+// newMsg returns the message for a queue, or nil if the message cannot be
+// delivered to it.
+func (m *intraProcessManager) publish(pub *intraProcessTopic, gid GID, newMsg func(*intraProcessQueue) (Message, MessageTypeSupport)) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	count := 0
+	for _, q := range m.subs {
+		if !q.matches(pub) {
+			continue
+		}
+		count++
+		if msg, ts := newMsg(q); msg != nil {
+			q.push(intraProcessMessage{msg: msg, typeSupport: ts, timestamp: now, publisherGID: gid})
+		}
+	}
+	return count
+}
+
+// intraProcessTopic identifies the topic of a publisher or a subscription
+// taking part in intra-process communication.
+type intraProcessTopic struct {
+	// name is the fully qualified name of the topic after remapping.
+	name        string
+	typeName    string
+	reliability ReliabilityPolicy
+}
+
+// newIntraProcessTopic returns the topic of an entity with the resolved topic
+// name, type support and QoS profile. Transient local entities don't take
+// part in intra-process communication, because the history of the topic is
+// kept by the middleware only, and nil is returned for them.
+func newIntraProcessTopic(name string, ts MessageTypeSupport, qos *QosProfile) *intraProcessTopic {
+	if qos.Durability == DurabilityTransientLocal {
+		return nil
+	}
+	return &intraProcessTopic{
+		name:        name,
+		typeName:    typeNameOf(ts),
+		reliability: qos.Reliability,
+	}
+}
+
+// typeNameOf returns the name of the type of ts, e.g. "std_msgs/msg/Header",
+// or the name of the Go type of ts if it doesn't have a TypeName method.
+func typeNameOf(ts MessageTypeSupport) string {
+	if named, ok := ts.(interface{ TypeName() string }); ok {
+		return named.TypeName()
+	}
+	return fmt.Sprintf("%T", ts)
+}
+
+// intraProcessMessage is a message published within a Context, or a message
+// of the middleware queued by Subscription.queueMessage.
+type intraProcessMessage struct {
+	msg Message
+	// typeSupport is the type support of msg, which may differ from the type
+	// support of the subscription if the Go types of the publisher and the
+	// subscription differ.
+	typeSupport  MessageTypeSupport
+	timestamp    time.Time
+	publisherGID GID
+	// middlewareInfo is the info of a message of the middleware.
+	middlewareInfo *MessageInfo
+}
+
+// copyTo sets out to m.
+func (m *intraProcessMessage) copyTo(out Message, ts MessageTypeSupport) {
+	// m.msg is not shared, so a shallow copy suffices.
+	if reflect.TypeOf(out) == reflect.TypeOf(m.msg) {
+		reflect.ValueOf(out).Elem().Set(reflect.ValueOf(m.msg).Elem())
+		return
+	}
+	buf := m.typeSupport.PrepareMemory()
+	defer m.typeSupport.ReleaseMemory(buf)
+	if r, ok := m.typeSupport.(MemoryResetter); ok {
+		r.ResetMemory(buf)
+	}
+	m.typeSupport.AsCStruct(buf, m.msg)
+	ts.AsGoStruct(out, buf)
+}
+
+func (m *intraProcessMessage) info() *MessageInfo {
+	if m.middlewareInfo != nil {
+		return m.middlewareInfo
+	}
+	return &MessageInfo{
+		SourceTimestamp:   m.timestamp,
+		ReceivedTimestamp: m.timestamp,
+		FromIntraProcess:  true,
+		PublisherGID:      m.publisherGID,
+	}
+}
+
+// intraProcessQueue holds the messages delivered to a subscription by
+// intra-process communication.
+type intraProcessQueue struct {
+	intraProcessTopic
+	typeSupport MessageTypeSupport
+	// depth is the maximum number of messages in the queue, or 0 if the
+	// queue is unbounded.
+	depth int
+	// wake is triggered when a message is pushed, so that wait sets waiting
+	// for the subscription wake up.
+	wake *guardCondition
+
+	mu   sync.Mutex
+	msgs []intraProcessMessage
+}
+
+// newIntraProcessQueue returns a queue for a subscription whose history QoS
+// policy is the one of qos.
+func newIntraProcessQueue(topic *intraProcessTopic, ts MessageTypeSupport, qos *QosProfile) *intraProcessQueue {
+	q := &intraProcessQueue{intraProcessTopic: *topic, typeSupport: ts}
+	if qos.History != HistoryKeepAll {
+		q.depth = max(qos.Depth, 1)
+	}
+	return q
+}
+
+// matches reports whether the messages of pub are delivered to q. Like in the
+// middleware, reliable subscriptions don't receive messages from best effort
+// publishers.
+func (q *intraProcessQueue) matches(pub *intraProcessTopic) bool {
+	return q.name == pub.name &&
+		q.typeName == pub.typeName &&
+		(q.reliability == ReliabilityBestEffort || pub.reliability != ReliabilityBestEffort)
+}
+
+// push adds m to q and wakes up the wait sets waiting for q.
+func (q *intraProcessQueue) push(m intraProcessMessage) {
+	q.add(m)
+	q.trigger()
+}
+
+// add adds m to q, dropping the oldest message if q is full.
+func (q *intraProcessQueue) add(m intraProcessMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.depth > 0 && len(q.msgs) >= q.depth {
+		q.msgs[0] = intraProcessMessage{}
+		q.msgs = q.msgs[1:]
+	}
+	q.msgs = append(q.msgs, m)
+}
+
+// pop removes and returns the oldest message of q. ok is false if q is nil or
+// empty.
+func (q *intraProcessQueue) pop() (m intraProcessMessage, ok bool) {
+	if q == nil {
+		return m, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.msgs) == 0 {
+		return m, false
+	}
+	m = q.msgs[0]
+	q.msgs[0] = intraProcessMessage{}
+	q.msgs = q.msgs[1:]
+	return m, true
+}
+
+// pending reports whether q has messages.
+func (q *intraProcessQueue) pending() bool {
+	if q == nil {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.msgs) > 0
+}
+
+func (q *intraProcessQueue) trigger() {
+	if q.wake != nil {
+		_ = q.wake.Trigger() //nolint:errcheck
+	}
+}
@@ -0,0 +1,142 @@
+package jazzy
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+type bufMsg struct {
+	data [256]byte
+	seq  *[256]byte
+}
+
+// bufTypeSupport allocates messages like the generated type supports, but in
+// Go memory, and counts the calls to its methods.
+type bufTypeSupport struct {
+	MessageTypeSupport
+	prepared, released, reset atomic.Int64
+}
+
+// PrepareMemory isn't inlined, so that buffers are allocated on the heap like
+// the C structs of generated type supports.
+//
+//go:noinline
+func (ts *bufTypeSupport) PrepareMemory() unsafe.Pointer {
+	ts.prepared.Add(1)
+	return unsafe.Pointer(&bufMsg{seq: new([256]byte)})
+}
+
+func (ts *bufTypeSupport) ReleaseMemory(p unsafe.Pointer) {
+	ts.released.Add(1)
+}
+
+func (ts *bufTypeSupport) ResetMemory(p unsafe.Pointer) {
+	ts.reset.Add(1)
+	(*bufMsg)(p).seq = nil
+}
+
+func (ts *bufTypeSupport) AsCStruct(dst unsafe.Pointer, msg Message) {
+	(*bufMsg)(dst).seq = new([256]byte)
+}
+
+func (ts *bufTypeSupport) check(t *testing.T, prepared, released, reset int64) {
+	t.Helper()
+	if got := ts.prepared.Load(); got != prepared {
+		t.Errorf("want %d prepared buffers, got %d", prepared, got)
+	}
+	if got := ts.released.Load(); got != released {
+		t.Errorf("want %d released buffers, got %d", released, got)
+	}
+	if got := ts.reset.Load(); got != reset {
+		t.Errorf("want %d resets, got %d", reset, got)
+	}
+}
+
+func TestMessageBufferPool(t *testing.T) {
+	ts := &bufTypeSupport{}
+	p := newMessageBufferPool(ts, true)
+	buf := p.get()
+	if (*bufMsg)(buf).seq != nil {
+		t.Fatal("new buffer was not reset")
+	}
+	p.put(buf)
+	if p.get() != buf {
+		t.Fatal("buffer was not reused")
+	}
+	p.put(buf)
+	ts.check(t, 1, 0, 3)
+
+	bufs := make([]unsafe.Pointer, maxPooledMessageBuffers+1)
+	for i := range bufs {
+		bufs[i] = p.get()
+	}
+	for _, buf := range bufs {
+		p.put(buf)
+	}
+	ts.check(t, maxPooledMessageBuffers+1, 1, 3+2*maxPooledMessageBuffers)
+
+	p.close()
+	ts.check(t, maxPooledMessageBuffers+1, maxPooledMessageBuffers+1, 3+2*maxPooledMessageBuffers)
+	p.put(p.get())
+	ts.check(t, maxPooledMessageBuffers+2, maxPooledMessageBuffers+2, 4+2*maxPooledMessageBuffers)
+}
+
+func TestMessageBufferPoolTake(t *testing.T) {
+	ts := &bufTypeSupport{}
+	p := newMessageBufferPool(ts, false)
+	p.put(p.get())
+	if (*bufMsg)(p.get()).seq == nil {
+		t.Fatal("take buffer was reset")
+	}
+	ts.check(t, 1, 0, 0)
+}
+
+// noResetTypeSupport hides the ResetMemory method of a bufTypeSupport like
+// the MessageTypeSupports not implementing MemoryResetter.
+type noResetTypeSupport struct {
+	MessageTypeSupport
+}
+
+func TestMessageBufferPoolWithoutReset(t *testing.T) {
+	ts := &bufTypeSupport{}
+	p := newMessageBufferPool(noResetTypeSupport{ts}, true)
+	buf := p.get()
+	if (*bufMsg)(buf).seq == nil {
+		t.Fatal("new buffer was reset without ResetMemory")
+	}
+	p.put(buf)
+	p.put(p.get())
+	// The buffers are freed instead of reused.
+	ts.check(t, 2, 2, 0)
+
+	p = newMessageBufferPool(noResetTypeSupport{ts}, false)
+	p.put(p.get())
+	p.put(p.get())
+	p.close()
+	ts.check(t, 3, 3, 0)
+}
+
+func TestMessageBufferPoolConcurrent(t *testing.T) {
+	ts := &bufTypeSupport{}
+	p := newMessageBufferPool(ts, true)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				buf := p.get()
+				ts.AsCStruct(buf, nil)
+				(*bufMsg)(buf).data[0]++
+				p.put(buf)
+			}
+		}()
+	}
+	wg.Wait()
+	p.close()
+	if prepared, released := ts.prepared.Load(), ts.released.Load(); prepared != released {
+		t.Fatalf("%d buffers prepared but %d released", prepared, released)
+	}
+}
@@ -0,0 +1,168 @@
+package msgcodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML encodes the generated message struct pointed to by msg as a
+// YAML node in the format of ros2 topic echo.
+func MarshalYAML(msg any) (*yaml.Node, error) {
+	return encodeYAML(reflect.ValueOf(msg))
+}
+
+func encodeYAML(v reflect.Value) (*yaml.Node, error) {
+	if sec, nanosec, ok := timeValue(v); ok {
+		return yamlMapping(
+			"sec", yamlScalar("!!int", strconv.FormatInt(sec, 10)),
+			"nanosec", yamlScalar("!!int", strconv.FormatInt(nanosec, 10)),
+		), nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return yamlScalar("!!null", "null"), nil
+		}
+		return encodeYAML(v.Elem())
+	case reflect.Bool:
+		return yamlScalar("!!bool", strconv.FormatBool(v.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return yamlScalar("!!int", strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return yamlScalar("!!int", strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return yamlScalar("!!float", formatYAMLFloat(v.Float(), v.Type().Bits())), nil
+	case reflect.String:
+		return yamlScalar("!!str", v.String()), nil
+	case reflect.Slice, reflect.Array:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, err := encodeYAML(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, elem)
+		}
+		return node, nil
+	case reflect.Struct:
+		node := yamlMapping()
+		for i := 0; i < v.NumField(); i++ {
+			name, ok := fieldName(v.Type().Field(i))
+			if !ok {
+				continue
+			}
+			value, err := encodeYAML(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, yamlScalar("!!str", name), value)
+		}
+		return node, nil
+	}
+	return nil, fmt.Errorf("cannot encode value of type %s", v.Type())
+}
+
+func yamlScalar(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// yamlMapping returns a mapping node of the alternating keys and values in
+// fields.
+func yamlMapping(fields ...any) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i < len(fields); i += 2 {
+		node.Content = append(node.Content, yamlScalar("!!str", fields[i].(string)), fields[i+1].(*yaml.Node))
+	}
+	return node
+}
+
+// formatYAMLFloat formats f like ros2 topic echo, which always prints a
+// decimal point or an exponent.
+func formatYAMLFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return ".nan"
+	case math.IsInf(f, 1):
+		return ".inf"
+	case math.IsInf(f, -1):
+		return "-.inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// UnmarshalYAML decodes YAML into the generated message struct pointed to by
+// msg. unmarshal is the function passed to the UnmarshalYAML method of the
+// message by gopkg.in/yaml.v2 or gopkg.in/yaml.v3. Fields missing from the
+// YAML are left untouched, which allows the partial input of ros2 topic pub,
+// e.g. "{header: {frame_id: map}}".
+func UnmarshalYAML(unmarshal func(any) error, msg any) error {
+	v, err := messageValue(msg)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	if value, err = fromYAML(value, ""); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	return decodeValue(v.Elem(), value, "")
+}
+
+// fromYAML converts a value decoded from YAML into the form decoded from JSON
+// by UnmarshalJSON, so that it can be decoded by decodeValue.
+func fromYAML(value any, path string) (any, error) {
+	switch value := value.(type) {
+	case nil, bool, string:
+		return value, nil
+	case int:
+		return json.Number(strconv.Itoa(value)), nil
+	case int64:
+		return json.Number(strconv.FormatInt(value, 10)), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(value, 10)), nil
+	case float64:
+		// NaN and ±Inf are formatted in a form accepted by strconv.ParseFloat.
+		return json.Number(strconv.FormatFloat(value, 'g', -1, 64)), nil
+	case []any:
+		for i, elem := range value {
+			var err error
+			if value[i], err = fromYAML(elem, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case map[string]any:
+		for k, elem := range value {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			var err error
+			if value[k], err = fromYAML(elem, fieldPath); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case map[any]any:
+		return nil, fmt.Errorf("field %q: field names must be strings", pathOrRoot(path))
+	}
+	return nil, fmt.Errorf("field %q: cannot decode YAML value of type %T", pathOrRoot(path), value)
+}
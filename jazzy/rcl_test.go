@@ -0,0 +1,249 @@
+package jazzy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+	std_msgs_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_msgs/msg"
+)
+
+// newTestContext returns a Context closed at the end of the test, or skips the
+// test if rcl cannot be initialized.
+func newTestContext(tb testing.TB, opts *jazzy.ContextOptions) *jazzy.Context {
+	tb.Helper()
+	ctx, err := jazzy.NewContextWithOpts(nil, opts)
+	if err != nil {
+		tb.Skipf("failed to initialize rcl: %v", err)
+	}
+	tb.Cleanup(func() { _ = ctx.Close() })
+	return ctx
+}
+
+var nonTopicChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// testTopic returns a topic name unique to the test and the process.
+func testTopic(tb testing.TB) string {
+	return fmt.Sprintf("/rclgo_test_%d/%s", os.Getpid(), nonTopicChars.ReplaceAllString(tb.Name(), "_"))
+}
+
+// spin runs a WaitSet of subs until the end of the test.
+func spin(tb testing.TB, rclctx *jazzy.Context, subs ...*jazzy.Subscription) {
+	tb.Helper()
+	ws, err := rclctx.NewWaitSet()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ws.AddSubscriptions(subs...)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ws.Run(ctx) }()
+	tb.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			tb.Error(err)
+		}
+		_ = ws.Close()
+	})
+}
+
+// waitForSubscriptions waits until pub is matched to count subscriptions.
+func waitForSubscriptions(tb testing.TB, pub *jazzy.Publisher, count int) {
+	tb.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		n, err := pub.GetSubscriptionCount()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if n >= count {
+			return
+		}
+		if time.Now().After(deadline) {
+			tb.Fatalf("want %d matched subscriptions, got %d", count, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type receivedString struct {
+	data string
+	info *jazzy.MessageInfo
+}
+
+// newStringReceiver returns a String subscription sending the messages it
+// takes to the returned channel.
+func newStringReceiver(tb testing.TB, node *jazzy.Node, topic string, opts *jazzy.SubscriptionOptions) (*std_msgs_msg.StringSubscription, <-chan receivedString) {
+	tb.Helper()
+	received := make(chan receivedString, 16)
+	sub, err := std_msgs_msg.NewStringSubscription(node, topic, opts, func(msg *std_msgs_msg.String, info *jazzy.MessageInfo, err error) {
+		if err != nil {
+			tb.Error(err)
+			return
+		}
+		received <- receivedString{data: msg.Data, info: info}
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return sub, received
+}
+
+// expectReceived checks that the messages received from ch are want, and that
+// no other messages are received shortly afterwards.
+func expectReceived(tb testing.TB, ch <-chan receivedString, fromIntraProcess bool, want ...string) {
+	tb.Helper()
+	timeout := time.After(5 * time.Second)
+	for _, w := range want {
+		select {
+		case got := <-ch:
+			if got.data != w {
+				tb.Fatalf("want %q, got %q", w, got.data)
+			}
+			if got.info.FromIntraProcess != fromIntraProcess {
+				tb.Fatalf("want FromIntraProcess %v for %q", fromIntraProcess, w)
+			}
+		case <-timeout:
+			tb.Fatalf("timed out waiting for %q", w)
+		}
+	}
+	select {
+	case got := <-ch:
+		tb.Fatalf("unexpected message %q", got.data)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestPublishTakeMessage(t *testing.T) {
+	rclctx := newTestContext(t, nil)
+	node, err := rclctx.NewNode("test_publish_take", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := testTopic(t)
+	subOpts := jazzy.NewDefaultSubscriptionOptions()
+	subOpts.Qos.Depth = 3
+	sub, received := newStringReceiver(t, node, topic, subOpts)
+	pub, err := std_msgs_msg.NewStringPublisher(node, topic, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForSubscriptions(t, pub.Publisher, 1)
+
+	// Messages exceeding the depth of the subscription are dropped until
+	// they are taken.
+	for i := 0; i < 5; i++ {
+		if err := pub.Publish(&std_msgs_msg.String{Data: fmt.Sprint(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(500 * time.Millisecond)
+	spin(t, rclctx, sub.Subscription)
+	expectReceived(t, received, false, "2", "3", "4")
+
+	if err := pub.Publish(&std_msgs_msg.String{Data: "5"}); err != nil {
+		t.Fatal(err)
+	}
+	expectReceived(t, received, false, "5")
+}
+
+func TestIntraProcessPublishTakeMessage(t *testing.T) {
+	opts := jazzy.NewDefaultContextOptions()
+	opts.IntraProcess = true
+	rclctx := newTestContext(t, opts)
+	node, err := rclctx.NewNode("test_intra_process", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := testTopic(t)
+	sub, received := newStringReceiver(t, node, topic, nil)
+	pub, err := std_msgs_msg.NewStringPublisher(node, topic, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transientLocalOpts := jazzy.NewDefaultPublisherOptions()
+	transientLocalOpts.Qos.Durability = jazzy.DurabilityTransientLocal
+	transientLocalPub, err := std_msgs_msg.NewStringPublisher(node, topic, transientLocalOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForSubscriptions(t, pub.Publisher, 1)
+	waitForSubscriptions(t, transientLocalPub.Publisher, 1)
+	spin(t, rclctx, sub.Subscription)
+
+	// The copy of the message received through the middleware is dropped.
+	if err := pub.Publish(&std_msgs_msg.String{Data: "intra"}); err != nil {
+		t.Fatal(err)
+	}
+	expectReceived(t, received, true, "intra")
+
+	// Transient local publishers publish through the middleware only.
+	if err := transientLocalPub.Publish(&std_msgs_msg.String{Data: "transient local"}); err != nil {
+		t.Fatal(err)
+	}
+	expectReceived(t, received, false, "transient local")
+}
+
+func BenchmarkMessageBuffers(b *testing.B) {
+	ts := std_msgs_msg.StringTypeSupport
+	msg := &std_msgs_msg.String{Data: strings.Repeat("x", 1024)}
+	b.Run("PrepareMemory", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := ts.PrepareMemory()
+			ts.AsCStruct(buf, msg)
+			ts.ReleaseMemory(buf)
+		}
+	})
+	b.Run("Pool", func(b *testing.B) {
+		p := jazzy.NewMessageBufferPool(ts)
+		defer p.Close()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := p.Get()
+			ts.AsCStruct(buf, msg)
+			p.Put(buf)
+		}
+	})
+}
+
+func BenchmarkPublishTakeMessage(b *testing.B) {
+	rclctx := newTestContext(b, nil)
+	node, err := rclctx.NewNode("benchmark_publish_take", "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	topic := testTopic(b)
+	received := make(chan struct{}, 1)
+	sub, err := std_msgs_msg.NewStringSubscription(node, topic, nil, func(msg *std_msgs_msg.String, _ *jazzy.MessageInfo, err error) {
+		if err != nil {
+			b.Error(err)
+		}
+		received <- struct{}{}
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	pub, err := std_msgs_msg.NewStringPublisher(node, topic, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	waitForSubscriptions(b, pub.Publisher, 1)
+	spin(b, rclctx, sub.Subscription)
+
+	msg := &std_msgs_msg.String{Data: strings.Repeat("x", 1024)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := pub.Publish(msg); err != nil {
+			b.Fatal(err)
+		}
+		<-received
+	}
+}
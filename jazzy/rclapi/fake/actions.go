@@ -0,0 +1,387 @@
+package fake
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy/rclapi"
+)
+
+// ActionHandler executes a goal. sendFeedback sends a feedback message, whose
+// type support is ActionTypeSupport.Feedback(), to the clients of the action.
+// ctx is canceled when a client requests the goal to be canceled.
+//
+// If ActionHandler returns a nil error, the goal succeeds with the returned
+// result. If the goal was canceled, it ends as canceled, and otherwise it is
+// aborted.
+type ActionHandler func(ctx context.Context, goal rclapi.Message, sendFeedback func(rclapi.Message)) (rclapi.Message, error)
+
+type action struct {
+	server  *ActionServer
+	clients []*ActionClient
+}
+
+// action returns the action with name, creating it if it does not exist. b.mu
+// must be held.
+func (b *Bus) action(name string) *action {
+	a := b.actions[name]
+	if a == nil {
+		a = &action{}
+		b.actions[name] = a
+	}
+	return a
+}
+
+// removeActionIfUnused removes the action with name if it has no server and
+// no clients. b.mu must be held.
+func (b *Bus) removeActionIfUnused(name string) {
+	if a := b.actions[name]; a != nil && a.server == nil && len(a.clients) == 0 {
+		delete(b.actions, name)
+	}
+}
+
+// ActionServer is an action server of a fake node.
+type ActionServer struct {
+	node        *Node
+	name        string
+	typeSupport rclapi.ActionTypeSupport
+	handler     ActionHandler
+	once        closeOnce
+
+	mu    sync.Mutex
+	goals map[rclapi.GoalID]*goal
+	wg    sync.WaitGroup
+}
+
+type goal struct {
+	cancel   context.CancelFunc
+	canceled bool
+	done     chan struct{}
+	status   rclapi.GoalStatus
+	result   rclapi.Message
+}
+
+// NewActionServer creates an action server that executes goals using handler.
+// Only one server may exist for each action name.
+func (n *Node) NewActionServer(name string, ts rclapi.ActionTypeSupport, handler ActionHandler) (*ActionServer, error) {
+	fullName, err := n.expandName(name)
+	if err != nil {
+		return nil, err
+	}
+	s := &ActionServer{
+		node:        n,
+		name:        fullName,
+		typeSupport: ts,
+		handler:     handler,
+		goals:       map[rclapi.GoalID]*goal{},
+	}
+	b := n.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	a := b.action(fullName)
+	if a.server != nil {
+		return nil, fmt.Errorf("action server %s already exists", fullName)
+	}
+	if err := n.addEntity(s); err != nil {
+		b.removeActionIfUnused(fullName)
+		return nil, err
+	}
+	a.server = s
+	return s, nil
+}
+
+// Close cancels the goals being executed and waits for their handlers to
+// return.
+func (s *ActionServer) Close() error {
+	if err := s.once.close("action server"); err != nil {
+		return err
+	}
+	s.node.removeEntity(s)
+	b := s.node.bus
+	b.mu.Lock()
+	if a := b.actions[s.name]; a != nil && a.server == s {
+		a.server = nil
+		b.removeActionIfUnused(s.name)
+	}
+	b.mu.Unlock()
+	s.mu.Lock()
+	for _, g := range s.goals {
+		g.cancel()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return nil
+}
+
+// accept starts executing a goal.
+func (s *ActionServer) accept(id rclapi.GoalID, description rclapi.Message) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &goal{cancel: cancel, done: make(chan struct{}), status: rclapi.GoalExecuting}
+	s.mu.Lock()
+	s.goals[id] = g
+	s.mu.Unlock()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		result, err := s.handler(ctx, description, func(fb rclapi.Message) {
+			s.sendFeedback(&id, fb)
+		})
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case err == nil:
+			g.status = rclapi.GoalSucceeded
+		case g.canceled:
+			g.status = rclapi.GoalCanceled
+		default:
+			g.status = rclapi.GoalAborted
+		}
+		if result == nil {
+			result = s.typeSupport.Result().New()
+		}
+		g.result = result
+		close(g.done)
+	}()
+}
+
+func (s *ActionServer) sendFeedback(id *rclapi.GoalID, fb rclapi.Message) {
+	b := s.node.bus
+	b.mu.Lock()
+	var clients []*ActionClient
+	if a := b.actions[s.name]; a != nil {
+		clients = append(clients, a.clients...)
+	}
+	b.mu.Unlock()
+	msg := s.typeSupport.NewFeedbackMessage(id, fb)
+	for _, c := range clients {
+		c.handleFeedback(id, msg)
+	}
+}
+
+// cancelGoals requests the goal with id to be canceled. If id is the zero
+// GoalID, all goals are canceled.
+func (s *ActionServer) cancelGoals(id *rclapi.GoalID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for goalID, g := range s.goals {
+		if (*id == rclapi.GoalID{} || goalID == *id) && g.status == rclapi.GoalExecuting {
+			g.status = rclapi.GoalCanceling
+			g.canceled = true
+			g.cancel()
+		}
+	}
+}
+
+// result waits for the goal with id to finish and returns its status and
+// result.
+func (s *ActionServer) result(ctx context.Context, id *rclapi.GoalID) (rclapi.GoalStatus, rclapi.Message, error) {
+	s.mu.Lock()
+	g := s.goals[*id]
+	s.mu.Unlock()
+	if g == nil {
+		return rclapi.GoalUnknown, s.typeSupport.Result().New(), nil
+	}
+	select {
+	case <-g.done:
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return g.status, s.typeSupport.Result().Clone(g.result), nil
+}
+
+// ActionClient is an action client of a fake node.
+type ActionClient struct {
+	node        *Node
+	name        string
+	typeSupport rclapi.ActionTypeSupport
+	once        closeOnce
+
+	mu       sync.Mutex
+	watchers map[*feedbackWatcher]struct{}
+}
+
+var _ rclapi.ActionClient = (*ActionClient)(nil)
+
+type feedbackWatcher struct {
+	ctx     context.Context
+	goalID  *rclapi.GoalID
+	handler rclapi.FeedbackHandler
+}
+
+func (n *Node) NewActionClient(name string, ts rclapi.ActionTypeSupport, opts *rclapi.ActionClientOptions) (rclapi.ActionClient, error) {
+	fullName, err := n.expandName(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &ActionClient{
+		node:        n,
+		name:        fullName,
+		typeSupport: ts,
+		watchers:    map[*feedbackWatcher]struct{}{},
+	}
+	if err := n.addEntity(c); err != nil {
+		return nil, err
+	}
+	b := n.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	a := b.action(fullName)
+	a.clients = append(a.clients, c)
+	return c, nil
+}
+
+func (c *ActionClient) server() (*ActionServer, error) {
+	b := c.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if a := b.actions[c.name]; a != nil && a.server != nil {
+		return a.server, nil
+	}
+	return nil, fmt.Errorf("action %s: %w", c.name, ErrNoServer)
+}
+
+// SendGoal sends goal to the action server, which always accepts it. If there
+// is no server, an error wrapping ErrNoServer is returned.
+func (c *ActionClient) SendGoal(ctx context.Context, goal rclapi.Message) (rclapi.Message, *rclapi.GoalID, error) {
+	goalID, err := newGoalID()
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.sendGoal(ctx, goalID, goal)
+}
+
+func newGoalID() (*rclapi.GoalID, error) {
+	var goalID rclapi.GoalID
+	if _, err := rand.Read(goalID[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate goal ID: %v", err)
+	}
+	return &goalID, nil
+}
+
+func (c *ActionClient) sendGoal(ctx context.Context, goalID *rclapi.GoalID, goal rclapi.Message) (rclapi.Message, *rclapi.GoalID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, goalID, err
+	}
+	s, err := c.server()
+	if err != nil {
+		return nil, goalID, err
+	}
+	s.accept(*goalID, c.typeSupport.Goal().Clone(goal))
+	stamp := time.Duration(c.node.bus.clock.Now().UnixNano())
+	return c.typeSupport.NewSendGoalResponse(true, stamp), goalID, nil
+}
+
+// WatchGoal sends goal to the server and waits for its result like
+// jazzy.ActionClient.WatchGoal. If ctx is canceled, the goal is canceled.
+func (c *ActionClient) WatchGoal(ctx context.Context, goal rclapi.Message, onFeedback rclapi.FeedbackHandler) (result rclapi.Message, goalID *rclapi.GoalID, retErr error) {
+	goalID, err := newGoalID()
+	if err != nil {
+		return nil, nil, err
+	}
+	if onFeedback != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		c.WatchFeedback(ctx, goalID, onFeedback)
+	}
+	if _, _, err := c.sendGoal(ctx, goalID, goal); err != nil {
+		return nil, goalID, err
+	}
+	defer func() {
+		if ctx.Err() != nil {
+			if _, err := c.CancelGoal(context.Background(), goalID); err != nil { //nolint:contextcheck
+				retErr = errors.Join(err, retErr)
+			}
+		}
+	}()
+	result, err = c.GetResult(ctx, goalID)
+	return result, goalID, err
+}
+
+// GetResult waits for the goal with goalID to finish and returns its result.
+// The status of an unknown goal is rclapi.GoalUnknown.
+func (c *ActionClient) GetResult(ctx context.Context, goalID *rclapi.GoalID) (rclapi.Message, error) {
+	s, err := c.server()
+	if err != nil {
+		return nil, err
+	}
+	status, result, err := s.result(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+	return c.typeSupport.NewGetResultResponse(status, result), nil
+}
+
+// CancelGoal cancels the goal with goalID, or all goals if goalID is zero. The
+// returned response does not list the goals being canceled.
+func (c *ActionClient) CancelGoal(ctx context.Context, goalID *rclapi.GoalID) (rclapi.Message, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, err := c.server()
+	if err != nil {
+		return nil, err
+	}
+	s.cancelGoals(goalID)
+	return c.typeSupport.CancelGoal().Response().New(), nil
+}
+
+// WatchFeedback calls handler for every feedback message for the goal with
+// goalID, or for all goals if goalID is nil, until ctx is canceled.
+func (c *ActionClient) WatchFeedback(ctx context.Context, goalID *rclapi.GoalID, handler rclapi.FeedbackHandler) <-chan error {
+	w := &feedbackWatcher{ctx: ctx, handler: handler}
+	if goalID != nil {
+		id := *goalID
+		w.goalID = &id
+	}
+	c.mu.Lock()
+	c.watchers[w] = struct{}{}
+	c.mu.Unlock()
+	errc := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		delete(c.watchers, w)
+		c.mu.Unlock()
+		errc <- ctx.Err()
+		close(errc)
+	}()
+	return errc
+}
+
+func (c *ActionClient) handleFeedback(id *rclapi.GoalID, msg rclapi.Message) {
+	c.mu.Lock()
+	var watchers []*feedbackWatcher
+	for w := range c.watchers {
+		if w.ctx.Err() == nil && (w.goalID == nil || *w.goalID == *id) {
+			watchers = append(watchers, w)
+		}
+	}
+	c.mu.Unlock()
+	for _, w := range watchers {
+		w.handler(w.ctx, c.typeSupport.FeedbackMessage().Clone(msg))
+	}
+}
+
+func (c *ActionClient) Close() error {
+	if err := c.once.close("action client"); err != nil {
+		return err
+	}
+	c.node.removeEntity(c)
+	b := c.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if a := b.actions[c.name]; a != nil {
+		a.clients = slices.DeleteFunc(a.clients, func(x *ActionClient) bool { return x == c })
+		b.removeActionIfUnused(c.name)
+	}
+	return nil
+}
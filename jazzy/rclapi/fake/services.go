@@ -0,0 +1,142 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/okieraised/rclgo/jazzy/rclapi"
+)
+
+// Service is a service of a fake node.
+type Service struct {
+	node        *Node
+	name        string
+	typeSupport rclapi.ServiceTypeSupport
+	reqType     reflect.Type
+	handler     rclapi.ServiceRequestHandler
+	once        closeOnce
+}
+
+var _ rclapi.Service = (*Service)(nil)
+
+func (n *Node) NewService(name string, ts rclapi.ServiceTypeSupport, opts *rclapi.ServiceOptions, handler rclapi.ServiceRequestHandler) (rclapi.Service, error) {
+	fullName, err := n.expandName(name)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{node: n, name: fullName, typeSupport: ts, reqType: reflect.TypeOf(ts.Request().New()), handler: handler}
+	b := n.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.services[fullName] != nil {
+		return nil, fmt.Errorf("service %s already exists", fullName)
+	}
+	if err := n.addEntity(s); err != nil {
+		return nil, err
+	}
+	b.services[fullName] = s
+	return s, nil
+}
+
+func (s *Service) Close() error {
+	if err := s.once.close("service"); err != nil {
+		return err
+	}
+	s.node.removeEntity(s)
+	b := s.node.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.services[s.name] == s {
+		delete(b.services, s.name)
+	}
+	return nil
+}
+
+// Client is a service client of a fake node.
+type Client struct {
+	node     *Node
+	name     string
+	once     closeOnce
+	validate bool
+
+	mu     sync.Mutex
+	seqNum int64
+}
+
+var _ rclapi.Client = (*Client)(nil)
+
+func (n *Node) NewClient(name string, ts rclapi.ServiceTypeSupport, opts *rclapi.ClientOptions) (rclapi.Client, error) {
+	fullName, err := n.expandName(name)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{node: n, name: fullName, validate: opts != nil && opts.Validate}
+	if err := n.addEntity(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Send calls the handler of the service with a clone of req and waits until
+// the handler sends a response or ctx is done. If the service does not exist,
+// an error wrapping ErrNoServer is returned.
+func (c *Client) Send(ctx context.Context, req rclapi.Message) (rclapi.Message, *rclapi.ServiceInfo, error) {
+	if c.validate {
+		if err := validate(req); err != nil {
+			return nil, nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+	b := c.node.bus
+	b.mu.Lock()
+	s := b.services[c.name]
+	b.mu.Unlock()
+	if s == nil {
+		return nil, nil, fmt.Errorf("service %s: %w", c.name, ErrNoServer)
+	}
+	if typ := reflect.TypeOf(req); typ != s.reqType {
+		return nil, nil, fmt.Errorf("service %s has request type %v, not %v", c.name, s.reqType, typ)
+	}
+	c.mu.Lock()
+	c.seqNum++
+	info := &rclapi.ServiceInfo{RequestID: rclapi.RequestID{SequenceNumber: c.seqNum}}
+	c.mu.Unlock()
+	info.SourceTimestamp = b.clock.Now()
+	info.ReceivedTimestamp = info.SourceTimestamp
+	respc := make(chan rclapi.Message, 1)
+	s.handler(info, s.typeSupport.Request().Clone(req), &responseSender{typeSupport: s.typeSupport.Response(), respc: respc})
+	select {
+	case resp := <-respc:
+		respInfo := *info
+		respInfo.SourceTimestamp = b.clock.Now()
+		respInfo.ReceivedTimestamp = respInfo.SourceTimestamp
+		return resp, &respInfo, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+func (c *Client) Close() error {
+	if err := c.once.close("client"); err != nil {
+		return err
+	}
+	c.node.removeEntity(c)
+	return nil
+}
+
+// responseSender passes a clone of the first response sent to it to respc.
+type responseSender struct {
+	typeSupport rclapi.MessageTypeSupport
+	respc       chan rclapi.Message
+}
+
+func (s *responseSender) SendResponse(resp rclapi.Message) error {
+	select {
+	case s.respc <- s.typeSupport.Clone(resp):
+		return nil
+	default:
+		return errors.New("a response has already been sent")
+	}
+}
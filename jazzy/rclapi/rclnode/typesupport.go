@@ -0,0 +1,105 @@
+package rclnode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/rclapi"
+)
+
+// MessageTypeSupport adapts ts to rclapi.MessageTypeSupport.
+func MessageTypeSupport(ts jazzy.MessageTypeSupport) rclapi.MessageTypeSupport {
+	return messageTypeSupport{ts}
+}
+
+// ServiceTypeSupport adapts ts to rclapi.ServiceTypeSupport.
+func ServiceTypeSupport(ts jazzy.ServiceTypeSupport) rclapi.ServiceTypeSupport {
+	return serviceTypeSupport{ts}
+}
+
+// ActionTypeSupport adapts ts to rclapi.ActionTypeSupport.
+func ActionTypeSupport(ts jazzy.ActionTypeSupport) rclapi.ActionTypeSupport {
+	return actionTypeSupport{ts}
+}
+
+type messageTypeSupport struct {
+	ts jazzy.MessageTypeSupport
+}
+
+func (t messageTypeSupport) New() rclapi.Message {
+	return t.ts.New()
+}
+
+func (t messageTypeSupport) Clone(msg rclapi.Message) rclapi.Message {
+	return msg.(jazzy.Message).CloneMsg()
+}
+
+type serviceTypeSupport struct {
+	ts jazzy.ServiceTypeSupport
+}
+
+func (t serviceTypeSupport) Request() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.Request()}
+}
+
+func (t serviceTypeSupport) Response() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.Response()}
+}
+
+type actionTypeSupport struct {
+	ts jazzy.ActionTypeSupport
+}
+
+func (t actionTypeSupport) Goal() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.Goal()}
+}
+
+func (t actionTypeSupport) Result() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.Result()}
+}
+
+func (t actionTypeSupport) Feedback() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.Feedback()}
+}
+
+func (t actionTypeSupport) FeedbackMessage() rclapi.MessageTypeSupport {
+	return messageTypeSupport{t.ts.FeedbackMessage()}
+}
+
+func (t actionTypeSupport) CancelGoal() rclapi.ServiceTypeSupport {
+	return serviceTypeSupport{t.ts.CancelGoal()}
+}
+
+func (t actionTypeSupport) NewSendGoalResponse(accepted bool, stamp time.Duration) rclapi.Message {
+	return t.ts.NewSendGoalResponse(accepted, stamp)
+}
+
+func (t actionTypeSupport) NewGetResultResponse(status rclapi.GoalStatus, result rclapi.Message) rclapi.Message {
+	return t.ts.NewGetResultResponse(int8(status), result.(jazzy.Message))
+}
+
+func (t actionTypeSupport) NewFeedbackMessage(goalID *rclapi.GoalID, feedback rclapi.Message) rclapi.Message {
+	return t.ts.NewFeedbackMessage((*jazzy.GoalID)(goalID), feedback.(jazzy.Message))
+}
+
+func unwrapMessageTypeSupport(ts rclapi.MessageTypeSupport) (jazzy.MessageTypeSupport, error) {
+	if t, ok := ts.(messageTypeSupport); ok {
+		return t.ts, nil
+	}
+	return nil, fmt.Errorf("type support %T was not created by rclnode.MessageTypeSupport", ts)
+}
+
+func unwrapServiceTypeSupport(ts rclapi.ServiceTypeSupport) (jazzy.ServiceTypeSupport, error) {
+	if t, ok := ts.(serviceTypeSupport); ok {
+		return t.ts, nil
+	}
+	return nil, fmt.Errorf("type support %T was not created by rclnode.ServiceTypeSupport", ts)
+}
+
+func unwrapActionTypeSupport(ts rclapi.ActionTypeSupport) (jazzy.ActionTypeSupport, error) {
+	if t, ok := ts.(actionTypeSupport); ok {
+		return t.ts, nil
+	}
+	return nil, fmt.Errorf("type support %T was not created by rclnode.ActionTypeSupport", ts)
+}
@@ -0,0 +1,98 @@
+package rosbridge
+
+import "encoding/json"
+
+// operation is a rosbridge v2 protocol message sent by a client. Fields that
+// are not used by an operation are left empty.
+type operation struct {
+	Op string `json:"op"`
+	// ID is echoed back in responses. Clients may use any JSON value.
+	ID json.RawMessage `json:"id,omitempty"`
+
+	// advertise, unadvertise, publish, subscribe and unsubscribe
+	Topic        string          `json:"topic"`
+	Type         string          `json:"type"`
+	Msg          json.RawMessage `json:"msg"`
+	Latch        bool            `json:"latch"`
+	QueueSize    int             `json:"queue_size"`
+	ThrottleRate int             `json:"throttle_rate"` // milliseconds
+	QueueLength  int             `json:"queue_length"`
+	Compression  string          `json:"compression"`
+
+	// call_service, advertise_service, unadvertise_service and
+	// service_response
+	Service string          `json:"service"`
+	Args    json.RawMessage `json:"args"`
+	Timeout float64         `json:"timeout"` // seconds
+	Values  json.RawMessage `json:"values"`
+	Result  *bool           `json:"result"`
+
+	// send_action_goal and cancel_action_goal
+	Action     string `json:"action"`
+	ActionType string `json:"action_type"`
+	Feedback   bool   `json:"feedback"`
+
+	// set_level
+	Level string `json:"level"`
+}
+
+// Operations sent by the server.
+
+type publishOp struct {
+	Op    string          `json:"op"`
+	Topic string          `json:"topic"`
+	Msg   json.RawMessage `json:"msg"`
+}
+
+type serviceResponseOp struct {
+	Op      string          `json:"op"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Service string          `json:"service"`
+	Values  any             `json:"values"`
+	Result  bool            `json:"result"`
+}
+
+type callServiceOp struct {
+	Op      string          `json:"op"`
+	ID      string          `json:"id"`
+	Service string          `json:"service"`
+	Args    json.RawMessage `json:"args"`
+}
+
+type actionFeedbackOp struct {
+	Op     string          `json:"op"`
+	ID     json.RawMessage `json:"id,omitempty"`
+	Action string          `json:"action"`
+	Values json.RawMessage `json:"values"`
+}
+
+type actionResultOp struct {
+	Op     string          `json:"op"`
+	ID     json.RawMessage `json:"id,omitempty"`
+	Action string          `json:"action"`
+	Values any             `json:"values"`
+	Status int8            `json:"status"`
+	Result bool            `json:"result"`
+}
+
+type statusOp struct {
+	Op    string          `json:"op"`
+	ID    json.RawMessage `json:"id,omitempty"`
+	Level string          `json:"level"`
+	Msg   string          `json:"msg"`
+}
+
+// Status levels in increasing order of severity.
+const (
+	levelInfo    = "info"
+	levelWarning = "warning"
+	levelError   = "error"
+	levelNone    = "none"
+)
+
+var statusLevels = map[string]int{
+	levelInfo:    0,
+	levelWarning: 1,
+	levelError:   2,
+	levelNone:    3,
+}
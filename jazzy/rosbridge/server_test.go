@@ -0,0 +1,369 @@
+package rosbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/okieraised/rclgo/jazzy"
+	std_msgs_msg "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_msgs/msg"
+	std_srvs_srv "github.com/okieraised/rclgo/jazzy/internal/testmsgs/std_srvs/srv"
+)
+
+func dialTestServer(t *testing.T) *websocket.Conn {
+	t.Helper()
+	return dial(t, newServer(nil, ServerOptions{}))
+}
+
+// dial serves server over HTTP and connects a WebSocket client to it.
+func dial(t *testing.T, server *Server) *websocket.Conn {
+	t.Helper()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		_ = server.Close()
+		httpServer.Close()
+	})
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func readStatus(t *testing.T, conn *websocket.Conn) statusOp {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var status statusOp
+	if err := conn.ReadJSON(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Op != "status" {
+		t.Fatalf("want a status operation, got %+v", status)
+	}
+	return status
+}
+
+func TestServerStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantID  string
+		wantMsg string
+	}{
+		{
+			name:    "Invalid JSON",
+			input:   `{"op":`,
+			wantMsg: "invalid message",
+		},
+		{
+			name:    "Unsupported operation",
+			input:   `{"op": "fragment", "id": "f1"}`,
+			wantID:  `"f1"`,
+			wantMsg: `unsupported operation "fragment"`,
+		},
+		{
+			name:    "Missing topic",
+			input:   `{"op": "subscribe", "id": 7}`,
+			wantID:  `7`,
+			wantMsg: "subscribe: topic is required",
+		},
+		{
+			name:    "Unsupported compression",
+			input:   `{"op": "subscribe", "topic": "/a", "compression": "png"}`,
+			wantMsg: `unsupported compression "png"`,
+		},
+		{
+			name:    "Unknown pending call",
+			input:   `{"op": "service_response", "id": "service_request:/s:1", "values": {}}`,
+			wantID:  `"service_request:/s:1"`,
+			wantMsg: `no pending call with ID "service_request:/s:1"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := dialTestServer(t)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(tc.input)); err != nil {
+				t.Fatal(err)
+			}
+			status := readStatus(t, conn)
+			if status.Level != levelError {
+				t.Errorf("want level %q, got %q", levelError, status.Level)
+			}
+			if string(status.ID) != tc.wantID {
+				t.Errorf("want ID %s, got %s", tc.wantID, status.ID)
+			}
+			if !strings.Contains(status.Msg, tc.wantMsg) {
+				t.Errorf("want message containing %q, got %q", tc.wantMsg, status.Msg)
+			}
+		})
+	}
+}
+
+func TestServerSetLevel(t *testing.T) {
+	conn := dialTestServer(t)
+	for _, msg := range []string{
+		`{"op": "set_level", "level": "none"}`,
+		`{"op": "bogus"}`,
+		`{"op": "set_level", "level": "error"}`,
+		`{"op": "bogus", "id": "after"}`,
+	} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if status := readStatus(t, conn); string(status.ID) != `"after"` {
+		t.Fatalf("status must not be sent while the level is none, got %+v", status)
+	}
+}
+
+// newGraph returns a node of a new Context and a server whose node belongs to
+// the same Context, so that the tests can use the server through a local ROS
+// graph. The test is skipped if rcl cannot be initialized.
+func newGraph(t *testing.T) (*jazzy.Node, *Server) {
+	t.Helper()
+	rclctx, err := jazzy.NewContextWithOpts(nil, nil)
+	if err != nil {
+		t.Skipf("failed to initialize rcl: %v", err)
+	}
+	t.Cleanup(func() { _ = rclctx.Close() })
+	node, err := rclctx.NewNode("rosbridge_test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewServer(rclctx, &ServerOptions{NodeName: "rosbridge_test_server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node, server
+}
+
+var nonNameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// testName returns a topic or service name unique to the test and the process.
+func testName(t *testing.T) string {
+	return fmt.Sprintf("/rosbridge_test_%d/%s", os.Getpid(), nonNameChars.ReplaceAllString(t.Name(), "_"))
+}
+
+// spin runs a wait set with the entities added by add until the end of the
+// test.
+func spin(t *testing.T, node *jazzy.Node, add func(ws *jazzy.WaitSet)) {
+	t.Helper()
+	ws, err := node.Context().NewWaitSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	add(ws)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ws.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			t.Error(err)
+		}
+		_ = ws.Close()
+	})
+}
+
+// serverOp is an operation sent by the server.
+type serverOp struct {
+	Op      string          `json:"op"`
+	ID      json.RawMessage `json:"id"`
+	Topic   string          `json:"topic"`
+	Service string          `json:"service"`
+	Msg     json.RawMessage `json:"msg"`
+	Args    json.RawMessage `json:"args"`
+	Values  json.RawMessage `json:"values"`
+	Result  bool            `json:"result"`
+}
+
+func writeOp(t *testing.T, conn *websocket.Conn, op string) {
+	t.Helper()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(op)); err != nil {
+		t.Error(err)
+	}
+}
+
+// readOp reads operations from conn until it reads an operation of type op.
+// Error statuses fail the test.
+func readOp(t *testing.T, conn *websocket.Conn, op string) serverOp {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var got serverOp
+		if err := conn.ReadJSON(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Op == op {
+			return got
+		}
+		if got.Op == "status" {
+			t.Fatalf("unexpected status %s", got.Msg)
+		}
+	}
+}
+
+// repeat calls f every 100 ms until the end of the test, as messages published
+// before the endpoints of a topic are matched are lost.
+func repeat(t *testing.T, f func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			f()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestServerSubscribe(t *testing.T) {
+	node, server := newGraph(t)
+	conn := dial(t, server)
+	topic := testName(t)
+	pub, err := std_msgs_msg.NewStringPublisher(node, topic, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeOp(t, conn, fmt.Sprintf(`{"op": "subscribe", "topic": %q, "type": "std_msgs/msg/String"}`, topic))
+	repeat(t, func() {
+		if err := pub.Publish(&std_msgs_msg.String{Data: "from ROS"}); err != nil {
+			t.Error(err)
+		}
+	})
+	got := readOp(t, conn, "publish")
+	if got.Topic != topic {
+		t.Errorf("want topic %s, got %s", topic, got.Topic)
+	}
+	if want := `{"data":"from ROS"}`; string(got.Msg) != want {
+		t.Errorf("want message %s, got %s", want, got.Msg)
+	}
+}
+
+func TestServerPublish(t *testing.T) {
+	node, server := newGraph(t)
+	conn := dial(t, server)
+	topic := testName(t)
+	received := make(chan string, 1)
+	sub, err := std_msgs_msg.NewStringSubscription(node, topic, nil, func(msg *std_msgs_msg.String, _ *jazzy.MessageInfo, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		select {
+		case received <- msg.Data:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spin(t, node, func(ws *jazzy.WaitSet) { ws.AddSubscriptions(sub.Subscription) })
+	writeOp(t, conn, fmt.Sprintf(`{"op": "advertise", "topic": %q, "type": "std_msgs/msg/String"}`, topic))
+	repeat(t, func() {
+		writeOp(t, conn, fmt.Sprintf(`{"op": "publish", "topic": %q, "msg": {"data": "from client"}}`, topic))
+	})
+	select {
+	case got := <-received:
+		if got != "from client" {
+			t.Errorf("want %q, got %q", "from client", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the message of the client")
+	}
+}
+
+func TestServerCallService(t *testing.T) {
+	node, server := newGraph(t)
+	conn := dial(t, server)
+	service := testName(t)
+	svc, err := std_srvs_srv.NewSetBoolService(node, service, nil, func(_ *jazzy.ServiceInfo, req *std_srvs_srv.SetBool_Request, sender std_srvs_srv.SetBoolServiceResponseSender) {
+		if err := sender.SendResponse(&std_srvs_srv.SetBool_Response{Success: req.Data, Message: "handled"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spin(t, node, func(ws *jazzy.WaitSet) { ws.AddServices(svc.Service) })
+	// Requests sent before the client is matched to the service are lost, so
+	// they are sent with a timeout until one of them succeeds.
+	for i := 0; ; i++ {
+		writeOp(t, conn, fmt.Sprintf(`{"op": "call_service", "id": %d, "service": %q, "type": "std_srvs/srv/SetBool", "args": {"data": true}, "timeout": 0.5}`, i, service))
+		got := readOp(t, conn, "service_response")
+		if got.Result {
+			if want := `{"success":true,"message":"handled"}`; string(got.Values) != want {
+				t.Errorf("want values %s, got %s", want, got.Values)
+			}
+			return
+		}
+		if i == 20 {
+			t.Fatalf("call failed: %s", got.Values)
+		}
+	}
+}
+
+func TestServerAdvertiseService(t *testing.T) {
+	node, server := newGraph(t)
+	conn := dial(t, server)
+	service := testName(t)
+	client, err := std_srvs_srv.NewSetBoolClient(node, service, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spin(t, node, func(ws *jazzy.WaitSet) { ws.AddClients(client.Client) })
+	writeOp(t, conn, fmt.Sprintf(`{"op": "advertise_service", "service": %q, "type": "std_srvs/srv/SetBool"}`, service))
+
+	responses := make(chan *std_srvs_srv.SetBool_Response, 1)
+	go func() {
+		defer close(responses)
+		for i := 0; i < 20; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			resp, _, err := client.Send(ctx, &std_srvs_srv.SetBool_Request{Data: true})
+			cancel()
+			if err == nil {
+				responses <- resp
+				return
+			}
+		}
+	}()
+	// Answer the requests until the client receives a response, as some of
+	// them may have timed out already.
+	for {
+		call := readOp(t, conn, "call_service")
+		if want := `{"data":true}`; string(call.Args) != want {
+			t.Errorf("want args %s, got %s", want, call.Args)
+		}
+		writeOp(t, conn, fmt.Sprintf(`{"op": "service_response", "id": %s, "service": %q, "values": {"success": true, "message": "from client"}, "result": true}`, call.ID, service))
+		select {
+		case resp, ok := <-responses:
+			if !ok {
+				t.Fatal("no response received")
+			}
+			if !resp.Success || resp.Message != "from client" {
+				t.Errorf("want the response of the client, got %+v", resp)
+			}
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
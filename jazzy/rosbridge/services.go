@@ -0,0 +1,257 @@
+package rosbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/okieraised/rclgo/jazzy"
+	"github.com/okieraised/rclgo/jazzy/msgcodec"
+)
+
+type client struct {
+	client *jazzy.Client
+	typ    string
+	ts     jazzy.ServiceTypeSupport
+	waiter *waiter
+}
+
+// callService calls a ROS service and sends the response to the client as a
+// service_response operation. The call runs in the background so that slow
+// services do not block other operations of the client.
+func (s *session) callService(op *operation) error {
+	if op.Service == "" {
+		return errors.New("service is required")
+	}
+	c, err := s.serviceClient(op.Service, op.Type)
+	if err != nil {
+		return err
+	}
+	req := c.ts.Request().New()
+	if err := unmarshalArgs(op.Args, req); err != nil {
+		return fmt.Errorf("invalid %s request: %w", c.typ, err)
+	}
+	s.goTask(func() {
+		ctx := s.ctx
+		if op.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(op.Timeout*float64(time.Second)))
+			defer cancel()
+		}
+		resp := serviceResponseOp{Op: "service_response", ID: op.ID, Service: op.Service}
+		msg, _, err := c.client.Send(ctx, req)
+		if err == nil {
+			resp.Values, err = msgcodec.MarshalJSON(msg)
+		}
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			resp.Values = err.Error()
+		} else {
+			resp.Result = true
+		}
+		_ = s.send(resp)
+	})
+	return nil
+}
+
+// unmarshalArgs decodes service call arguments. Some clients send an empty
+// list instead of an empty object for requests without fields.
+func unmarshalArgs(args []byte, msg jazzy.Message) error {
+	if string(args) == "[]" {
+		return nil
+	}
+	return msgcodec.UnmarshalJSON(args, msg)
+}
+
+func (s *session) serviceClient(service, typ string) (*client, error) {
+	s.mu.Lock()
+	c := s.clients[service]
+	s.mu.Unlock()
+	if c != nil {
+		if typ == "" || sameType(c.typ, typ) {
+			return c, nil
+		}
+		// The service has been replaced by a service of another type.
+		if err := s.removeClient(service, c); err != nil {
+			return nil, err
+		}
+	}
+	if typ == "" {
+		var err error
+		if typ, err = s.serviceType(service); err != nil {
+			return nil, err
+		}
+	}
+	ts, ok := jazzy.GetService(typ)
+	if !ok {
+		return nil, fmt.Errorf("type support for %s is not registered", typ)
+	}
+	rc, err := s.server.node.NewClient(service, ts, nil)
+	if err != nil {
+		return nil, err
+	}
+	c = &client{client: rc, typ: typ, ts: ts}
+	if c.waiter, err = s.startWaiter(func(ws *jazzy.WaitSet) { ws.AddClients(rc) }); err != nil {
+		return nil, errors.Join(err, rc.Close())
+	}
+	s.mu.Lock()
+	s.clients[service] = c
+	s.mu.Unlock()
+	return c, nil
+}
+
+// removeClient stops waiting for the responses of c and closes it. Calls in
+// progress fail.
+func (s *session) removeClient(service string, c *client) error {
+	s.mu.Lock()
+	if s.clients[service] == c {
+		delete(s.clients, service)
+	}
+	s.mu.Unlock()
+	s.stopWaiter(c.waiter)
+	return c.client.Close()
+}
+
+// serviceType returns the type of service in the ROS graph.
+func (s *session) serviceType(service string) (string, error) {
+	names, namespaces, err := s.server.node.GetNodeNames()
+	if err != nil {
+		return "", err
+	}
+	found := map[string]bool{}
+	for i := range names {
+		services, err := s.server.node.GetServiceNamesAndTypesByNode(names[i], namespaces[i])
+		if err != nil {
+			return "", err
+		}
+		for _, typ := range services[service] {
+			found[typ] = true
+		}
+	}
+	if len(found) != 1 {
+		return "", fmt.Errorf("type of service %s is unknown or ambiguous, set it explicitly", service)
+	}
+	for typ := range found {
+		return typ, nil
+	}
+	panic("unreachable")
+}
+
+// advertisedService is a ROS service whose requests are handled by the client.
+type advertisedService struct {
+	service *jazzy.Service
+	typ     string
+	ts      jazzy.ServiceTypeSupport
+	waiter  *waiter
+}
+
+// pendingCall is a request forwarded to the client that has not been
+// responded to yet.
+type pendingCall struct {
+	service *advertisedService
+	sender  jazzy.ServiceResponseSender
+}
+
+func (s *session) advertiseService(op *operation) error {
+	if op.Service == "" {
+		return errors.New("service is required")
+	}
+	if op.Type == "" {
+		return errors.New("type is required")
+	}
+	s.mu.Lock()
+	_, exists := s.services[op.Service]
+	s.mu.Unlock()
+	if exists {
+		return fmt.Errorf("service %s is already advertised", op.Service)
+	}
+	ts, ok := jazzy.GetService(op.Type)
+	if !ok {
+		return fmt.Errorf("type support for %s is not registered", op.Type)
+	}
+	svc := &advertisedService{typ: op.Type, ts: ts}
+	name := op.Service
+	var err error
+	svc.service, err = s.server.node.NewService(name, ts, nil,
+		func(_ *jazzy.ServiceInfo, req jazzy.Message, sender jazzy.ServiceResponseSender) {
+			s.forwardRequest(name, svc, req, sender)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	svc.waiter, err = s.startWaiter(func(ws *jazzy.WaitSet) { ws.AddServices(svc.service) })
+	if err != nil {
+		return errors.Join(err, svc.service.Close())
+	}
+	s.mu.Lock()
+	s.services[name] = svc
+	s.mu.Unlock()
+	return nil
+}
+
+// forwardRequest sends a request received by an advertised service to the
+// client as a call_service operation. The response is sent once the client
+// replies with a service_response operation.
+func (s *session) forwardRequest(name string, svc *advertisedService, req jazzy.Message, sender jazzy.ServiceResponseSender) {
+	args, err := msgcodec.MarshalJSON(req)
+	if err != nil {
+		s.server.logf("failed to encode request to %s: %v", name, err)
+		return
+	}
+	s.mu.Lock()
+	s.nextCallID++
+	id := "service_request:" + name + ":" + strconv.FormatUint(s.nextCallID, 10)
+	s.pendingCalls[id] = &pendingCall{service: svc, sender: sender}
+	s.mu.Unlock()
+	if err := s.send(callServiceOp{Op: "call_service", ID: id, Service: name, Args: args}); err != nil {
+		s.mu.Lock()
+		delete(s.pendingCalls, id)
+		s.mu.Unlock()
+	}
+}
+
+func (s *session) serviceResponse(op *operation) error {
+	var id string
+	if err := unmarshalID(op.ID, &id); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	call := s.pendingCalls[id]
+	delete(s.pendingCalls, id)
+	s.mu.Unlock()
+	if call == nil {
+		return fmt.Errorf("no pending call with ID %q", id)
+	}
+	if op.Result != nil && !*op.Result {
+		// ROS services cannot report failures, so the caller will not
+		// receive a response.
+		return fmt.Errorf("client failed to handle call %q", id)
+	}
+	resp := call.service.ts.Response().New()
+	if err := unmarshalArgs(op.Values, resp); err != nil {
+		return fmt.Errorf("invalid %s response: %w", call.service.typ, err)
+	}
+	return call.sender.SendResponse(resp)
+}
+
+func (s *session) unadvertiseService(op *operation) error {
+	s.mu.Lock()
+	svc := s.services[op.Service]
+	delete(s.services, op.Service)
+	for id, call := range s.pendingCalls {
+		if call.service == svc {
+			delete(s.pendingCalls, id)
+		}
+	}
+	s.mu.Unlock()
+	if svc == nil {
+		return fmt.Errorf("service %s is not advertised", op.Service)
+	}
+	s.stopWaiter(svc.waiter)
+	return svc.service.Close()
+}
@@ -0,0 +1,15 @@
+package rosbridge
+
+import "github.com/okieraised/rclgo/jazzy"
+
+// sameType reports whether a and b name the same interface. Clients may omit
+// the interface type, e.g. "std_msgs/String" is the same as
+// "std_msgs/msg/String".
+func sameType(a, b string) bool {
+	pkgA, typA, ifaceA, errA := jazzy.SplitTypeName(a)
+	pkgB, typB, ifaceB, errB := jazzy.SplitTypeName(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return pkgA == pkgB && ifaceA == ifaceB && (typA == "" || typB == "" || typA == typB)
+}
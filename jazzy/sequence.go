@@ -0,0 +1,114 @@
+package jazzy
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// CSequence has the memory layout of the sequence types of rosidl_runtime_c,
+// e.g. rosidl_runtime_c__double__Sequence for CSequence[C.double]. Generated
+// code converts pointers to C sequences to pointers to CSequence to pass them
+// to the conversion helpers in this file.
+//
+// The helpers hold the conversion loops of primitives.gen.go and of generated
+// message packages. Generated packages keep their exported per-type functions,
+// e.g. PointSequenceToGo, as one-line wrappers because the packages of other
+// interfaces call them, so a message package is only about 5% smaller than
+// with the loops inlined.
+type CSequence[CT any] struct {
+	Data     *CT
+	Size     uintptr
+	Capacity uintptr
+}
+
+// SequenceToGo sets dst to a new slice of the elements of src converted by
+// conv. dst is left unchanged if src is empty.
+func SequenceToGo[T, CT any](dst *[]T, src *CSequence[CT], conv func(*T, *CT)) {
+	if src.Size == 0 {
+		return
+	}
+	*dst = make([]T, src.Size)
+	ArrayToGo(*dst, unsafe.Slice(src.Data, src.Size), conv)
+}
+
+// SequenceToC sets dst to the elements of src converted by conv. The elements
+// are allocated with malloc, so that dst can be finalized by rosidl_runtime_c.
+// dst is set to an empty sequence if src is empty.
+func SequenceToC[T, CT any](dst *CSequence[CT], src []T, conv func(*CT, *T)) {
+	if !allocSequence(dst, len(src)) {
+		return
+	}
+	ArrayToC(unsafe.Slice(dst.Data, dst.Size), src, conv)
+}
+
+// ArrayToGo sets the elements of dst to the elements of src converted by conv.
+func ArrayToGo[T, CT any](dst []T, src []CT, conv func(*T, *CT)) {
+	for i := range src {
+		conv(&dst[i], &src[i])
+	}
+}
+
+// ArrayToC sets the elements of dst to the elements of src converted by conv.
+func ArrayToC[T, CT any](dst []CT, src []T, conv func(*CT, *T)) {
+	for i := range src {
+		conv(&dst[i], &src[i])
+	}
+}
+
+// CopySequenceToGo is like SequenceToGo for primitive types T and CT with the
+// same memory layout, whose elements are copied as is.
+func CopySequenceToGo[T, CT any](dst *[]T, src *CSequence[CT]) {
+	if src.Size == 0 {
+		return
+	}
+	*dst = make([]T, src.Size)
+	CopyArrayToGo(*dst, unsafe.Slice(src.Data, src.Size))
+}
+
+// CopySequenceToC is like SequenceToC for primitive types T and CT with the
+// same memory layout, whose elements are copied as is.
+func CopySequenceToC[T, CT any](dst *CSequence[CT], src []T) {
+	if !allocSequence(dst, len(src)) {
+		return
+	}
+	CopyArrayToC(unsafe.Slice(dst.Data, dst.Size), src)
+}
+
+// CopyArrayToGo is like ArrayToGo for primitive types T and CT with the same
+// memory layout, whose elements are copied as is.
+func CopyArrayToGo[T, CT any](dst []T, src []CT) {
+	copy(dst, castSlice[T](src))
+}
+
+// CopyArrayToC is like ArrayToC for primitive types T and CT with the same
+// memory layout, whose elements are copied as is.
+func CopyArrayToC[T, CT any](dst []CT, src []T) {
+	copy(dst, castSlice[CT](src))
+}
+
+func castSlice[T, U any](s []U) []T {
+	var t T
+	var u U
+	if unsafe.Sizeof(t) != unsafe.Sizeof(u) {
+		panic("element types of different sizes")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&s[0])), len(s))
+}
+
+// allocSequence allocates n elements for seq and reports whether n > 0.
+func allocSequence[CT any](seq *CSequence[CT], n int) bool {
+	if n == 0 {
+		*seq = CSequence[CT]{}
+		return false
+	}
+	var elem CT
+	seq.Data = (*CT)(C.malloc(C.size_t(unsafe.Sizeof(elem) * uintptr(n))))
+	seq.Size = uintptr(n)
+	seq.Capacity = seq.Size
+	return true
+}
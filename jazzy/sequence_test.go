@@ -0,0 +1,113 @@
+package jazzy
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/okieraised/rclgo/jazzy/internal/cmem"
+)
+
+// freeSequence frees the elements of seq allocated by allocSequence.
+func freeSequence[CT any](seq *CSequence[CT]) {
+	cmem.Free(unsafe.Pointer(seq.Data))
+	*seq = CSequence[CT]{}
+}
+
+type seqPoint struct {
+	X, Y float64
+	Name string
+}
+
+// seqCPoint has a different layout than seqPoint like the C structs of
+// generated messages.
+type seqCPoint struct {
+	Y, X float64
+	N    int32
+}
+
+func seqPointToC(dst *seqCPoint, src *seqPoint) {
+	dst.X, dst.Y, dst.N = src.X, src.Y, int32(len(src.Name))
+}
+
+func seqPointToGo(dst *seqPoint, src *seqCPoint) {
+	dst.X, dst.Y, dst.Name = src.X, src.Y, string(make([]byte, src.N))
+}
+
+func checkPoints(t *testing.T, got, want []seqPoint) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSequenceRoundTrip(t *testing.T) {
+	src := []seqPoint{{1, 2, "\x00"}, {3, 4, ""}, {5, 6, "\x00\x00"}}
+	var seq CSequence[seqCPoint]
+	SequenceToC(&seq, src, seqPointToC)
+	defer freeSequence(&seq)
+	if seq.Size != 3 || seq.Capacity != 3 {
+		t.Fatalf("want size and capacity 3, got %d and %d", seq.Size, seq.Capacity)
+	}
+	var dst []seqPoint
+	SequenceToGo(&dst, &seq, seqPointToGo)
+	checkPoints(t, dst, src)
+
+	var arr [3]seqPoint
+	ArrayToGo(arr[:], []seqCPoint{{2, 1, 1}, {4, 3, 0}, {6, 5, 2}}, seqPointToGo)
+	checkPoints(t, arr[:], src)
+	var carr [3]seqCPoint
+	ArrayToC(carr[:], src, seqPointToC)
+	ArrayToGo(arr[:], carr[:], seqPointToGo)
+	checkPoints(t, arr[:], src)
+}
+
+func TestSequenceRoundTripEmpty(t *testing.T) {
+	seq := CSequence[seqCPoint]{Size: 1, Capacity: 1}
+	SequenceToC(&seq, nil, seqPointToC)
+	if seq != (CSequence[seqCPoint]{}) {
+		t.Fatalf("want an empty sequence, got %+v", seq)
+	}
+	dst := []seqPoint{}
+	SequenceToGo(&dst, &seq, seqPointToGo)
+	if dst == nil || len(dst) != 0 {
+		t.Fatalf("empty sequence changed dst to %#v", dst)
+	}
+}
+
+func TestCopySequenceRoundTrip(t *testing.T) {
+	type cint32 int32
+	src := []int32{1, -2, 3}
+	var seq CSequence[cint32]
+	CopySequenceToC(&seq, src)
+	defer freeSequence(&seq)
+	var dst []int32
+	CopySequenceToGo(&dst, &seq)
+	if len(dst) != len(src) || dst[0] != 1 || dst[1] != -2 || dst[2] != 3 {
+		t.Fatalf("want %v, got %v", src, dst)
+	}
+
+	var arr [3]int32
+	CopyArrayToGo(arr[:], []cint32{4, 5, 6})
+	if arr != [3]int32{4, 5, 6} {
+		t.Fatalf("want [4 5 6], got %v", arr)
+	}
+	var carr [3]cint32
+	CopyArrayToC(carr[:], arr[:])
+	if carr != [3]cint32{4, 5, 6} {
+		t.Fatalf("want [4 5 6], got %v", carr)
+	}
+}
+
+func TestCopyArrayDifferentSizes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("copying elements of different sizes must panic")
+		}
+	}()
+	CopyArrayToGo(make([]int64, 1), []int32{1})
+}
@@ -0,0 +1,40 @@
+package jazzy
+
+import "time"
+
+// TimeFromParts converts the fields of builtin_interfaces/msg/Time to a
+// time.Time. The zero ROS time, which usually means that the time is not
+// set, is converted to the zero time.Time rather than the Unix epoch, so
+// time.Unix(0, 0) doesn't round-trip through TimeToParts and TimeFromParts.
+func TimeFromParts(sec int32, nanosec uint32) time.Time {
+	if sec == 0 && nanosec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), int64(nanosec))
+}
+
+// TimeToParts converts t to the fields of builtin_interfaces/msg/Time. The
+// zero time.Time is converted to the zero ROS time.
+func TimeToParts(t time.Time) (sec int32, nanosec uint32) {
+	if t.IsZero() {
+		return 0, 0
+	}
+	return int32(t.Unix()), uint32(t.Nanosecond())
+}
+
+// DurationFromParts converts the fields of builtin_interfaces/msg/Duration to
+// a time.Duration.
+func DurationFromParts(sec int32, nanosec uint32) time.Duration {
+	return time.Duration(sec)*time.Second + time.Duration(nanosec)
+}
+
+// DurationToParts converts d to the fields of
+// builtin_interfaces/msg/Duration. Like in ROS, nanosec is never negative,
+// e.g. -1.5s is converted to -2s and 500000000ns.
+func DurationToParts(d time.Duration) (sec int32, nanosec uint32) {
+	s, ns := d/time.Second, d%time.Second
+	if ns < 0 {
+		s, ns = s-1, ns+time.Second
+	}
+	return int32(s), uint32(ns)
+}
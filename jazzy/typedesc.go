@@ -0,0 +1,316 @@
+package jazzy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Type IDs of type_description_interfaces/msg/FieldType. The IDs of arrays,
+// bounded sequences and unbounded sequences are the IDs of their element types
+// plus FieldTypeArray, FieldTypeBoundedSequence and
+// FieldTypeUnboundedSequence respectively.
+const (
+	FieldTypeNotSet         uint8 = 0
+	FieldTypeNestedType     uint8 = 1
+	FieldTypeInt8           uint8 = 2
+	FieldTypeUint8          uint8 = 3
+	FieldTypeInt16          uint8 = 4
+	FieldTypeUint16         uint8 = 5
+	FieldTypeInt32          uint8 = 6
+	FieldTypeUint32         uint8 = 7
+	FieldTypeInt64          uint8 = 8
+	FieldTypeUint64         uint8 = 9
+	FieldTypeFloat          uint8 = 10
+	FieldTypeDouble         uint8 = 11
+	FieldTypeLongDouble     uint8 = 12
+	FieldTypeChar           uint8 = 13
+	FieldTypeWChar          uint8 = 14
+	FieldTypeBoolean        uint8 = 15
+	FieldTypeByte           uint8 = 16
+	FieldTypeString         uint8 = 17
+	FieldTypeWString        uint8 = 18
+	FieldTypeFixedString    uint8 = 19
+	FieldTypeFixedWString   uint8 = 20
+	FieldTypeBoundedString  uint8 = 21
+	FieldTypeBoundedWString uint8 = 22
+
+	FieldTypeArray             uint8 = 48
+	FieldTypeBoundedSequence   uint8 = 96
+	FieldTypeUnboundedSequence uint8 = 144
+)
+
+// FieldType is the type of a field like type_description_interfaces/msg/FieldType.
+type FieldType struct {
+	TypeID uint8
+	// Capacity is the size of an array or the bound of a sequence.
+	Capacity uint64
+	// StringCapacity is the bound of a bounded string.
+	StringCapacity uint64
+	// NestedTypeName is the name of the type of a message field, e.g.
+	// "std_msgs/msg/Header".
+	NestedTypeName string
+}
+
+// FieldDescription describes a field like type_description_interfaces/msg/Field.
+type FieldDescription struct {
+	Name         string
+	Type         FieldType
+	DefaultValue string
+}
+
+// TypeDescription describes a type without the types it references, like
+// type_description_interfaces/msg/IndividualTypeDescription.
+type TypeDescription struct {
+	TypeName string
+	Fields   []FieldDescription
+}
+
+// TypeDescriber is implemented by the type supports of generated messages,
+// services and actions.
+type TypeDescriber interface {
+	// TypeName returns the full name of the type, e.g. "std_msgs/msg/Header".
+	TypeName() string
+	// TypeDescription returns the description of the type itself.
+	TypeDescription() *TypeDescription
+	// ReferencedTypes returns the types of the fields of the type.
+	ReferencedTypes() []TypeDescriber
+	// Source returns the text the type is defined by, or "" if it is not
+	// defined by a file of its own, like the Event messages of services.
+	Source() string
+	// Definition returns the full definition of the type. See Definition.
+	Definition() string
+	// TypeHash returns the RIHS01 hash of the type. See TypeHash.
+	TypeHash() string
+}
+
+// DescribedMessageTypeSupport is a MessageTypeSupport describing its type,
+// like the type supports of generated messages. MessageTypeSupport doesn't
+// require TypeDescriber, so that other implementations don't have to describe
+// their types.
+type DescribedMessageTypeSupport interface {
+	MessageTypeSupport
+	TypeDescriber
+}
+
+// DescribedServiceTypeSupport is a ServiceTypeSupport describing its type,
+// like the type supports of generated services.
+type DescribedServiceTypeSupport interface {
+	ServiceTypeSupport
+	TypeDescriber
+}
+
+// DescribedActionTypeSupport is an ActionTypeSupport describing its type,
+// like the type supports of generated actions.
+type DescribedActionTypeSupport interface {
+	ActionTypeSupport
+	TypeDescriber
+}
+
+// ReferencedTypeDescriptions returns the descriptions of the types d uses
+// directly or indirectly, sorted by name.
+func ReferencedTypeDescriptions(d TypeDescriber) []*TypeDescription {
+	seen := map[string]bool{d.TypeName(): true}
+	var descs []*TypeDescription
+	var add func(TypeDescriber)
+	add = func(t TypeDescriber) {
+		for _, ref := range t.ReferencedTypes() {
+			if name := ref.TypeName(); !seen[name] {
+				seen[name] = true
+				descs = append(descs, ref.TypeDescription())
+				add(ref)
+			}
+		}
+	}
+	add(d)
+	sort.Slice(descs, func(i, j int) bool { return descs[i].TypeName < descs[j].TypeName })
+	return descs
+}
+
+var typeHashes sync.Map // type name -> hash
+
+// TypeHash returns the RIHS01 hash of the type description of d, e.g.
+// "RIHS01_df668c74...", as computed by rosidl_generator_type_description. It
+// implements the TypeHash methods of generated type supports.
+func TypeHash(d TypeDescriber) string {
+	name := d.TypeName()
+	if hash, ok := typeHashes.Load(name); ok {
+		return hash.(string)
+	}
+	var b strings.Builder
+	b.WriteString(`{"type_description": `)
+	writeTypeDescriptionJSON(&b, d.TypeDescription())
+	b.WriteString(`, "referenced_type_descriptions": [`)
+	for i, ref := range ReferencedTypeDescriptions(d) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeTypeDescriptionJSON(&b, ref)
+	}
+	b.WriteString("]}")
+	sum := sha256.Sum256([]byte(b.String()))
+	hash := "RIHS01_" + hex.EncodeToString(sum[:])
+	typeHashes.Store(name, hash)
+	return hash
+}
+
+// writeTypeDescriptionJSON writes desc like json.dumps of Python with the
+// separators used for hashing. Default values are not part of the hash.
+func writeTypeDescriptionJSON(b *strings.Builder, desc *TypeDescription) {
+	b.WriteString(`{"type_name": `)
+	b.WriteString(strconv.Quote(desc.TypeName))
+	b.WriteString(`, "fields": [`)
+	for i, f := range desc.Fields {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(`{"name": `)
+		b.WriteString(strconv.Quote(f.Name))
+		b.WriteString(`, "type": {"type_id": `)
+		b.WriteString(strconv.FormatUint(uint64(f.Type.TypeID), 10))
+		b.WriteString(`, "capacity": `)
+		b.WriteString(strconv.FormatUint(f.Type.Capacity, 10))
+		b.WriteString(`, "string_capacity": `)
+		b.WriteString(strconv.FormatUint(f.Type.StringCapacity, 10))
+		b.WriteString(`, "nested_type_name": `)
+		b.WriteString(strconv.Quote(f.Type.NestedTypeName))
+		b.WriteString("}}")
+	}
+	b.WriteString("]}")
+}
+
+// Definition returns the full definition of d in the format of rosbag2 and
+// MCAP: the text of d followed by the texts of the types it uses, each
+// preceded by a line of 80 "=" and a line "MSG: pkg/Name". Types defined in
+// the same file as d, like the requests of services, are not repeated.
+func Definition(d TypeDescriber) string {
+	var b strings.Builder
+	b.WriteString(d.Source())
+	root := d.TypeName()
+	seen := map[string]bool{root: true}
+	var add func(TypeDescriber)
+	add = func(t TypeDescriber) {
+		for _, ref := range t.ReferencedTypes() {
+			name := ref.TypeName()
+			if seen[name] || ref.Source() == "" {
+				continue
+			}
+			seen[name] = true
+			if !strings.HasPrefix(name, root+"_") {
+				b.WriteString("\n")
+				b.WriteString(strings.Repeat("=", 80))
+				b.WriteString("\nMSG: ")
+				b.WriteString(strings.Replace(name, "/msg/", "/", 1))
+				b.WriteString("\n")
+				b.WriteString(ref.Source())
+			}
+			add(ref)
+		}
+	}
+	add(d)
+	return b.String()
+}
+
+// ServiceTypeDescription returns the description of the service typeName,
+// e.g. "std_srvs/srv/Empty", which consists of its request, response and
+// event messages.
+func ServiceTypeDescription(typeName string) *TypeDescription {
+	return &TypeDescription{
+		TypeName: typeName,
+		Fields: []FieldDescription{
+			{Name: "request_message", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: typeName + "_Request"}},
+			{Name: "response_message", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: typeName + "_Response"}},
+			{Name: "event_message", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: typeName + "_Event"}},
+		},
+	}
+}
+
+// ServiceReferencedTypes returns the types referenced by the service
+// typeName: request, response and the Event message of the service.
+func ServiceReferencedTypes(typeName string, request, response TypeDescriber) []TypeDescriber {
+	event := &staticType{
+		desc: &TypeDescription{
+			TypeName: typeName + "_Event",
+			Fields: []FieldDescription{
+				{Name: "info", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: serviceEventInfoType.desc.TypeName}},
+				{Name: "request", Type: FieldType{TypeID: FieldTypeNestedType + FieldTypeBoundedSequence, Capacity: 1, NestedTypeName: request.TypeName()}},
+				{Name: "response", Type: FieldType{TypeID: FieldTypeNestedType + FieldTypeBoundedSequence, Capacity: 1, NestedTypeName: response.TypeName()}},
+			},
+		},
+		refs: []TypeDescriber{serviceEventInfoType, request, response},
+	}
+	return []TypeDescriber{request, response, event}
+}
+
+// ActionTypeDescription returns the description of the action typeName,
+// e.g. "example_interfaces/action/Fibonacci", which consists of the messages
+// and services of the action.
+func ActionTypeDescription(typeName string) *TypeDescription {
+	desc := &TypeDescription{TypeName: typeName}
+	for _, part := range []struct{ field, suffix string }{
+		{"goal", "_Goal"},
+		{"result", "_Result"},
+		{"feedback", "_Feedback"},
+		{"send_goal_service", "_SendGoal"},
+		{"get_result_service", "_GetResult"},
+		{"feedback_message", "_FeedbackMessage"},
+	} {
+		desc.Fields = append(desc.Fields, FieldDescription{
+			Name: part.field,
+			Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: typeName + part.suffix},
+		})
+	}
+	return desc
+}
+
+// staticType is a TypeDescriber of a type the runtime must describe without
+// depending on its generated package.
+type staticType struct {
+	desc   *TypeDescription
+	refs   []TypeDescriber
+	source string
+}
+
+func (t *staticType) TypeName() string                  { return t.desc.TypeName }
+func (t *staticType) TypeDescription() *TypeDescription { return t.desc }
+func (t *staticType) ReferencedTypes() []TypeDescriber  { return t.refs }
+func (t *staticType) Source() string                    { return t.source }
+func (t *staticType) Definition() string                { return Definition(t) }
+func (t *staticType) TypeHash() string                  { return TypeHash(t) }
+
+var builtinTimeType = &staticType{
+	desc: &TypeDescription{
+		TypeName: "builtin_interfaces/msg/Time",
+		Fields: []FieldDescription{
+			{Name: "sec", Type: FieldType{TypeID: FieldTypeInt32}},
+			{Name: "nanosec", Type: FieldType{TypeID: FieldTypeUint32}},
+		},
+	},
+	source: "int32 sec\nuint32 nanosec\n",
+}
+
+var serviceEventInfoType = &staticType{
+	desc: &TypeDescription{
+		TypeName: "service_msgs/msg/ServiceEventInfo",
+		Fields: []FieldDescription{
+			{Name: "event_type", Type: FieldType{TypeID: FieldTypeUint8}},
+			{Name: "stamp", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: builtinTimeType.desc.TypeName}},
+			{Name: "client_gid", Type: FieldType{TypeID: FieldTypeUint8 + FieldTypeArray, Capacity: 16}},
+			{Name: "sequence_number", Type: FieldType{TypeID: FieldTypeInt64}},
+		},
+	},
+	refs: []TypeDescriber{builtinTimeType},
+	source: `uint8 REQUEST_SENT = 0
+uint8 REQUEST_RECEIVED = 1
+uint8 RESPONSE_SENT = 2
+uint8 RESPONSE_RECEIVED = 3
+
+uint8 event_type
+builtin_interfaces/Time stamp
+char[16] client_gid
+int64 sequence_number
+`,
+}
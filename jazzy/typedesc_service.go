@@ -0,0 +1,61 @@
+package jazzy
+
+/*
+#cgo LDFLAGS: -ltype_description_interfaces__rosidl_generator_c
+
+#include <rcl/node_type_description_service.h>
+#include <type_description_interfaces/srv/get_type_description.h>
+*/
+import "C"
+
+import "unsafe"
+
+// newTypeDescriptionService starts the ~/get_type_description service of n.
+// The service and the descriptions of the types registered by the entities of
+// n are managed by rcl, like in rclcpp.
+func (n *Node) newTypeDescriptionService() (*Service, error) {
+	rc := C.rcl_node_type_description_service_init(n.rclNodeT)
+	if rc != C.RCL_RET_OK {
+		return nil, errorsCastC(rc, "failed to initialize type description service")
+	}
+	var rclService *C.rcl_service_t
+	rc = C.rcl_node_get_type_description_service(n.rclNodeT, &rclService)
+	if rc != C.RCL_RET_OK {
+		C.rcl_node_type_description_service_fini(n.rclNodeT)
+		return nil, errorsCastC(rc, "failed to get type description service")
+	}
+	s := &Service{
+		node:            n,
+		rclService:      rclService,
+		name:            C.CString("~/get_type_description"),
+		typeDescription: true,
+	}
+	n.addResource(s)
+	return s, nil
+}
+
+func (s *Service) finiTypeDescriptionService() error {
+	rc := C.rcl_node_type_description_service_fini(s.node.rclNodeT)
+	if rc != C.RCL_RET_OK {
+		return errorsCastC(rc, "failed to finalize type description service")
+	}
+	return nil
+}
+
+func (s *Service) handleTypeDescriptionRequest() {
+	var reqHeader C.rmw_service_info_t
+	req := C.type_description_interfaces__srv__GetTypeDescription_Request__create()
+	defer C.type_description_interfaces__srv__GetTypeDescription_Request__destroy(req)
+	switch rc := C.rcl_take_request_with_info(s.rclService, &reqHeader, unsafe.Pointer(req)); rc {
+	case C.RCL_RET_OK:
+		resp := C.type_description_interfaces__srv__GetTypeDescription_Response__create()
+		defer C.type_description_interfaces__srv__GetTypeDescription_Response__destroy(resp)
+		C.rcl_node_type_description_service_handle_request(s.node.rclNodeT, &reqHeader.request_id, req, resp)
+		if rc := C.rcl_send_response(s.rclService, &reqHeader.request_id, unsafe.Pointer(resp)); rc != C.RCL_RET_OK {
+			_ = s.node.Logger().Debug(errorsCastC(rc, "failed to send type description"))
+		}
+	case C.RCL_RET_SERVICE_TAKE_FAILED:
+	default:
+		_ = s.node.Logger().Debug(errorsCastC(rc, "failed to take type description request"))
+	}
+}
@@ -0,0 +1,82 @@
+package jazzy
+
+import (
+	"strings"
+	"testing"
+)
+
+// The descriptions of these types are the ones generated by ros2gen, see the
+// golden files of TestGenerateGolden of core and TestGeneratedTypeHash.
+
+func newTestType(name string, source string, fields []FieldDescription, refs ...TypeDescriber) *staticType {
+	return &staticType{desc: &TypeDescription{TypeName: name, Fields: fields}, refs: refs, source: source}
+}
+
+var (
+	testStringType = newTestType("std_msgs/msg/String", "string data\n", []FieldDescription{
+		{Name: "data", Type: FieldType{TypeID: FieldTypeString}},
+	})
+	testHeaderType = newTestType("std_msgs/msg/Header", "builtin_interfaces/Time stamp\nstring frame_id\n", []FieldDescription{
+		{Name: "stamp", Type: FieldType{TypeID: FieldTypeNestedType, NestedTypeName: "builtin_interfaces/msg/Time"}},
+		{Name: "frame_id", Type: FieldType{TypeID: FieldTypeString}},
+	}, builtinTimeType)
+	testAddTwoIntsRequestType = newTestType("example_interfaces/srv/AddTwoInts_Request", "int64 a\nint64 b\n", []FieldDescription{
+		{Name: "a", Type: FieldType{TypeID: FieldTypeInt64}},
+		{Name: "b", Type: FieldType{TypeID: FieldTypeInt64}},
+	})
+	testAddTwoIntsResponseType = newTestType("example_interfaces/srv/AddTwoInts_Response", "int64 sum\n", []FieldDescription{
+		{Name: "sum", Type: FieldType{TypeID: FieldTypeInt64}},
+	})
+	testAddTwoIntsType = &staticType{
+		desc:   ServiceTypeDescription("example_interfaces/srv/AddTwoInts"),
+		refs:   ServiceReferencedTypes("example_interfaces/srv/AddTwoInts", testAddTwoIntsRequestType, testAddTwoIntsResponseType),
+		source: "int64 a\nint64 b\n---\nint64 sum\n",
+	}
+)
+
+func TestTypeHash(t *testing.T) {
+	for _, tt := range []struct {
+		typ  TypeDescriber
+		want string
+	}{
+		// The hashes of the generated type supports of Jazzy.
+		{testStringType, "RIHS01_df668c740482bbd48fb39d76a70dfd4bd59db1288021743503259e948f6b1a18"},
+		{builtinTimeType, "RIHS01_b106235e25a4c5ed35098aa0a61a3ee9c9b18d197f398b0e4206cea9acf9c197"},
+		{testHeaderType, "RIHS01_f49fb3ae2cf070f793645ff749683ac6b06203e41c891e17701b1cb597ce6a01"},
+		{testAddTwoIntsType, "RIHS01_e118de6bf5eeb66a2491b5bda11202e7b68f198d6f67922cf30364858239c81a"},
+	} {
+		if got := TypeHash(tt.typ); got != tt.want {
+			t.Errorf("%s: want %s, got %s", tt.typ.TypeName(), tt.want, got)
+		}
+		if got := tt.typ.TypeHash(); got != tt.want {
+			t.Errorf("%s: TypeHash method: want %s, got %s", tt.typ.TypeName(), tt.want, got)
+		}
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	want := "builtin_interfaces/Time stamp\nstring frame_id\n" +
+		"\n" + strings.Repeat("=", 80) + "\nMSG: builtin_interfaces/Time\nint32 sec\nuint32 nanosec\n"
+	if got := testHeaderType.Definition(); got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	// The request and response are part of the text of the service, and the
+	// Event message isn't defined by a file.
+	want = "int64 a\nint64 b\n---\nint64 sum\n"
+	if got := Definition(testAddTwoIntsType); got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestDynamicTypeName(t *testing.T) {
+	var ts MessageTypeSupport = &dynamicMessageTypeSupport{typeName: "std_msgs/msg/String"}
+	if _, ok := ts.(TypeDescriber); ok {
+		t.Fatal("dynamic type supports must not implement TypeDescriber")
+	}
+	if got := typeNameOf(ts); got != "std_msgs/msg/String" {
+		t.Fatalf("want std_msgs/msg/String, got %s", got)
+	}
+	if got := typeNameOf(intraTypeSupport{}); got != "test_msgs/msg/Intra" {
+		t.Fatalf("want test_msgs/msg/Intra, got %s", got)
+	}
+}
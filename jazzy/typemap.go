@@ -2,6 +2,7 @@ package jazzy
 
 import (
 	"fmt"
+	"strings"
 )
 
 // messageTypeMap maps the ROS2 Message type name to the type implementation in Go,
@@ -34,6 +35,36 @@ func MustGetMessage(msgType string) MessageTypeSupport {
 	return ros2msg
 }
 
+// LoadMessageTypeSupport returns the registered type support of the message
+// type name, e.g. "std_msgs/msg/String". If it is not registered, it is loaded
+// with LoadDynamicMessageTypeSupport.
+func LoadMessageTypeSupport(name string) (MessageTypeSupport, error) {
+	if ts, ok := GetMessage(name); ok {
+		return ts, nil
+	}
+	pkg, typ, iface, err := SplitTypeName(name)
+	if err != nil {
+		return nil, err
+	}
+	if typ != "" && typ != "msg" {
+		return nil, fmt.Errorf("type support for %s is not registered", name)
+	}
+	return LoadDynamicMessageTypeSupport(pkg, iface)
+}
+
+// SplitTypeName splits a type name of the form "pkg/type/Name" or "pkg/Name".
+// If the interface type is omitted, typ is empty.
+func SplitTypeName(name string) (pkg, typ, iface string, err error) {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		return parts[0], parts[1], parts[2], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return parts[0], "", parts[1], nil
+	}
+	return "", "", "", fmt.Errorf("invalid interface type name %q", name)
+}
+
 // serviceTypeMap is the messageTypeMap equivalent for services.
 var serviceTypeMap = make(map[string]ServiceTypeSupport)
 
@@ -21,6 +21,17 @@ type MessageTypeSupport interface {
 	TypeSupport() unsafe.Pointer // *C.rosidl_message_type_support_t
 }
 
+// MemoryResetter is implemented by the MessageTypeSupports of generated
+// messages. ResetMemory frees the memory owned by the fields of p, such as
+// strings and sequences, so that p can be filled again by AsCStruct. p itself
+// must still be freed with ReleaseMemory.
+//
+// The C structs of a MessageTypeSupport not implementing MemoryResetter are
+// not reused between the messages published or sent with it.
+type MemoryResetter interface {
+	ResetMemory(p unsafe.Pointer)
+}
+
 type ServiceTypeSupport interface {
 	Request() MessageTypeSupport
 	Response() MessageTypeSupport
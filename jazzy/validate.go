@@ -0,0 +1,86 @@
+package jazzy
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Validator is implemented by generated messages. Validate checks the
+// constraints of the message definition that the Go types can't express,
+// such as the bounds of bounded strings and sequences.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is returned by the Validate method of generated messages.
+type ValidationError struct {
+	// Field is the path of the invalid field, e.g. "poses[2].header.frame_id".
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateMessage validates msg if it implements Validator.
+func ValidateMessage(msg Message) error {
+	if v, ok := msg.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// ValidateField prefixes the field path of err, returned by the Validate
+// method of the message in field, with field.
+func ValidateField(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*ValidationError); ok {
+		sep := "."
+		if len(e.Field) > 0 && e.Field[0] == '[' {
+			sep = ""
+		}
+		return &ValidationError{Field: field + sep + e.Field, Err: e.Err}
+	}
+	return &ValidationError{Field: field, Err: err}
+}
+
+// FieldIndex returns the path of the element i of the sequence or array
+// field.
+func FieldIndex(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
+
+// ValidateSequenceBound checks that the bounded sequence field has at most
+// bound elements.
+func ValidateSequenceBound(field string, n, bound int) error {
+	if n > bound {
+		return &ValidationError{Field: field, Err: fmt.Errorf("sequence has %d elements, exceeding its bound of %d", n, bound)}
+	}
+	return nil
+}
+
+// ValidateStringBound checks that the bounded string field has at most bound
+// bytes.
+func ValidateStringBound(field, s string, bound int) error {
+	if len(s) > bound {
+		return &ValidationError{Field: field, Err: fmt.Errorf("string has %d bytes, exceeding its bound of %d", len(s), bound)}
+	}
+	return nil
+}
+
+// ValidateU16StringBound checks that the bounded wstring field has at most
+// bound UTF-16 code units.
+func ValidateU16StringBound(field, s string, bound int) error {
+	if n := len(utf16.Encode([]rune(s))); n > bound {
+		return &ValidationError{Field: field, Err: fmt.Errorf("wstring has %d code units, exceeding its bound of %d", n, bound)}
+	}
+	return nil
+}